@@ -0,0 +1,338 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.28.0
+// 	protoc        v3.19.4
+// source: swipe.proto
+
+package pb
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	reflect "reflect"
+	sync "sync"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+type SwipeProfileRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	ProfileId int64 `protobuf:"varint,1,opt,name=profile_id,json=profileId,proto3" json:"profile_id,omitempty"`
+	// LIKE or PASS
+	SwipeType string `protobuf:"bytes,2,opt,name=swipe_type,json=swipeType,proto3" json:"swipe_type,omitempty"`
+}
+
+func (x *SwipeProfileRequest) Reset() {
+	*x = SwipeProfileRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_swipe_proto_msgTypes[0]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *SwipeProfileRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SwipeProfileRequest) ProtoMessage() {}
+
+func (x *SwipeProfileRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_swipe_proto_msgTypes[0]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SwipeProfileRequest.ProtoReflect.Descriptor instead.
+func (*SwipeProfileRequest) Descriptor() ([]byte, []int) {
+	return file_swipe_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *SwipeProfileRequest) GetProfileId() int64 {
+	if x != nil {
+		return x.ProfileId
+	}
+	return 0
+}
+
+func (x *SwipeProfileRequest) GetSwipeType() string {
+	if x != nil {
+		return x.SwipeType
+	}
+	return ""
+}
+
+type SwipeProfileResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+}
+
+func (x *SwipeProfileResponse) Reset() {
+	*x = SwipeProfileResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_swipe_proto_msgTypes[1]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *SwipeProfileResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SwipeProfileResponse) ProtoMessage() {}
+
+func (x *SwipeProfileResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_swipe_proto_msgTypes[1]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SwipeProfileResponse.ProtoReflect.Descriptor instead.
+func (*SwipeProfileResponse) Descriptor() ([]byte, []int) {
+	return file_swipe_proto_rawDescGZIP(), []int{1}
+}
+
+type UnmatchRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	ProfileId int64 `protobuf:"varint,1,opt,name=profile_id,json=profileId,proto3" json:"profile_id,omitempty"`
+}
+
+func (x *UnmatchRequest) Reset() {
+	*x = UnmatchRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_swipe_proto_msgTypes[2]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *UnmatchRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UnmatchRequest) ProtoMessage() {}
+
+func (x *UnmatchRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_swipe_proto_msgTypes[2]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UnmatchRequest.ProtoReflect.Descriptor instead.
+func (*UnmatchRequest) Descriptor() ([]byte, []int) {
+	return file_swipe_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *UnmatchRequest) GetProfileId() int64 {
+	if x != nil {
+		return x.ProfileId
+	}
+	return 0
+}
+
+type UnmatchResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+}
+
+func (x *UnmatchResponse) Reset() {
+	*x = UnmatchResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_swipe_proto_msgTypes[3]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *UnmatchResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UnmatchResponse) ProtoMessage() {}
+
+func (x *UnmatchResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_swipe_proto_msgTypes[3]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UnmatchResponse.ProtoReflect.Descriptor instead.
+func (*UnmatchResponse) Descriptor() ([]byte, []int) {
+	return file_swipe_proto_rawDescGZIP(), []int{3}
+}
+
+var File_swipe_proto protoreflect.FileDescriptor
+
+var file_swipe_proto_rawDesc = []byte{
+	0x0a, 0x0b, 0x73, 0x77, 0x69, 0x70, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x12, 0x09, 0x64,
+	0x61, 0x74, 0x69, 0x6e, 0x67, 0x2e, 0x76, 0x31, 0x22, 0x53, 0x0a, 0x13, 0x53, 0x77, 0x69, 0x70,
+	0x65, 0x50, 0x72, 0x6f, 0x66, 0x69, 0x6c, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12,
+	0x1d, 0x0a, 0x0a, 0x70, 0x72, 0x6f, 0x66, 0x69, 0x6c, 0x65, 0x5f, 0x69, 0x64, 0x18, 0x01, 0x20,
+	0x01, 0x28, 0x03, 0x52, 0x09, 0x70, 0x72, 0x6f, 0x66, 0x69, 0x6c, 0x65, 0x49, 0x64, 0x12, 0x1d,
+	0x0a, 0x0a, 0x73, 0x77, 0x69, 0x70, 0x65, 0x5f, 0x74, 0x79, 0x70, 0x65, 0x18, 0x02, 0x20, 0x01,
+	0x28, 0x09, 0x52, 0x09, 0x73, 0x77, 0x69, 0x70, 0x65, 0x54, 0x79, 0x70, 0x65, 0x22, 0x16, 0x0a,
+	0x14, 0x53, 0x77, 0x69, 0x70, 0x65, 0x50, 0x72, 0x6f, 0x66, 0x69, 0x6c, 0x65, 0x52, 0x65, 0x73,
+	0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x2f, 0x0a, 0x0e, 0x55, 0x6e, 0x6d, 0x61, 0x74, 0x63, 0x68,
+	0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x1d, 0x0a, 0x0a, 0x70, 0x72, 0x6f, 0x66, 0x69,
+	0x6c, 0x65, 0x5f, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x03, 0x52, 0x09, 0x70, 0x72, 0x6f,
+	0x66, 0x69, 0x6c, 0x65, 0x49, 0x64, 0x22, 0x11, 0x0a, 0x0f, 0x55, 0x6e, 0x6d, 0x61, 0x74, 0x63,
+	0x68, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x32, 0xa1, 0x01, 0x0a, 0x0c, 0x53, 0x77,
+	0x69, 0x70, 0x65, 0x53, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x12, 0x4f, 0x0a, 0x0c, 0x53, 0x77,
+	0x69, 0x70, 0x65, 0x50, 0x72, 0x6f, 0x66, 0x69, 0x6c, 0x65, 0x12, 0x1e, 0x2e, 0x64, 0x61, 0x74,
+	0x69, 0x6e, 0x67, 0x2e, 0x76, 0x31, 0x2e, 0x53, 0x77, 0x69, 0x70, 0x65, 0x50, 0x72, 0x6f, 0x66,
+	0x69, 0x6c, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x1f, 0x2e, 0x64, 0x61, 0x74,
+	0x69, 0x6e, 0x67, 0x2e, 0x76, 0x31, 0x2e, 0x53, 0x77, 0x69, 0x70, 0x65, 0x50, 0x72, 0x6f, 0x66,
+	0x69, 0x6c, 0x65, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x40, 0x0a, 0x07, 0x55,
+	0x6e, 0x6d, 0x61, 0x74, 0x63, 0x68, 0x12, 0x19, 0x2e, 0x64, 0x61, 0x74, 0x69, 0x6e, 0x67, 0x2e,
+	0x76, 0x31, 0x2e, 0x55, 0x6e, 0x6d, 0x61, 0x74, 0x63, 0x68, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73,
+	0x74, 0x1a, 0x1a, 0x2e, 0x64, 0x61, 0x74, 0x69, 0x6e, 0x67, 0x2e, 0x76, 0x31, 0x2e, 0x55, 0x6e,
+	0x6d, 0x61, 0x74, 0x63, 0x68, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x42, 0x37, 0x5a,
+	0x35, 0x67, 0x69, 0x74, 0x68, 0x75, 0x62, 0x2e, 0x63, 0x6f, 0x6d, 0x2f, 0x72, 0x61, 0x64, 0x79,
+	0x61, 0x74, 0x61, 0x6d, 0x61, 0x61, 0x2f, 0x64, 0x61, 0x74, 0x69, 0x6e, 0x67, 0x2d, 0x61, 0x70,
+	0x70, 0x73, 0x2d, 0x61, 0x70, 0x69, 0x2f, 0x61, 0x70, 0x69, 0x2f, 0x70, 0x72, 0x6f, 0x74, 0x6f,
+	0x2f, 0x76, 0x31, 0x3b, 0x70, 0x62, 0x62, 0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
+}
+
+var (
+	file_swipe_proto_rawDescOnce sync.Once
+	file_swipe_proto_rawDescData = file_swipe_proto_rawDesc
+)
+
+func file_swipe_proto_rawDescGZIP() []byte {
+	file_swipe_proto_rawDescOnce.Do(func() {
+		file_swipe_proto_rawDescData = protoimpl.X.CompressGZIP(file_swipe_proto_rawDescData)
+	})
+	return file_swipe_proto_rawDescData
+}
+
+var file_swipe_proto_msgTypes = make([]protoimpl.MessageInfo, 4)
+var file_swipe_proto_goTypes = []interface{}{
+	(*SwipeProfileRequest)(nil),  // 0: dating.v1.SwipeProfileRequest
+	(*SwipeProfileResponse)(nil), // 1: dating.v1.SwipeProfileResponse
+	(*UnmatchRequest)(nil),       // 2: dating.v1.UnmatchRequest
+	(*UnmatchResponse)(nil),      // 3: dating.v1.UnmatchResponse
+}
+var file_swipe_proto_depIdxs = []int32{
+	0, // 0: dating.v1.SwipeService.SwipeProfile:input_type -> dating.v1.SwipeProfileRequest
+	2, // 1: dating.v1.SwipeService.Unmatch:input_type -> dating.v1.UnmatchRequest
+	1, // 2: dating.v1.SwipeService.SwipeProfile:output_type -> dating.v1.SwipeProfileResponse
+	3, // 3: dating.v1.SwipeService.Unmatch:output_type -> dating.v1.UnmatchResponse
+	2, // [2:4] is the sub-list for method output_type
+	0, // [0:2] is the sub-list for method input_type
+	0, // [0:0] is the sub-list for extension type_name
+	0, // [0:0] is the sub-list for extension extendee
+	0, // [0:0] is the sub-list for field type_name
+}
+
+func init() { file_swipe_proto_init() }
+func file_swipe_proto_init() {
+	if File_swipe_proto != nil {
+		return
+	}
+	if !protoimpl.UnsafeEnabled {
+		file_swipe_proto_msgTypes[0].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*SwipeProfileRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_swipe_proto_msgTypes[1].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*SwipeProfileResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_swipe_proto_msgTypes[2].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*UnmatchRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_swipe_proto_msgTypes[3].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*UnmatchResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: file_swipe_proto_rawDesc,
+			NumEnums:      0,
+			NumMessages:   4,
+			NumExtensions: 0,
+			NumServices:   1,
+		},
+		GoTypes:           file_swipe_proto_goTypes,
+		DependencyIndexes: file_swipe_proto_depIdxs,
+		MessageInfos:      file_swipe_proto_msgTypes,
+	}.Build()
+	File_swipe_proto = out.File
+	file_swipe_proto_rawDesc = nil
+	file_swipe_proto_goTypes = nil
+	file_swipe_proto_depIdxs = nil
+}
@@ -0,0 +1,141 @@
+// Code generated by protoc-gen-go-grpc. DO NOT EDIT.
+// versions:
+// - protoc-gen-go-grpc v1.2.0
+// - protoc             v3.19.4
+// source: swipe.proto
+
+package pb
+
+import (
+	context "context"
+	grpc "google.golang.org/grpc"
+	codes "google.golang.org/grpc/codes"
+	status "google.golang.org/grpc/status"
+)
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+// Requires gRPC-Go v1.32.0 or later.
+const _ = grpc.SupportPackageIsVersion7
+
+// SwipeServiceClient is the client API for SwipeService service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+type SwipeServiceClient interface {
+	SwipeProfile(ctx context.Context, in *SwipeProfileRequest, opts ...grpc.CallOption) (*SwipeProfileResponse, error)
+	Unmatch(ctx context.Context, in *UnmatchRequest, opts ...grpc.CallOption) (*UnmatchResponse, error)
+}
+
+type swipeServiceClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewSwipeServiceClient(cc grpc.ClientConnInterface) SwipeServiceClient {
+	return &swipeServiceClient{cc}
+}
+
+func (c *swipeServiceClient) SwipeProfile(ctx context.Context, in *SwipeProfileRequest, opts ...grpc.CallOption) (*SwipeProfileResponse, error) {
+	out := new(SwipeProfileResponse)
+	err := c.cc.Invoke(ctx, "/dating.v1.SwipeService/SwipeProfile", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *swipeServiceClient) Unmatch(ctx context.Context, in *UnmatchRequest, opts ...grpc.CallOption) (*UnmatchResponse, error) {
+	out := new(UnmatchResponse)
+	err := c.cc.Invoke(ctx, "/dating.v1.SwipeService/Unmatch", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// SwipeServiceServer is the server API for SwipeService service.
+// All implementations must embed UnimplementedSwipeServiceServer
+// for forward compatibility
+type SwipeServiceServer interface {
+	SwipeProfile(context.Context, *SwipeProfileRequest) (*SwipeProfileResponse, error)
+	Unmatch(context.Context, *UnmatchRequest) (*UnmatchResponse, error)
+	mustEmbedUnimplementedSwipeServiceServer()
+}
+
+// UnimplementedSwipeServiceServer must be embedded to have forward compatible implementations.
+type UnimplementedSwipeServiceServer struct {
+}
+
+func (UnimplementedSwipeServiceServer) SwipeProfile(context.Context, *SwipeProfileRequest) (*SwipeProfileResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SwipeProfile not implemented")
+}
+func (UnimplementedSwipeServiceServer) Unmatch(context.Context, *UnmatchRequest) (*UnmatchResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Unmatch not implemented")
+}
+func (UnimplementedSwipeServiceServer) mustEmbedUnimplementedSwipeServiceServer() {}
+
+// UnsafeSwipeServiceServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to SwipeServiceServer will
+// result in compilation errors.
+type UnsafeSwipeServiceServer interface {
+	mustEmbedUnimplementedSwipeServiceServer()
+}
+
+func RegisterSwipeServiceServer(s grpc.ServiceRegistrar, srv SwipeServiceServer) {
+	s.RegisterService(&SwipeService_ServiceDesc, srv)
+}
+
+func _SwipeService_SwipeProfile_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SwipeProfileRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SwipeServiceServer).SwipeProfile(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/dating.v1.SwipeService/SwipeProfile",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SwipeServiceServer).SwipeProfile(ctx, req.(*SwipeProfileRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _SwipeService_Unmatch_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UnmatchRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SwipeServiceServer).Unmatch(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/dating.v1.SwipeService/Unmatch",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SwipeServiceServer).Unmatch(ctx, req.(*UnmatchRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+// SwipeService_ServiceDesc is the grpc.ServiceDesc for SwipeService service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var SwipeService_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "dating.v1.SwipeService",
+	HandlerType: (*SwipeServiceServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "SwipeProfile",
+			Handler:    _SwipeService_SwipeProfile_Handler,
+		},
+		{
+			MethodName: "Unmatch",
+			Handler:    _SwipeService_Unmatch_Handler,
+		},
+	},
+	Streams:  []grpc.StreamDesc{},
+	Metadata: "swipe.proto",
+}
@@ -0,0 +1,44 @@
+package main
+
+import (
+	"github.com/radyatamaa/dating-apps-api/internal/middlewares"
+	"github.com/radyatamaa/dating-apps-api/internal/profile"
+	"github.com/radyatamaa/dating-apps-api/internal/swipe"
+	"github.com/radyatamaa/dating-apps-api/internal/user"
+	"github.com/radyatamaa/dating-apps-api/pkg/jwt"
+	"github.com/radyatamaa/dating-apps-api/pkg/zaplogger"
+	"google.golang.org/grpc"
+
+	profileGrpcHandler "github.com/radyatamaa/dating-apps-api/internal/profile/delivery/grpc/v1"
+	swipeGrpcHandler "github.com/radyatamaa/dating-apps-api/internal/swipe/delivery/grpc/v1"
+	userGrpcHandler "github.com/radyatamaa/dating-apps-api/internal/user/delivery/grpc/v1"
+)
+
+// grpcUseCases are the usecases served by the grpc api.
+type grpcUseCases struct {
+	user    user.UseCase
+	profile profile.UseCase
+	swipe   swipe.UseCase
+}
+
+// newGrpcServer returns the grpc server of the user, profile and swipe services.
+// The interceptors run in order: request id, access log, error status, recovery, rate limit then auth,
+// so the access log has the status of the errors and the panics. A nil rateLimit disables the rate limit.
+func newGrpcServer(auth jwt.JWT, rateLimit *middlewares.RateLimitConfig, u grpcUseCases, zapLog zaplogger.Logger) *grpc.Server {
+	interceptors := []grpc.UnaryServerInterceptor{
+		middlewares.GrpcRequestID(),
+		middlewares.GrpcAccessLog(zapLog),
+		middlewares.GrpcErrorStatus(),
+		middlewares.GrpcRecovery(zapLog),
+	}
+	if rateLimit != nil {
+		interceptors = append(interceptors, middlewares.GrpcRateLimit(*rateLimit))
+	}
+	interceptors = append(interceptors, middlewares.GrpcAuth(auth))
+	server := grpc.NewServer(grpc.ChainUnaryInterceptor(interceptors...))
+
+	userGrpcHandler.NewUserHandler(server, u.user, zapLog)
+	profileGrpcHandler.NewProfileHandler(server, u.profile, zapLog)
+	swipeGrpcHandler.NewSwipeHandler(server, u.swipe, zapLog)
+	return server
+}
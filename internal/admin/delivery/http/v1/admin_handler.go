@@ -0,0 +1,225 @@
+package v1
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/radyatamaa/dating-apps-api/internal/admin"
+	"github.com/radyatamaa/dating-apps-api/pkg/database/paginator"
+	"github.com/radyatamaa/dating-apps-api/pkg/validator"
+
+	beego "github.com/beego/beego/v2/server/web"
+	"github.com/radyatamaa/dating-apps-api/internal"
+	"github.com/radyatamaa/dating-apps-api/internal/domain"
+	"github.com/radyatamaa/dating-apps-api/pkg/response"
+	"github.com/radyatamaa/dating-apps-api/pkg/zaplogger"
+)
+
+type AdminHandler struct {
+	ZapLogger zaplogger.Logger
+	internal.BaseController
+	response.ApiResponse
+	Usecase admin.UseCase
+}
+
+func NewAdminHandler(useCase admin.UseCase, zapLogger zaplogger.Logger) {
+	pHandler := &AdminHandler{
+		ZapLogger: zapLogger,
+		Usecase:   useCase,
+	}
+	beego.Router("/api/admin/v1/users", pHandler, "get:SearchUsers")
+	beego.Router("/api/admin/v1/users/:id", pHandler, "get:GetUser")
+	beego.Router("/api/admin/v1/users/:id/premium", pHandler, "post:GrantPremium")
+	beego.Router("/api/admin/v1/users/:id/role", pHandler, "put:UpdateRole")
+	beego.Router("/api/admin/v1/users/:id/photo", pHandler, "delete:RemovePhoto")
+}
+
+func (h *AdminHandler) Prepare() {
+	// check user access when needed
+	h.SetLangVersion()
+}
+
+func (h *AdminHandler) userIdParam() (int, bool) {
+	userId, err := strconv.Atoi(h.Ctx.Input.Param(":id"))
+	if err != nil {
+		h.Ctx.Input.SetData("stackTrace", h.ZapLogger.SetMessageLog(err))
+		h.ResponseError(h.Ctx, http.StatusBadRequest, response.PathParamInvalidCode, response.ErrorCodeText(response.PathParamInvalidCode, h.Locale.Lang), err)
+		return 0, false
+	}
+	return userId, true
+}
+
+func (h *AdminHandler) bindAndValidate(request interface{}) bool {
+	if err := h.BindJSON(request); err != nil {
+		h.Ctx.Input.SetData("stackTrace", h.ZapLogger.SetMessageLog(err))
+		h.ResponseError(h.Ctx, http.StatusBadRequest, response.ApiValidationCodeError, response.ErrorCodeText(response.ApiValidationCodeError, h.Locale.Lang), err)
+		return false
+	}
+	if err := validator.Validate.ValidateStruct(request); err != nil {
+		h.Ctx.Input.SetData("stackTrace", h.ZapLogger.SetMessageLog(err))
+		h.ResponseError(h.Ctx, http.StatusBadRequest, response.ApiValidationCodeError, response.ErrorCodeText(response.ApiValidationCodeError, h.Locale.Lang), err)
+		return false
+	}
+	return true
+}
+
+// SearchUsers
+// @Title SearchUsers
+// @Tags Admin
+// @Summary SearchUsers returns the users with their profile
+// @Produce json
+// @Security ApiKeyAuth
+// @Param Accept-Language header string false "lang"
+// @Success 200 {object} swagger.BaseResponse{errors=[]object,data=domain.AdminUserResponsePaginationResponse}
+// @Failure 400 {object} swagger.BadRequestErrorValidationResponse{errors=[]swagger.ValidationErrors,data=object}
+// @Failure 403 {object} swagger.BaseResponse{errors=[]object,data=object}
+// @Failure 408 {object} swagger.RequestTimeoutResponse{errors=[]object,data=object}
+// @Failure 500 {object} swagger.InternalServerErrorResponse{errors=[]object,data=object}
+// @Param pageSize query int false "page size"
+// @Param page query int false "page"
+// @Param email query string false "email contains"
+// @Router /admin/v1/users [get]
+func (h *AdminHandler) SearchUsers() {
+	pageSize, page, err := paginator.PaginationQueryParamValidation(h.Ctx.Input.Query("pageSize"), h.Ctx.Input.Query("page"))
+	if err != nil {
+		h.ResponseError(h.Ctx, http.StatusBadRequest, response.QueryParamInvalidCode, response.ErrorCodeText(response.QueryParamInvalidCode, h.Locale.Lang), err)
+		return
+	}
+	limit, page, offset := paginator.Pagination(page, pageSize)
+
+	result, err := h.Usecase.SearchUsers(h.Ctx.Request.Context(), page, limit, offset, h.Ctx.Input.Query("email"))
+	if err != nil {
+		h.Fail(err)
+		return
+	}
+	h.Ok(h.Ctx, h.Tr("message.success"), result)
+	return
+}
+
+// GetUser
+// @Title GetUser
+// @Tags Admin
+// @Summary GetUser returns the full profile of the user
+// @Produce json
+// @Security ApiKeyAuth
+// @Param Accept-Language header string false "lang"
+// @Param id path int true "user id"
+// @Success 200 {object} swagger.BaseResponse{errors=[]object,data=domain.AdminUserResponse}
+// @Failure 400 {object} swagger.BadRequestErrorValidationResponse{errors=[]swagger.ValidationErrors,data=object}
+// @Failure 403 {object} swagger.BaseResponse{errors=[]object,data=object}
+// @Failure 408 {object} swagger.RequestTimeoutResponse{errors=[]object,data=object}
+// @Failure 500 {object} swagger.InternalServerErrorResponse{errors=[]object,data=object}
+// @Router /admin/v1/users/{id} [get]
+func (h *AdminHandler) GetUser() {
+	userId, ok := h.userIdParam()
+	if !ok {
+		return
+	}
+
+	result, err := h.Usecase.GetUser(h.Ctx.Request.Context(), userId)
+	if err != nil {
+		h.Fail(err)
+		return
+	}
+	h.Ok(h.Ctx, h.Tr("message.success"), result)
+	return
+}
+
+// GrantPremium
+// @Title GrantPremium
+// @Tags Admin
+// @Summary GrantPremium grants or extends the premium of the user
+// @Produce json
+// @Security ApiKeyAuth
+// @Param Accept-Language header string false "lang"
+// @Param id path int true "user id"
+// @Success 200 {object} swagger.BaseResponse{errors=[]object,data=object}
+// @Failure 400 {object} swagger.BadRequestErrorValidationResponse{errors=[]swagger.ValidationErrors,data=object}
+// @Failure 403 {object} swagger.BaseResponse{errors=[]object,data=object}
+// @Failure 408 {object} swagger.RequestTimeoutResponse{errors=[]object,data=object}
+// @Failure 500 {object} swagger.InternalServerErrorResponse{errors=[]object,data=object}
+// @Param body body domain.GrantPremiumRequest true "request payload"
+// @Router /admin/v1/users/{id}/premium [post]
+func (h *AdminHandler) GrantPremium() {
+	userId, ok := h.userIdParam()
+	if !ok {
+		return
+	}
+	var request domain.GrantPremiumRequest
+	if !h.bindAndValidate(&request) {
+		return
+	}
+
+	if err := h.Usecase.GrantPremium(h.Ctx.Request.Context(), userId, request); err != nil {
+		h.Fail(err)
+		return
+	}
+	h.Ok(h.Ctx, h.Tr("message.success"), nil)
+	return
+}
+
+// UpdateRole
+// @Title UpdateRole
+// @Tags Admin
+// @Summary UpdateRole changes the role of the user, the user has to login again
+// @Produce json
+// @Security ApiKeyAuth
+// @Param Accept-Language header string false "lang"
+// @Param id path int true "user id"
+// @Success 200 {object} swagger.BaseResponse{errors=[]object,data=object}
+// @Failure 400 {object} swagger.BadRequestErrorValidationResponse{errors=[]swagger.ValidationErrors,data=object}
+// @Failure 403 {object} swagger.BaseResponse{errors=[]object,data=object}
+// @Failure 408 {object} swagger.RequestTimeoutResponse{errors=[]object,data=object}
+// @Failure 500 {object} swagger.InternalServerErrorResponse{errors=[]object,data=object}
+// @Param body body domain.UpdateRoleRequest true "request payload"
+// @Router /admin/v1/users/{id}/role [put]
+func (h *AdminHandler) UpdateRole() {
+	userId, ok := h.userIdParam()
+	if !ok {
+		return
+	}
+	var request domain.UpdateRoleRequest
+	if !h.bindAndValidate(&request) {
+		return
+	}
+
+	if err := h.Usecase.UpdateRole(h.Ctx.Request.Context(), userId, request); err != nil {
+		h.Fail(err)
+		return
+	}
+	h.Ok(h.Ctx, h.Tr("message.success"), nil)
+	return
+}
+
+// RemovePhoto
+// @Title RemovePhoto
+// @Tags Admin
+// @Summary RemovePhoto removes the profile photo of the user
+// @Produce json
+// @Security ApiKeyAuth
+// @Param Accept-Language header string false "lang"
+// @Param id path int true "user id"
+// @Success 200 {object} swagger.BaseResponse{errors=[]object,data=object}
+// @Failure 400 {object} swagger.BadRequestErrorValidationResponse{errors=[]swagger.ValidationErrors,data=object}
+// @Failure 403 {object} swagger.BaseResponse{errors=[]object,data=object}
+// @Failure 408 {object} swagger.RequestTimeoutResponse{errors=[]object,data=object}
+// @Failure 500 {object} swagger.InternalServerErrorResponse{errors=[]object,data=object}
+// @Param body body domain.RemovePhotoRequest true "request payload"
+// @Router /admin/v1/users/{id}/photo [delete]
+func (h *AdminHandler) RemovePhoto() {
+	userId, ok := h.userIdParam()
+	if !ok {
+		return
+	}
+	var request domain.RemovePhotoRequest
+	if !h.bindAndValidate(&request) {
+		return
+	}
+
+	if err := h.Usecase.RemovePhoto(h.Ctx.Request.Context(), userId, request); err != nil {
+		h.Fail(err)
+		return
+	}
+	h.Ok(h.Ctx, h.Tr("message.success"), nil)
+	return
+}
@@ -0,0 +1,16 @@
+package admin
+
+import (
+	"context"
+
+	"github.com/radyatamaa/dating-apps-api/internal/domain"
+)
+
+// UseCase Interface
+type UseCase interface {
+	SearchUsers(ctx context.Context, page, limit, offset int, email string) (*domain.AdminUserResponsePaginationResponse, error)
+	GetUser(ctx context.Context, userId int) (*domain.AdminUserResponse, error)
+	GrantPremium(ctx context.Context, userId int, request domain.GrantPremiumRequest) error
+	UpdateRole(ctx context.Context, userId int, request domain.UpdateRoleRequest) error
+	RemovePhoto(ctx context.Context, userId int, request domain.RemovePhotoRequest) error
+}
@@ -0,0 +1,282 @@
+package usecase
+
+import (
+	"context"
+	"database/sql"
+	"time"
+
+	"github.com/radyatamaa/dating-apps-api/internal/admin"
+	"github.com/radyatamaa/dating-apps-api/internal/audit"
+	"github.com/radyatamaa/dating-apps-api/internal/domain"
+	"github.com/radyatamaa/dating-apps-api/internal/moderation"
+	"github.com/radyatamaa/dating-apps-api/internal/profile"
+	"github.com/radyatamaa/dating-apps-api/internal/user"
+	"github.com/radyatamaa/dating-apps-api/pkg/authuser"
+	"github.com/radyatamaa/dating-apps-api/pkg/database"
+	"github.com/radyatamaa/dating-apps-api/pkg/helper"
+	"github.com/radyatamaa/dating-apps-api/pkg/jwt"
+	"github.com/radyatamaa/dating-apps-api/pkg/requestinfo"
+	"github.com/radyatamaa/dating-apps-api/pkg/tracing"
+	"github.com/radyatamaa/dating-apps-api/pkg/zaplogger"
+)
+
+// adminUserQuery selects the fields of the user and the profile returned to the admins.
+func adminUserQuery() *database.Query {
+	return database.NewQuery().
+		Select(
+			"users.*",
+			"profile.id as profile_id",
+			"profile.name as name",
+			"profile.photo as photo",
+			"profile.age as age",
+			"profile.bio as bio",
+			"profile.longitude as longitude",
+			"profile.latitude as latitude",
+		).
+		Join(database.LeftJoin("profile", database.EqColumn("profile.user_id", "users.id")))
+}
+
+type adminUseCase struct {
+	zapLogger                 zaplogger.Logger
+	contextTimeout            time.Duration
+	txManager                 database.TxManager
+	jwtAuth                   jwt.JWT
+	mysqlUserRepository       user.MysqlRepository
+	mysqlProfileRepository    profile.MysqlRepository
+	mysqlModerationRepository moderation.MysqlRepository
+	auditUseCase              audit.UseCase
+}
+
+func NewAdminUseCase(timeout time.Duration,
+	txManager database.TxManager,
+	mysqlUserRepository user.MysqlRepository,
+	mysqlProfileRepository profile.MysqlRepository,
+	mysqlModerationRepository moderation.MysqlRepository,
+	jwtAuth jwt.JWT,
+	auditUseCase audit.UseCase,
+	zapLogger zaplogger.Logger) admin.UseCase {
+	return &adminUseCase{
+		mysqlUserRepository:       mysqlUserRepository,
+		mysqlProfileRepository:    mysqlProfileRepository,
+		mysqlModerationRepository: mysqlModerationRepository,
+		jwtAuth:                   jwtAuth,
+		auditUseCase:              auditUseCase,
+		contextTimeout:            timeout,
+		txManager:                 txManager,
+		zapLogger:                 zapLogger,
+	}
+}
+
+func (r adminUseCase) singleUserWithFilter(ctx context.Context, criteria ...database.Criterion) (*domain.UserQueryWithProfile, error) {
+	var entity domain.UserQueryWithProfile
+	if err := r.mysqlUserRepository.SingleWithFilter(
+		ctx,
+		adminUserQuery().Where(criteria...),
+		&entity); err != nil {
+		return nil, err
+	}
+	return &entity, nil
+}
+
+/////////////////// SearchUsers
+
+func (r adminUseCase) SearchUsers(ctx context.Context, page, limit, offset int, email string) (*domain.AdminUserResponsePaginationResponse, error) {
+	ctx, cancel := context.WithTimeout(ctx, r.contextTimeout)
+	defer cancel()
+	ctx, span := tracing.Start(ctx, "adminUseCase.SearchUsers")
+	defer span.End()
+
+	query := adminUserQuery().OrderBy(database.Asc("users.id")).CacheTotal()
+	if email != "" {
+		query.Where(database.Like("users.email", "%"+email+"%"))
+	}
+
+	var entity []domain.UserQueryWithProfile
+	fetchUsers, err := r.mysqlUserRepository.FetchWithFilterAndPagination(
+		ctx,
+		limit,
+		offset,
+		query,
+		&entity,
+	)
+	if err != nil {
+		zaplogger.SetStackTrace(ctx, r.zapLogger.SetMessageLog(err))
+		return nil, err
+	}
+
+	datas := make([]domain.AdminUserResponse, 0)
+	records := fetchUsers.Records.(*[]domain.UserQueryWithProfile)
+	if records != nil {
+		for _, e := range *records {
+			datas = append(datas, domain.FromUserToAdminUserResponse(e))
+		}
+	}
+
+	return domain.ToAdminUserResponsePaginationResponse(datas, page, limit, offset, fetchUsers), nil
+}
+
+//////////////////
+
+/////////////////// GetUser
+
+func (r adminUseCase) GetUser(ctx context.Context, userId int) (*domain.AdminUserResponse, error) {
+	ctx, cancel := context.WithTimeout(ctx, r.contextTimeout)
+	defer cancel()
+	ctx, span := tracing.Start(ctx, "adminUseCase.GetUser")
+	defer span.End()
+
+	userSingle, err := r.singleUserWithFilter(ctx, database.Eq("users.id", userId))
+	if err != nil {
+		zaplogger.SetStackTrace(ctx, r.zapLogger.SetMessageLog(err))
+		return nil, err
+	}
+
+	result := domain.FromUserToAdminUserResponse(*userSingle)
+	return &result, nil
+}
+
+//////////////////
+
+/////////////////// GrantPremium
+
+func (r adminUseCase) GrantPremium(ctx context.Context, userId int, request domain.GrantPremiumRequest) error {
+	ctx, cancel := context.WithTimeout(ctx, r.contextTimeout)
+	defer cancel()
+	ctx, span := tracing.Start(ctx, "adminUseCase.GrantPremium")
+	defer span.End()
+
+	userSingle, err := r.singleUserWithFilter(ctx, database.Eq("users.id", userId))
+	if err != nil {
+		zaplogger.SetStackTrace(ctx, r.zapLogger.SetMessageLog(err))
+		return err
+	}
+
+	// the grant extends a running premium
+	premiumExpiresAt := time.Now()
+	if domain.IsPremium(userSingle.PremiumExpiresAt) {
+		premiumExpiresAt = userSingle.PremiumExpiresAt.Time
+	}
+
+	premiumExpiresAt = premiumExpiresAt.AddDate(0, 0, request.Days)
+	if err = r.mysqlUserRepository.UpdateSelectedField(ctx, []string{"premium_expires_at", "updated_at"}, map[string]interface{}{
+		"premium_expires_at": premiumExpiresAt,
+		"updated_at":         time.Now(),
+	}, userSingle.ID); err != nil {
+		zaplogger.SetStackTrace(ctx, r.zapLogger.SetMessageLog(err))
+		return err
+	}
+
+	r.auditUseCase.Record(ctx, domain.AuditEntry{
+		Action:     domain.AuditActionPremiumGrant,
+		TargetType: domain.AuditTargetUser,
+		TargetID:   userSingle.ID,
+		Before:     map[string]interface{}{"premium_expires_at": userSingle.PremiumExpiresAt},
+		After:      map[string]interface{}{"premium_expires_at": premiumExpiresAt},
+	})
+
+	return nil
+}
+
+//////////////////
+
+/////////////////// UpdateRole
+
+func (r adminUseCase) UpdateRole(ctx context.Context, userId int, request domain.UpdateRoleRequest) error {
+	ctx, cancel := context.WithTimeout(ctx, r.contextTimeout)
+	defer cancel()
+	ctx, span := tracing.Start(ctx, "adminUseCase.UpdateRole")
+	defer span.End()
+
+	userSingle, err := r.singleUserWithFilter(ctx, database.Eq("users.id", userId))
+	if err != nil {
+		zaplogger.SetStackTrace(ctx, r.zapLogger.SetMessageLog(err))
+		return err
+	}
+
+	if err = r.mysqlUserRepository.UpdateSelectedField(ctx, []string{"role", "updated_at"}, map[string]interface{}{
+		"role":       request.Role,
+		"updated_at": time.Now(),
+	}, userSingle.ID); err != nil {
+		zaplogger.SetStackTrace(ctx, r.zapLogger.SetMessageLog(err))
+		return err
+	}
+
+	r.auditUseCase.Record(ctx, domain.AuditEntry{
+		Action:     domain.AuditActionRoleUpdate,
+		TargetType: domain.AuditTargetUser,
+		TargetID:   userSingle.ID,
+		Before:     map[string]interface{}{"role": userSingle.Role},
+		After:      map[string]interface{}{"role": request.Role},
+	})
+
+	// the permissions are embedded in the token, the user has to login again
+	if err = r.jwtAuth.Ctx(ctx).DestroyIdentity(requestinfo.FromContext(ctx).Host, userSingle.ID); err != nil {
+		zaplogger.SetStackTrace(ctx, r.zapLogger.SetMessageLog(err))
+		return err
+	}
+
+	return nil
+}
+
+//////////////////
+
+/////////////////// RemovePhoto
+
+func (r adminUseCase) RemovePhoto(ctx context.Context, userId int, request domain.RemovePhotoRequest) error {
+	ctx, cancel := context.WithTimeout(ctx, r.contextTimeout)
+	defer cancel()
+	ctx, span := tracing.Start(ctx, "adminUseCase.RemovePhoto")
+	defer span.End()
+
+	authUser, err := authuser.FromContext(ctx)
+	if err != nil {
+		zaplogger.SetStackTrace(ctx, r.zapLogger.SetMessageLog(err))
+		return err
+	}
+
+	var profileSingle domain.Profile
+	if err := r.mysqlProfileRepository.SingleWithFilter(ctx, database.NewQuery().
+		Select("id", "user_id", "photo").Where(database.Eq("user_id", userId)), &profileSingle); err != nil {
+		zaplogger.SetStackTrace(ctx, r.zapLogger.SetMessageLog(err))
+		return err
+	}
+
+	now := time.Now()
+	err = r.txManager.WithinTransaction(ctx, func(ctx context.Context) error {
+		if err := r.mysqlProfileRepository.UpdateSelectedField(ctx, []string{"photo", "updated_at"}, map[string]interface{}{
+			"photo":      "",
+			"updated_at": now,
+		}, profileSingle.ID); err != nil {
+			return err
+		}
+		_, err := r.mysqlModerationRepository.Store(ctx, domain.ModerationAction{
+			UserID:    userId,
+			ActorID:   sql.NullInt64{Int64: int64(authUser.ID), Valid: true},
+			Action:    domain.ModerationActionRemovePhoto,
+			Reason:    request.Reason,
+			CreatedAt: now,
+		})
+		return err
+	})
+	if err != nil {
+		zaplogger.SetStackTrace(ctx, r.zapLogger.SetMessageLog(err))
+		return err
+	}
+
+	r.auditUseCase.Record(ctx, domain.AuditEntry{
+		Action:     domain.AuditActionPhotoRemove,
+		TargetType: domain.AuditTargetUser,
+		TargetID:   userId,
+		Before:     map[string]interface{}{"photo": profileSingle.Photo},
+		After:      map[string]interface{}{"photo": ""},
+	})
+
+	if err = helper.DeleteFileJpeg(profileSingle.Photo); err != nil {
+		zaplogger.SetStackTrace(ctx, r.zapLogger.SetMessageLog(err))
+		return err
+	}
+
+	return nil
+}
+
+//////////////////
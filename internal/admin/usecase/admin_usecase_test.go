@@ -0,0 +1,124 @@
+package usecase
+
+import (
+	"context"
+	"database/sql"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/radyatamaa/dating-apps-api/internal/domain"
+	"github.com/radyatamaa/dating-apps-api/internal/domain/mocks"
+	"github.com/radyatamaa/dating-apps-api/pkg/database"
+	"github.com/radyatamaa/dating-apps-api/pkg/authuser"
+	mockZaplogger "github.com/radyatamaa/dating-apps-api/pkg/zaplogger/mocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/suite"
+	"gorm.io/gorm"
+)
+
+type AdminUseCaseTestSuite struct {
+	suite.Suite
+}
+
+type fields struct {
+	zapLogger                 *mockZaplogger.MockLogger
+	contextTimeout            time.Duration
+	mysqlUserRepository       *mocks.UserMysqlRepository
+	mysqlProfileRepository    *mocks.ProfileMysqlRepository
+	mysqlModerationRepository *mocks.ModerationMysqlRepository
+	auditUseCase              *mocks.MockAuditUseCase
+}
+
+func toField(ctrl *gomock.Controller) fields {
+	return fields{
+		zapLogger:                 mockZaplogger.NewMockLogger(ctrl),
+		contextTimeout:            time.Second * 30,
+		mysqlUserRepository:       mocks.NewUserMysqlRepository(ctrl),
+		mysqlProfileRepository:    mocks.NewProfileMysqlRepository(ctrl),
+		mysqlModerationRepository: mocks.NewModerationMysqlRepository(ctrl),
+		auditUseCase:              mocks.NewMockAuditUseCase(ctrl),
+	}
+}
+
+func (t *AdminUseCaseTestSuite) TestAdminUseCase_GrantPremium() {
+	mockUserLogin := authuser.AuthUser{ID: 1, Email: "admin@gmail.com", ProfileID: 1}
+	ctx := authuser.NewContext(context.TODO(), mockUserLogin)
+
+	premiumExpiresAt := time.Now().AddDate(0, 0, 10)
+
+	type args struct {
+		ctx      context.Context
+		userId   int
+		request  domain.GrantPremiumRequest
+	}
+	tests := []struct {
+		name    string
+		fields  func(ctrl *gomock.Controller) fields
+		args    args
+		wantErr assert.ErrorAssertionFunc
+	}{
+		{
+			name:    "success extend running premium",
+			wantErr: assert.NoError,
+			fields: func(ctrl *gomock.Controller) fields {
+				fields := toField(ctrl)
+				fields.mysqlUserRepository.EXPECT().SingleWithFilter(gomock.Any(), adminUserQuery().Where(database.Eq("users.id", 2)), gomock.Any()).
+					SetArg(2, domain.UserQueryWithProfile{ID: 2, PremiumExpiresAt: sql.NullTime{Time: premiumExpiresAt, Valid: true}}).Return(nil)
+				fields.mysqlUserRepository.EXPECT().UpdateSelectedField(gomock.Any(), []string{"premium_expires_at", "updated_at"}, gomock.Any(), 2).
+					DoAndReturn(func(ctx context.Context, field []string, values map[string]interface{}, id int) error {
+						assert.True(t.T(), values["premium_expires_at"].(time.Time).Equal(premiumExpiresAt.AddDate(0, 0, 30)))
+						return nil
+					})
+				fields.auditUseCase.EXPECT().Record(gomock.Any(), gomock.Any()).Do(func(ctx context.Context, entry domain.AuditEntry) {
+					assert.Equal(t.T(), domain.AuditActionPremiumGrant, entry.Action)
+					assert.Equal(t.T(), 2, entry.TargetID)
+				})
+				return fields
+			},
+			args: args{
+				ctx:      ctx,
+				userId:   2,
+				request:  domain.GrantPremiumRequest{Days: 30},
+			},
+		},
+		{
+			name:    "error user not found",
+			wantErr: assert.Error,
+			fields: func(ctrl *gomock.Controller) fields {
+				fields := toField(ctrl)
+				fields.mysqlUserRepository.EXPECT().SingleWithFilter(gomock.Any(), adminUserQuery().Where(database.Eq("users.id", 3)), gomock.Any()).
+					Return(gorm.ErrRecordNotFound)
+				fields.zapLogger.EXPECT().SetMessageLog(gorm.ErrRecordNotFound)
+				return fields
+			},
+			args: args{
+				ctx:      ctx,
+				userId:   3,
+				request:  domain.GrantPremiumRequest{Days: 30},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func() {
+			ctrl := gomock.NewController(t.T())
+			defer ctrl.Finish()
+
+			fields := tt.fields(ctrl)
+			r := adminUseCase{
+				zapLogger:                 fields.zapLogger,
+				contextTimeout:            fields.contextTimeout,
+				mysqlUserRepository:       fields.mysqlUserRepository,
+				mysqlProfileRepository:    fields.mysqlProfileRepository,
+				mysqlModerationRepository: fields.mysqlModerationRepository,
+				auditUseCase:              fields.auditUseCase,
+			}
+			err := r.GrantPremium(tt.args.ctx, tt.args.userId, tt.args.request)
+			tt.wantErr(t.T(), err)
+		})
+	}
+}
+
+func TestAdminUseCaseTestSuite(t *testing.T) {
+	suite.Run(t, new(AdminUseCaseTestSuite))
+}
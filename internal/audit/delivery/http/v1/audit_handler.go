@@ -0,0 +1,93 @@
+package v1
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/radyatamaa/dating-apps-api/internal/audit"
+	"github.com/radyatamaa/dating-apps-api/pkg/database/paginator"
+
+	beego "github.com/beego/beego/v2/server/web"
+	"github.com/radyatamaa/dating-apps-api/internal"
+	"github.com/radyatamaa/dating-apps-api/internal/domain"
+	"github.com/radyatamaa/dating-apps-api/pkg/response"
+	"github.com/radyatamaa/dating-apps-api/pkg/zaplogger"
+)
+
+type AuditHandler struct {
+	ZapLogger zaplogger.Logger
+	internal.BaseController
+	response.ApiResponse
+	Usecase audit.UseCase
+}
+
+func NewAuditHandler(useCase audit.UseCase, zapLogger zaplogger.Logger) {
+	pHandler := &AuditHandler{
+		ZapLogger: zapLogger,
+		Usecase:   useCase,
+	}
+	beego.Router("/api/admin/v1/audit-logs", pHandler, "get:GetAuditLogs")
+}
+
+func (h *AuditHandler) Prepare() {
+	// check user access when needed
+	h.SetLangVersion()
+}
+
+// queryInt returns the int query param, 0 when it's empty.
+func (h *AuditHandler) queryInt(key string) (int, error) {
+	value := h.Ctx.Input.Query(key)
+	if value == "" {
+		return 0, nil
+	}
+	return strconv.Atoi(value)
+}
+
+// GetAuditLogs
+// @Title GetAuditLogs
+// @Tags Admin
+// @Summary GetAuditLogs returns the audit logs, the latest first
+// @Produce json
+// @Security ApiKeyAuth
+// @Param Accept-Language header string false "lang"
+// @Success 200 {object} swagger.BaseResponse{errors=[]object,data=domain.AuditLogResponsePaginationResponse}
+// @Failure 400 {object} swagger.BadRequestErrorValidationResponse{errors=[]swagger.ValidationErrors,data=object}
+// @Failure 403 {object} swagger.BaseResponse{errors=[]object,data=object}
+// @Failure 408 {object} swagger.RequestTimeoutResponse{errors=[]object,data=object}
+// @Failure 500 {object} swagger.InternalServerErrorResponse{errors=[]object,data=object}
+// @Param pageSize query int false "page size"
+// @Param page query int false "page"
+// @Param actorId query int false "actor user id"
+// @Param action query string false "action, ex: ROLE_UPDATE"
+// @Param targetType query string false "target type, ex: user"
+// @Param targetId query int false "target id"
+// @Router /admin/v1/audit-logs [get]
+func (h *AuditHandler) GetAuditLogs() {
+	pageSize, page, err := paginator.PaginationQueryParamValidation(h.Ctx.Input.Query("pageSize"), h.Ctx.Input.Query("page"))
+	if err != nil {
+		h.ResponseError(h.Ctx, http.StatusBadRequest, response.QueryParamInvalidCode, response.ErrorCodeText(response.QueryParamInvalidCode, h.Locale.Lang), err)
+		return
+	}
+	limit, page, offset := paginator.Pagination(page, pageSize)
+
+	filter := domain.AuditLogFilter{
+		Action:     h.Ctx.Input.Query("action"),
+		TargetType: h.Ctx.Input.Query("targetType"),
+	}
+	if filter.ActorID, err = h.queryInt("actorId"); err != nil {
+		h.ResponseError(h.Ctx, http.StatusBadRequest, response.QueryParamInvalidCode, response.ErrorCodeText(response.QueryParamInvalidCode, h.Locale.Lang), err)
+		return
+	}
+	if filter.TargetID, err = h.queryInt("targetId"); err != nil {
+		h.ResponseError(h.Ctx, http.StatusBadRequest, response.QueryParamInvalidCode, response.ErrorCodeText(response.QueryParamInvalidCode, h.Locale.Lang), err)
+		return
+	}
+
+	result, err := h.Usecase.GetAuditLogs(h.Ctx.Request.Context(), page, limit, offset, filter)
+	if err != nil {
+		h.Fail(err)
+		return
+	}
+	h.Ok(h.Ctx, h.Tr("message.success"), result)
+	return
+}
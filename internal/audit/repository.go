@@ -0,0 +1,17 @@
+package audit
+
+import (
+	"context"
+	"time"
+
+	"github.com/radyatamaa/dating-apps-api/internal/domain"
+	"github.com/radyatamaa/dating-apps-api/pkg/database"
+	"github.com/radyatamaa/dating-apps-api/pkg/database/paginator"
+)
+
+// MysqlRepository Repository Interface, audit logs are append only and removed by the retention policy
+type MysqlRepository interface {
+	FetchWithFilterAndPagination(ctx context.Context, limit int, offset int, query *database.Query, model interface{}) (*paginator.Paginator, error)
+	Store(ctx context.Context, data domain.AuditLog) (domain.AuditLog, error)
+	DeleteCreatedBefore(ctx context.Context, createdBefore time.Time) (int64, error)
+}
@@ -0,0 +1,43 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"github.com/radyatamaa/dating-apps-api/internal/audit"
+	"github.com/radyatamaa/dating-apps-api/internal/domain"
+	"github.com/radyatamaa/dating-apps-api/pkg/database"
+	baseRepository "github.com/radyatamaa/dating-apps-api/pkg/database/repository"
+	"github.com/radyatamaa/dating-apps-api/pkg/zaplogger"
+	"gorm.io/gorm"
+)
+
+type mysqlRepository struct {
+	baseRepository.Repository
+	zapLogger zaplogger.Logger
+}
+
+func NewMysqlRepository(db *gorm.DB, zapLogger zaplogger.Logger) audit.MysqlRepository {
+	return &mysqlRepository{
+		Repository: baseRepository.New(db, domain.AuditLog{}),
+		zapLogger:  zapLogger,
+	}
+}
+
+func (c mysqlRepository) Store(ctx context.Context, data domain.AuditLog) (domain.AuditLog, error) {
+
+	err := c.Create(ctx, &data)
+	if err != nil {
+		return data, err
+	}
+	return data, nil
+}
+
+func (c mysqlRepository) DeleteCreatedBefore(ctx context.Context, createdBefore time.Time) (int64, error) {
+
+	result := database.FromContext(ctx, c.DB()).Where("created_at < ?", createdBefore).Delete(&domain.AuditLog{})
+	if result.Error != nil {
+		return 0, result.Error
+	}
+	return result.RowsAffected, nil
+}
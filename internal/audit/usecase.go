@@ -0,0 +1,15 @@
+package audit
+
+import (
+	"context"
+	"time"
+
+	"github.com/radyatamaa/dating-apps-api/internal/domain"
+)
+
+// UseCase Interface
+type UseCase interface {
+	Record(ctx context.Context, entry domain.AuditEntry)
+	GetAuditLogs(ctx context.Context, page, limit, offset int, filter domain.AuditLogFilter) (*domain.AuditLogResponsePaginationResponse, error)
+	PurgeExpiredAuditLogs(ctx context.Context, createdBefore time.Time) (int64, error)
+}
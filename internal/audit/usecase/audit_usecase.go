@@ -0,0 +1,128 @@
+package usecase
+
+import (
+	"context"
+	"database/sql"
+	"time"
+
+	"github.com/radyatamaa/dating-apps-api/internal/audit"
+	"github.com/radyatamaa/dating-apps-api/internal/domain"
+	"github.com/radyatamaa/dating-apps-api/pkg/authuser"
+	"github.com/radyatamaa/dating-apps-api/pkg/database"
+	"github.com/radyatamaa/dating-apps-api/pkg/requestinfo"
+	"github.com/radyatamaa/dating-apps-api/pkg/tracing"
+	"github.com/radyatamaa/dating-apps-api/pkg/zaplogger"
+)
+
+type auditUseCase struct {
+	zapLogger            zaplogger.Logger
+	contextTimeout       time.Duration
+	mysqlAuditRepository audit.MysqlRepository
+}
+
+func NewAuditUseCase(timeout time.Duration,
+	mysqlAuditRepository audit.MysqlRepository,
+	zapLogger zaplogger.Logger) audit.UseCase {
+	return &auditUseCase{
+		mysqlAuditRepository: mysqlAuditRepository,
+		contextTimeout:       timeout,
+		zapLogger:            zapLogger,
+	}
+}
+
+/////////////////// Record
+
+// Record stores the audit log of the entry, the action is already done when it's recorded
+// so a failure is logged and doesn't fail the request.
+func (r auditUseCase) Record(ctx context.Context, entry domain.AuditEntry) {
+	ctx, cancel := context.WithTimeout(ctx, r.contextTimeout)
+	defer cancel()
+	ctx, span := tracing.Start(ctx, "auditUseCase.Record")
+	defer span.End()
+
+	info := requestinfo.FromContext(ctx)
+	auditLog := domain.AuditLog{
+		Action:     entry.Action,
+		TargetType: entry.TargetType,
+		IP:         info.IP,
+		RequestID:  info.ID,
+		CreatedAt:  time.Now(),
+	}
+	if entry.ActorID != 0 {
+		auditLog.ActorID = sql.NullInt64{Int64: int64(entry.ActorID), Valid: true}
+	} else if authUser, err := authuser.FromContext(ctx); err == nil {
+		auditLog.ActorID = sql.NullInt64{Int64: int64(authUser.ID), Valid: true}
+	}
+	if entry.TargetID != 0 {
+		auditLog.TargetID = sql.NullInt64{Int64: int64(entry.TargetID), Valid: true}
+	}
+
+	changes, err := domain.AuditChanges(entry.Before, entry.After)
+	if err != nil {
+		r.zapLogger.WithFields(zaplogger.Fields{zaplogger.FieldRequestID: info.ID}).Errorw("failed diff audit log", "action", entry.Action, zaplogger.FieldError, err)
+	}
+	auditLog.Changes = changes
+
+	if _, err = r.mysqlAuditRepository.Store(ctx, auditLog); err != nil {
+		r.zapLogger.WithFields(zaplogger.Fields{zaplogger.FieldRequestID: info.ID}).Errorw("failed record audit log", "action", entry.Action, "target_type", entry.TargetType, "target_id", entry.TargetID, zaplogger.FieldError, err)
+	}
+}
+
+//////////////////
+
+/////////////////// GetAuditLogs
+
+func (r auditUseCase) GetAuditLogs(ctx context.Context, page, limit, offset int, filter domain.AuditLogFilter) (*domain.AuditLogResponsePaginationResponse, error) {
+	ctx, cancel := context.WithTimeout(ctx, r.contextTimeout)
+	defer cancel()
+	ctx, span := tracing.Start(ctx, "auditUseCase.GetAuditLogs")
+	defer span.End()
+
+	query := database.NewQuery().OrderBy(database.Desc("id")).CacheTotal()
+	if filter.ActorID != 0 {
+		query.Where(database.Eq("actor_id", filter.ActorID))
+	}
+	if filter.Action != "" {
+		query.Where(database.Eq("action", filter.Action))
+	}
+	if filter.TargetType != "" {
+		query.Where(database.Eq("target_type", filter.TargetType))
+	}
+	if filter.TargetID != 0 {
+		query.Where(database.Eq("target_id", filter.TargetID))
+	}
+
+	var entity []domain.AuditLog
+	fetchAuditLogs, err := r.mysqlAuditRepository.FetchWithFilterAndPagination(
+		ctx,
+		limit,
+		offset,
+		query,
+		&entity,
+	)
+	if err != nil {
+		zaplogger.SetStackTrace(ctx, r.zapLogger.SetMessageLog(err))
+		return nil, err
+	}
+
+	datas := make([]domain.AuditLogResponse, 0)
+	records := fetchAuditLogs.Records.(*[]domain.AuditLog)
+	if records != nil {
+		for _, e := range *records {
+			datas = append(datas, domain.FromAuditLogToAuditLogResponse(e))
+		}
+	}
+
+	return domain.ToAuditLogResponsePaginationResponse(datas, page, limit, offset, fetchAuditLogs), nil
+}
+
+//////////////////
+
+/////////////////// PurgeExpiredAuditLogs
+
+// PurgeExpiredAuditLogs deletes the audit logs created before createdBefore and returns the number deleted.
+func (r auditUseCase) PurgeExpiredAuditLogs(ctx context.Context, createdBefore time.Time) (int64, error) {
+	return r.mysqlAuditRepository.DeleteCreatedBefore(ctx, createdBefore)
+}
+
+//////////////////
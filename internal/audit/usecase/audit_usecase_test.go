@@ -0,0 +1,119 @@
+package usecase
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/radyatamaa/dating-apps-api/internal/domain"
+	"github.com/radyatamaa/dating-apps-api/internal/domain/mocks"
+	"github.com/radyatamaa/dating-apps-api/pkg/authuser"
+	"github.com/radyatamaa/dating-apps-api/pkg/requestinfo"
+	"github.com/radyatamaa/dating-apps-api/pkg/zaplogger"
+	mockZaplogger "github.com/radyatamaa/dating-apps-api/pkg/zaplogger/mocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/suite"
+)
+
+type AuditUseCaseTestSuite struct {
+	suite.Suite
+}
+
+type fields struct {
+	zapLogger            *mockZaplogger.MockLogger
+	contextTimeout       time.Duration
+	mysqlAuditRepository *mocks.AuditMysqlRepository
+}
+
+func toField(ctrl *gomock.Controller) fields {
+	return fields{
+		zapLogger:            mockZaplogger.NewMockLogger(ctrl),
+		contextTimeout:       time.Second * 30,
+		mysqlAuditRepository: mocks.NewAuditMysqlRepository(ctrl),
+	}
+}
+
+func (t *AuditUseCaseTestSuite) TestAuditUseCase_Record() {
+	mockUserLogin := authuser.AuthUser{ID: 1, Email: "admin@gmail.com", ProfileID: 1}
+	ctx := requestinfo.NewContext(authuser.NewContext(context.TODO(), mockUserLogin), requestinfo.Info{
+		ID: "request-id",
+		IP: "10.0.0.1",
+	})
+
+	type args struct {
+		ctx   context.Context
+		entry domain.AuditEntry
+	}
+	tests := []struct {
+		name   string
+		fields func(ctrl *gomock.Controller) fields
+		args   args
+	}{
+		{
+			name: "success actor of the jwt payload",
+			fields: func(ctrl *gomock.Controller) fields {
+				fields := toField(ctrl)
+				fields.mysqlAuditRepository.EXPECT().Store(gomock.Any(), gomock.Any()).DoAndReturn(func(ctx context.Context, data domain.AuditLog) (domain.AuditLog, error) {
+					assert.Equal(t.T(), sql.NullInt64{Int64: 1, Valid: true}, data.ActorID)
+					assert.Equal(t.T(), domain.AuditActionRoleUpdate, data.Action)
+					assert.Equal(t.T(), sql.NullInt64{Int64: 2, Valid: true}, data.TargetID)
+					assert.JSONEq(t.T(), `{"role":{"before":"user","after":"admin"}}`, data.Changes)
+					assert.Equal(t.T(), "10.0.0.1", data.IP)
+					assert.Equal(t.T(), "request-id", data.RequestID)
+					return data, nil
+				})
+				return fields
+			},
+			args: args{
+				ctx: ctx,
+				entry: domain.AuditEntry{
+					Action:     domain.AuditActionRoleUpdate,
+					TargetType: domain.AuditTargetUser,
+					TargetID:   2,
+					Before:     map[string]interface{}{"role": "user", "email": "test@gmail.com"},
+					After:      map[string]interface{}{"role": "admin", "email": "test@gmail.com"},
+				},
+			},
+		},
+		{
+			name: "error store is logged",
+			fields: func(ctrl *gomock.Controller) fields {
+				fields := toField(ctrl)
+				fields.mysqlAuditRepository.EXPECT().Store(gomock.Any(), gomock.Any()).Return(domain.AuditLog{}, errors.New("context deadline exceeded"))
+				fields.zapLogger.EXPECT().WithFields(zaplogger.Fields{zaplogger.FieldRequestID: "request-id"}).Return(fields.zapLogger)
+				fields.zapLogger.EXPECT().Errorw("failed record audit log", gomock.Any())
+				return fields
+			},
+			args: args{
+				ctx: ctx,
+				entry: domain.AuditEntry{
+					ActorID:    2,
+					Action:     domain.AuditActionLogin,
+					TargetType: domain.AuditTargetUser,
+					TargetID:   2,
+				},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func() {
+			ctrl := gomock.NewController(t.T())
+			defer ctrl.Finish()
+
+			fields := tt.fields(ctrl)
+			r := auditUseCase{
+				zapLogger:            fields.zapLogger,
+				contextTimeout:       fields.contextTimeout,
+				mysqlAuditRepository: fields.mysqlAuditRepository,
+			}
+			r.Record(tt.args.ctx, tt.args.entry)
+		})
+	}
+}
+
+func TestAuditUseCaseTestSuite(t *testing.T) {
+	suite.Run(t, new(AuditUseCaseTestSuite))
+}
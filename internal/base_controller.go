@@ -3,6 +3,7 @@ package internal
 import (
 	beego "github.com/beego/beego/v2/server/web"
 	"github.com/beego/i18n"
+	"github.com/radyatamaa/dating-apps-api/pkg/response"
 )
 
 type BaseController struct {
@@ -36,3 +37,9 @@ func (r *BaseController) SetLangVersion() {
 	// Set language properties.
 	r.Lang = lang
 }
+
+// Fail responds the error returned by a usecase with the api response of its domain error,
+// the unmapped errors are responded as a server error.
+func (r *BaseController) Fail(err error) {
+	response.Fail(r.Ctx, r.Lang, err)
+}
@@ -0,0 +1,135 @@
+package v1
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/radyatamaa/dating-apps-api/internal/block"
+	"github.com/radyatamaa/dating-apps-api/pkg/database/paginator"
+	"github.com/radyatamaa/dating-apps-api/pkg/validator"
+
+	beego "github.com/beego/beego/v2/server/web"
+	"github.com/radyatamaa/dating-apps-api/internal"
+	"github.com/radyatamaa/dating-apps-api/internal/domain"
+	"github.com/radyatamaa/dating-apps-api/pkg/response"
+	"github.com/radyatamaa/dating-apps-api/pkg/zaplogger"
+)
+
+type BlockHandler struct {
+	ZapLogger zaplogger.Logger
+	internal.BaseController
+	response.ApiResponse
+	Usecase block.UseCase
+}
+
+func NewBlockHandler(useCase block.UseCase, zapLogger zaplogger.Logger) {
+	pHandler := &BlockHandler{
+		ZapLogger: zapLogger,
+		Usecase:   useCase,
+	}
+	beego.Router("/api/v1/block", pHandler, "get:GetBlockedProfiles")
+	beego.Router("/api/v1/block/profile", pHandler, "post:BlockProfile")
+	beego.Router("/api/v1/block/profile/:id", pHandler, "delete:UnblockProfile")
+}
+
+func (h *BlockHandler) Prepare() {
+	// check user access when needed
+	h.SetLangVersion()
+}
+
+// GetBlockedProfiles
+// @Title GetBlockedProfiles
+// @Tags Block
+// @Summary GetBlockedProfiles
+// @Produce json
+// @Security ApiKeyAuth
+// @Param Accept-Language header string false "lang"
+// @Success 200 {object} swagger.BaseResponse{errors=[]object,data=domain.BlockedProfileResponsePaginationResponse}
+// @Failure 400 {object} swagger.BadRequestErrorValidationResponse{errors=[]swagger.ValidationErrors,data=object}
+// @Failure 408 {object} swagger.RequestTimeoutResponse{errors=[]object,data=object}
+// @Failure 500 {object} swagger.InternalServerErrorResponse{errors=[]object,data=object}
+// @Param pageSize query int false "page size"
+// @Param page query int false "page"
+// @Router /v1/block [get]
+func (h *BlockHandler) GetBlockedProfiles() {
+	pageSize, page, err := paginator.PaginationQueryParamValidation(h.Ctx.Input.Query("pageSize"), h.Ctx.Input.Query("page"))
+	if err != nil {
+		h.ResponseError(h.Ctx, http.StatusBadRequest, response.QueryParamInvalidCode, response.ErrorCodeText(response.QueryParamInvalidCode, h.Locale.Lang), err)
+		return
+	}
+	limit, page, offset := paginator.Pagination(page, pageSize)
+
+	result, err := h.Usecase.GetBlockedProfiles(h.Ctx.Request.Context(), page, limit, offset)
+	if err != nil {
+		h.Fail(err)
+		return
+	}
+	h.Ok(h.Ctx, h.Tr("message.success"), result)
+	return
+}
+
+// BlockProfile
+// @Title BlockProfile
+// @Tags Block
+// @Summary BlockProfile hides the profile and the user login from each other
+// @Produce json
+// @Security ApiKeyAuth
+// @Param Accept-Language header string false "lang"
+// @Success 200 {object} swagger.BaseResponse{errors=[]object,data=object}
+// @Failure 400 {object} swagger.BadRequestErrorValidationResponse{errors=[]swagger.ValidationErrors,data=object}
+// @Failure 408 {object} swagger.RequestTimeoutResponse{errors=[]object,data=object}
+// @Failure 500 {object} swagger.InternalServerErrorResponse{errors=[]object,data=object}
+// @Param body body domain.BlockProfileRequest true "request payload"
+// @Router /v1/block/profile [post]
+func (h *BlockHandler) BlockProfile() {
+	var request domain.BlockProfileRequest
+
+	if err := h.BindJSON(&request); err != nil {
+		h.Ctx.Input.SetData("stackTrace", h.ZapLogger.SetMessageLog(err))
+		h.ResponseError(h.Ctx, http.StatusBadRequest, response.ApiValidationCodeError, response.ErrorCodeText(response.ApiValidationCodeError, h.Locale.Lang), err)
+		return
+	}
+	if err := validator.Validate.ValidateStruct(&request); err != nil {
+		h.Ctx.Input.SetData("stackTrace", h.ZapLogger.SetMessageLog(err))
+		h.ResponseError(h.Ctx, http.StatusBadRequest, response.ApiValidationCodeError, response.ErrorCodeText(response.ApiValidationCodeError, h.Locale.Lang), err)
+		return
+	}
+
+	err := h.Usecase.BlockProfile(h.Ctx.Request.Context(), request)
+	if err != nil {
+		h.Fail(err)
+		return
+	}
+	h.Ok(h.Ctx, h.Tr("message.success"), nil)
+	return
+}
+
+// UnblockProfile
+// @Title UnblockProfile
+// @Tags Block
+// @Summary UnblockProfile
+// @Produce json
+// @Security ApiKeyAuth
+// @Param Accept-Language header string false "lang"
+// @Param id path int true "profile id"
+// @Success 200 {object} swagger.BaseResponse{errors=[]object,data=object}
+// @Failure 400 {object} swagger.BadRequestErrorValidationResponse{errors=[]swagger.ValidationErrors,data=object}
+// @Failure 408 {object} swagger.RequestTimeoutResponse{errors=[]object,data=object}
+// @Failure 500 {object} swagger.InternalServerErrorResponse{errors=[]object,data=object}
+// @Router /v1/block/profile/{id} [delete]
+func (h *BlockHandler) UnblockProfile() {
+	profileId, err := strconv.Atoi(h.Ctx.Input.Param(":id"))
+	if err != nil {
+		h.Ctx.Input.SetData("stackTrace", h.ZapLogger.SetMessageLog(err))
+		h.ResponseError(h.Ctx, http.StatusBadRequest, response.PathParamInvalidCode, response.ErrorCodeText(response.PathParamInvalidCode, h.Locale.Lang), err)
+		return
+	}
+
+	err = h.Usecase.UnblockProfile(h.Ctx.Request.Context(), profileId)
+	if err != nil {
+		h.Fail(err)
+		return
+	}
+	h.Ok(h.Ctx, h.Tr("message.success"), nil)
+	return
+}
@@ -0,0 +1,20 @@
+package block
+
+import (
+	"context"
+	"github.com/radyatamaa/dating-apps-api/internal/domain"
+	"github.com/radyatamaa/dating-apps-api/pkg/database"
+	"github.com/radyatamaa/dating-apps-api/pkg/database/paginator"
+)
+
+// MysqlRepository Repository Interface
+type MysqlRepository interface {
+	FetchWithFilterAndPagination(ctx context.Context, limit int, offset int, query *database.Query, model interface{}) (*paginator.Paginator, error)
+	SingleWithFilter(ctx context.Context, query *database.Query, model interface{}) error
+	FetchWithFilter(ctx context.Context, query *database.Query, model interface{}) (interface{}, error)
+	Update(ctx context.Context, data domain.Block) error
+	UpdateSelectedField(ctx context.Context, field []string, values map[string]interface{}, id int) error
+	Store(ctx context.Context, data domain.Block) (domain.Block, error)
+	Delete(ctx context.Context, id int) (int, error)
+	FetchBlockedUserIds(ctx context.Context, userId int) ([]int, error)
+}
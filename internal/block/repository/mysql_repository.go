@@ -0,0 +1,50 @@
+package repository
+
+import (
+	"context"
+	"github.com/radyatamaa/dating-apps-api/internal/block"
+
+	"github.com/radyatamaa/dating-apps-api/internal/domain"
+	"github.com/radyatamaa/dating-apps-api/pkg/database"
+	baseRepository "github.com/radyatamaa/dating-apps-api/pkg/database/repository"
+	"github.com/radyatamaa/dating-apps-api/pkg/zaplogger"
+	"gorm.io/gorm"
+)
+
+type mysqlRepository struct {
+	baseRepository.Repository
+	zapLogger zaplogger.Logger
+}
+
+func NewMysqlRepository(db *gorm.DB, zapLogger zaplogger.Logger) block.MysqlRepository {
+	return &mysqlRepository{
+		Repository: baseRepository.New(db, domain.Block{}, domain.Profile{}),
+		zapLogger:  zapLogger,
+	}
+}
+
+func (c mysqlRepository) Update(ctx context.Context, data domain.Block) error {
+	return c.Updates(ctx, &data)
+}
+
+func (c mysqlRepository) Store(ctx context.Context, data domain.Block) (domain.Block, error) {
+
+	err := c.Create(ctx, &data)
+	if err != nil {
+		return data, err
+	}
+	return data, nil
+}
+
+// FetchBlockedUserIds returns the users blocked by the user and the users who blocked the user.
+func (c mysqlRepository) FetchBlockedUserIds(ctx context.Context, userId int) ([]int, error) {
+	ids := make([]int, 0)
+
+	err := database.FromContext(ctx, c.DB()).Raw(
+		"SELECT blocked_user_id FROM "+c.Table()+" WHERE user_id = ? UNION SELECT user_id FROM "+c.Table()+" WHERE blocked_user_id = ?",
+		userId, userId).Scan(&ids).Error
+	if err != nil {
+		return nil, err
+	}
+	return ids, nil
+}
@@ -0,0 +1,14 @@
+package block
+
+import (
+	"context"
+
+	"github.com/radyatamaa/dating-apps-api/internal/domain"
+)
+
+// UseCase Interface
+type UseCase interface {
+	BlockProfile(ctx context.Context, request domain.BlockProfileRequest) error
+	UnblockProfile(ctx context.Context, profileId int) error
+	GetBlockedProfiles(ctx context.Context, page, limit, offset int) (*domain.BlockedProfileResponsePaginationResponse, error)
+}
@@ -0,0 +1,186 @@
+package usecase
+
+import (
+	"context"
+	"time"
+
+	"github.com/radyatamaa/dating-apps-api/internal/block"
+	"github.com/radyatamaa/dating-apps-api/internal/domain"
+	"github.com/radyatamaa/dating-apps-api/internal/profile"
+	"github.com/radyatamaa/dating-apps-api/pkg/authuser"
+	"github.com/radyatamaa/dating-apps-api/pkg/database"
+	"github.com/radyatamaa/dating-apps-api/pkg/response"
+	"github.com/radyatamaa/dating-apps-api/pkg/tracing"
+	"github.com/radyatamaa/dating-apps-api/pkg/zaplogger"
+	"gorm.io/gorm"
+)
+
+type blockUseCase struct {
+	zapLogger              zaplogger.Logger
+	contextTimeout         time.Duration
+	mysqlBlockRepository   block.MysqlRepository
+	mysqlProfileRepository profile.MysqlRepository
+}
+
+func NewBlockUseCase(timeout time.Duration,
+	mysqlBlockRepository block.MysqlRepository,
+	mysqlProfileRepository profile.MysqlRepository,
+	zapLogger zaplogger.Logger) block.UseCase {
+	return &blockUseCase{
+		mysqlBlockRepository:   mysqlBlockRepository,
+		mysqlProfileRepository: mysqlProfileRepository,
+		contextTimeout:         timeout,
+		zapLogger:              zapLogger,
+	}
+}
+
+func (r blockUseCase) singleProfileWithFilter(ctx context.Context, criteria ...database.Criterion) (*domain.Profile, error) {
+	var entity domain.Profile
+	if err := r.mysqlProfileRepository.SingleWithFilter(ctx, database.NewQuery().Select("id", "user_id").Where(criteria...), &entity); err != nil {
+		return nil, err
+	}
+	return &entity, nil
+}
+
+func (r blockUseCase) singleBlockWithFilter(ctx context.Context, criteria ...database.Criterion) (*domain.Block, error) {
+	var entity domain.Block
+	if err := r.mysqlBlockRepository.SingleWithFilter(ctx, database.NewQuery().Where(criteria...), &entity); err != nil {
+		return nil, err
+	}
+	return &entity, nil
+}
+
+/////////////////// BlockProfile
+
+func (r blockUseCase) BlockProfile(ctx context.Context, request domain.BlockProfileRequest) error {
+	ctx, cancel := context.WithTimeout(ctx, r.contextTimeout)
+	defer cancel()
+	ctx, span := tracing.Start(ctx, "blockUseCase.BlockProfile")
+	defer span.End()
+
+	authUser, err := authuser.FromContext(ctx)
+	if err != nil {
+		zaplogger.SetStackTrace(ctx, r.zapLogger.SetMessageLog(err))
+		return err
+	}
+	userId := authUser.ID
+
+	blockedProfile, err := r.singleProfileWithFilter(ctx, database.Eq("id", request.ProfileID))
+	if err != nil {
+		zaplogger.SetStackTrace(ctx, r.zapLogger.SetMessageLog(err))
+		return err
+	}
+	if blockedProfile.UserID == userId {
+		zaplogger.SetStackTrace(ctx, r.zapLogger.SetMessageLog(response.ErrCannotBlockSelf))
+		return response.ErrCannotBlockSelf
+	}
+
+	// blocking twice is a no-op
+	_, err = r.singleBlockWithFilter(ctx, database.Eq("user_id", userId), database.Eq("blocked_user_id", blockedProfile.UserID))
+	if err == nil {
+		return nil
+	}
+	if err != gorm.ErrRecordNotFound {
+		zaplogger.SetStackTrace(ctx, r.zapLogger.SetMessageLog(err))
+		return err
+	}
+
+	if _, err = r.mysqlBlockRepository.Store(ctx, domain.Block{
+		UserID:        userId,
+		BlockedUserID: blockedProfile.UserID,
+	}); err != nil {
+		zaplogger.SetStackTrace(ctx, r.zapLogger.SetMessageLog(err))
+		return err
+	}
+
+	return nil
+}
+
+//////////////////
+
+/////////////////// UnblockProfile
+
+func (r blockUseCase) UnblockProfile(ctx context.Context, profileId int) error {
+	ctx, cancel := context.WithTimeout(ctx, r.contextTimeout)
+	defer cancel()
+	ctx, span := tracing.Start(ctx, "blockUseCase.UnblockProfile")
+	defer span.End()
+
+	authUser, err := authuser.FromContext(ctx)
+	if err != nil {
+		zaplogger.SetStackTrace(ctx, r.zapLogger.SetMessageLog(err))
+		return err
+	}
+
+	blockedProfile, err := r.singleProfileWithFilter(ctx, database.Eq("id", profileId))
+	if err != nil {
+		zaplogger.SetStackTrace(ctx, r.zapLogger.SetMessageLog(err))
+		return err
+	}
+
+	blockSingle, err := r.singleBlockWithFilter(ctx, database.Eq("user_id", authUser.ID), database.Eq("blocked_user_id", blockedProfile.UserID))
+	if err != nil {
+		zaplogger.SetStackTrace(ctx, r.zapLogger.SetMessageLog(err))
+		return err
+	}
+
+	if _, err = r.mysqlBlockRepository.Delete(ctx, blockSingle.ID); err != nil {
+		zaplogger.SetStackTrace(ctx, r.zapLogger.SetMessageLog(err))
+		return err
+	}
+
+	return nil
+}
+
+//////////////////
+
+/////////////////// GetBlockedProfiles
+
+func (r blockUseCase) GetBlockedProfiles(ctx context.Context, page, limit, offset int) (*domain.BlockedProfileResponsePaginationResponse, error) {
+	ctx, cancel := context.WithTimeout(ctx, r.contextTimeout)
+	defer cancel()
+	ctx, span := tracing.Start(ctx, "blockUseCase.GetBlockedProfiles")
+	defer span.End()
+
+	authUser, err := authuser.FromContext(ctx)
+	if err != nil {
+		zaplogger.SetStackTrace(ctx, r.zapLogger.SetMessageLog(err))
+		return nil, err
+	}
+
+	var entity []domain.BlockQueryWithProfile
+	fetchBlocks, err := r.mysqlBlockRepository.FetchWithFilterAndPagination(
+		ctx,
+		limit,
+		offset,
+		database.NewQuery().
+			Select(
+				"blocks.id",
+				"blocks.blocked_user_id",
+				"blocks.created_at",
+				"profile.id as profile_id",
+				"profile.name as name",
+				"profile.photo as photo",
+			).
+			Join(database.InnerJoin("profile", database.EqColumn("profile.user_id", "blocks.blocked_user_id"))).
+			Where(database.Eq("blocks.user_id", authUser.ID)).
+			OrderBy(database.Desc("blocks.id")),
+		&entity,
+	)
+	if err != nil {
+		zaplogger.SetStackTrace(ctx, r.zapLogger.SetMessageLog(err))
+		return nil, err
+	}
+
+	datas := make([]domain.BlockedProfileResponse, 0)
+	records := fetchBlocks.Records.(*[]domain.BlockQueryWithProfile)
+	if records != nil {
+		for _, e := range *records {
+			datas = append(datas, domain.FromBlockToBlockedProfileResponse(e))
+		}
+	}
+
+	return domain.ToBlockedProfileResponsePaginationResponse(datas, page, limit, offset, fetchBlocks), nil
+}
+
+//////////////////
@@ -0,0 +1,126 @@
+package usecase
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/radyatamaa/dating-apps-api/internal/domain"
+	"github.com/radyatamaa/dating-apps-api/internal/domain/mocks"
+	"github.com/radyatamaa/dating-apps-api/pkg/database"
+	"github.com/radyatamaa/dating-apps-api/pkg/authuser"
+	"github.com/radyatamaa/dating-apps-api/pkg/response"
+	mockZaplogger "github.com/radyatamaa/dating-apps-api/pkg/zaplogger/mocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/suite"
+	"gorm.io/gorm"
+)
+
+type BlockUseCaseTestSuite struct {
+	suite.Suite
+}
+
+type fields struct {
+	zapLogger              *mockZaplogger.MockLogger
+	contextTimeout         time.Duration
+	mysqlBlockRepository   *mocks.BlockMysqlRepository
+	mysqlProfileRepository *mocks.ProfileMysqlRepository
+}
+
+func toField(ctrl *gomock.Controller) fields {
+	return fields{
+		zapLogger:              mockZaplogger.NewMockLogger(ctrl),
+		contextTimeout:         time.Second * 30,
+		mysqlBlockRepository:   mocks.NewBlockMysqlRepository(ctrl),
+		mysqlProfileRepository: mocks.NewProfileMysqlRepository(ctrl),
+	}
+}
+
+func (t *BlockUseCaseTestSuite) TestBlockUseCase_BlockProfile() {
+	mockUserLogin := authuser.AuthUser{ID: 1, Email: "test@gmail.com", ProfileID: 1}
+	ctx := authuser.NewContext(context.TODO(), mockUserLogin)
+
+	type args struct {
+		ctx      context.Context
+		request  domain.BlockProfileRequest
+	}
+	tests := []struct {
+		name    string
+		fields  func(ctrl *gomock.Controller) fields
+		args    args
+		wantErr assert.ErrorAssertionFunc
+	}{
+		{
+			name:    "success",
+			wantErr: assert.NoError,
+			fields: func(ctrl *gomock.Controller) fields {
+				fields := toField(ctrl)
+				fields.mysqlProfileRepository.EXPECT().SingleWithFilter(gomock.Any(), database.NewQuery().Select("id", "user_id").Where(database.Eq("id", 2)), gomock.Any()).
+					SetArg(2, domain.Profile{ID: 2, UserID: 2}).Return(nil)
+				fields.mysqlBlockRepository.EXPECT().SingleWithFilter(gomock.Any(), database.NewQuery().Where(database.Eq("user_id", 1), database.Eq("blocked_user_id", 2)), gomock.Any()).
+					Return(gorm.ErrRecordNotFound)
+				fields.mysqlBlockRepository.EXPECT().Store(gomock.Any(), domain.Block{UserID: 1, BlockedUserID: 2}).
+					Return(domain.Block{ID: 1, UserID: 1, BlockedUserID: 2}, nil)
+				return fields
+			},
+			args: args{
+				ctx:      ctx,
+				request:  domain.BlockProfileRequest{ProfileID: 2},
+			},
+		},
+		{
+			name:    "success already blocked",
+			wantErr: assert.NoError,
+			fields: func(ctrl *gomock.Controller) fields {
+				fields := toField(ctrl)
+				fields.mysqlProfileRepository.EXPECT().SingleWithFilter(gomock.Any(), database.NewQuery().Select("id", "user_id").Where(database.Eq("id", 2)), gomock.Any()).
+					SetArg(2, domain.Profile{ID: 2, UserID: 2}).Return(nil)
+				fields.mysqlBlockRepository.EXPECT().SingleWithFilter(gomock.Any(), database.NewQuery().Where(database.Eq("user_id", 1), database.Eq("blocked_user_id", 2)), gomock.Any()).
+					SetArg(2, domain.Block{ID: 1, UserID: 1, BlockedUserID: 2}).Return(nil)
+				return fields
+			},
+			args: args{
+				ctx:      ctx,
+				request:  domain.BlockProfileRequest{ProfileID: 2},
+			},
+		},
+		{
+			name: "error block self",
+			wantErr: func(t assert.TestingT, err error, i ...interface{}) bool {
+				return assert.ErrorIs(t, err, response.ErrCannotBlockSelf)
+			},
+			fields: func(ctrl *gomock.Controller) fields {
+				fields := toField(ctrl)
+				fields.mysqlProfileRepository.EXPECT().SingleWithFilter(gomock.Any(), database.NewQuery().Select("id", "user_id").Where(database.Eq("id", 1)), gomock.Any()).
+					SetArg(2, domain.Profile{ID: 1, UserID: 1}).Return(nil)
+				fields.zapLogger.EXPECT().SetMessageLog(response.ErrCannotBlockSelf)
+				return fields
+			},
+			args: args{
+				ctx:      ctx,
+				request:  domain.BlockProfileRequest{ProfileID: 1},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func() {
+			ctrl := gomock.NewController(t.T())
+			defer ctrl.Finish()
+
+			fields := tt.fields(ctrl)
+			r := blockUseCase{
+				zapLogger:              fields.zapLogger,
+				contextTimeout:         fields.contextTimeout,
+				mysqlBlockRepository:   fields.mysqlBlockRepository,
+				mysqlProfileRepository: fields.mysqlProfileRepository,
+			}
+			err := r.BlockProfile(tt.args.ctx, tt.args.request)
+			tt.wantErr(t.T(), err)
+		})
+	}
+}
+
+func TestBlockUseCaseTestSuite(t *testing.T) {
+	suite.Run(t, new(BlockUseCaseTestSuite))
+}
@@ -0,0 +1,306 @@
+package config
+
+import (
+	"time"
+
+	"github.com/radyatamaa/dating-apps-api/pkg/database"
+	"github.com/radyatamaa/dating-apps-api/pkg/tracing"
+	"github.com/radyatamaa/dating-apps-api/pkg/zaplogger"
+)
+
+// Config is the typed configuration of the application.
+//
+// A field tagged with config is read from the ini key, then overridden by the environment
+// variable of the env tag and then by the secret file of a field tagged with secret,
+// a slice is split by the sep tag, ex: lang="en|id". A secret tagged required must be
+// changed from its default in prod.
+type Config struct {
+	RunMode string
+
+	App        AppConfig
+	Log        LogConfig
+	Jwt        JwtConfig
+	Redis      RedisConfig
+	Database   DatabaseConfig
+	AccessLog  AccessLogConfig
+	RateLimit  RateLimitConfig
+	Account    AccountConfig
+	OAuth      OAuthConfig
+	Moderation ModerationConfig
+	Audit      AuditConfig
+	Event      EventConfig
+	Worker     WorkerConfig
+	Grpc       GrpcConfig
+	Admin      AdminConfig
+	Health     HealthConfig
+	Metrics    MetricsConfig
+	Tracing    TracingConfig
+	Migration  MigrationConfig
+}
+
+type AppConfig struct {
+	Version string   `config:"version" env:"APP_VERSION"`
+	Lang    []string `config:"lang" env:"APP_LANG" sep:"|"`
+	// ServerTimeout of the http server in seconds.
+	ServerTimeout int64 `config:"serverTimeout" env:"SERVER_TIMEOUT"`
+	// ExecutionTimeout of the usecases in seconds.
+	ExecutionTimeout           int  `config:"executionTimeout" env:"EXECUTION_TIMEOUT"`
+	InitDataDummyProfileSeeder bool `config:"initDataDummyProfileSeeder" env:"INIT_DATA_DUMMY_PROFILE_SEEDER"`
+}
+
+type LogConfig struct {
+	Path            string `config:"logPath" env:"LOG_PATH"`
+	Encoding        string `config:"logEncoding" env:"LOG_ENCODING"`
+	SlackWebhookUrl string `config:"slackWebhookUrlLog" env:"SLACK_WEBHOOK_URL_LOG" secret:"optional"`
+}
+
+type JwtConfig struct {
+	SecretKey string `config:"jwtSecretKey" env:"JWT_SECRET_KEY" secret:"required"`
+	// TokenExpired in seconds.
+	TokenExpired int64 `config:"tokenExpired" env:"TOKEN_EXPIRED"`
+}
+
+type RedisConfig struct {
+	// Connection config of the beego redis cache, ex: {"conn":"127.0.0.1:6379"}.
+	Connection string `config:"redisBeegoConConfig" env:"REDIS_CONNECTION" secret:"optional"`
+}
+
+type DatabaseConfig struct {
+	Driver   string `config:"database::driver" env:"DATABASE_DRIVER"`
+	Host     string `config:"database::host" env:"DATABASE_HOST"`
+	Port     string `config:"database::port" env:"DATABASE_PORT"`
+	Name     string `config:"database::name" env:"DATABASE_NAME"`
+	Username string `config:"database::username" env:"DATABASE_USERNAME"`
+	Password string `config:"database::password" env:"DATABASE_PASSWORD" secret:"required"`
+	Options  string `config:"database::options" env:"DATABASE_OPTIONS"`
+	Debug    bool   `config:"database::debug" env:"DATABASE_DEBUG"`
+	// MaxLifeTimeConn and MaxIdleTimeConn in seconds.
+	MaxOpenConn     int `config:"database::maxOpenConn" env:"DATABASE_MAX_OPEN_CONN"`
+	MaxIdleConn     int `config:"database::maxIdleConn" env:"DATABASE_MAX_IDLE_CONN"`
+	MaxLifeTimeConn int `config:"database::maxLifeTimeConn" env:"DATABASE_MAX_LIFE_TIME_CONN"`
+	MaxIdleTimeConn int `config:"database::maxIdleTimeConn" env:"DATABASE_MAX_IDLE_TIME_CONN"`
+	// Replicas as host:port serve the reads, with the credentials of the primary.
+	Replicas             []string      `config:"database::replicas" env:"DATABASE_REPLICAS" sep:"|"`
+	ReplicaMaxLag        time.Duration `config:"database::replicaMaxLag" env:"DATABASE_REPLICA_MAX_LAG"`
+	ReplicaCheckInterval time.Duration `config:"database::replicaCheckInterval" env:"DATABASE_REPLICA_CHECK_INTERVAL"`
+	// CountCacheTTL of the totals of the paginated listings in redis, 0 disables the cache.
+	CountCacheTTL time.Duration `config:"database::countCacheTTL" env:"DATABASE_COUNT_CACHE_TTL"`
+}
+
+type AccessLogConfig struct {
+	// the unset fields keep the defaults of the access log middleware
+	RedactRequestFields  []string `config:"accesslog::redactRequestFields" env:"ACCESSLOG_REDACT_REQUEST_FIELDS"`
+	RedactResponseFields []string `config:"accesslog::redactResponseFields" env:"ACCESSLOG_REDACT_RESPONSE_FIELDS"`
+	SkipFormFields       []string `config:"accesslog::skipFormFields" env:"ACCESSLOG_SKIP_FORM_FIELDS"`
+	MaxBodySize          int      `config:"accesslog::maxBodySize" env:"ACCESSLOG_MAX_BODY_SIZE"`
+}
+
+type RateLimitConfig struct {
+	Enabled bool   `config:"ratelimit::enabled" env:"RATELIMIT_ENABLED"`
+	Store   string `config:"ratelimit::store" env:"RATELIMIT_STORE"`
+	KeyBy   string `config:"ratelimit::keyBy" env:"RATELIMIT_KEY_BY"`
+	Default string `config:"ratelimit::default" env:"RATELIMIT_DEFAULT"`
+	Routes  string `config:"ratelimit::routes" env:"RATELIMIT_ROUTES"`
+}
+
+type AccountConfig struct {
+	DeletionGracePeriod time.Duration `config:"account::deletionGracePeriod" env:"ACCOUNT_DELETION_GRACE_PERIOD"`
+	PurgeInterval       time.Duration `config:"account::purgeInterval" env:"ACCOUNT_PURGE_INTERVAL"`
+	ExportPath          string        `config:"account::exportPath" env:"ACCOUNT_EXPORT_PATH"`
+	ExportExpire        time.Duration `config:"account::exportExpire" env:"ACCOUNT_EXPORT_EXPIRE"`
+}
+
+type OAuthConfig struct {
+	Providers []string `config:"oauth::providers" env:"OAUTH_PROVIDERS" sep:"|"`
+
+	// Provider by name is read from the [oauth_<name>] section and the OAUTH_<NAME>_ environment variables.
+	Provider map[string]OAuthProviderConfig
+}
+
+type OAuthProviderConfig struct {
+	Issuer       string   `config:"issuer" env:"ISSUER"`
+	ClientID     string   `config:"clientId" env:"CLIENT_ID"`
+	ClientSecret string   `config:"clientSecret" env:"CLIENT_SECRET" secret:"optional"`
+	RedirectURL  string   `config:"redirectUrl" env:"REDIRECT_URL"`
+	Scopes       []string `config:"scopes" env:"SCOPES" sep:"|"`
+}
+
+type ModerationConfig struct {
+	AutoHideThreshold int `config:"moderation::autoHideThreshold" env:"MODERATION_AUTO_HIDE_THRESHOLD"`
+}
+
+type AuditConfig struct {
+	Retention time.Duration `config:"audit::retention" env:"AUDIT_RETENTION"`
+}
+
+type EventConfig struct {
+	// Broker the outbox relay publishes the domain events to, none delivers them to the in process subscribers.
+	Broker         string        `config:"event::broker" env:"EVENT_BROKER"`
+	RelayInterval  time.Duration `config:"event::relayInterval" env:"EVENT_RELAY_INTERVAL"`
+	RelayBatchSize int           `config:"event::relayBatchSize" env:"EVENT_RELAY_BATCH_SIZE"`
+	MaxAttempts    int           `config:"event::maxAttempts" env:"EVENT_MAX_ATTEMPTS"`
+	Retention      time.Duration `config:"event::retention" env:"EVENT_RETENTION"`
+	KafkaBrokers   []string      `config:"event::kafkaBrokers" env:"EVENT_KAFKA_BROKERS" sep:"|"`
+	KafkaTopic     string        `config:"event::kafkaTopic" env:"EVENT_KAFKA_TOPIC"`
+	KafkaGroupID   string        `config:"event::kafkaGroupId" env:"EVENT_KAFKA_GROUP_ID"`
+	// KafkaDeadLetterTopic receives the consumed events failing MaxAttempts times, empty logs and skips them
+	KafkaDeadLetterTopic string `config:"event::kafkaDeadLetterTopic" env:"EVENT_KAFKA_DEAD_LETTER_TOPIC"`
+}
+
+type WorkerConfig struct {
+	// Enabled runs the jobs in the api process, else they're run by the worker command
+	Enabled bool `config:"worker::enabled" env:"WORKER_ENABLED"`
+	// LockStore of the runs, memory only locks the runs of the process
+	LockStore    string        `config:"worker::lockStore" env:"WORKER_LOCK_STORE"`
+	Timeout      time.Duration `config:"worker::timeout" env:"WORKER_TIMEOUT"`
+	Retries      int           `config:"worker::retries" env:"WORKER_RETRIES"`
+	RetryBackoff time.Duration `config:"worker::retryBackoff" env:"WORKER_RETRY_BACKOFF"`
+
+	ExpirePremiumSchedule  string        `config:"worker::expirePremiumSchedule" env:"WORKER_EXPIRE_PREMIUM_SCHEDULE"`
+	CleanStorageSchedule   string        `config:"worker::cleanStorageSchedule" env:"WORKER_CLEAN_STORAGE_SCHEDULE"`
+	StorageOrphanAge       time.Duration `config:"worker::storageOrphanAge" env:"WORKER_STORAGE_ORPHAN_AGE"`
+	RecommendationSchedule string        `config:"worker::recommendationSchedule" env:"WORKER_RECOMMENDATION_SCHEDULE"`
+	RecommendationWindow   time.Duration `config:"worker::recommendationWindow" env:"WORKER_RECOMMENDATION_WINDOW"`
+}
+
+type GrpcConfig struct {
+	// Enabled serves the grpc api of user, profile and swipe on Port beside the rest api
+	Enabled bool `config:"grpc::enabled" env:"GRPC_ENABLED"`
+	Port    int  `config:"grpc::port" env:"GRPC_PORT"`
+}
+
+type AdminConfig struct {
+	BootstrapEmails []string `config:"admin::bootstrapEmails" env:"ADMIN_BOOTSTRAP_EMAILS" sep:"|"`
+}
+
+type HealthConfig struct {
+	Timeout time.Duration `config:"health::timeout" env:"HEALTH_TIMEOUT"`
+}
+
+type MetricsConfig struct {
+	Enabled bool   `config:"metrics::enabled" env:"METRICS_ENABLED"`
+	Path    string `config:"metrics::path" env:"METRICS_PATH"`
+	// Port serves the metrics on their own listener, they're not exposed on the public port of the api
+	Port int `config:"metrics::port" env:"METRICS_PORT"`
+}
+
+type MigrationConfig struct {
+	// AutoApply applies the pending migrations at startup, else the server refuses to start while migrations are pending.
+	AutoApply bool `config:"migration::autoApply" env:"MIGRATION_AUTO_APPLY"`
+}
+
+type TracingConfig struct {
+	Exporter    string  `config:"tracing::exporter" env:"TRACING_EXPORTER"`
+	ServiceName string  `config:"tracing::serviceName" env:"TRACING_SERVICE_NAME"`
+	Endpoint    string  `config:"tracing::endpoint" env:"TRACING_ENDPOINT"`
+	Insecure    bool    `config:"tracing::insecure" env:"TRACING_INSECURE"`
+	SampleRatio float64 `config:"tracing::sampleRatio" env:"TRACING_SAMPLE_RATIO"`
+}
+
+// Default returns the config used when a value is not set.
+func Default() Config {
+	return Config{
+		RunMode: RunModeDev,
+		App: AppConfig{
+			Version:                    "1",
+			Lang:                       []string{"en", "id"},
+			ServerTimeout:              60,
+			ExecutionTimeout:           5,
+			InitDataDummyProfileSeeder: true,
+		},
+		Log: LogConfig{
+			Path:     "./logs/api.log",
+			Encoding: zaplogger.EncodingConsole,
+		},
+		Jwt: JwtConfig{
+			SecretKey:    "secret",
+			TokenExpired: 86400,
+		},
+		Redis: RedisConfig{
+			Connection: `{"conn":"127.0.0.1:6379"}`,
+		},
+		Database: DatabaseConfig{
+			Driver:               "mysql",
+			Debug:                true,
+			MaxOpenConn:          25,
+			MaxIdleConn:          25,
+			MaxLifeTimeConn:      300,
+			MaxIdleTimeConn:      300,
+			ReplicaMaxLag:        database.DefaultReplicaMaxLag,
+			ReplicaCheckInterval: database.DefaultReplicaCheckInterval,
+			CountCacheTTL:        30 * time.Second,
+		},
+		RateLimit: RateLimitConfig{
+			Enabled: true,
+			Store:   RateLimitStoreMemory,
+			KeyBy:   RateLimitKeyByIPAndUID,
+			Default: "120/1m",
+			Routes:  "POST:/api/v1/user/register=5/1m;POST:/api/v1/user/login=10/1m;POST:/api/v1/user/login/verify=10/1m;GET:/api/v1/oauth/*=20/1m",
+		},
+		Account: AccountConfig{
+			DeletionGracePeriod: 720 * time.Hour,
+			PurgeInterval:       time.Hour,
+			ExportPath:          "./storage/exports",
+			ExportExpire:        168 * time.Hour,
+		},
+		OAuth: OAuthConfig{
+			Provider: map[string]OAuthProviderConfig{},
+		},
+		Moderation: ModerationConfig{
+			AutoHideThreshold: 5,
+		},
+		Audit: AuditConfig{
+			Retention: 8760 * time.Hour,
+		},
+		Event: EventConfig{
+			Broker:               EventBrokerNone,
+			RelayInterval:        time.Second,
+			RelayBatchSize:       100,
+			MaxAttempts:          10,
+			Retention:            168 * time.Hour,
+			KafkaTopic:           "dating-apps.domain-events",
+			KafkaGroupID:         "dating-apps-api",
+			KafkaDeadLetterTopic: "dating-apps.domain-events.dead-letter",
+		},
+		Worker: WorkerConfig{
+			Enabled:                true,
+			LockStore:              WorkerLockStoreRedis,
+			Timeout:                5 * time.Minute,
+			Retries:                3,
+			RetryBackoff:           time.Second,
+			ExpirePremiumSchedule:  "*/5 * * * *",
+			CleanStorageSchedule:   "0 3 * * *",
+			StorageOrphanAge:       24 * time.Hour,
+			RecommendationSchedule: "*/30 * * * *",
+			RecommendationWindow:   720 * time.Hour,
+		},
+		Grpc: GrpcConfig{
+			Port: 9090,
+		},
+		Health: HealthConfig{
+			Timeout: 2 * time.Second,
+		},
+		Metrics: MetricsConfig{
+			Enabled: true,
+			Path:    "/metrics",
+			Port:    9100,
+		},
+		Tracing: TracingConfig{
+			Exporter:    tracing.ExporterNone,
+			ServiceName: "dating-apps-api",
+			SampleRatio: 1,
+		},
+	}
+}
+
+// DefaultOAuthProvider returns the provider config used when a value is not set.
+func DefaultOAuthProvider() OAuthProviderConfig {
+	return OAuthProviderConfig{
+		Scopes: []string{"email", "profile"},
+	}
+}
+
+// ExecutionTimeoutDuration returns the execution timeout of the usecases.
+func (c AppConfig) ExecutionTimeoutDuration() time.Duration {
+	return time.Duration(c.ExecutionTimeout) * time.Second
+}
@@ -0,0 +1,212 @@
+package config
+
+import (
+	"errors"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/radyatamaa/dating-apps-api/pkg/database"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type mapSource map[string]string
+
+func (m mapSource) String(key string) (string, error) {
+	if value, ok := m[key]; ok {
+		return value, nil
+	}
+	return "", errors.New("key not found")
+}
+
+func validSource() mapSource {
+	return mapSource{
+		"database::host":     "localhost",
+		"database::port":     "3306",
+		"database::name":     "dating_apps",
+		"database::username": "root",
+	}
+}
+
+func envOf(env map[string]string) func(string) (string, bool) {
+	return func(key string) (string, bool) {
+		value, ok := env[key]
+		return value, ok
+	}
+}
+
+func TestLoader_Load(t *testing.T) {
+	secretsDir, err := ioutil.TempDir("", "secrets")
+	require.NoError(t, err)
+	defer os.RemoveAll(secretsDir)
+	require.NoError(t, ioutil.WriteFile(filepath.Join(secretsDir, "database_password"), []byte("from-secret-dir\n"), 0600))
+	jwtFile := filepath.Join(secretsDir, "jwt")
+	require.NoError(t, ioutil.WriteFile(jwtFile, []byte("from-secret-file"), 0600))
+
+	t.Run("defaults", func(t *testing.T) {
+		cfg, err := Loader{Source: validSource(), LookupEnv: envOf(nil), SecretsDir: t.TempDir()}.Load()
+		require.NoError(t, err)
+		assert.Equal(t, Default().Jwt, cfg.Jwt)
+		assert.Equal(t, []string{"en", "id"}, cfg.App.Lang)
+		assert.Equal(t, 5*time.Second, cfg.App.ExecutionTimeoutDuration())
+		assert.Equal(t, 720*time.Hour, cfg.Account.DeletionGracePeriod)
+	})
+
+	t.Run("ini then env then secret files", func(t *testing.T) {
+		source := validSource()
+		source["executionTimeout"] = "30"
+		source["lang"] = "en"
+		source["jwtSecretKey"] = "from-ini"
+		source["database::password"] = "from-ini"
+		source["audit::retention"] = "24h"
+		source["tracing::sampleRatio"] = "0.5"
+		source["accesslog::skipFormFields"] = "photo;document"
+
+		cfg, err := Loader{
+			Source: source,
+			LookupEnv: envOf(map[string]string{
+				"EXECUTION_TIMEOUT":   "10",
+				"JWT_SECRET_KEY":      "from-env",
+				"JWT_SECRET_KEY_FILE": jwtFile,
+				"DATABASE_PASSWORD":   "from-env",
+				"METRICS_ENABLED":     "false",
+			}),
+			SecretsDir: secretsDir,
+		}.Load()
+		require.NoError(t, err)
+		assert.Equal(t, 10, cfg.App.ExecutionTimeout)
+		assert.Equal(t, []string{"en"}, cfg.App.Lang)
+		assert.Equal(t, "from-secret-file", cfg.Jwt.SecretKey)
+		assert.Equal(t, "from-secret-dir", cfg.Database.Password)
+		assert.Equal(t, 24*time.Hour, cfg.Audit.Retention)
+		assert.Equal(t, 0.5, cfg.Tracing.SampleRatio)
+		assert.Equal(t, []string{"photo", "document"}, cfg.AccessLog.SkipFormFields)
+		assert.False(t, cfg.Metrics.Enabled)
+	})
+
+	t.Run("oauth providers", func(t *testing.T) {
+		source := validSource()
+		source["oauth::providers"] = "google|apple"
+		source["oauth_google::issuer"] = "https://accounts.google.com"
+		source["oauth_google::clientId"] = "google-client"
+		source["oauth_google::redirectUrl"] = "http://localhost/callback"
+
+		cfg, err := Loader{
+			Source:     source,
+			LookupEnv:  envOf(map[string]string{"OAUTH_GOOGLE_CLIENT_SECRET": "google-secret"}),
+			SecretsDir: t.TempDir(),
+		}.Load()
+		require.NoError(t, err)
+		assert.Equal(t, OAuthProviderConfig{
+			Issuer:       "https://accounts.google.com",
+			ClientID:     "google-client",
+			ClientSecret: "google-secret",
+			RedirectURL:  "http://localhost/callback",
+			Scopes:       []string{"email", "profile"},
+		}, cfg.OAuth.Provider["google"])
+		assert.Equal(t, "", cfg.OAuth.Provider["apple"].ClientID)
+	})
+
+	t.Run("invalid value", func(t *testing.T) {
+		source := validSource()
+		source["audit::retention"] = "one year"
+
+		_, err := Loader{Source: source, LookupEnv: envOf(nil), SecretsDir: t.TempDir()}.Load()
+		assert.EqualError(t, err, `config audit::retention from ini: invalid duration "one year", ex: 30s, 1h`)
+	})
+
+	t.Run("missing secret file", func(t *testing.T) {
+		_, err := Loader{
+			Source:     validSource(),
+			LookupEnv:  envOf(map[string]string{"JWT_SECRET_KEY_FILE": filepath.Join(secretsDir, "missing")}),
+			SecretsDir: secretsDir,
+		}.Load()
+		assert.Error(t, err)
+	})
+}
+
+func TestConfig_Validate(t *testing.T) {
+	valid := func() Config {
+		cfg := Default()
+		cfg.Database.Host = "localhost"
+		cfg.Database.Port = "3306"
+		cfg.Database.Name = "dating_apps"
+		cfg.Database.Username = "root"
+		return cfg
+	}
+
+	tests := []struct {
+		name       string
+		config     func() Config
+		wantErrors []string
+	}{
+		{
+			name:   "success",
+			config: valid,
+		},
+		{
+			name: "invalid values",
+			config: func() Config {
+				cfg := valid()
+				cfg.Log.Encoding = "xml"
+				cfg.Database.Driver = database.SqlServerDriver
+				cfg.Database.Host = ""
+				cfg.RateLimit.KeyBy = "token"
+				cfg.RateLimit.Default = ""
+				cfg.Grpc.Enabled = true
+				cfg.Grpc.Port = 0
+				cfg.Metrics.Port = 70000
+				cfg.Health.Timeout = 0
+				return cfg
+			},
+			wantErrors: []string{
+				`logEncoding "xml" must be one of json, console`,
+				`database::driver "mssql" is not supported, there are no migrations for it`,
+				"database::host is required",
+				`ratelimit::keyBy "token" must be one of ip, uid, ip_uid`,
+				"ratelimit::default is required",
+				"grpc::port 0 is invalid",
+				"health::timeout must be a positive duration, got 0s",
+				"metrics::port 70000 is invalid",
+			},
+		},
+		{
+			name: "prod with default secrets",
+			config: func() Config {
+				cfg := valid()
+				cfg.RunMode = RunModeProd
+				return cfg
+			},
+			wantErrors: []string{
+				"jwtSecretKey must be set to a non default secret in prod, use the JWT_SECRET_KEY environment variable or secret file",
+				"database::password must be set to a non default secret in prod, use the DATABASE_PASSWORD environment variable or secret file",
+				"jwtSecretKey must be at least 32 characters in prod",
+			},
+		},
+		{
+			name: "prod with secrets",
+			config: func() Config {
+				cfg := valid()
+				cfg.RunMode = RunModeProd
+				cfg.Jwt.SecretKey = "0123456789abcdef0123456789abcdef"
+				cfg.Database.Password = "password"
+				return cfg
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.config().Validate()
+			if len(tt.wantErrors) == 0 {
+				assert.NoError(t, err)
+				return
+			}
+			var validationErr *ValidationError
+			require.True(t, errors.As(err, &validationErr))
+			assert.Equal(t, tt.wantErrors, validationErr.Errors)
+		})
+	}
+}
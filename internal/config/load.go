@@ -0,0 +1,217 @@
+package config
+
+import (
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const (
+	RunModeDev  = "dev"
+	RunModeTest = "test"
+	RunModeProd = "prod"
+
+	RateLimitStoreMemory = "memory"
+	RateLimitStoreRedis  = "redis"
+
+	RateLimitKeyByIP       = "ip"
+	RateLimitKeyByUID      = "uid"
+	RateLimitKeyByIPAndUID = "ip_uid"
+
+	EventBrokerNone  = "none"
+	EventBrokerKafka = "kafka"
+
+	WorkerLockStoreMemory = "memory"
+	WorkerLockStoreRedis  = "redis"
+
+	// EnvSecretsDir is the environment variable of the directory the secret files are mounted in.
+	EnvSecretsDir = "CONFIG_SECRETS_DIR"
+	// DefaultSecretsDir is the directory of the docker and kubernetes secrets.
+	DefaultSecretsDir = "/run/secrets"
+
+	defaultSeparator = ";"
+	secretFileSuffix = "_FILE"
+)
+
+type (
+	// Source of the ini values, ex: beego.AppConfig, an empty value is not set.
+	Source interface {
+		String(key string) (string, error)
+	}
+
+	// Loader loads the Config from the Source, the environment variables and the secret files.
+	Loader struct {
+		// Source of the ini values.
+		Source Source
+
+		// RunMode of the application, dev | test | prod.
+		RunMode string
+
+		// LookupEnv defines a function to lookup an environment variable.
+		// Optional. Default value os.LookupEnv.
+		LookupEnv func(key string) (string, bool)
+
+		// SecretsDir where the secret files are mounted, a file named like the environment
+		// variable in lower case overrides the value, ex: /run/secrets/jwt_secret_key.
+		// The path of the file can be set as well with the environment variable suffixed with _FILE,
+		// ex: JWT_SECRET_KEY_FILE=/run/secrets/jwt.
+		// Optional. Default value from CONFIG_SECRETS_DIR or /run/secrets.
+		SecretsDir string
+	}
+)
+
+// Load loads and validates the config.
+func (l Loader) Load() (*Config, error) {
+	if l.LookupEnv == nil {
+		l.LookupEnv = os.LookupEnv
+	}
+	if l.SecretsDir == "" {
+		if dir, ok := l.LookupEnv(EnvSecretsDir); ok && dir != "" {
+			l.SecretsDir = dir
+		} else {
+			l.SecretsDir = DefaultSecretsDir
+		}
+	}
+
+	config := Default()
+	if l.RunMode != "" {
+		config.RunMode = l.RunMode
+	}
+
+	if err := l.loadStruct(reflect.ValueOf(&config).Elem(), "", ""); err != nil {
+		return nil, err
+	}
+
+	for _, name := range config.OAuth.Providers {
+		name = strings.ToLower(strings.TrimSpace(name))
+		if name == "" {
+			continue
+		}
+		provider := DefaultOAuthProvider()
+		if err := l.loadStruct(reflect.ValueOf(&provider).Elem(), "oauth_"+name+"::", "OAUTH_"+strings.ToUpper(name)+"_"); err != nil {
+			return nil, err
+		}
+		config.OAuth.Provider[name] = provider
+	}
+
+	if err := config.Validate(); err != nil {
+		return nil, err
+	}
+	return &config, nil
+}
+
+// loadStruct sets the fields tagged with config of the struct, untagged structs are walked.
+func (l Loader) loadStruct(value reflect.Value, keyPrefix, envPrefix string) error {
+	for i := 0; i < value.NumField(); i++ {
+		field := value.Type().Field(i)
+		key, ok := field.Tag.Lookup("config")
+		if !ok {
+			if field.Type.Kind() == reflect.Struct && field.Type != reflect.TypeOf(time.Duration(0)) {
+				if err := l.loadStruct(value.Field(i), keyPrefix, envPrefix); err != nil {
+					return err
+				}
+			}
+			continue
+		}
+		key = keyPrefix + key
+		env := envPrefix + field.Tag.Get("env")
+
+		raw, source, err := l.lookup(key, env, field.Tag.Get("secret") != "")
+		if err != nil {
+			return err
+		}
+		if source == "" {
+			continue
+		}
+		if err := setValue(value.Field(i), raw, field.Tag.Get("sep")); err != nil {
+			return fmt.Errorf("config %s from %s: %w", key, source, err)
+		}
+	}
+	return nil
+}
+
+// lookup returns the raw value of the key and its source, the secret files override
+// the environment variables which override the ini values.
+func (l Loader) lookup(key, env string, secret bool) (string, string, error) {
+	var raw, source string
+	if l.Source != nil {
+		if value, err := l.Source.String(key); err == nil && value != "" {
+			raw, source = value, "ini"
+		}
+	}
+	if env == "" {
+		return raw, source, nil
+	}
+	if value, ok := l.LookupEnv(env); ok {
+		raw, source = value, "env "+env
+	}
+	if !secret {
+		return raw, source, nil
+	}
+
+	if path, ok := l.LookupEnv(env + secretFileSuffix); ok && path != "" {
+		value, err := ioutil.ReadFile(path)
+		if err != nil {
+			return "", "", fmt.Errorf("config %s: read secret file %s: %w", key, path, err)
+		}
+		return strings.TrimSpace(string(value)), "file " + path, nil
+	}
+	path := filepath.Join(l.SecretsDir, strings.ToLower(env))
+	if value, err := ioutil.ReadFile(path); err == nil {
+		return strings.TrimSpace(string(value)), "file " + path, nil
+	} else if !os.IsNotExist(err) {
+		return "", "", fmt.Errorf("config %s: read secret file %s: %w", key, path, err)
+	}
+	return raw, source, nil
+}
+
+func setValue(value reflect.Value, raw, sep string) error {
+	raw = strings.TrimSpace(raw)
+	switch value.Interface().(type) {
+	case string:
+		value.SetString(raw)
+	case bool:
+		parsed, err := strconv.ParseBool(raw)
+		if err != nil {
+			return fmt.Errorf("invalid bool %q", raw)
+		}
+		value.SetBool(parsed)
+	case int, int64:
+		parsed, err := strconv.ParseInt(raw, 10, 64)
+		if err != nil {
+			return fmt.Errorf("invalid integer %q", raw)
+		}
+		value.SetInt(parsed)
+	case float64:
+		parsed, err := strconv.ParseFloat(raw, 64)
+		if err != nil {
+			return fmt.Errorf("invalid number %q", raw)
+		}
+		value.SetFloat(parsed)
+	case time.Duration:
+		parsed, err := time.ParseDuration(raw)
+		if err != nil {
+			return fmt.Errorf("invalid duration %q, ex: 30s, 1h", raw)
+		}
+		value.SetInt(int64(parsed))
+	case []string:
+		if sep == "" {
+			sep = defaultSeparator
+		}
+		values := make([]string, 0)
+		for _, v := range strings.Split(raw, sep) {
+			if v = strings.TrimSpace(v); v != "" {
+				values = append(values, v)
+			}
+		}
+		value.Set(reflect.ValueOf(values))
+	default:
+		return fmt.Errorf("unsupported type %s", value.Type())
+	}
+	return nil
+}
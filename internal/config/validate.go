@@ -0,0 +1,175 @@
+package config
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+	"time"
+
+	"github.com/radyatamaa/dating-apps-api/pkg/database"
+	"github.com/radyatamaa/dating-apps-api/pkg/scheduler"
+	"github.com/radyatamaa/dating-apps-api/pkg/tracing"
+	"github.com/radyatamaa/dating-apps-api/pkg/zaplogger"
+)
+
+// minProdSecretLength of the jwt secret key in prod.
+const minProdSecretLength = 32
+
+// ValidationError lists every invalid value of the config.
+type ValidationError struct {
+	Errors []string
+}
+
+func (e *ValidationError) Error() string {
+	return "invalid config: " + strings.Join(e.Errors, "; ")
+}
+
+// Validate validates the config, in prod the required secrets must be changed from their default.
+func (c Config) Validate() error {
+	v := &ValidationError{}
+
+	v.oneOf("runmode", c.RunMode, RunModeDev, RunModeTest, RunModeProd)
+	v.positive("serverTimeout", c.App.ServerTimeout)
+	v.positive("executionTimeout", int64(c.App.ExecutionTimeout))
+	if len(c.App.Lang) == 0 {
+		v.add("lang is required")
+	}
+	v.oneOf("logEncoding", c.Log.Encoding, zaplogger.EncodingJSON, zaplogger.EncodingConsole)
+
+	v.required("jwtSecretKey", c.Jwt.SecretKey)
+	v.positive("tokenExpired", c.Jwt.TokenExpired)
+	v.required("redisBeegoConConfig", c.Redis.Connection)
+
+	// the migrations are shipped for mysql and postgres only, the app can't create the tables of sqlserver
+	if c.Database.Driver == database.SqlServerDriver {
+		v.add(fmt.Sprintf("database::driver %q is not supported, there are no migrations for it", c.Database.Driver))
+	} else {
+		v.oneOf("database::driver", c.Database.Driver, database.MysqlDriver, database.PostgresDriver)
+	}
+	v.required("database::host", c.Database.Host)
+	v.required("database::port", c.Database.Port)
+	v.required("database::name", c.Database.Name)
+	v.required("database::username", c.Database.Username)
+	if len(c.Database.Replicas) > 0 {
+		v.duration("database::replicaMaxLag", c.Database.ReplicaMaxLag)
+		v.duration("database::replicaCheckInterval", c.Database.ReplicaCheckInterval)
+	}
+
+	v.oneOf("ratelimit::store", c.RateLimit.Store, RateLimitStoreMemory, RateLimitStoreRedis)
+	v.oneOf("ratelimit::keyBy", c.RateLimit.KeyBy, RateLimitKeyByIP, RateLimitKeyByUID, RateLimitKeyByIPAndUID)
+	v.required("ratelimit::default", c.RateLimit.Default)
+
+	v.duration("account::deletionGracePeriod", c.Account.DeletionGracePeriod)
+	v.duration("account::purgeInterval", c.Account.PurgeInterval)
+	v.duration("account::exportExpire", c.Account.ExportExpire)
+	v.required("account::exportPath", c.Account.ExportPath)
+	for name, provider := range c.OAuth.Provider {
+		if provider.ClientID != "" {
+			v.required("oauth_"+name+"::issuer", provider.Issuer)
+			v.required("oauth_"+name+"::redirectUrl", provider.RedirectURL)
+		}
+	}
+	v.positive("moderation::autoHideThreshold", int64(c.Moderation.AutoHideThreshold))
+	v.duration("audit::retention", c.Audit.Retention)
+	v.oneOf("event::broker", c.Event.Broker, EventBrokerNone, EventBrokerKafka)
+	v.duration("event::relayInterval", c.Event.RelayInterval)
+	v.positive("event::relayBatchSize", int64(c.Event.RelayBatchSize))
+	v.positive("event::maxAttempts", int64(c.Event.MaxAttempts))
+	v.duration("event::retention", c.Event.Retention)
+	if c.Event.Broker == EventBrokerKafka {
+		if len(c.Event.KafkaBrokers) == 0 {
+			v.add("event::kafkaBrokers is required with the kafka broker")
+		}
+		v.required("event::kafkaTopic", c.Event.KafkaTopic)
+		v.required("event::kafkaGroupId", c.Event.KafkaGroupID)
+	}
+	v.oneOf("worker::lockStore", c.Worker.LockStore, WorkerLockStoreMemory, WorkerLockStoreRedis)
+	v.duration("worker::timeout", c.Worker.Timeout)
+	v.duration("worker::retryBackoff", c.Worker.RetryBackoff)
+	v.duration("worker::storageOrphanAge", c.Worker.StorageOrphanAge)
+	v.duration("worker::recommendationWindow", c.Worker.RecommendationWindow)
+	v.schedule("worker::expirePremiumSchedule", c.Worker.ExpirePremiumSchedule)
+	v.schedule("worker::cleanStorageSchedule", c.Worker.CleanStorageSchedule)
+	v.schedule("worker::recommendationSchedule", c.Worker.RecommendationSchedule)
+	if c.Grpc.Enabled && (c.Grpc.Port <= 0 || c.Grpc.Port > 65535) {
+		v.add(fmt.Sprintf("grpc::port %d is invalid", c.Grpc.Port))
+	}
+	v.duration("health::timeout", c.Health.Timeout)
+	if c.Metrics.Enabled && !strings.HasPrefix(c.Metrics.Path, "/") {
+		v.add(fmt.Sprintf("metrics::path %q must start with /", c.Metrics.Path))
+	}
+	if c.Metrics.Enabled && (c.Metrics.Port <= 0 || c.Metrics.Port > 65535) {
+		v.add(fmt.Sprintf("metrics::port %d is invalid", c.Metrics.Port))
+	}
+	v.oneOf("tracing::exporter", c.Tracing.Exporter, tracing.ExporterNone, tracing.ExporterStdout, tracing.ExporterOTLP)
+	if c.Tracing.SampleRatio < 0 || c.Tracing.SampleRatio > 1 {
+		v.add(fmt.Sprintf("tracing::sampleRatio %v must be between 0 and 1", c.Tracing.SampleRatio))
+	}
+
+	if c.RunMode == RunModeProd {
+		v.prodSecrets(reflect.ValueOf(c), reflect.ValueOf(Default()))
+		if len(c.Jwt.SecretKey) < minProdSecretLength {
+			v.add(fmt.Sprintf("jwtSecretKey must be at least %d characters in prod", minProdSecretLength))
+		}
+	}
+
+	if len(v.Errors) > 0 {
+		return v
+	}
+	return nil
+}
+
+// prodSecrets refuses the required secrets left to their default value, ex: jwtSecretKey "secret".
+func (v *ValidationError) prodSecrets(value, defaultValue reflect.Value) {
+	for i := 0; i < value.NumField(); i++ {
+		field := value.Type().Field(i)
+		if field.Type.Kind() == reflect.Struct && field.Type != reflect.TypeOf(time.Duration(0)) {
+			v.prodSecrets(value.Field(i), defaultValue.Field(i))
+			continue
+		}
+		if field.Tag.Get("secret") != "required" {
+			continue
+		}
+		if value.Field(i).String() == "" || value.Field(i).String() == defaultValue.Field(i).String() {
+			v.add(fmt.Sprintf("%s must be set to a non default secret in prod, use the %s environment variable or secret file",
+				field.Tag.Get("config"), field.Tag.Get("env")))
+		}
+	}
+}
+
+func (v *ValidationError) add(message string) {
+	v.Errors = append(v.Errors, message)
+}
+
+func (v *ValidationError) required(key, value string) {
+	if strings.TrimSpace(value) == "" {
+		v.add(key + " is required")
+	}
+}
+
+func (v *ValidationError) positive(key string, value int64) {
+	if value <= 0 {
+		v.add(fmt.Sprintf("%s must be greater than 0, got %d", key, value))
+	}
+}
+
+func (v *ValidationError) duration(key string, value time.Duration) {
+	if value <= 0 {
+		v.add(fmt.Sprintf("%s must be a positive duration, got %s", key, value))
+	}
+}
+
+func (v *ValidationError) oneOf(key, value string, allowed ...string) {
+	for _, a := range allowed {
+		if value == a {
+			return
+		}
+	}
+	v.add(fmt.Sprintf("%s %q must be one of %s", key, value, strings.Join(allowed, ", ")))
+}
+
+func (v *ValidationError) schedule(key, value string) {
+	if err := scheduler.ParseSchedule(value); err != nil {
+		v.add(fmt.Sprintf("%s %q is not a valid schedule", key, value))
+	}
+}
@@ -0,0 +1,117 @@
+package v1
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/radyatamaa/dating-apps-api/internal/dataexport"
+
+	beego "github.com/beego/beego/v2/server/web"
+	"github.com/radyatamaa/dating-apps-api/internal"
+	"github.com/radyatamaa/dating-apps-api/pkg/response"
+	"github.com/radyatamaa/dating-apps-api/pkg/zaplogger"
+)
+
+type DataExportHandler struct {
+	ZapLogger zaplogger.Logger
+	internal.BaseController
+	response.ApiResponse
+	Usecase dataexport.UseCase
+}
+
+func NewDataExportHandler(useCase dataexport.UseCase, zapLogger zaplogger.Logger) {
+	pHandler := &DataExportHandler{
+		ZapLogger: zapLogger,
+		Usecase:   useCase,
+	}
+	beego.Router("/api/v1/user/me/export", pHandler, "post:RequestExport")
+	beego.Router("/api/v1/user/me/export/:id", pHandler, "get:GetExport")
+	beego.Router("/api/v1/user/me/export/:id/download", pHandler, "get:DownloadExport")
+}
+
+func (h *DataExportHandler) Prepare() {
+	// check user access when needed
+	h.SetLangVersion()
+}
+
+// RequestExport
+// @Title RequestExport
+// @Tags User
+// @Summary RequestExport starts the export of the data of the user login, the archive is built in background
+// @Produce json
+// @Security ApiKeyAuth
+// @Param Accept-Language header string false "lang"
+// @Success 200 {object} swagger.BaseResponse{errors=[]object,data=domain.DataExportResponse}
+// @Failure 400 {object} swagger.BadRequestErrorValidationResponse{errors=[]swagger.ValidationErrors,data=object}
+// @Failure 408 {object} swagger.RequestTimeoutResponse{errors=[]object,data=object}
+// @Failure 500 {object} swagger.InternalServerErrorResponse{errors=[]object,data=object}
+// @Router /v1/user/me/export [post]
+func (h *DataExportHandler) RequestExport() {
+	result, err := h.Usecase.RequestExport(h.Ctx.Request.Context())
+	if err != nil {
+		h.Fail(err)
+		return
+	}
+	h.Ok(h.Ctx, h.Tr("message.success"), result)
+	return
+}
+
+// GetExport
+// @Title GetExport
+// @Tags User
+// @Summary GetExport returns the status of an export, the download url is set when completed
+// @Produce json
+// @Security ApiKeyAuth
+// @Param Accept-Language header string false "lang"
+// @Param id path int true "export id"
+// @Success 200 {object} swagger.BaseResponse{errors=[]object,data=domain.DataExportResponse}
+// @Failure 400 {object} swagger.BadRequestErrorValidationResponse{errors=[]swagger.ValidationErrors,data=object}
+// @Failure 408 {object} swagger.RequestTimeoutResponse{errors=[]object,data=object}
+// @Failure 500 {object} swagger.InternalServerErrorResponse{errors=[]object,data=object}
+// @Router /v1/user/me/export/{id} [get]
+func (h *DataExportHandler) GetExport() {
+	id, err := strconv.Atoi(h.Ctx.Input.Param(":id"))
+	if err != nil {
+		h.Ctx.Input.SetData("stackTrace", h.ZapLogger.SetMessageLog(err))
+		h.ResponseError(h.Ctx, http.StatusBadRequest, response.PathParamInvalidCode, response.ErrorCodeText(response.PathParamInvalidCode, h.Locale.Lang), err)
+		return
+	}
+
+	result, err := h.Usecase.GetExport(h.Ctx.Request.Context(), id)
+	if err != nil {
+		h.Fail(err)
+		return
+	}
+	h.Ok(h.Ctx, h.Tr("message.success"), result)
+	return
+}
+
+// DownloadExport
+// @Title DownloadExport
+// @Tags User
+// @Summary DownloadExport downloads the zip archive of a completed export
+// @Produce application/zip
+// @Security ApiKeyAuth
+// @Param Accept-Language header string false "lang"
+// @Param id path int true "export id"
+// @Success 200 {file} file
+// @Failure 400 {object} swagger.BadRequestErrorValidationResponse{errors=[]swagger.ValidationErrors,data=object}
+// @Failure 408 {object} swagger.RequestTimeoutResponse{errors=[]object,data=object}
+// @Failure 500 {object} swagger.InternalServerErrorResponse{errors=[]object,data=object}
+// @Router /v1/user/me/export/{id}/download [get]
+func (h *DataExportHandler) DownloadExport() {
+	id, err := strconv.Atoi(h.Ctx.Input.Param(":id"))
+	if err != nil {
+		h.Ctx.Input.SetData("stackTrace", h.ZapLogger.SetMessageLog(err))
+		h.ResponseError(h.Ctx, http.StatusBadRequest, response.PathParamInvalidCode, response.ErrorCodeText(response.PathParamInvalidCode, h.Locale.Lang), err)
+		return
+	}
+
+	filePath, err := h.Usecase.DownloadExport(h.Ctx.Request.Context(), id)
+	if err != nil {
+		h.Fail(err)
+		return
+	}
+	h.Ctx.Output.Download(filePath, "data-export-"+strconv.Itoa(id)+".zip")
+	return
+}
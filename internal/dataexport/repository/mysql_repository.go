@@ -0,0 +1,36 @@
+package repository
+
+import (
+	"context"
+	"github.com/radyatamaa/dating-apps-api/internal/dataexport"
+
+	"github.com/radyatamaa/dating-apps-api/internal/domain"
+	baseRepository "github.com/radyatamaa/dating-apps-api/pkg/database/repository"
+	"github.com/radyatamaa/dating-apps-api/pkg/zaplogger"
+	"gorm.io/gorm"
+)
+
+type mysqlRepository struct {
+	baseRepository.Repository
+	zapLogger zaplogger.Logger
+}
+
+func NewMysqlRepository(db *gorm.DB, zapLogger zaplogger.Logger) dataexport.MysqlRepository {
+	return &mysqlRepository{
+		Repository: baseRepository.New(db, domain.DataExport{}),
+		zapLogger:  zapLogger,
+	}
+}
+
+func (c mysqlRepository) Update(ctx context.Context, data domain.DataExport) error {
+	return c.Updates(ctx, &data)
+}
+
+func (c mysqlRepository) Store(ctx context.Context, data domain.DataExport) (domain.DataExport, error) {
+
+	err := c.Create(ctx, &data)
+	if err != nil {
+		return data, err
+	}
+	return data, nil
+}
@@ -0,0 +1,18 @@
+package dataexport
+
+import (
+	"context"
+	"time"
+
+	"github.com/radyatamaa/dating-apps-api/internal/domain"
+)
+
+// UseCase Interface
+type UseCase interface {
+	RequestExport(ctx context.Context) (*domain.DataExportResponse, error)
+	GetExport(ctx context.Context, id int) (*domain.DataExportResponse, error)
+	DownloadExport(ctx context.Context, id int) (string, error)
+	PurgeExpiredExports(ctx context.Context, now time.Time) (int, error)
+	FailStaleExports(ctx context.Context, now time.Time) (int, error)
+	DeleteUserExports(ctx context.Context, userId int) error
+}
@@ -0,0 +1,421 @@
+package usecase
+
+import (
+	"archive/zip"
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+	"time"
+
+	"github.com/radyatamaa/dating-apps-api/internal/dataexport"
+	"github.com/radyatamaa/dating-apps-api/internal/domain"
+	"github.com/radyatamaa/dating-apps-api/internal/oauth"
+	"github.com/radyatamaa/dating-apps-api/internal/profile"
+	"github.com/radyatamaa/dating-apps-api/internal/swipe"
+	"github.com/radyatamaa/dating-apps-api/internal/user"
+	"github.com/radyatamaa/dating-apps-api/pkg/authuser"
+	"github.com/radyatamaa/dating-apps-api/pkg/database"
+	"github.com/radyatamaa/dating-apps-api/pkg/helper"
+	"github.com/radyatamaa/dating-apps-api/pkg/requestinfo"
+	"github.com/radyatamaa/dating-apps-api/pkg/response"
+	"github.com/radyatamaa/dating-apps-api/pkg/tracing"
+	"github.com/radyatamaa/dating-apps-api/pkg/zaplogger"
+	"gorm.io/gorm"
+)
+
+const (
+	// exportTimeout execution timeout of the background export
+	exportTimeout  = 5 * time.Minute
+	purgeBatchSize = 100
+)
+
+// errExportInterrupted is the error of the exports whose process was interrupted.
+var errExportInterrupted = errors.New("data export was interrupted")
+
+type dataExportUseCase struct {
+	zapLogger                 zaplogger.Logger
+	contextTimeout            time.Duration
+	exportPath                string
+	exportExpire              time.Duration
+	mysqlDataExportRepository dataexport.MysqlRepository
+	mysqlUserRepository       user.MysqlRepository
+	mysqlProfileRepository    profile.MysqlRepository
+	mysqlSwipeRepository      swipe.MysqlRepository
+	mysqlOAuthRepository      oauth.MysqlRepository
+}
+
+func NewDataExportUseCase(timeout time.Duration,
+	mysqlDataExportRepository dataexport.MysqlRepository,
+	mysqlUserRepository user.MysqlRepository,
+	mysqlProfileRepository profile.MysqlRepository,
+	mysqlSwipeRepository swipe.MysqlRepository,
+	mysqlOAuthRepository oauth.MysqlRepository,
+	exportPath string,
+	exportExpire time.Duration,
+	zapLogger zaplogger.Logger) dataexport.UseCase {
+	return &dataExportUseCase{
+		mysqlDataExportRepository: mysqlDataExportRepository,
+		mysqlUserRepository:       mysqlUserRepository,
+		mysqlProfileRepository:    mysqlProfileRepository,
+		mysqlSwipeRepository:      mysqlSwipeRepository,
+		mysqlOAuthRepository:      mysqlOAuthRepository,
+		exportPath:                exportPath,
+		exportExpire:              exportExpire,
+		contextTimeout:            timeout,
+		zapLogger:                 zapLogger,
+	}
+}
+
+func (r dataExportUseCase) downloadUrl(ctx context.Context, id int) string {
+	info := requestinfo.FromContext(ctx)
+	return fmt.Sprintf("%s://%s/api/v1/user/me/export/%d/download", info.Scheme, info.Host, id)
+}
+
+// singleExportWithFilter reads the export from the primary, its status is polled right after the request.
+func (r dataExportUseCase) singleExportWithFilter(ctx context.Context, criteria ...database.Criterion) (*domain.DataExport, error) {
+	var entity domain.DataExport
+	if err := r.mysqlDataExportRepository.SingleWithFilter(database.WithPrimary(ctx), database.NewQuery().Where(criteria...), &entity); err != nil {
+		return nil, err
+	}
+	return &entity, nil
+}
+
+/////////////////// RequestExport
+
+func (r dataExportUseCase) RequestExport(ctx context.Context) (*domain.DataExportResponse, error) {
+	ctx, cancel := context.WithTimeout(ctx, r.contextTimeout)
+	defer cancel()
+	ctx, span := tracing.Start(ctx, "dataExportUseCase.RequestExport")
+	defer span.End()
+
+	authUser, err := authuser.FromContext(ctx)
+	if err != nil {
+		zaplogger.SetStackTrace(ctx, r.zapLogger.SetMessageLog(err))
+		return nil, err
+	}
+	userId := authUser.ID
+
+	// an export in progress is returned instead of starting a new one,
+	// an export not updated within exportTimeout is stale, its process was interrupted
+	running, err := r.singleExportWithFilter(ctx, database.Eq("user_id", userId),
+		database.In("status", []string{domain.DataExportStatusPending, domain.DataExportStatusProcessing}),
+		database.Gte("updated_at", time.Now().Add(-exportTimeout)))
+	if err == nil {
+		res := domain.FromDataExportToResponse(*running, "")
+		return &res, nil
+	}
+	if err != gorm.ErrRecordNotFound {
+		zaplogger.SetStackTrace(ctx, r.zapLogger.SetMessageLog(err))
+		return nil, err
+	}
+
+	export, err := r.mysqlDataExportRepository.Store(ctx, domain.DataExport{
+		UserID: userId,
+		Status: domain.DataExportStatusPending,
+	})
+	if err != nil {
+		zaplogger.SetStackTrace(ctx, r.zapLogger.SetMessageLog(err))
+		return nil, err
+	}
+
+	go r.process(export)
+
+	res := domain.FromDataExportToResponse(export, "")
+	return &res, nil
+}
+
+//////////////////
+
+/////////////////// process
+
+// process builds the archive of the export in background and stores the result on the export.
+func (r dataExportUseCase) process(export domain.DataExport) {
+	ctx, cancel := context.WithTimeout(context.Background(), exportTimeout)
+	defer cancel()
+
+	values := map[string]interface{}{
+		"status":     domain.DataExportStatusProcessing,
+		"updated_at": time.Now(),
+	}
+	if err := r.mysqlDataExportRepository.UpdateSelectedField(ctx, []string{"status", "updated_at"}, values, export.ID); err != nil {
+		r.zapLogger.Errorw("failed update data export", "data_export_id", export.ID, zaplogger.FieldUserID, export.UserID, zaplogger.FieldError, err)
+		r.fail(export, err)
+		return
+	}
+
+	filePath, err := r.buildArchive(ctx, export)
+	if err != nil {
+		r.zapLogger.Errorw("failed build data export", "data_export_id", export.ID, zaplogger.FieldUserID, export.UserID, zaplogger.FieldError, err)
+		r.fail(export, err)
+		return
+	}
+
+	values = map[string]interface{}{
+		"status":     domain.DataExportStatusCompleted,
+		"file_path":  filePath,
+		"expires_at": sql.NullTime{Time: time.Now().Add(r.exportExpire), Valid: true},
+		"updated_at": time.Now(),
+	}
+	if err := r.mysqlDataExportRepository.UpdateSelectedField(ctx, []string{"status", "file_path", "expires_at", "updated_at"}, values, export.ID); err != nil {
+		r.zapLogger.Errorw("failed update data export", "data_export_id", export.ID, zaplogger.FieldUserID, export.UserID, zaplogger.FieldError, err)
+	}
+}
+
+// fail sets the export failed with a new context, the context of process is done after a timeout.
+func (r dataExportUseCase) fail(export domain.DataExport, cause error) {
+	ctx, cancel := context.WithTimeout(context.Background(), r.contextTimeout)
+	defer cancel()
+
+	values := map[string]interface{}{
+		"status":     domain.DataExportStatusFailed,
+		"error":      cause.Error(),
+		"updated_at": time.Now(),
+	}
+	if err := r.mysqlDataExportRepository.UpdateSelectedField(ctx, []string{"status", "error", "updated_at"}, values, export.ID); err != nil {
+		r.zapLogger.Errorw("failed update data export", "data_export_id", export.ID, zaplogger.FieldUserID, export.UserID, zaplogger.FieldError, err)
+	}
+}
+
+func (r dataExportUseCase) buildArchive(ctx context.Context, export domain.DataExport) (string, error) {
+	files, err := r.collect(ctx, export.UserID)
+	if err != nil {
+		return "", err
+	}
+
+	outputPath := filepath.Join(r.exportPath, strconv.Itoa(export.UserID))
+	if err := os.MkdirAll(outputPath, 0700); err != nil {
+		return "", err
+	}
+	filePath := filepath.Join(outputPath, fmt.Sprintf("%d-%s.zip", export.ID, helper.GenerateRandomString(10)))
+
+	dst, err := os.OpenFile(filePath, os.O_CREATE|os.O_WRONLY|os.O_TRUNC, 0600)
+	if err != nil {
+		return "", err
+	}
+	defer dst.Close()
+
+	archive := zip.NewWriter(dst)
+	for _, name := range []string{"account.json", "profile.json", "swipes.json", "matches.json", "identities.json"} {
+		w, err := archive.Create(name)
+		if err != nil {
+			return "", err
+		}
+		encoder := json.NewEncoder(w)
+		encoder.SetIndent("", "  ")
+		if err := encoder.Encode(files[name]); err != nil {
+			return "", err
+		}
+	}
+	if err := archive.Close(); err != nil {
+		return "", err
+	}
+
+	return filePath, nil
+}
+
+// collect returns the data of the user by file name of the archive.
+func (r dataExportUseCase) collect(ctx context.Context, userId int) (map[string]interface{}, error) {
+	var account domain.User
+	if err := r.mysqlUserRepository.SingleWithFilter(ctx, database.NewQuery().Where(database.Eq("id", userId)), &account); err != nil {
+		return nil, err
+	}
+
+	var userProfile domain.Profile
+	if err := r.mysqlProfileRepository.SingleWithFilter(ctx, database.NewQuery().Where(database.Eq("user_id", userId)), &userProfile); err != nil {
+		return nil, err
+	}
+
+	var outgoing []domain.Swipe
+	if _, err := r.mysqlSwipeRepository.FetchWithFilter(ctx, database.NewQuery().
+		Where(database.Eq("user_id", userId)).OrderBy(database.Asc("id")), &outgoing); err != nil {
+		return nil, err
+	}
+	var incoming []domain.Swipe
+	if _, err := r.mysqlSwipeRepository.FetchWithFilter(ctx, database.NewQuery().
+		Where(database.Eq("profile_id", userProfile.ID)).OrderBy(database.Asc("id")), &incoming); err != nil {
+		return nil, err
+	}
+
+	var identities []domain.UserIdentity
+	if _, err := r.mysqlOAuthRepository.FetchWithFilter(ctx, database.NewQuery().
+		Where(database.Eq("user_id", userId)).OrderBy(database.Asc("id")), &identities); err != nil {
+		return nil, err
+	}
+
+	swipes := make([]domain.DataExportSwipe, 0, len(outgoing))
+	liked := map[int]domain.Swipe{}
+	for i := range outgoing {
+		swipes = append(swipes, domain.FromSwipeToDataExportSwipe(outgoing[i]))
+		if outgoing[i].SwipeType == "LIKE" {
+			liked[outgoing[i].ProfileID] = outgoing[i]
+		}
+	}
+
+	// a match is a like of a profile whose user likes the profile of the user back
+	matches := make([]domain.DataExportMatch, 0)
+	for i := range incoming {
+		if incoming[i].SwipeType != "LIKE" {
+			continue
+		}
+		var likerProfile domain.Profile
+		err := r.mysqlProfileRepository.SingleWithFilter(ctx, database.NewQuery().
+			Select("id", "name").Where(database.Eq("user_id", incoming[i].UserID)), &likerProfile)
+		if err == gorm.ErrRecordNotFound {
+			continue
+		}
+		if err != nil {
+			return nil, err
+		}
+		like, ok := liked[likerProfile.ID]
+		if !ok {
+			continue
+		}
+		matchedAt := like.UpdatedAt
+		if incoming[i].UpdatedAt.After(matchedAt) {
+			matchedAt = incoming[i].UpdatedAt
+		}
+		matches = append(matches, domain.DataExportMatch{
+			ProfileId: likerProfile.ID,
+			Name:      likerProfile.Name,
+			MatchedAt: matchedAt.String(),
+		})
+	}
+
+	exportIdentities := make([]domain.DataExportIdentity, 0, len(identities))
+	for i := range identities {
+		exportIdentities = append(exportIdentities, domain.FromUserIdentityToDataExportIdentity(identities[i]))
+	}
+
+	return map[string]interface{}{
+		"account.json":    domain.FromUserToDataExportAccount(account),
+		"profile.json":    domain.FromProfileToDataExportProfile(userProfile),
+		"swipes.json":     swipes,
+		"matches.json":    matches,
+		"identities.json": exportIdentities,
+	}, nil
+}
+
+//////////////////
+
+/////////////////// GetExport
+
+func (r dataExportUseCase) GetExport(ctx context.Context, id int) (*domain.DataExportResponse, error) {
+	ctx, cancel := context.WithTimeout(ctx, r.contextTimeout)
+	defer cancel()
+	ctx, span := tracing.Start(ctx, "dataExportUseCase.GetExport")
+	defer span.End()
+
+	authUser, err := authuser.FromContext(ctx)
+	if err != nil {
+		zaplogger.SetStackTrace(ctx, r.zapLogger.SetMessageLog(err))
+		return nil, err
+	}
+
+	export, err := r.singleExportWithFilter(ctx, database.Eq("id", id), database.Eq("user_id", authUser.ID))
+	if err != nil {
+		zaplogger.SetStackTrace(ctx, r.zapLogger.SetMessageLog(err))
+		return nil, err
+	}
+
+	res := domain.FromDataExportToResponse(*export, r.downloadUrl(ctx, export.ID))
+	return &res, nil
+}
+
+//////////////////
+
+/////////////////// DownloadExport
+
+// DownloadExport returns the path of the archive of a completed export.
+func (r dataExportUseCase) DownloadExport(ctx context.Context, id int) (string, error) {
+	ctx, cancel := context.WithTimeout(ctx, r.contextTimeout)
+	defer cancel()
+	ctx, span := tracing.Start(ctx, "dataExportUseCase.DownloadExport")
+	defer span.End()
+
+	authUser, err := authuser.FromContext(ctx)
+	if err != nil {
+		zaplogger.SetStackTrace(ctx, r.zapLogger.SetMessageLog(err))
+		return "", err
+	}
+
+	export, err := r.singleExportWithFilter(ctx, database.Eq("id", id), database.Eq("user_id", authUser.ID))
+	if err != nil {
+		zaplogger.SetStackTrace(ctx, r.zapLogger.SetMessageLog(err))
+		return "", err
+	}
+
+	if export.Status != domain.DataExportStatusCompleted || export.ExpiresAt.Time.Before(time.Now()) {
+		zaplogger.SetStackTrace(ctx, r.zapLogger.SetMessageLog(response.ErrDataExportNotReady))
+		return "", response.ErrDataExportNotReady
+	}
+
+	return export.FilePath, nil
+}
+
+//////////////////
+
+/////////////////// PurgeExpiredExports
+
+// PurgeExpiredExports removes the exports expired before now with their archive.
+func (r dataExportUseCase) PurgeExpiredExports(ctx context.Context, now time.Time) (int, error) {
+	var exports []domain.DataExport
+	if _, err := r.mysqlDataExportRepository.FetchWithFilter(ctx, database.NewQuery().
+		Where(database.Lt("expires_at", now)).OrderBy(database.Asc("id")).Limit(purgeBatchSize), &exports); err != nil {
+		return 0, err
+	}
+
+	for i := range exports {
+		if exports[i].FilePath != "" {
+			if err := os.Remove(exports[i].FilePath); err != nil && !os.IsNotExist(err) {
+				return i, err
+			}
+		}
+		if _, err := r.mysqlDataExportRepository.Delete(ctx, exports[i].ID); err != nil {
+			return i, err
+		}
+	}
+
+	return len(exports), nil
+}
+
+//////////////////
+
+/////////////////// FailStaleExports
+
+// FailStaleExports sets failed the pending and processing exports not updated within exportTimeout before now,
+// their process was interrupted by a restart or a crash of the api.
+func (r dataExportUseCase) FailStaleExports(ctx context.Context, now time.Time) (int, error) {
+	var exports []domain.DataExport
+	if _, err := r.mysqlDataExportRepository.FetchWithFilter(ctx, database.NewQuery().
+		Select("id", "user_id").
+		Where(database.In("status", []string{domain.DataExportStatusPending, domain.DataExportStatusProcessing}),
+			database.Lt("updated_at", now.Add(-exportTimeout))).
+		OrderBy(database.Asc("id")).Limit(purgeBatchSize), &exports); err != nil {
+		return 0, err
+	}
+
+	for i := range exports {
+		values := map[string]interface{}{
+			"status":     domain.DataExportStatusFailed,
+			"error":      errExportInterrupted.Error(),
+			"updated_at": time.Now(),
+		}
+		if err := r.mysqlDataExportRepository.UpdateSelectedField(ctx, []string{"status", "error", "updated_at"}, values, exports[i].ID); err != nil {
+			return i, err
+		}
+	}
+
+	return len(exports), nil
+}
+
+//////////////////
+
+// DeleteUserExports removes the archives of the user, the rows are removed by the foreign key cascade.
+func (r dataExportUseCase) DeleteUserExports(ctx context.Context, userId int) error {
+	return os.RemoveAll(filepath.Join(r.exportPath, strconv.Itoa(userId)))
+}
@@ -0,0 +1,87 @@
+package domain
+
+import (
+	"github.com/radyatamaa/dating-apps-api/pkg/database/paginator"
+)
+
+// Requests
+type GrantPremiumRequest struct {
+	Days int `json:"days" validate:"required,min=1"`
+}
+
+type UpdateRoleRequest struct {
+	Role string `json:"role" validate:"required,enum=user-moderator-admin"`
+}
+
+type RemovePhotoRequest struct {
+	Reason string `json:"reason" validate:"required,max=500"`
+}
+
+//////////////////////////
+
+// Responses
+type AdminUserResponse struct {
+	Id               int     `json:"id"`
+	Email            string  `json:"email"`
+	Role             string  `json:"role"`
+	ProfileId        int     `json:"profile_id"`
+	Name             string  `json:"name"`
+	Photo            string  `json:"photo"`
+	Age              int     `json:"age"`
+	Bio              string  `json:"bio"`
+	Longitude        float64 `json:"longitude"`
+	Latitude         float64 `json:"latitude"`
+	Verified         bool    `json:"verified"`
+	PremiumExpiresAt string  `json:"premium_expires_at"`
+	TwoFactorEnabled bool    `json:"two_factor_enabled"`
+	HiddenAt         string  `json:"hidden_at"`
+	SuspendedUntil   string  `json:"suspended_until"`
+	BannedAt         string  `json:"banned_at"`
+	CreatedAt        string  `json:"created_at"`
+}
+
+type AdminUserResponsePaginationResponse struct {
+	Data      []AdminUserResponse             `json:"data"`
+	Paginator paginator.MetaPaginatorResponse `json:"paginator"`
+}
+
+//////////////////////////
+
+// Mapping
+func FromUserToAdminUserResponse(data UserQueryWithProfile) AdminUserResponse {
+	res := AdminUserResponse{
+		Id:               data.ID,
+		Email:            data.Email,
+		Role:             data.Role,
+		ProfileId:        data.ProfileId,
+		Name:             data.Name,
+		Photo:            data.Photo,
+		Age:              data.Age,
+		Bio:              data.Bio,
+		Longitude:        data.Longitude,
+		Latitude:         data.Latitude,
+		Verified:         IsPremium(data.PremiumExpiresAt),
+		TwoFactorEnabled: data.TwoFactorEnabled,
+		CreatedAt:        data.CreatedAt.String(),
+	}
+	if data.PremiumExpiresAt.Valid {
+		res.PremiumExpiresAt = data.PremiumExpiresAt.Time.String()
+	}
+	if data.HiddenAt.Valid {
+		res.HiddenAt = data.HiddenAt.Time.String()
+	}
+	if data.SuspendedUntil.Valid {
+		res.SuspendedUntil = data.SuspendedUntil.Time.String()
+	}
+	if data.BannedAt.Valid {
+		res.BannedAt = data.BannedAt.Time.String()
+	}
+	return res
+}
+
+func ToAdminUserResponsePaginationResponse(data []AdminUserResponse, page, limit, offset int, paging *paginator.Paginator) *AdminUserResponsePaginationResponse {
+	return &AdminUserResponsePaginationResponse{
+		Data:      data,
+		Paginator: paginator.MetaPaginatorResponse{}.MappingPaginator(page, limit, offset, paging, len(data)),
+	}
+}
@@ -0,0 +1,163 @@
+package domain
+
+import (
+	"database/sql"
+	"database/sql/driver"
+	"encoding/json"
+	"reflect"
+	"time"
+
+	"github.com/radyatamaa/dating-apps-api/pkg/database/paginator"
+)
+
+const (
+	AuditActionLogin            = "LOGIN"
+	AuditActionLoginFailed      = "LOGIN_FAILED"
+	AuditActionTwoFactorEnable  = "TWO_FACTOR_ENABLE"
+	AuditActionPremiumPurchase  = "PREMIUM_PURCHASE"
+	AuditActionAccountDelete    = "ACCOUNT_DELETE"
+	AuditActionPremiumGrant     = "PREMIUM_GRANT"
+	AuditActionRoleUpdate       = "ROLE_UPDATE"
+	AuditActionPhotoRemove      = "PHOTO_REMOVE"
+	AuditActionModerationAction = "MODERATION_ACTION"
+	AuditActionReportUpdate     = "REPORT_STATUS_UPDATE"
+)
+
+const (
+	AuditTargetUser   = "user"
+	AuditTargetReport = "report"
+)
+
+// Entity
+
+// AuditLog is the append only trail of the sensitive actions, rows are only removed by the retention policy.
+type AuditLog struct {
+	ID int `gorm:"column:id;primarykey;autoIncrement:true"`
+	// ActorID is the user who did the action, null when done by the system
+	ActorID    sql.NullInt64 `gorm:"column:actor_id;index"`
+	Action     string        `gorm:"type:varchar(50);column:action;index"`
+	TargetType string        `gorm:"type:varchar(50);column:target_type"`
+	TargetID   sql.NullInt64 `gorm:"column:target_id;index"`
+	// Changes is the json diff {"field":{"before":...,"after":...}} of the target
+	Changes   string    `gorm:"type:text;column:changes"`
+	IP        string    `gorm:"type:varchar(45);column:ip"`
+	RequestID string    `gorm:"type:varchar(64);column:request_id"`
+	CreatedAt time.Time `gorm:"column:created_at;index"`
+}
+
+// TableName name of table
+func (r AuditLog) TableName() string {
+	return "audit_logs"
+}
+
+// AuditEntry is the sensitive action recorded by the audit usecase,
+// the IP and the request id are taken from the request.
+type AuditEntry struct {
+	// ActorID defaults to the uid of the JWT payload
+	ActorID    int
+	Action     string
+	TargetType string
+	TargetID   int
+	Before     map[string]interface{}
+	After      map[string]interface{}
+}
+
+//////////////////////////
+
+// Requests
+type AuditLogFilter struct {
+	ActorID    int
+	Action     string
+	TargetType string
+	TargetID   int
+}
+
+//////////////////////////
+
+// Responses
+type AuditChange struct {
+	Before interface{} `json:"before"`
+	After  interface{} `json:"after"`
+}
+
+type AuditLogResponse struct {
+	Id         int                    `json:"id"`
+	ActorId    *int                   `json:"actor_id"`
+	Action     string                 `json:"action"`
+	TargetType string                 `json:"target_type"`
+	TargetId   *int                   `json:"target_id"`
+	Changes    map[string]AuditChange `json:"changes"`
+	Ip         string                 `json:"ip"`
+	RequestId  string                 `json:"request_id"`
+	CreatedAt  string                 `json:"created_at"`
+}
+
+type AuditLogResponsePaginationResponse struct {
+	Data      []AuditLogResponse              `json:"data"`
+	Paginator paginator.MetaPaginatorResponse `json:"paginator"`
+}
+
+//////////////////////////
+
+// Mapping
+
+// auditValue unwraps the sql null types so the diff holds plain values.
+func auditValue(value interface{}) interface{} {
+	if valuer, ok := value.(driver.Valuer); ok {
+		if v, err := valuer.Value(); err == nil {
+			return v
+		}
+	}
+	return value
+}
+
+// AuditChanges returns the json diff of the fields changed between before and after.
+func AuditChanges(before, after map[string]interface{}) (string, error) {
+	changes := make(map[string]AuditChange)
+	for field, value := range before {
+		changes[field] = AuditChange{Before: auditValue(value)}
+	}
+	for field, value := range after {
+		change := changes[field]
+		change.After = auditValue(value)
+		changes[field] = change
+	}
+	for field, change := range changes {
+		if reflect.DeepEqual(change.Before, change.After) {
+			delete(changes, field)
+		}
+	}
+	if len(changes) == 0 {
+		return "", nil
+	}
+
+	result, err := json.Marshal(changes)
+	if err != nil {
+		return "", err
+	}
+	return string(result), nil
+}
+
+func FromAuditLogToAuditLogResponse(data AuditLog) AuditLogResponse {
+	res := AuditLogResponse{
+		Id:         data.ID,
+		ActorId:    nullInt64ToIntPtr(data.ActorID),
+		Action:     data.Action,
+		TargetType: data.TargetType,
+		TargetId:   nullInt64ToIntPtr(data.TargetID),
+		Ip:         data.IP,
+		RequestId:  data.RequestID,
+		CreatedAt:  data.CreatedAt.String(),
+	}
+	if data.Changes != "" {
+		_ = json.Unmarshal([]byte(data.Changes), &res.Changes)
+	}
+	return res
+}
+
+func ToAuditLogResponsePaginationResponse(data []AuditLogResponse, page, limit, offset int, paging *paginator.Paginator) *AuditLogResponsePaginationResponse {
+	return &AuditLogResponsePaginationResponse{
+		Data:      data,
+		Paginator: paginator.MetaPaginatorResponse{}.MappingPaginator(page, limit, offset, paging, len(data)),
+	}
+}
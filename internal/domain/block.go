@@ -0,0 +1,78 @@
+package domain
+
+import (
+	"time"
+
+	"github.com/radyatamaa/dating-apps-api/pkg/database/paginator"
+)
+
+// Entity
+type Block struct {
+	ID            int       `gorm:"column:id;primarykey;autoIncrement:true"`
+	User          User      `gorm:"foreignkey:UserID;constraint:OnUpdate:CASCADE,OnDelete:CASCADE;->"`
+	UserID        int       `gorm:"column:user_id;uniqueIndex:idx_user_blocked_user"`
+	BlockedUser   User      `gorm:"foreignkey:BlockedUserID;constraint:OnUpdate:CASCADE,OnDelete:CASCADE;->"`
+	BlockedUserID int       `gorm:"column:blocked_user_id;uniqueIndex:idx_user_blocked_user;index"`
+	CreatedAt     time.Time `gorm:"column:created_at"`
+	UpdatedAt     time.Time `gorm:"column:updated_at"`
+}
+
+// TableName name of table
+func (r Block) TableName() string {
+	return "blocks"
+}
+
+type BlockQueryWithProfile struct {
+	ID            int       `gorm:"column:id"`
+	BlockedUserID int       `gorm:"column:blocked_user_id"`
+	CreatedAt     time.Time `gorm:"column:created_at"`
+	ProfileId     int       `gorm:"column:profile_id"`
+	Name          string    `gorm:"column:name"`
+	Photo         string    `gorm:"column:photo"`
+}
+
+// TableName name of table
+func (r BlockQueryWithProfile) TableName() string {
+	return "blocks"
+}
+
+//////////////////////////
+
+// Requests
+type BlockProfileRequest struct {
+	ProfileID int `json:"profile_id" validate:"required,check_fk=ProfileID:profile:id"`
+}
+
+//////////////////////////
+
+// Responses
+type BlockedProfileResponse struct {
+	ProfileId int    `json:"profile_id"`
+	Name      string `json:"name"`
+	Photo     string `json:"photo"`
+	BlockedAt string `json:"blocked_at"`
+}
+
+type BlockedProfileResponsePaginationResponse struct {
+	Data      []BlockedProfileResponse        `json:"data"`
+	Paginator paginator.MetaPaginatorResponse `json:"paginator"`
+}
+
+//////////////////////////
+
+// Mapping
+func FromBlockToBlockedProfileResponse(data BlockQueryWithProfile) BlockedProfileResponse {
+	return BlockedProfileResponse{
+		ProfileId: data.ProfileId,
+		Name:      data.Name,
+		Photo:     data.Photo,
+		BlockedAt: data.CreatedAt.String(),
+	}
+}
+
+func ToBlockedProfileResponsePaginationResponse(data []BlockedProfileResponse, page, limit, offset int, paging *paginator.Paginator) *BlockedProfileResponsePaginationResponse {
+	return &BlockedProfileResponsePaginationResponse{
+		Data:      data,
+		Paginator: paginator.MetaPaginatorResponse{}.MappingPaginator(page, limit, offset, paging, len(data)),
+	}
+}
@@ -0,0 +1,145 @@
+package domain
+
+import (
+	"database/sql"
+	"time"
+)
+
+const (
+	DataExportStatusPending    = "PENDING"
+	DataExportStatusProcessing = "PROCESSING"
+	DataExportStatusCompleted  = "COMPLETED"
+	DataExportStatusFailed     = "FAILED"
+)
+
+// Entity
+type DataExport struct {
+	ID        int          `gorm:"column:id;primarykey;autoIncrement:true"`
+	User      User         `gorm:"foreignkey:UserID;constraint:OnUpdate:CASCADE,OnDelete:CASCADE;->"`
+	UserID    int          `gorm:"column:user_id;index"`
+	Status    string       `gorm:"type:varchar(20);column:status"`
+	FilePath  string       `gorm:"type:varchar(255);column:file_path"`
+	Error     string       `gorm:"type:text;column:error"`
+	ExpiresAt sql.NullTime `gorm:"column:expires_at"`
+	CreatedAt time.Time    `gorm:"column:created_at"`
+	UpdatedAt time.Time    `gorm:"column:updated_at"`
+}
+
+// TableName name of table
+func (r DataExport) TableName() string {
+	return "data_exports"
+}
+
+//////////////////////////
+
+// Responses
+type DataExportResponse struct {
+	Id        int    `json:"id"`
+	Status    string `json:"status"`
+	CreatedAt string `json:"created_at"`
+	// ExpiresAt and DownloadUrl are set when the status is COMPLETED
+	ExpiresAt   string `json:"expires_at,omitempty"`
+	DownloadUrl string `json:"download_url,omitempty"`
+}
+
+// Archive, each part is written as a json file of the zip archive
+type DataExportAccount struct {
+	Id               int    `json:"id"`
+	Email            string `json:"email"`
+	PremiumExpiresAt string `json:"premium_expires_at,omitempty"`
+	TwoFactorEnabled bool   `json:"two_factor_enabled"`
+	CreatedAt        string `json:"created_at"`
+	UpdatedAt        string `json:"updated_at"`
+}
+
+type DataExportProfile struct {
+	Id        int     `json:"id"`
+	Name      string  `json:"name"`
+	Photo     string  `json:"photo"`
+	Age       int     `json:"age"`
+	Bio       string  `json:"bio"`
+	Longitude float64 `json:"longitude"`
+	Latitude  float64 `json:"latitude"`
+	CreatedAt string  `json:"created_at"`
+	UpdatedAt string  `json:"updated_at"`
+}
+
+type DataExportSwipe struct {
+	ProfileId int    `json:"profile_id"`
+	SwipeType string `json:"swipe_type"`
+	CreatedAt string `json:"created_at"`
+	UpdatedAt string `json:"updated_at"`
+}
+
+type DataExportMatch struct {
+	ProfileId int    `json:"profile_id"`
+	Name      string `json:"name"`
+	MatchedAt string `json:"matched_at"`
+}
+
+type DataExportIdentity struct {
+	Provider  string `json:"provider"`
+	Email     string `json:"email"`
+	CreatedAt string `json:"created_at"`
+}
+
+//////////////////////////
+
+// Mapping
+func FromDataExportToResponse(data DataExport, downloadUrl string) DataExportResponse {
+	res := DataExportResponse{
+		Id:        data.ID,
+		Status:    data.Status,
+		CreatedAt: data.CreatedAt.String(),
+	}
+	if data.Status == DataExportStatusCompleted {
+		res.ExpiresAt = data.ExpiresAt.Time.String()
+		res.DownloadUrl = downloadUrl
+	}
+	return res
+}
+
+func FromUserToDataExportAccount(data User) DataExportAccount {
+	res := DataExportAccount{
+		Id:               data.ID,
+		Email:            data.Email,
+		TwoFactorEnabled: data.TwoFactorEnabled,
+		CreatedAt:        data.CreatedAt.String(),
+		UpdatedAt:        data.UpdatedAt.String(),
+	}
+	if data.PremiumExpiresAt.Valid {
+		res.PremiumExpiresAt = data.PremiumExpiresAt.Time.String()
+	}
+	return res
+}
+
+func FromProfileToDataExportProfile(data Profile) DataExportProfile {
+	return DataExportProfile{
+		Id:        data.ID,
+		Name:      data.Name,
+		Photo:     data.Photo,
+		Age:       data.Age,
+		Bio:       data.Bio,
+		Longitude: data.Longitude,
+		Latitude:  data.Latitude,
+		CreatedAt: data.CreatedAt.String(),
+		UpdatedAt: data.UpdatedAt.String(),
+	}
+}
+
+func FromSwipeToDataExportSwipe(data Swipe) DataExportSwipe {
+	return DataExportSwipe{
+		ProfileId: data.ProfileID,
+		SwipeType: data.SwipeType,
+		CreatedAt: data.CreatedAt.String(),
+		UpdatedAt: data.UpdatedAt.String(),
+	}
+}
+
+func FromUserIdentityToDataExportIdentity(data UserIdentity) DataExportIdentity {
+	return DataExportIdentity{
+		Provider:  data.Provider,
+		Email:     data.Email,
+		CreatedAt: data.CreatedAt.String(),
+	}
+}
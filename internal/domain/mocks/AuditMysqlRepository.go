@@ -0,0 +1,84 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: internal/audit/repository.go
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	context "context"
+	reflect "reflect"
+	time "time"
+
+	gomock "github.com/golang/mock/gomock"
+	domain "github.com/radyatamaa/dating-apps-api/internal/domain"
+	database "github.com/radyatamaa/dating-apps-api/pkg/database"
+	paginator "github.com/radyatamaa/dating-apps-api/pkg/database/paginator"
+)
+
+// AuditMysqlRepository is a mock of MysqlRepository interface.
+type AuditMysqlRepository struct {
+	ctrl     *gomock.Controller
+	recorder *AuditMysqlRepositoryMockRecorder
+}
+
+// AuditMysqlRepositoryMockRecorder is the mock recorder for AuditMysqlRepository.
+type AuditMysqlRepositoryMockRecorder struct {
+	mock *AuditMysqlRepository
+}
+
+// NewAuditMysqlRepository creates a new mock instance.
+func NewAuditMysqlRepository(ctrl *gomock.Controller) *AuditMysqlRepository {
+	mock := &AuditMysqlRepository{ctrl: ctrl}
+	mock.recorder = &AuditMysqlRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *AuditMysqlRepository) EXPECT() *AuditMysqlRepositoryMockRecorder {
+	return m.recorder
+}
+
+// DeleteCreatedBefore mocks base method.
+func (m *AuditMysqlRepository) DeleteCreatedBefore(ctx context.Context, createdBefore time.Time) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteCreatedBefore", ctx, createdBefore)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DeleteCreatedBefore indicates an expected call of DeleteCreatedBefore.
+func (mr *AuditMysqlRepositoryMockRecorder) DeleteCreatedBefore(ctx, createdBefore interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteCreatedBefore", reflect.TypeOf((*AuditMysqlRepository)(nil).DeleteCreatedBefore), ctx, createdBefore)
+}
+
+// FetchWithFilterAndPagination mocks base method.
+func (m *AuditMysqlRepository) FetchWithFilterAndPagination(ctx context.Context, limit, offset int, query *database.Query, model interface{}) (*paginator.Paginator, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FetchWithFilterAndPagination", ctx, limit, offset, query, model)
+	ret0, _ := ret[0].(*paginator.Paginator)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FetchWithFilterAndPagination indicates an expected call of FetchWithFilterAndPagination.
+func (mr *AuditMysqlRepositoryMockRecorder) FetchWithFilterAndPagination(ctx, limit, offset, query, model interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FetchWithFilterAndPagination", reflect.TypeOf((*AuditMysqlRepository)(nil).FetchWithFilterAndPagination), ctx, limit, offset, query, model)
+}
+
+// Store mocks base method.
+func (m *AuditMysqlRepository) Store(ctx context.Context, data domain.AuditLog) (domain.AuditLog, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Store", ctx, data)
+	ret0, _ := ret[0].(domain.AuditLog)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Store indicates an expected call of Store.
+func (mr *AuditMysqlRepositoryMockRecorder) Store(ctx, data interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Store", reflect.TypeOf((*AuditMysqlRepository)(nil).Store), ctx, data)
+}
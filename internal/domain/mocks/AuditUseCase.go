@@ -0,0 +1,79 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: internal/audit/usecase.go
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	context "context"
+	reflect "reflect"
+	time "time"
+
+	gomock "github.com/golang/mock/gomock"
+	domain "github.com/radyatamaa/dating-apps-api/internal/domain"
+)
+
+// MockAuditUseCase is a mock of UseCase interface.
+type MockAuditUseCase struct {
+	ctrl     *gomock.Controller
+	recorder *MockAuditUseCaseMockRecorder
+}
+
+// MockAuditUseCaseMockRecorder is the mock recorder for MockAuditUseCase.
+type MockAuditUseCaseMockRecorder struct {
+	mock *MockAuditUseCase
+}
+
+// NewMockAuditUseCase creates a new mock instance.
+func NewMockAuditUseCase(ctrl *gomock.Controller) *MockAuditUseCase {
+	mock := &MockAuditUseCase{ctrl: ctrl}
+	mock.recorder = &MockAuditUseCaseMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockAuditUseCase) EXPECT() *MockAuditUseCaseMockRecorder {
+	return m.recorder
+}
+
+// GetAuditLogs mocks base method.
+func (m *MockAuditUseCase) GetAuditLogs(ctx context.Context, page, limit, offset int, filter domain.AuditLogFilter) (*domain.AuditLogResponsePaginationResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetAuditLogs", ctx, page, limit, offset, filter)
+	ret0, _ := ret[0].(*domain.AuditLogResponsePaginationResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetAuditLogs indicates an expected call of GetAuditLogs.
+func (mr *MockAuditUseCaseMockRecorder) GetAuditLogs(ctx, page, limit, offset, filter interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAuditLogs", reflect.TypeOf((*MockAuditUseCase)(nil).GetAuditLogs), ctx, page, limit, offset, filter)
+}
+
+// PurgeExpiredAuditLogs mocks base method.
+func (m *MockAuditUseCase) PurgeExpiredAuditLogs(ctx context.Context, createdBefore time.Time) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "PurgeExpiredAuditLogs", ctx, createdBefore)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// PurgeExpiredAuditLogs indicates an expected call of PurgeExpiredAuditLogs.
+func (mr *MockAuditUseCaseMockRecorder) PurgeExpiredAuditLogs(ctx, createdBefore interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PurgeExpiredAuditLogs", reflect.TypeOf((*MockAuditUseCase)(nil).PurgeExpiredAuditLogs), ctx, createdBefore)
+}
+
+// Record mocks base method.
+func (m *MockAuditUseCase) Record(ctx context.Context, entry domain.AuditEntry) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "Record", ctx, entry)
+}
+
+// Record indicates an expected call of Record.
+func (mr *MockAuditUseCaseMockRecorder) Record(ctx, entry interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Record", reflect.TypeOf((*MockAuditUseCase)(nil).Record), ctx, entry)
+}
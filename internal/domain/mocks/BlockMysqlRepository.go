@@ -0,0 +1,155 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: internal/block/repository.go
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	context "context"
+	reflect "reflect"
+
+	gomock "github.com/golang/mock/gomock"
+	domain "github.com/radyatamaa/dating-apps-api/internal/domain"
+	database "github.com/radyatamaa/dating-apps-api/pkg/database"
+	paginator "github.com/radyatamaa/dating-apps-api/pkg/database/paginator"
+)
+
+// BlockMysqlRepository is a mock of MysqlRepository interface.
+type BlockMysqlRepository struct {
+	ctrl     *gomock.Controller
+	recorder *BlockMysqlRepositoryMockRecorder
+}
+
+// BlockMysqlRepositoryMockRecorder is the mock recorder for BlockMysqlRepository.
+type BlockMysqlRepositoryMockRecorder struct {
+	mock *BlockMysqlRepository
+}
+
+// NewBlockMysqlRepository creates a new mock instance.
+func NewBlockMysqlRepository(ctrl *gomock.Controller) *BlockMysqlRepository {
+	mock := &BlockMysqlRepository{ctrl: ctrl}
+	mock.recorder = &BlockMysqlRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *BlockMysqlRepository) EXPECT() *BlockMysqlRepositoryMockRecorder {
+	return m.recorder
+}
+
+// Delete mocks base method.
+func (m *BlockMysqlRepository) Delete(ctx context.Context, id int) (int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Delete", ctx, id)
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Delete indicates an expected call of Delete.
+func (mr *BlockMysqlRepositoryMockRecorder) Delete(ctx, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Delete", reflect.TypeOf((*BlockMysqlRepository)(nil).Delete), ctx, id)
+}
+
+// FetchBlockedUserIds mocks base method.
+func (m *BlockMysqlRepository) FetchBlockedUserIds(ctx context.Context, userId int) ([]int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FetchBlockedUserIds", ctx, userId)
+	ret0, _ := ret[0].([]int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FetchBlockedUserIds indicates an expected call of FetchBlockedUserIds.
+func (mr *BlockMysqlRepositoryMockRecorder) FetchBlockedUserIds(ctx, userId interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FetchBlockedUserIds", reflect.TypeOf((*BlockMysqlRepository)(nil).FetchBlockedUserIds), ctx, userId)
+}
+
+// FetchWithFilter mocks base method.
+func (m *BlockMysqlRepository) FetchWithFilter(ctx context.Context, query *database.Query, model interface{}) (interface{}, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FetchWithFilter", ctx, query, model)
+	ret0, _ := ret[0].(interface{})
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FetchWithFilter indicates an expected call of FetchWithFilter.
+func (mr *BlockMysqlRepositoryMockRecorder) FetchWithFilter(ctx, query, model interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FetchWithFilter", reflect.TypeOf((*BlockMysqlRepository)(nil).FetchWithFilter), ctx, query, model)
+}
+
+// FetchWithFilterAndPagination mocks base method.
+func (m *BlockMysqlRepository) FetchWithFilterAndPagination(ctx context.Context, limit, offset int, query *database.Query, model interface{}) (*paginator.Paginator, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FetchWithFilterAndPagination", ctx, limit, offset, query, model)
+	ret0, _ := ret[0].(*paginator.Paginator)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FetchWithFilterAndPagination indicates an expected call of FetchWithFilterAndPagination.
+func (mr *BlockMysqlRepositoryMockRecorder) FetchWithFilterAndPagination(ctx, limit, offset, query, model interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FetchWithFilterAndPagination", reflect.TypeOf((*BlockMysqlRepository)(nil).FetchWithFilterAndPagination), ctx, limit, offset, query, model)
+}
+
+// SingleWithFilter mocks base method.
+func (m *BlockMysqlRepository) SingleWithFilter(ctx context.Context, query *database.Query, model interface{}) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SingleWithFilter", ctx, query, model)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SingleWithFilter indicates an expected call of SingleWithFilter.
+func (mr *BlockMysqlRepositoryMockRecorder) SingleWithFilter(ctx, query, model interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SingleWithFilter", reflect.TypeOf((*BlockMysqlRepository)(nil).SingleWithFilter), ctx, query, model)
+}
+
+// Store mocks base method.
+func (m *BlockMysqlRepository) Store(ctx context.Context, data domain.Block) (domain.Block, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Store", ctx, data)
+	ret0, _ := ret[0].(domain.Block)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Store indicates an expected call of Store.
+func (mr *BlockMysqlRepositoryMockRecorder) Store(ctx, data interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Store", reflect.TypeOf((*BlockMysqlRepository)(nil).Store), ctx, data)
+}
+
+// Update mocks base method.
+func (m *BlockMysqlRepository) Update(ctx context.Context, data domain.Block) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Update", ctx, data)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Update indicates an expected call of Update.
+func (mr *BlockMysqlRepositoryMockRecorder) Update(ctx, data interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Update", reflect.TypeOf((*BlockMysqlRepository)(nil).Update), ctx, data)
+}
+
+// UpdateSelectedField mocks base method.
+func (m *BlockMysqlRepository) UpdateSelectedField(ctx context.Context, field []string, values map[string]interface{}, id int) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateSelectedField", ctx, field, values, id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateSelectedField indicates an expected call of UpdateSelectedField.
+func (mr *BlockMysqlRepositoryMockRecorder) UpdateSelectedField(ctx, field, values, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateSelectedField", reflect.TypeOf((*BlockMysqlRepository)(nil).UpdateSelectedField), ctx, field, values, id)
+}
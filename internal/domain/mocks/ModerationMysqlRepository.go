@@ -0,0 +1,97 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: internal/moderation/repository.go
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	context "context"
+	reflect "reflect"
+
+	gomock "github.com/golang/mock/gomock"
+	domain "github.com/radyatamaa/dating-apps-api/internal/domain"
+	database "github.com/radyatamaa/dating-apps-api/pkg/database"
+	paginator "github.com/radyatamaa/dating-apps-api/pkg/database/paginator"
+)
+
+// ModerationMysqlRepository is a mock of MysqlRepository interface.
+type ModerationMysqlRepository struct {
+	ctrl     *gomock.Controller
+	recorder *ModerationMysqlRepositoryMockRecorder
+}
+
+// ModerationMysqlRepositoryMockRecorder is the mock recorder for ModerationMysqlRepository.
+type ModerationMysqlRepositoryMockRecorder struct {
+	mock *ModerationMysqlRepository
+}
+
+// NewModerationMysqlRepository creates a new mock instance.
+func NewModerationMysqlRepository(ctrl *gomock.Controller) *ModerationMysqlRepository {
+	mock := &ModerationMysqlRepository{ctrl: ctrl}
+	mock.recorder = &ModerationMysqlRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *ModerationMysqlRepository) EXPECT() *ModerationMysqlRepositoryMockRecorder {
+	return m.recorder
+}
+
+// FetchWithFilter mocks base method.
+func (m *ModerationMysqlRepository) FetchWithFilter(ctx context.Context, query *database.Query, model interface{}) (interface{}, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FetchWithFilter", ctx, query, model)
+	ret0, _ := ret[0].(interface{})
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FetchWithFilter indicates an expected call of FetchWithFilter.
+func (mr *ModerationMysqlRepositoryMockRecorder) FetchWithFilter(ctx, query, model interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FetchWithFilter", reflect.TypeOf((*ModerationMysqlRepository)(nil).FetchWithFilter), ctx, query, model)
+}
+
+// FetchWithFilterAndPagination mocks base method.
+func (m *ModerationMysqlRepository) FetchWithFilterAndPagination(ctx context.Context, limit, offset int, query *database.Query, model interface{}) (*paginator.Paginator, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FetchWithFilterAndPagination", ctx, limit, offset, query, model)
+	ret0, _ := ret[0].(*paginator.Paginator)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FetchWithFilterAndPagination indicates an expected call of FetchWithFilterAndPagination.
+func (mr *ModerationMysqlRepositoryMockRecorder) FetchWithFilterAndPagination(ctx, limit, offset, query, model interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FetchWithFilterAndPagination", reflect.TypeOf((*ModerationMysqlRepository)(nil).FetchWithFilterAndPagination), ctx, limit, offset, query, model)
+}
+
+// SingleWithFilter mocks base method.
+func (m *ModerationMysqlRepository) SingleWithFilter(ctx context.Context, query *database.Query, model interface{}) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SingleWithFilter", ctx, query, model)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SingleWithFilter indicates an expected call of SingleWithFilter.
+func (mr *ModerationMysqlRepositoryMockRecorder) SingleWithFilter(ctx, query, model interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SingleWithFilter", reflect.TypeOf((*ModerationMysqlRepository)(nil).SingleWithFilter), ctx, query, model)
+}
+
+// Store mocks base method.
+func (m *ModerationMysqlRepository) Store(ctx context.Context, data domain.ModerationAction) (domain.ModerationAction, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Store", ctx, data)
+	ret0, _ := ret[0].(domain.ModerationAction)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Store indicates an expected call of Store.
+func (mr *ModerationMysqlRepositoryMockRecorder) Store(ctx, data interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Store", reflect.TypeOf((*ModerationMysqlRepository)(nil).Store), ctx, data)
+}
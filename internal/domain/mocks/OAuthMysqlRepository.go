@@ -0,0 +1,140 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: internal/oauth/repository.go
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	context "context"
+	reflect "reflect"
+
+	gomock "github.com/golang/mock/gomock"
+	domain "github.com/radyatamaa/dating-apps-api/internal/domain"
+	database "github.com/radyatamaa/dating-apps-api/pkg/database"
+	paginator "github.com/radyatamaa/dating-apps-api/pkg/database/paginator"
+)
+
+// OAuthMysqlRepository is a mock of MysqlRepository interface.
+type OAuthMysqlRepository struct {
+	ctrl     *gomock.Controller
+	recorder *OAuthMysqlRepositoryMockRecorder
+}
+
+// OAuthMysqlRepositoryMockRecorder is the mock recorder for OAuthMysqlRepository.
+type OAuthMysqlRepositoryMockRecorder struct {
+	mock *OAuthMysqlRepository
+}
+
+// NewOAuthMysqlRepository creates a new mock instance.
+func NewOAuthMysqlRepository(ctrl *gomock.Controller) *OAuthMysqlRepository {
+	mock := &OAuthMysqlRepository{ctrl: ctrl}
+	mock.recorder = &OAuthMysqlRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *OAuthMysqlRepository) EXPECT() *OAuthMysqlRepositoryMockRecorder {
+	return m.recorder
+}
+
+// Delete mocks base method.
+func (m *OAuthMysqlRepository) Delete(ctx context.Context, id int) (int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Delete", ctx, id)
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Delete indicates an expected call of Delete.
+func (mr *OAuthMysqlRepositoryMockRecorder) Delete(ctx, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Delete", reflect.TypeOf((*OAuthMysqlRepository)(nil).Delete), ctx, id)
+}
+
+// FetchWithFilter mocks base method.
+func (m *OAuthMysqlRepository) FetchWithFilter(ctx context.Context, query *database.Query, model interface{}) (interface{}, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FetchWithFilter", ctx, query, model)
+	ret0, _ := ret[0].(interface{})
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FetchWithFilter indicates an expected call of FetchWithFilter.
+func (mr *OAuthMysqlRepositoryMockRecorder) FetchWithFilter(ctx, query, model interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FetchWithFilter", reflect.TypeOf((*OAuthMysqlRepository)(nil).FetchWithFilter), ctx, query, model)
+}
+
+// FetchWithFilterAndPagination mocks base method.
+func (m *OAuthMysqlRepository) FetchWithFilterAndPagination(ctx context.Context, limit, offset int, query *database.Query, model interface{}) (*paginator.Paginator, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FetchWithFilterAndPagination", ctx, limit, offset, query, model)
+	ret0, _ := ret[0].(*paginator.Paginator)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FetchWithFilterAndPagination indicates an expected call of FetchWithFilterAndPagination.
+func (mr *OAuthMysqlRepositoryMockRecorder) FetchWithFilterAndPagination(ctx, limit, offset, query, model interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FetchWithFilterAndPagination", reflect.TypeOf((*OAuthMysqlRepository)(nil).FetchWithFilterAndPagination), ctx, limit, offset, query, model)
+}
+
+// SingleWithFilter mocks base method.
+func (m *OAuthMysqlRepository) SingleWithFilter(ctx context.Context, query *database.Query, model interface{}) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SingleWithFilter", ctx, query, model)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SingleWithFilter indicates an expected call of SingleWithFilter.
+func (mr *OAuthMysqlRepositoryMockRecorder) SingleWithFilter(ctx, query, model interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SingleWithFilter", reflect.TypeOf((*OAuthMysqlRepository)(nil).SingleWithFilter), ctx, query, model)
+}
+
+// Store mocks base method.
+func (m *OAuthMysqlRepository) Store(ctx context.Context, data domain.UserIdentity) (domain.UserIdentity, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Store", ctx, data)
+	ret0, _ := ret[0].(domain.UserIdentity)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Store indicates an expected call of Store.
+func (mr *OAuthMysqlRepositoryMockRecorder) Store(ctx, data interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Store", reflect.TypeOf((*OAuthMysqlRepository)(nil).Store), ctx, data)
+}
+
+// Update mocks base method.
+func (m *OAuthMysqlRepository) Update(ctx context.Context, data domain.UserIdentity) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Update", ctx, data)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Update indicates an expected call of Update.
+func (mr *OAuthMysqlRepositoryMockRecorder) Update(ctx, data interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Update", reflect.TypeOf((*OAuthMysqlRepository)(nil).Update), ctx, data)
+}
+
+// UpdateSelectedField mocks base method.
+func (m *OAuthMysqlRepository) UpdateSelectedField(ctx context.Context, field []string, values map[string]interface{}, id int) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateSelectedField", ctx, field, values, id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateSelectedField indicates an expected call of UpdateSelectedField.
+func (mr *OAuthMysqlRepositoryMockRecorder) UpdateSelectedField(ctx, field, values, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateSelectedField", reflect.TypeOf((*OAuthMysqlRepository)(nil).UpdateSelectedField), ctx, field, values, id)
+}
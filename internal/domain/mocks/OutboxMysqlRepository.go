@@ -0,0 +1,114 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: internal/outbox/repository.go
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	context "context"
+	reflect "reflect"
+	time "time"
+
+	gomock "github.com/golang/mock/gomock"
+	domain "github.com/radyatamaa/dating-apps-api/internal/domain"
+)
+
+// OutboxMysqlRepository is a mock of MysqlRepository interface.
+type OutboxMysqlRepository struct {
+	ctrl     *gomock.Controller
+	recorder *OutboxMysqlRepositoryMockRecorder
+}
+
+// OutboxMysqlRepositoryMockRecorder is the mock recorder for OutboxMysqlRepository.
+type OutboxMysqlRepositoryMockRecorder struct {
+	mock *OutboxMysqlRepository
+}
+
+// NewOutboxMysqlRepository creates a new mock instance.
+func NewOutboxMysqlRepository(ctrl *gomock.Controller) *OutboxMysqlRepository {
+	mock := &OutboxMysqlRepository{ctrl: ctrl}
+	mock.recorder = &OutboxMysqlRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *OutboxMysqlRepository) EXPECT() *OutboxMysqlRepositoryMockRecorder {
+	return m.recorder
+}
+
+// DeletePublishedBefore mocks base method.
+func (m *OutboxMysqlRepository) DeletePublishedBefore(ctx context.Context, publishedBefore time.Time) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeletePublishedBefore", ctx, publishedBefore)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DeletePublishedBefore indicates an expected call of DeletePublishedBefore.
+func (mr *OutboxMysqlRepositoryMockRecorder) DeletePublishedBefore(ctx, publishedBefore interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeletePublishedBefore", reflect.TypeOf((*OutboxMysqlRepository)(nil).DeletePublishedBefore), ctx, publishedBefore)
+}
+
+// FetchUnpublished mocks base method.
+func (m *OutboxMysqlRepository) FetchUnpublished(ctx context.Context, limit, maxAttempts int) ([]domain.OutboxEvent, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FetchUnpublished", ctx, limit, maxAttempts)
+	ret0, _ := ret[0].([]domain.OutboxEvent)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FetchUnpublished indicates an expected call of FetchUnpublished.
+func (mr *OutboxMysqlRepositoryMockRecorder) FetchUnpublished(ctx, limit, maxAttempts interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FetchUnpublished", reflect.TypeOf((*OutboxMysqlRepository)(nil).FetchUnpublished), ctx, limit, maxAttempts)
+}
+
+// MarkFailed mocks base method.
+func (m *OutboxMysqlRepository) MarkFailed(ctx context.Context, ids []int, lastError string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "MarkFailed", ctx, ids, lastError)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// MarkFailed indicates an expected call of MarkFailed.
+func (mr *OutboxMysqlRepositoryMockRecorder) MarkFailed(ctx, ids, lastError interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "MarkFailed", reflect.TypeOf((*OutboxMysqlRepository)(nil).MarkFailed), ctx, ids, lastError)
+}
+
+// MarkPublished mocks base method.
+func (m *OutboxMysqlRepository) MarkPublished(ctx context.Context, ids []int, publishedAt time.Time) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "MarkPublished", ctx, ids, publishedAt)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// MarkPublished indicates an expected call of MarkPublished.
+func (mr *OutboxMysqlRepositoryMockRecorder) MarkPublished(ctx, ids, publishedAt interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "MarkPublished", reflect.TypeOf((*OutboxMysqlRepository)(nil).MarkPublished), ctx, ids, publishedAt)
+}
+
+// Store mocks base method.
+func (m *OutboxMysqlRepository) Store(ctx context.Context, data ...domain.OutboxEvent) error {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{ctx}
+	for _, a := range data {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "Store", varargs...)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Store indicates an expected call of Store.
+func (mr *OutboxMysqlRepositoryMockRecorder) Store(ctx interface{}, data ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]interface{}{ctx}, data...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Store", reflect.TypeOf((*OutboxMysqlRepository)(nil).Store), varargs...)
+}
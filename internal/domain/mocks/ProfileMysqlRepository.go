@@ -7,11 +7,12 @@ package mocks
 import (
 	context "context"
 	reflect "reflect"
+	time "time"
 
 	gomock "github.com/golang/mock/gomock"
 	domain "github.com/radyatamaa/dating-apps-api/internal/domain"
+	database "github.com/radyatamaa/dating-apps-api/pkg/database"
 	paginator "github.com/radyatamaa/dating-apps-api/pkg/database/paginator"
-	gorm "gorm.io/gorm"
 )
 
 // ProfileMysqlRepository is a mock of MysqlRepository interface.
@@ -37,20 +38,6 @@ func (m *ProfileMysqlRepository) EXPECT() *ProfileMysqlRepositoryMockRecorder {
 	return m.recorder
 }
 
-// DB mocks base method.
-func (m *ProfileMysqlRepository) DB() *gorm.DB {
-	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "DB")
-	ret0, _ := ret[0].(*gorm.DB)
-	return ret0
-}
-
-// DB indicates an expected call of DB.
-func (mr *ProfileMysqlRepositoryMockRecorder) DB() *gomock.Call {
-	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DB", reflect.TypeOf((*ProfileMysqlRepository)(nil).DB))
-}
-
 // Delete mocks base method.
 func (m *ProfileMysqlRepository) Delete(ctx context.Context, id int) (int, error) {
 	m.ctrl.T.Helper()
@@ -67,62 +54,62 @@ func (mr *ProfileMysqlRepositoryMockRecorder) Delete(ctx, id interface{}) *gomoc
 }
 
 // FetchWithFilter mocks base method.
-func (m *ProfileMysqlRepository) FetchWithFilter(ctx context.Context, limit, offset int, order string, fields, associate, filter []string, model interface{}, args ...interface{}) (interface{}, error) {
+func (m *ProfileMysqlRepository) FetchWithFilter(ctx context.Context, query *database.Query, model interface{}) (interface{}, error) {
 	m.ctrl.T.Helper()
-	varargs := []interface{}{ctx, limit, offset, order, fields, associate, filter, model}
-	for _, a := range args {
-		varargs = append(varargs, a)
-	}
-	ret := m.ctrl.Call(m, "FetchWithFilter", varargs...)
+	ret := m.ctrl.Call(m, "FetchWithFilter", ctx, query, model)
 	ret0, _ := ret[0].(interface{})
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // FetchWithFilter indicates an expected call of FetchWithFilter.
-func (mr *ProfileMysqlRepositoryMockRecorder) FetchWithFilter(ctx, limit, offset, order, fields, associate, filter, model interface{}, args ...interface{}) *gomock.Call {
+func (mr *ProfileMysqlRepositoryMockRecorder) FetchWithFilter(ctx, query, model interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	varargs := append([]interface{}{ctx, limit, offset, order, fields, associate, filter, model}, args...)
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FetchWithFilter", reflect.TypeOf((*ProfileMysqlRepository)(nil).FetchWithFilter), varargs...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FetchWithFilter", reflect.TypeOf((*ProfileMysqlRepository)(nil).FetchWithFilter), ctx, query, model)
 }
 
 // FetchWithFilterAndPagination mocks base method.
-func (m *ProfileMysqlRepository) FetchWithFilterAndPagination(ctx context.Context, limit, offset int, order string, fields, associate, filter []string, model interface{}, args ...interface{}) (*paginator.Paginator, error) {
+func (m *ProfileMysqlRepository) FetchWithFilterAndPagination(ctx context.Context, limit, offset int, query *database.Query, model interface{}) (*paginator.Paginator, error) {
 	m.ctrl.T.Helper()
-	varargs := []interface{}{ctx, limit, offset, order, fields, associate, filter, model}
-	for _, a := range args {
-		varargs = append(varargs, a)
-	}
-	ret := m.ctrl.Call(m, "FetchWithFilterAndPagination", varargs...)
+	ret := m.ctrl.Call(m, "FetchWithFilterAndPagination", ctx, limit, offset, query, model)
 	ret0, _ := ret[0].(*paginator.Paginator)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // FetchWithFilterAndPagination indicates an expected call of FetchWithFilterAndPagination.
-func (mr *ProfileMysqlRepositoryMockRecorder) FetchWithFilterAndPagination(ctx, limit, offset, order, fields, associate, filter, model interface{}, args ...interface{}) *gomock.Call {
+func (mr *ProfileMysqlRepositoryMockRecorder) FetchWithFilterAndPagination(ctx, limit, offset, query, model interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	varargs := append([]interface{}{ctx, limit, offset, order, fields, associate, filter, model}, args...)
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FetchWithFilterAndPagination", reflect.TypeOf((*ProfileMysqlRepository)(nil).FetchWithFilterAndPagination), varargs...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FetchWithFilterAndPagination", reflect.TypeOf((*ProfileMysqlRepository)(nil).FetchWithFilterAndPagination), ctx, limit, offset, query, model)
+}
+
+// RecomputeRecommendationScores mocks base method.
+func (m *ProfileMysqlRepository) RecomputeRecommendationScores(ctx context.Context, likedSince time.Time) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RecomputeRecommendationScores", ctx, likedSince)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// RecomputeRecommendationScores indicates an expected call of RecomputeRecommendationScores.
+func (mr *ProfileMysqlRepositoryMockRecorder) RecomputeRecommendationScores(ctx, likedSince interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RecomputeRecommendationScores", reflect.TypeOf((*ProfileMysqlRepository)(nil).RecomputeRecommendationScores), ctx, likedSince)
 }
 
 // SingleWithFilter mocks base method.
-func (m *ProfileMysqlRepository) SingleWithFilter(ctx context.Context, fields, associate, filter []string, model interface{}, args ...interface{}) error {
+func (m *ProfileMysqlRepository) SingleWithFilter(ctx context.Context, query *database.Query, model interface{}) error {
 	m.ctrl.T.Helper()
-	varargs := []interface{}{ctx, fields, associate, filter, model}
-	for _, a := range args {
-		varargs = append(varargs, a)
-	}
-	ret := m.ctrl.Call(m, "SingleWithFilter", varargs...)
+	ret := m.ctrl.Call(m, "SingleWithFilter", ctx, query, model)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
 // SingleWithFilter indicates an expected call of SingleWithFilter.
-func (mr *ProfileMysqlRepositoryMockRecorder) SingleWithFilter(ctx, fields, associate, filter, model interface{}, args ...interface{}) *gomock.Call {
+func (mr *ProfileMysqlRepositoryMockRecorder) SingleWithFilter(ctx, query, model interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	varargs := append([]interface{}{ctx, fields, associate, filter, model}, args...)
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SingleWithFilter", reflect.TypeOf((*ProfileMysqlRepository)(nil).SingleWithFilter), varargs...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SingleWithFilter", reflect.TypeOf((*ProfileMysqlRepository)(nil).SingleWithFilter), ctx, query, model)
 }
 
 // SoftDelete mocks base method.
@@ -155,21 +142,6 @@ func (mr *ProfileMysqlRepositoryMockRecorder) Store(ctx, data interface{}) *gomo
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Store", reflect.TypeOf((*ProfileMysqlRepository)(nil).Store), ctx, data)
 }
 
-// StoreWithTx mocks base method.
-func (m *ProfileMysqlRepository) StoreWithTx(ctx context.Context, tx *gorm.DB, data domain.Profile) (int, error) {
-	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "StoreWithTx", ctx, tx, data)
-	ret0, _ := ret[0].(int)
-	ret1, _ := ret[1].(error)
-	return ret0, ret1
-}
-
-// StoreWithTx indicates an expected call of StoreWithTx.
-func (mr *ProfileMysqlRepositoryMockRecorder) StoreWithTx(ctx, tx, data interface{}) *gomock.Call {
-	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "StoreWithTx", reflect.TypeOf((*ProfileMysqlRepository)(nil).StoreWithTx), ctx, tx, data)
-}
-
 // Update mocks base method.
 func (m *ProfileMysqlRepository) Update(ctx context.Context, data domain.Profile) error {
 	m.ctrl.T.Helper()
@@ -197,18 +169,3 @@ func (mr *ProfileMysqlRepositoryMockRecorder) UpdateSelectedField(ctx, field, va
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateSelectedField", reflect.TypeOf((*ProfileMysqlRepository)(nil).UpdateSelectedField), ctx, field, values, id)
 }
-
-// UpdateSelectedFieldWithTx mocks base method.
-func (m *ProfileMysqlRepository) UpdateSelectedFieldWithTx(ctx context.Context, tx *gorm.DB, field []string, values map[string]interface{}, id int) error {
-	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "UpdateSelectedFieldWithTx", ctx, tx, field, values, id)
-	ret0, _ := ret[0].(error)
-	return ret0
-}
-
-// UpdateSelectedFieldWithTx indicates an expected call of UpdateSelectedFieldWithTx.
-func (mr *ProfileMysqlRepositoryMockRecorder) UpdateSelectedFieldWithTx(ctx, tx, field, values, id interface{}) *gomock.Call {
-	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateSelectedFieldWithTx", reflect.TypeOf((*ProfileMysqlRepository)(nil).UpdateSelectedFieldWithTx), ctx, tx, field, values, id)
-}
-
@@ -0,0 +1,155 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: internal/report/repository.go
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	context "context"
+	reflect "reflect"
+
+	gomock "github.com/golang/mock/gomock"
+	domain "github.com/radyatamaa/dating-apps-api/internal/domain"
+	database "github.com/radyatamaa/dating-apps-api/pkg/database"
+	paginator "github.com/radyatamaa/dating-apps-api/pkg/database/paginator"
+)
+
+// ReportMysqlRepository is a mock of MysqlRepository interface.
+type ReportMysqlRepository struct {
+	ctrl     *gomock.Controller
+	recorder *ReportMysqlRepositoryMockRecorder
+}
+
+// ReportMysqlRepositoryMockRecorder is the mock recorder for ReportMysqlRepository.
+type ReportMysqlRepositoryMockRecorder struct {
+	mock *ReportMysqlRepository
+}
+
+// NewReportMysqlRepository creates a new mock instance.
+func NewReportMysqlRepository(ctrl *gomock.Controller) *ReportMysqlRepository {
+	mock := &ReportMysqlRepository{ctrl: ctrl}
+	mock.recorder = &ReportMysqlRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *ReportMysqlRepository) EXPECT() *ReportMysqlRepositoryMockRecorder {
+	return m.recorder
+}
+
+// CountDistinctReporters mocks base method.
+func (m *ReportMysqlRepository) CountDistinctReporters(ctx context.Context, reportedUserId int, status []string) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CountDistinctReporters", ctx, reportedUserId, status)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CountDistinctReporters indicates an expected call of CountDistinctReporters.
+func (mr *ReportMysqlRepositoryMockRecorder) CountDistinctReporters(ctx, reportedUserId, status interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CountDistinctReporters", reflect.TypeOf((*ReportMysqlRepository)(nil).CountDistinctReporters), ctx, reportedUserId, status)
+}
+
+// Delete mocks base method.
+func (m *ReportMysqlRepository) Delete(ctx context.Context, id int) (int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Delete", ctx, id)
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Delete indicates an expected call of Delete.
+func (mr *ReportMysqlRepositoryMockRecorder) Delete(ctx, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Delete", reflect.TypeOf((*ReportMysqlRepository)(nil).Delete), ctx, id)
+}
+
+// FetchWithFilter mocks base method.
+func (m *ReportMysqlRepository) FetchWithFilter(ctx context.Context, query *database.Query, model interface{}) (interface{}, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FetchWithFilter", ctx, query, model)
+	ret0, _ := ret[0].(interface{})
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FetchWithFilter indicates an expected call of FetchWithFilter.
+func (mr *ReportMysqlRepositoryMockRecorder) FetchWithFilter(ctx, query, model interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FetchWithFilter", reflect.TypeOf((*ReportMysqlRepository)(nil).FetchWithFilter), ctx, query, model)
+}
+
+// FetchWithFilterAndPagination mocks base method.
+func (m *ReportMysqlRepository) FetchWithFilterAndPagination(ctx context.Context, limit, offset int, query *database.Query, model interface{}) (*paginator.Paginator, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FetchWithFilterAndPagination", ctx, limit, offset, query, model)
+	ret0, _ := ret[0].(*paginator.Paginator)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FetchWithFilterAndPagination indicates an expected call of FetchWithFilterAndPagination.
+func (mr *ReportMysqlRepositoryMockRecorder) FetchWithFilterAndPagination(ctx, limit, offset, query, model interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FetchWithFilterAndPagination", reflect.TypeOf((*ReportMysqlRepository)(nil).FetchWithFilterAndPagination), ctx, limit, offset, query, model)
+}
+
+// SingleWithFilter mocks base method.
+func (m *ReportMysqlRepository) SingleWithFilter(ctx context.Context, query *database.Query, model interface{}) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SingleWithFilter", ctx, query, model)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SingleWithFilter indicates an expected call of SingleWithFilter.
+func (mr *ReportMysqlRepositoryMockRecorder) SingleWithFilter(ctx, query, model interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SingleWithFilter", reflect.TypeOf((*ReportMysqlRepository)(nil).SingleWithFilter), ctx, query, model)
+}
+
+// Store mocks base method.
+func (m *ReportMysqlRepository) Store(ctx context.Context, data domain.Report) (domain.Report, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Store", ctx, data)
+	ret0, _ := ret[0].(domain.Report)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Store indicates an expected call of Store.
+func (mr *ReportMysqlRepositoryMockRecorder) Store(ctx, data interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Store", reflect.TypeOf((*ReportMysqlRepository)(nil).Store), ctx, data)
+}
+
+// Update mocks base method.
+func (m *ReportMysqlRepository) Update(ctx context.Context, data domain.Report) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Update", ctx, data)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Update indicates an expected call of Update.
+func (mr *ReportMysqlRepositoryMockRecorder) Update(ctx, data interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Update", reflect.TypeOf((*ReportMysqlRepository)(nil).Update), ctx, data)
+}
+
+// UpdateSelectedField mocks base method.
+func (m *ReportMysqlRepository) UpdateSelectedField(ctx context.Context, field []string, values map[string]interface{}, id int) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateSelectedField", ctx, field, values, id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateSelectedField indicates an expected call of UpdateSelectedField.
+func (mr *ReportMysqlRepositoryMockRecorder) UpdateSelectedField(ctx, field, values, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateSelectedField", reflect.TypeOf((*ReportMysqlRepository)(nil).UpdateSelectedField), ctx, field, values, id)
+}
@@ -10,8 +10,8 @@ import (
 
 	gomock "github.com/golang/mock/gomock"
 	domain "github.com/radyatamaa/dating-apps-api/internal/domain"
+	database "github.com/radyatamaa/dating-apps-api/pkg/database"
 	paginator "github.com/radyatamaa/dating-apps-api/pkg/database/paginator"
-	gorm "gorm.io/gorm"
 )
 
 // SwipeMysqlRepository is a mock of MysqlRepository interface.
@@ -37,18 +37,19 @@ func (m *SwipeMysqlRepository) EXPECT() *SwipeMysqlRepositoryMockRecorder {
 	return m.recorder
 }
 
-// DB mocks base method.
-func (m *SwipeMysqlRepository) DB() *gorm.DB {
+// Count mocks base method.
+func (m *SwipeMysqlRepository) Count(ctx context.Context, query *database.Query) (int64, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "DB")
-	ret0, _ := ret[0].(*gorm.DB)
-	return ret0
+	ret := m.ctrl.Call(m, "Count", ctx, query)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
 }
 
-// DB indicates an expected call of DB.
-func (mr *SwipeMysqlRepositoryMockRecorder) DB() *gomock.Call {
+// Count indicates an expected call of Count.
+func (mr *SwipeMysqlRepositoryMockRecorder) Count(ctx, query interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DB", reflect.TypeOf((*SwipeMysqlRepository)(nil).DB))
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Count", reflect.TypeOf((*SwipeMysqlRepository)(nil).Count), ctx, query)
 }
 
 // Delete mocks base method.
@@ -67,62 +68,47 @@ func (mr *SwipeMysqlRepositoryMockRecorder) Delete(ctx, id interface{}) *gomock.
 }
 
 // FetchWithFilter mocks base method.
-func (m *SwipeMysqlRepository) FetchWithFilter(ctx context.Context, limit, offset int, order string, fields, associate, filter []string, model interface{}, args ...interface{}) (interface{}, error) {
+func (m *SwipeMysqlRepository) FetchWithFilter(ctx context.Context, query *database.Query, model interface{}) (interface{}, error) {
 	m.ctrl.T.Helper()
-	varargs := []interface{}{ctx, limit, offset, order, fields, associate, filter, model}
-	for _, a := range args {
-		varargs = append(varargs, a)
-	}
-	ret := m.ctrl.Call(m, "FetchWithFilter", varargs...)
+	ret := m.ctrl.Call(m, "FetchWithFilter", ctx, query, model)
 	ret0, _ := ret[0].(interface{})
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // FetchWithFilter indicates an expected call of FetchWithFilter.
-func (mr *SwipeMysqlRepositoryMockRecorder) FetchWithFilter(ctx, limit, offset, order, fields, associate, filter, model interface{}, args ...interface{}) *gomock.Call {
+func (mr *SwipeMysqlRepositoryMockRecorder) FetchWithFilter(ctx, query, model interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	varargs := append([]interface{}{ctx, limit, offset, order, fields, associate, filter, model}, args...)
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FetchWithFilter", reflect.TypeOf((*SwipeMysqlRepository)(nil).FetchWithFilter), varargs...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FetchWithFilter", reflect.TypeOf((*SwipeMysqlRepository)(nil).FetchWithFilter), ctx, query, model)
 }
 
 // FetchWithFilterAndPagination mocks base method.
-func (m *SwipeMysqlRepository) FetchWithFilterAndPagination(ctx context.Context, limit, offset int, order string, fields, associate, filter []string, model interface{}, args ...interface{}) (*paginator.Paginator, error) {
+func (m *SwipeMysqlRepository) FetchWithFilterAndPagination(ctx context.Context, limit, offset int, query *database.Query, model interface{}) (*paginator.Paginator, error) {
 	m.ctrl.T.Helper()
-	varargs := []interface{}{ctx, limit, offset, order, fields, associate, filter, model}
-	for _, a := range args {
-		varargs = append(varargs, a)
-	}
-	ret := m.ctrl.Call(m, "FetchWithFilterAndPagination", varargs...)
+	ret := m.ctrl.Call(m, "FetchWithFilterAndPagination", ctx, limit, offset, query, model)
 	ret0, _ := ret[0].(*paginator.Paginator)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // FetchWithFilterAndPagination indicates an expected call of FetchWithFilterAndPagination.
-func (mr *SwipeMysqlRepositoryMockRecorder) FetchWithFilterAndPagination(ctx, limit, offset, order, fields, associate, filter, model interface{}, args ...interface{}) *gomock.Call {
+func (mr *SwipeMysqlRepositoryMockRecorder) FetchWithFilterAndPagination(ctx, limit, offset, query, model interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	varargs := append([]interface{}{ctx, limit, offset, order, fields, associate, filter, model}, args...)
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FetchWithFilterAndPagination", reflect.TypeOf((*SwipeMysqlRepository)(nil).FetchWithFilterAndPagination), varargs...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FetchWithFilterAndPagination", reflect.TypeOf((*SwipeMysqlRepository)(nil).FetchWithFilterAndPagination), ctx, limit, offset, query, model)
 }
 
 // SingleWithFilter mocks base method.
-func (m *SwipeMysqlRepository) SingleWithFilter(ctx context.Context, fields, associate, filter []string, model interface{}, args ...interface{}) error {
+func (m *SwipeMysqlRepository) SingleWithFilter(ctx context.Context, query *database.Query, model interface{}) error {
 	m.ctrl.T.Helper()
-	varargs := []interface{}{ctx, fields, associate, filter, model}
-	for _, a := range args {
-		varargs = append(varargs, a)
-	}
-	ret := m.ctrl.Call(m, "SingleWithFilter", varargs...)
+	ret := m.ctrl.Call(m, "SingleWithFilter", ctx, query, model)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
 // SingleWithFilter indicates an expected call of SingleWithFilter.
-func (mr *SwipeMysqlRepositoryMockRecorder) SingleWithFilter(ctx, fields, associate, filter, model interface{}, args ...interface{}) *gomock.Call {
+func (mr *SwipeMysqlRepositoryMockRecorder) SingleWithFilter(ctx, query, model interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	varargs := append([]interface{}{ctx, fields, associate, filter, model}, args...)
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SingleWithFilter", reflect.TypeOf((*SwipeMysqlRepository)(nil).SingleWithFilter), varargs...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SingleWithFilter", reflect.TypeOf((*SwipeMysqlRepository)(nil).SingleWithFilter), ctx, query, model)
 }
 
 // SoftDelete mocks base method.
@@ -155,21 +141,6 @@ func (mr *SwipeMysqlRepositoryMockRecorder) Store(ctx, data interface{}) *gomock
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Store", reflect.TypeOf((*SwipeMysqlRepository)(nil).Store), ctx, data)
 }
 
-// StoreWithTx mocks base method.
-func (m *SwipeMysqlRepository) StoreWithTx(ctx context.Context, tx *gorm.DB, data domain.Swipe) (int, error) {
-	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "StoreWithTx", ctx, tx, data)
-	ret0, _ := ret[0].(int)
-	ret1, _ := ret[1].(error)
-	return ret0, ret1
-}
-
-// StoreWithTx indicates an expected call of StoreWithTx.
-func (mr *SwipeMysqlRepositoryMockRecorder) StoreWithTx(ctx, tx, data interface{}) *gomock.Call {
-	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "StoreWithTx", reflect.TypeOf((*SwipeMysqlRepository)(nil).StoreWithTx), ctx, tx, data)
-}
-
 // Update mocks base method.
 func (m *SwipeMysqlRepository) Update(ctx context.Context, data domain.Swipe) error {
 	m.ctrl.T.Helper()
@@ -198,20 +169,6 @@ func (mr *SwipeMysqlRepositoryMockRecorder) UpdateSelectedField(ctx, field, valu
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateSelectedField", reflect.TypeOf((*SwipeMysqlRepository)(nil).UpdateSelectedField), ctx, field, values, id)
 }
 
-// UpdateSelectedFieldWithTx mocks base method.
-func (m *SwipeMysqlRepository) UpdateSelectedFieldWithTx(ctx context.Context, tx *gorm.DB, field []string, values map[string]interface{}, id int) error {
-	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "UpdateSelectedFieldWithTx", ctx, tx, field, values, id)
-	ret0, _ := ret[0].(error)
-	return ret0
-}
-
-// UpdateSelectedFieldWithTx indicates an expected call of UpdateSelectedFieldWithTx.
-func (mr *SwipeMysqlRepositoryMockRecorder) UpdateSelectedFieldWithTx(ctx, tx, field, values, id interface{}) *gomock.Call {
-	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateSelectedFieldWithTx", reflect.TypeOf((*SwipeMysqlRepository)(nil).UpdateSelectedFieldWithTx), ctx, tx, field, values, id)
-}
-
 // Upsert mocks base method.
 func (m *SwipeMysqlRepository) Upsert(ctx context.Context, onConflictField []string, data ...domain.Swipe) error {
 	m.ctrl.T.Helper()
@@ -230,4 +187,3 @@ func (mr *SwipeMysqlRepositoryMockRecorder) Upsert(ctx, onConflictField interfac
 	varargs := append([]interface{}{ctx, onConflictField}, data...)
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Upsert", reflect.TypeOf((*SwipeMysqlRepository)(nil).Upsert), varargs...)
 }
-
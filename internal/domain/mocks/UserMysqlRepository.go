@@ -7,11 +7,12 @@ package mocks
 import (
 	context "context"
 	reflect "reflect"
+	time "time"
 
 	gomock "github.com/golang/mock/gomock"
 	domain "github.com/radyatamaa/dating-apps-api/internal/domain"
+	database "github.com/radyatamaa/dating-apps-api/pkg/database"
 	paginator "github.com/radyatamaa/dating-apps-api/pkg/database/paginator"
-	gorm "gorm.io/gorm"
 )
 
 // UserMysqlRepository is a mock of MysqlRepository interface.
@@ -37,20 +38,6 @@ func (m *UserMysqlRepository) EXPECT() *UserMysqlRepositoryMockRecorder {
 	return m.recorder
 }
 
-// DB mocks base method.
-func (m *UserMysqlRepository) DB() *gorm.DB {
-	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "DB")
-	ret0, _ := ret[0].(*gorm.DB)
-	return ret0
-}
-
-// DB indicates an expected call of DB.
-func (mr *UserMysqlRepositoryMockRecorder) DB() *gomock.Call {
-	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DB", reflect.TypeOf((*UserMysqlRepository)(nil).DB))
-}
-
 // Delete mocks base method.
 func (m *UserMysqlRepository) Delete(ctx context.Context, id int) (int, error) {
 	m.ctrl.T.Helper()
@@ -66,63 +53,63 @@ func (mr *UserMysqlRepositoryMockRecorder) Delete(ctx, id interface{}) *gomock.C
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Delete", reflect.TypeOf((*UserMysqlRepository)(nil).Delete), ctx, id)
 }
 
+// FetchSoftDeleted mocks base method.
+func (m *UserMysqlRepository) FetchSoftDeleted(ctx context.Context, deletedBefore time.Time, limit int) ([]domain.User, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FetchSoftDeleted", ctx, deletedBefore, limit)
+	ret0, _ := ret[0].([]domain.User)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FetchSoftDeleted indicates an expected call of FetchSoftDeleted.
+func (mr *UserMysqlRepositoryMockRecorder) FetchSoftDeleted(ctx, deletedBefore, limit interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FetchSoftDeleted", reflect.TypeOf((*UserMysqlRepository)(nil).FetchSoftDeleted), ctx, deletedBefore, limit)
+}
+
 // FetchWithFilter mocks base method.
-func (m *UserMysqlRepository) FetchWithFilter(ctx context.Context, limit, offset int, order string, fields, associate, filter []string, model interface{}, args ...interface{}) (interface{}, error) {
+func (m *UserMysqlRepository) FetchWithFilter(ctx context.Context, query *database.Query, model interface{}) (interface{}, error) {
 	m.ctrl.T.Helper()
-	varargs := []interface{}{ctx, limit, offset, order, fields, associate, filter, model}
-	for _, a := range args {
-		varargs = append(varargs, a)
-	}
-	ret := m.ctrl.Call(m, "FetchWithFilter", varargs...)
+	ret := m.ctrl.Call(m, "FetchWithFilter", ctx, query, model)
 	ret0, _ := ret[0].(interface{})
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // FetchWithFilter indicates an expected call of FetchWithFilter.
-func (mr *UserMysqlRepositoryMockRecorder) FetchWithFilter(ctx, limit, offset, order, fields, associate, filter, model interface{}, args ...interface{}) *gomock.Call {
+func (mr *UserMysqlRepositoryMockRecorder) FetchWithFilter(ctx, query, model interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	varargs := append([]interface{}{ctx, limit, offset, order, fields, associate, filter, model}, args...)
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FetchWithFilter", reflect.TypeOf((*UserMysqlRepository)(nil).FetchWithFilter), varargs...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FetchWithFilter", reflect.TypeOf((*UserMysqlRepository)(nil).FetchWithFilter), ctx, query, model)
 }
 
 // FetchWithFilterAndPagination mocks base method.
-func (m *UserMysqlRepository) FetchWithFilterAndPagination(ctx context.Context, limit, offset int, order string, fields, associate, filter []string, model interface{}, args ...interface{}) (*paginator.Paginator, error) {
+func (m *UserMysqlRepository) FetchWithFilterAndPagination(ctx context.Context, limit, offset int, query *database.Query, model interface{}) (*paginator.Paginator, error) {
 	m.ctrl.T.Helper()
-	varargs := []interface{}{ctx, limit, offset, order, fields, associate, filter, model}
-	for _, a := range args {
-		varargs = append(varargs, a)
-	}
-	ret := m.ctrl.Call(m, "FetchWithFilterAndPagination", varargs...)
+	ret := m.ctrl.Call(m, "FetchWithFilterAndPagination", ctx, limit, offset, query, model)
 	ret0, _ := ret[0].(*paginator.Paginator)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // FetchWithFilterAndPagination indicates an expected call of FetchWithFilterAndPagination.
-func (mr *UserMysqlRepositoryMockRecorder) FetchWithFilterAndPagination(ctx, limit, offset, order, fields, associate, filter, model interface{}, args ...interface{}) *gomock.Call {
+func (mr *UserMysqlRepositoryMockRecorder) FetchWithFilterAndPagination(ctx, limit, offset, query, model interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	varargs := append([]interface{}{ctx, limit, offset, order, fields, associate, filter, model}, args...)
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FetchWithFilterAndPagination", reflect.TypeOf((*UserMysqlRepository)(nil).FetchWithFilterAndPagination), varargs...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FetchWithFilterAndPagination", reflect.TypeOf((*UserMysqlRepository)(nil).FetchWithFilterAndPagination), ctx, limit, offset, query, model)
 }
 
 // SingleWithFilter mocks base method.
-func (m *UserMysqlRepository) SingleWithFilter(ctx context.Context, fields, associate, filter []string, model interface{}, args ...interface{}) error {
+func (m *UserMysqlRepository) SingleWithFilter(ctx context.Context, query *database.Query, model interface{}) error {
 	m.ctrl.T.Helper()
-	varargs := []interface{}{ctx, fields, associate, filter, model}
-	for _, a := range args {
-		varargs = append(varargs, a)
-	}
-	ret := m.ctrl.Call(m, "SingleWithFilter", varargs...)
+	ret := m.ctrl.Call(m, "SingleWithFilter", ctx, query, model)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
 // SingleWithFilter indicates an expected call of SingleWithFilter.
-func (mr *UserMysqlRepositoryMockRecorder) SingleWithFilter(ctx, fields, associate, filter, model interface{}, args ...interface{}) *gomock.Call {
+func (mr *UserMysqlRepositoryMockRecorder) SingleWithFilter(ctx, query, model interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	varargs := append([]interface{}{ctx, fields, associate, filter, model}, args...)
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SingleWithFilter", reflect.TypeOf((*UserMysqlRepository)(nil).SingleWithFilter), varargs...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SingleWithFilter", reflect.TypeOf((*UserMysqlRepository)(nil).SingleWithFilter), ctx, query, model)
 }
 
 // SoftDelete mocks base method.
@@ -155,21 +142,6 @@ func (mr *UserMysqlRepositoryMockRecorder) Store(ctx, data interface{}) *gomock.
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Store", reflect.TypeOf((*UserMysqlRepository)(nil).Store), ctx, data)
 }
 
-// StoreWithTx mocks base method.
-func (m *UserMysqlRepository) StoreWithTx(ctx context.Context, tx *gorm.DB, data domain.User) (int, error) {
-	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "StoreWithTx", ctx, tx, data)
-	ret0, _ := ret[0].(int)
-	ret1, _ := ret[1].(error)
-	return ret0, ret1
-}
-
-// StoreWithTx indicates an expected call of StoreWithTx.
-func (mr *UserMysqlRepositoryMockRecorder) StoreWithTx(ctx, tx, data interface{}) *gomock.Call {
-	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "StoreWithTx", reflect.TypeOf((*UserMysqlRepository)(nil).StoreWithTx), ctx, tx, data)
-}
-
 // Update mocks base method.
 func (m *UserMysqlRepository) Update(ctx context.Context, data domain.User) error {
 	m.ctrl.T.Helper()
@@ -184,31 +156,31 @@ func (mr *UserMysqlRepositoryMockRecorder) Update(ctx, data interface{}) *gomock
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Update", reflect.TypeOf((*UserMysqlRepository)(nil).Update), ctx, data)
 }
 
-// UpdateSelectedField mocks base method.
-func (m *UserMysqlRepository) UpdateSelectedField(ctx context.Context, field []string, values map[string]interface{}, id int) error {
+// UpdateBackupCodes mocks base method.
+func (m *UserMysqlRepository) UpdateBackupCodes(ctx context.Context, id int, current, remaining string) (bool, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "UpdateSelectedField", ctx, field, values, id)
-	ret0, _ := ret[0].(error)
-	return ret0
+	ret := m.ctrl.Call(m, "UpdateBackupCodes", ctx, id, current, remaining)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
 }
 
-// UpdateSelectedField indicates an expected call of UpdateSelectedField.
-func (mr *UserMysqlRepositoryMockRecorder) UpdateSelectedField(ctx, field, values, id interface{}) *gomock.Call {
+// UpdateBackupCodes indicates an expected call of UpdateBackupCodes.
+func (mr *UserMysqlRepositoryMockRecorder) UpdateBackupCodes(ctx, id, current, remaining interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateSelectedField", reflect.TypeOf((*UserMysqlRepository)(nil).UpdateSelectedField), ctx, field, values, id)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateBackupCodes", reflect.TypeOf((*UserMysqlRepository)(nil).UpdateBackupCodes), ctx, id, current, remaining)
 }
 
-// UpdateSelectedFieldWithTx mocks base method.
-func (m *UserMysqlRepository) UpdateSelectedFieldWithTx(ctx context.Context, tx *gorm.DB, field []string, values map[string]interface{}, id int) error {
+// UpdateSelectedField mocks base method.
+func (m *UserMysqlRepository) UpdateSelectedField(ctx context.Context, field []string, values map[string]interface{}, id int) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "UpdateSelectedFieldWithTx", ctx, tx, field, values, id)
+	ret := m.ctrl.Call(m, "UpdateSelectedField", ctx, field, values, id)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
-// UpdateSelectedFieldWithTx indicates an expected call of UpdateSelectedFieldWithTx.
-func (mr *UserMysqlRepositoryMockRecorder) UpdateSelectedFieldWithTx(ctx, tx, field, values, id interface{}) *gomock.Call {
+// UpdateSelectedField indicates an expected call of UpdateSelectedField.
+func (mr *UserMysqlRepositoryMockRecorder) UpdateSelectedField(ctx, field, values, id interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateSelectedFieldWithTx", reflect.TypeOf((*UserMysqlRepository)(nil).UpdateSelectedFieldWithTx), ctx, tx, field, values, id)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateSelectedField", reflect.TypeOf((*UserMysqlRepository)(nil).UpdateSelectedField), ctx, field, values, id)
 }
-
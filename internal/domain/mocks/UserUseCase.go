@@ -5,9 +5,10 @@
 package mocks
 
 import (
+	context "context"
 	reflect "reflect"
+	time "time"
 
-	context "github.com/beego/beego/v2/server/web/context"
 	gomock "github.com/golang/mock/gomock"
 	domain "github.com/radyatamaa/dating-apps-api/internal/domain"
 )
@@ -35,46 +36,149 @@ func (m *MockUserUseCase) EXPECT() *MockUserUseCaseMockRecorder {
 	return m.recorder
 }
 
+// ConfirmTwoFactor mocks base method.
+func (m *MockUserUseCase) ConfirmTwoFactor(ctx context.Context, request domain.ConfirmTwoFactorRequest) (*domain.ConfirmTwoFactorResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ConfirmTwoFactor", ctx, request)
+	ret0, _ := ret[0].(*domain.ConfirmTwoFactorResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ConfirmTwoFactor indicates an expected call of ConfirmTwoFactor.
+func (mr *MockUserUseCaseMockRecorder) ConfirmTwoFactor(ctx, request interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ConfirmTwoFactor", reflect.TypeOf((*MockUserUseCase)(nil).ConfirmTwoFactor), ctx, request)
+}
+
+// DeleteAccount mocks base method.
+func (m *MockUserUseCase) DeleteAccount(ctx context.Context) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteAccount", ctx)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteAccount indicates an expected call of DeleteAccount.
+func (mr *MockUserUseCaseMockRecorder) DeleteAccount(ctx interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteAccount", reflect.TypeOf((*MockUserUseCase)(nil).DeleteAccount), ctx)
+}
+
+// EnrollTwoFactor mocks base method.
+func (m *MockUserUseCase) EnrollTwoFactor(ctx context.Context) (*domain.EnrollTwoFactorResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "EnrollTwoFactor", ctx)
+	ret0, _ := ret[0].(*domain.EnrollTwoFactorResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// EnrollTwoFactor indicates an expected call of EnrollTwoFactor.
+func (mr *MockUserUseCaseMockRecorder) EnrollTwoFactor(ctx interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "EnrollTwoFactor", reflect.TypeOf((*MockUserUseCase)(nil).EnrollTwoFactor), ctx)
+}
+
+// ExpirePremiumUsers mocks base method.
+func (m *MockUserUseCase) ExpirePremiumUsers(ctx context.Context, now time.Time) (int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ExpirePremiumUsers", ctx, now)
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ExpirePremiumUsers indicates an expected call of ExpirePremiumUsers.
+func (mr *MockUserUseCaseMockRecorder) ExpirePremiumUsers(ctx, now interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ExpirePremiumUsers", reflect.TypeOf((*MockUserUseCase)(nil).ExpirePremiumUsers), ctx, now)
+}
+
 // Login mocks base method.
-func (m *MockUserUseCase) Login(beegoCtx *context.Context, request domain.LoginRequest) (*domain.LoginResponse, error) {
+func (m *MockUserUseCase) Login(ctx context.Context, request domain.LoginRequest) (*domain.LoginResponse, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "Login", beegoCtx, request)
+	ret := m.ctrl.Call(m, "Login", ctx, request)
 	ret0, _ := ret[0].(*domain.LoginResponse)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // Login indicates an expected call of Login.
-func (mr *MockUserUseCaseMockRecorder) Login(beegoCtx, request interface{}) *gomock.Call {
+func (mr *MockUserUseCaseMockRecorder) Login(ctx, request interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Login", reflect.TypeOf((*MockUserUseCase)(nil).Login), ctx, request)
+}
+
+// LoginWithUserId mocks base method.
+func (m *MockUserUseCase) LoginWithUserId(ctx context.Context, userId int) (*domain.LoginResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "LoginWithUserId", ctx, userId)
+	ret0, _ := ret[0].(*domain.LoginResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// LoginWithUserId indicates an expected call of LoginWithUserId.
+func (mr *MockUserUseCaseMockRecorder) LoginWithUserId(ctx, userId interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Login", reflect.TypeOf((*MockUserUseCase)(nil).Login), beegoCtx, request)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "LoginWithUserId", reflect.TypeOf((*MockUserUseCase)(nil).LoginWithUserId), ctx, userId)
 }
 
 // PurchasePremiumUpdateStatus mocks base method.
-func (m *MockUserUseCase) PurchasePremiumUpdateStatus(beegoCtx *context.Context) error {
+func (m *MockUserUseCase) PurchasePremiumUpdateStatus(ctx context.Context) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "PurchasePremiumUpdateStatus", beegoCtx)
+	ret := m.ctrl.Call(m, "PurchasePremiumUpdateStatus", ctx)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
 // PurchasePremiumUpdateStatus indicates an expected call of PurchasePremiumUpdateStatus.
-func (mr *MockUserUseCaseMockRecorder) PurchasePremiumUpdateStatus(beegoCtx interface{}) *gomock.Call {
+func (mr *MockUserUseCaseMockRecorder) PurchasePremiumUpdateStatus(ctx interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PurchasePremiumUpdateStatus", reflect.TypeOf((*MockUserUseCase)(nil).PurchasePremiumUpdateStatus), ctx)
+}
+
+// PurgeDeletedUsers mocks base method.
+func (m *MockUserUseCase) PurgeDeletedUsers(ctx context.Context, deletedBefore time.Time) ([]int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "PurgeDeletedUsers", ctx, deletedBefore)
+	ret0, _ := ret[0].([]int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// PurgeDeletedUsers indicates an expected call of PurgeDeletedUsers.
+func (mr *MockUserUseCaseMockRecorder) PurgeDeletedUsers(ctx, deletedBefore interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PurchasePremiumUpdateStatus", reflect.TypeOf((*MockUserUseCase)(nil).PurchasePremiumUpdateStatus), beegoCtx)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PurgeDeletedUsers", reflect.TypeOf((*MockUserUseCase)(nil).PurgeDeletedUsers), ctx, deletedBefore)
 }
 
 // Register mocks base method.
-func (m *MockUserUseCase) Register(beegoCtx *context.Context, request domain.RegisterRequest) error {
+func (m *MockUserUseCase) Register(ctx context.Context, request domain.RegisterRequest) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "Register", beegoCtx, request)
+	ret := m.ctrl.Call(m, "Register", ctx, request)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
 // Register indicates an expected call of Register.
-func (mr *MockUserUseCaseMockRecorder) Register(beegoCtx, request interface{}) *gomock.Call {
+func (mr *MockUserUseCaseMockRecorder) Register(ctx, request interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Register", reflect.TypeOf((*MockUserUseCase)(nil).Register), beegoCtx, request)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Register", reflect.TypeOf((*MockUserUseCase)(nil).Register), ctx, request)
+}
+
+// VerifyTwoFactorLogin mocks base method.
+func (m *MockUserUseCase) VerifyTwoFactorLogin(ctx context.Context, request domain.VerifyTwoFactorLoginRequest) (*domain.LoginResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "VerifyTwoFactorLogin", ctx, request)
+	ret0, _ := ret[0].(*domain.LoginResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
 }
 
+// VerifyTwoFactorLogin indicates an expected call of VerifyTwoFactorLogin.
+func (mr *MockUserUseCaseMockRecorder) VerifyTwoFactorLogin(ctx, request interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "VerifyTwoFactorLogin", reflect.TypeOf((*MockUserUseCase)(nil).VerifyTwoFactorLogin), ctx, request)
+}
@@ -0,0 +1,110 @@
+package domain
+
+import (
+	"database/sql"
+	"time"
+
+	"github.com/radyatamaa/dating-apps-api/pkg/database/paginator"
+)
+
+const (
+	ModerationActionWarn    = "WARN"
+	ModerationActionSuspend = "SUSPEND"
+	ModerationActionBan     = "BAN"
+	// ModerationActionHide is taken by the system when a user reaches the auto hide threshold of reports.
+	ModerationActionHide = "HIDE"
+	// ModerationActionRestore lifts the hide, the suspension and the ban of a user.
+	ModerationActionRestore = "RESTORE"
+	// ModerationActionRemovePhoto is taken when an admin removes the photo of the profile.
+	ModerationActionRemovePhoto = "REMOVE_PHOTO"
+)
+
+// Entity
+
+// ModerationAction is the append only history of the moderation actions taken on a user.
+type ModerationAction struct {
+	ID     int  `gorm:"column:id;primarykey;autoIncrement:true"`
+	User   User `gorm:"foreignkey:UserID;constraint:OnUpdate:CASCADE,OnDelete:CASCADE;->"`
+	UserID int  `gorm:"column:user_id;index"`
+	// ActorID is the admin who took the action, null when taken by the system
+	ActorID   sql.NullInt64 `gorm:"column:actor_id"`
+	ReportID  sql.NullInt64 `gorm:"column:report_id"`
+	Action    string        `gorm:"type:varchar(20);column:action"`
+	Reason    string        `gorm:"type:text;column:reason"`
+	ExpiresAt sql.NullTime  `gorm:"column:expires_at"`
+	CreatedAt time.Time     `gorm:"column:created_at"`
+}
+
+// TableName name of table
+func (r ModerationAction) TableName() string {
+	return "moderation_actions"
+}
+
+//////////////////////////
+
+// Requests
+type ModerationActionRequest struct {
+	Action string `json:"action" validate:"required,enum=WARN-SUSPEND-BAN-RESTORE"`
+	Reason string `json:"reason" validate:"required,max=500"`
+	// SuspendDays is the duration of a SUSPEND action, default 7 days
+	SuspendDays int `json:"suspend_days" validate:"min=0"`
+	// ReportID is marked as actioned when set
+	ReportID int `json:"report_id"`
+}
+
+//////////////////////////
+
+// Responses
+type ModerationActionResponse struct {
+	Id        int    `json:"id"`
+	UserId    int    `json:"user_id"`
+	ActorId   *int   `json:"actor_id"`
+	ReportId  *int   `json:"report_id"`
+	Action    string `json:"action"`
+	Reason    string `json:"reason"`
+	ExpiresAt string `json:"expires_at"`
+	CreatedAt string `json:"created_at"`
+}
+
+type ModerationActionResponsePaginationResponse struct {
+	Data      []ModerationActionResponse      `json:"data"`
+	Paginator paginator.MetaPaginatorResponse `json:"paginator"`
+}
+
+//////////////////////////
+
+// Mapping
+func IsSuspended(suspendedUntil sql.NullTime) bool {
+	return suspendedUntil.Valid && suspendedUntil.Time.After(time.Now())
+}
+
+func nullInt64ToIntPtr(value sql.NullInt64) *int {
+	if !value.Valid {
+		return nil
+	}
+	result := int(value.Int64)
+	return &result
+}
+
+func FromModerationActionToModerationActionResponse(data ModerationAction) ModerationActionResponse {
+	res := ModerationActionResponse{
+		Id:        data.ID,
+		UserId:    data.UserID,
+		ActorId:   nullInt64ToIntPtr(data.ActorID),
+		ReportId:  nullInt64ToIntPtr(data.ReportID),
+		Action:    data.Action,
+		Reason:    data.Reason,
+		CreatedAt: data.CreatedAt.String(),
+	}
+	if data.ExpiresAt.Valid {
+		res.ExpiresAt = data.ExpiresAt.Time.String()
+	}
+	return res
+}
+
+func ToModerationActionResponsePaginationResponse(data []ModerationActionResponse, page, limit, offset int, paging *paginator.Paginator) *ModerationActionResponsePaginationResponse {
+	return &ModerationActionResponsePaginationResponse{
+		Data:      data,
+		Paginator: paginator.MetaPaginatorResponse{}.MappingPaginator(page, limit, offset, paging, len(data)),
+	}
+}
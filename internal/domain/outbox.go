@@ -0,0 +1,89 @@
+package domain
+
+import (
+	"database/sql"
+	"time"
+
+	"github.com/radyatamaa/dating-apps-api/pkg/event"
+)
+
+// Names of the domain events
+const (
+	EventUserRegistered   = "user.registered"
+	EventMatchCreated     = "match.created"
+	EventPremiumActivated = "premium.activated"
+	EventPremiumExpired   = "premium.expired"
+)
+
+// Entity
+
+// OutboxEvent is a domain event stored in the transaction of its change, the relay publishes
+// it to the broker and sets PublishedAt, the published events are removed by the retention policy.
+type OutboxEvent struct {
+	ID         int       `gorm:"column:id;primarykey;autoIncrement:true"`
+	EventID    string    `gorm:"type:varchar(36);column:event_id;uniqueIndex"`
+	Name       string    `gorm:"type:varchar(100);column:name"`
+	EventKey   string    `gorm:"type:varchar(100);column:event_key"`
+	Payload    string    `gorm:"type:text;column:payload"`
+	OccurredAt time.Time `gorm:"column:occurred_at"`
+	// PublishedAt is null until the event is published to the broker
+	PublishedAt sql.NullTime `gorm:"column:published_at;index"`
+	Attempts    int          `gorm:"column:attempts"`
+	LastError   string       `gorm:"type:text;column:last_error"`
+}
+
+// TableName name of table
+func (r OutboxEvent) TableName() string {
+	return "outbox_events"
+}
+
+//////////////////////////
+
+// Payloads
+
+type UserRegisteredPayload struct {
+	UserID   int    `json:"user_id"`
+	Email    string `json:"email"`
+	Provider string `json:"provider"`
+}
+
+// MatchCreatedPayload is the LIKE of UserID to the profile of MatchedUserID liking back UserID.
+type MatchCreatedPayload struct {
+	UserID           int `json:"user_id"`
+	ProfileID        int `json:"profile_id"`
+	MatchedUserID    int `json:"matched_user_id"`
+	MatchedProfileID int `json:"matched_profile_id"`
+}
+
+type PremiumActivatedPayload struct {
+	UserID           int       `json:"user_id"`
+	PremiumExpiresAt time.Time `json:"premium_expires_at"`
+}
+
+type PremiumExpiredPayload struct {
+	UserID    int       `json:"user_id"`
+	ExpiredAt time.Time `json:"expired_at"`
+}
+
+//////////////////////////
+
+// Mapping
+func FromEventToOutboxEvent(e event.Event) OutboxEvent {
+	return OutboxEvent{
+		EventID:    e.ID,
+		Name:       e.Name,
+		EventKey:   e.Key,
+		Payload:    string(e.Payload),
+		OccurredAt: e.OccurredAt,
+	}
+}
+
+func (r OutboxEvent) ToEvent() event.Event {
+	return event.Event{
+		ID:         r.EventID,
+		Name:       r.Name,
+		Key:        r.EventKey,
+		Payload:    []byte(r.Payload),
+		OccurredAt: r.OccurredAt,
+	}
+}
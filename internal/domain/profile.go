@@ -19,6 +19,8 @@ type Profile struct {
 	Bio      string `gorm:"type:text;column:bio"`
 	Longitude float64 `gorm:"column:longitude"`
 	Latitude  float64 `gorm:"column:latitude"`
+	// RecommendationScore orders the feed, recomputed by the recommendation job
+	RecommendationScore float64 `gorm:"column:recommendation_score;index"`
 	CreatedAt time.Time `gorm:"column:created_at"`
 	UpdatedAt time.Time `gorm:"column:updated_at"`
 }
@@ -38,7 +40,7 @@ type ProfileQueryWithUser struct {
 	CreatedAt time.Time `gorm:"column:created_at"`
 	UpdatedAt time.Time `gorm:"column:updated_at"`
 	Distance float64  `gorm:"column:distance"`
-	User
+	PremiumExpiresAt sql.NullTime `gorm:"column:premium_expires_at"`
 }
 
 // TableName name of table
@@ -96,10 +98,10 @@ func FromProfileToGetProfilesResponse(data ProfileQueryWithUser) GetProfilesResp
 	}
 }
 
-func ToGetProfilesResponsePaginationResponsee(data []GetProfilesResponse, page, limit, offset, totalAllRecords int) *GetProfilesResponsePaginationResponse {
+func ToGetProfilesResponsePaginationResponsee(data []GetProfilesResponse, page, limit, offset int, paging *paginator.Paginator) *GetProfilesResponsePaginationResponse {
 	return &GetProfilesResponsePaginationResponse{
 		Data:      data,
-		Paginator: paginator.MetaPaginatorResponse{}.MappingPaginator(page, limit, offset, totalAllRecords, len(data)),
+		Paginator: paginator.MetaPaginatorResponse{}.MappingPaginator(page, limit, offset, paging, len(data)),
 	}
 }
 //////////////////////////
@@ -0,0 +1,111 @@
+package domain
+
+import (
+	"database/sql"
+	"time"
+
+	"github.com/radyatamaa/dating-apps-api/pkg/database/paginator"
+)
+
+const (
+	ReportStatusOpen      = "OPEN"
+	ReportStatusReviewing = "REVIEWING"
+	ReportStatusActioned  = "ACTIONED"
+	ReportStatusDismissed = "DISMISSED"
+)
+
+// Entity
+type Report struct {
+	ID             int           `gorm:"column:id;primarykey;autoIncrement:true"`
+	Reporter       User          `gorm:"foreignkey:ReporterID;constraint:OnUpdate:CASCADE,OnDelete:CASCADE;->"`
+	ReporterID     int           `gorm:"column:reporter_id;index"`
+	ReportedUser   User          `gorm:"foreignkey:ReportedUserID;constraint:OnUpdate:CASCADE,OnDelete:CASCADE;->"`
+	ReportedUserID int           `gorm:"column:reported_user_id;index"`
+	Profile        Profile       `gorm:"foreignkey:ProfileID;constraint:OnUpdate:CASCADE,OnDelete:CASCADE;->"`
+	ProfileID      int           `gorm:"column:profile_id"`
+	Reason         string        `gorm:"type:varchar(50);column:reason"`
+	Description    string        `gorm:"type:text;column:description"`
+	Status         string        `gorm:"type:varchar(20);column:status;index"`
+	ReviewedBy     sql.NullInt64 `gorm:"column:reviewed_by"`
+	ReviewedAt     sql.NullTime  `gorm:"column:reviewed_at"`
+	CreatedAt      time.Time     `gorm:"column:created_at"`
+	UpdatedAt      time.Time     `gorm:"column:updated_at"`
+}
+
+// TableName name of table
+func (r Report) TableName() string {
+	return "reports"
+}
+
+//////////////////////////
+
+// Requests
+type CreateReportRequest struct {
+	ProfileID   int    `json:"profile_id" validate:"required,check_fk=ProfileID:profile:id"`
+	Reason      string `json:"reason" validate:"required,enum=SPAM-HARASSMENT-FAKE_PROFILE-INAPPROPRIATE_CONTENT-UNDERAGE-OTHER"`
+	Description string `json:"description" validate:"max=500"`
+}
+
+type UpdateReportStatusRequest struct {
+	Status string `json:"status" validate:"required,enum=REVIEWING-ACTIONED-DISMISSED"`
+}
+
+//////////////////////////
+
+// Responses
+type ReportResponse struct {
+	Id             int    `json:"id"`
+	ReporterId     int    `json:"reporter_id"`
+	ReportedUserId int    `json:"reported_user_id"`
+	ProfileId      int    `json:"profile_id"`
+	Reason         string `json:"reason"`
+	Description    string `json:"description"`
+	Status         string `json:"status"`
+	ReviewedBy     *int   `json:"reviewed_by"`
+	ReviewedAt     string `json:"reviewed_at"`
+	CreatedAt      string `json:"created_at"`
+}
+
+type ReportResponsePaginationResponse struct {
+	Data      []ReportResponse                `json:"data"`
+	Paginator paginator.MetaPaginatorResponse `json:"paginator"`
+}
+
+//////////////////////////
+
+// Mapping
+func (r CreateReportRequest) ToReport(reporterId, reportedUserId int) Report {
+	return Report{
+		ReporterID:     reporterId,
+		ReportedUserID: reportedUserId,
+		ProfileID:      r.ProfileID,
+		Reason:         r.Reason,
+		Description:    r.Description,
+		Status:         ReportStatusOpen,
+	}
+}
+
+func FromReportToReportResponse(data Report) ReportResponse {
+	res := ReportResponse{
+		Id:             data.ID,
+		ReporterId:     data.ReporterID,
+		ReportedUserId: data.ReportedUserID,
+		ProfileId:      data.ProfileID,
+		Reason:         data.Reason,
+		Description:    data.Description,
+		Status:         data.Status,
+		ReviewedBy:     nullInt64ToIntPtr(data.ReviewedBy),
+		CreatedAt:      data.CreatedAt.String(),
+	}
+	if data.ReviewedAt.Valid {
+		res.ReviewedAt = data.ReviewedAt.Time.String()
+	}
+	return res
+}
+
+func ToReportResponsePaginationResponse(data []ReportResponse, page, limit, offset int, paging *paginator.Paginator) *ReportResponsePaginationResponse {
+	return &ReportResponsePaginationResponse{
+		Data:      data,
+		Paginator: paginator.MetaPaginatorResponse{}.MappingPaginator(page, limit, offset, paging, len(data)),
+	}
+}
@@ -0,0 +1,57 @@
+package domain
+
+import (
+	"gorm.io/gorm"
+)
+
+const (
+	RoleUser      = "user"
+	RoleModerator = "moderator"
+	RoleAdmin     = "admin"
+)
+
+const (
+	PermissionUsersRead      = "users:read"
+	PermissionUsersModerate  = "users:moderate"
+	PermissionUsersPremium   = "users:premium"
+	PermissionUsersRole      = "users:role"
+	PermissionPhotosModerate = "photos:moderate"
+	PermissionReportsManage  = "reports:manage"
+	PermissionAuditRead      = "audit:read"
+)
+
+// RolePermissions are the permissions granted to each role, embedded in the JWT payload at login.
+var RolePermissions = map[string][]string{
+	RoleUser: {},
+	RoleModerator: {
+		PermissionUsersRead,
+		PermissionUsersModerate,
+		PermissionPhotosModerate,
+		PermissionReportsManage,
+	},
+	RoleAdmin: {
+		PermissionUsersRead,
+		PermissionUsersModerate,
+		PermissionUsersPremium,
+		PermissionUsersRole,
+		PermissionPhotosModerate,
+		PermissionReportsManage,
+		PermissionAuditRead,
+	},
+}
+
+// PermissionsOfRole returns the permissions of the role, an unknown role has no permission.
+func PermissionsOfRole(role string) []string {
+	if permissions, ok := RolePermissions[role]; ok {
+		return permissions
+	}
+	return []string{}
+}
+
+// SeederRole grants the role to the users with the emails, used to bootstrap the first admins.
+func SeederRole(db *gorm.DB, role string, emails []string) error {
+	if len(emails) == 0 {
+		return nil
+	}
+	return db.Model(&User{}).Where("email in (?)", emails).Update("role", role).Error
+}
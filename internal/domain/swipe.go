@@ -27,6 +27,10 @@ type SwipeProfileRequest struct {
 	ProfileID int `json:"profile_id" validate:"required,check_fk=ProfileID:profile:id"`
 	SwipeType string `json:"swipe_type" validate:"required,enum=LIKE-PASS"`
 }
+
+type UnmatchRequest struct {
+	ProfileID int `json:"profile_id" validate:"required,check_fk=ProfileID:profile:id"`
+}
 //////////////////////////
 
 
@@ -12,9 +12,18 @@ type User struct {
 	ID              int       `gorm:"column:id;primarykey;autoIncrement:true"`
 	PasswordHash    string    `gorm:"type:varchar(255);column:password_hash"`
 	Email           string    `gorm:"type:varchar(255);column:email"`
+	Role            string    `gorm:"type:varchar(20);column:role;default:user"`
 	PremiumExpiresAt sql.NullTime `gorm:"column:premium_expires_at"`
+	TwoFactorEnabled bool `gorm:"column:two_factor_enabled;default:false"`
+	TwoFactorSecret string `gorm:"type:varchar(255);column:two_factor_secret"`
+	TwoFactorBackupCodes string `gorm:"type:text;column:two_factor_backup_codes"`
+	// HiddenAt is set when the user reaches the auto hide threshold of reports
+	HiddenAt        sql.NullTime `gorm:"column:hidden_at"`
+	SuspendedUntil  sql.NullTime `gorm:"column:suspended_until"`
+	BannedAt        sql.NullTime `gorm:"column:banned_at"`
 	CreatedAt       time.Time `gorm:"column:created_at"`
 	UpdatedAt       time.Time `gorm:"column:updated_at"`
+	DeletedAt       gorm.DeletedAt `gorm:"column:deleted_at;index"`
 }
 
 // TableName name of table
@@ -36,9 +45,18 @@ type UserQueryWithProfile struct {
 	ID              int       `gorm:"column:id;primarykey;autoIncrement:true"`
 	PasswordHash    string    `gorm:"type:varchar(255);column:password_hash"`
 	Email           string    `gorm:"type:varchar(255);column:email"`
+	Role            string    `gorm:"type:varchar(20);column:role"`
 	PremiumExpiresAt sql.NullTime `gorm:"column:premium_expires_at"`
+	TwoFactorEnabled bool `gorm:"column:two_factor_enabled"`
+	TwoFactorSecret string `gorm:"type:varchar(255);column:two_factor_secret"`
+	TwoFactorBackupCodes string `gorm:"type:text;column:two_factor_backup_codes"`
+	// HiddenAt is set when the user reaches the auto hide threshold of reports
+	HiddenAt        sql.NullTime `gorm:"column:hidden_at"`
+	SuspendedUntil  sql.NullTime `gorm:"column:suspended_until"`
+	BannedAt        sql.NullTime `gorm:"column:banned_at"`
 	CreatedAt       time.Time `gorm:"column:created_at"`
 	UpdatedAt       time.Time `gorm:"column:updated_at"`
+	DeletedAt       gorm.DeletedAt `gorm:"column:deleted_at"`
 	ProfileId 		int `gorm:"column:profile_id"`
 	Name     string `gorm:"type:varchar(255);column:name"`
 	Photo    string `gorm:"type:text;column:photo"`
@@ -69,6 +87,16 @@ type RegisterRequest struct {
 	Email    string `form:"email" validate:"required,email_address,unique_store=email:users,max=100"`
 	Password string `form:"password"  validate:"required,max=20"`
 }
+
+type ConfirmTwoFactorRequest struct {
+	Code string `json:"code" validate:"required"`
+}
+
+type VerifyTwoFactorLoginRequest struct {
+	ChallengeToken string `json:"challenge_token" validate:"required"`
+	// Code is either the authenticator code or one of the backup codes
+	Code string `json:"code" validate:"required"`
+}
 //////////////////////////
 
 // Responses
@@ -76,6 +104,19 @@ type LoginResponse struct {
 	Token     string    `json:"token"`
 	ExpiredAt string    `json:"expired_at"`
 	User      UserLogin `json:"user"`
+	// TwoFactorRequired when true Token is empty, the ChallengeToken and the
+	// authenticator code must be sent to /v1/user/login/verify to get the token
+	TwoFactorRequired bool   `json:"two_factor_required"`
+	ChallengeToken    string `json:"challenge_token,omitempty"`
+}
+
+type EnrollTwoFactorResponse struct {
+	Secret     string `json:"secret"`
+	OtpAuthUri string `json:"otpauth_uri"`
+}
+
+type ConfirmTwoFactorResponse struct {
+	BackupCodes []string `json:"backup_codes"`
 }
 
 type UserLogin struct {
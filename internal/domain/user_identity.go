@@ -0,0 +1,47 @@
+package domain
+
+import (
+	"time"
+)
+
+// Entity
+type UserIdentity struct {
+	ID        int       `gorm:"column:id;primarykey;autoIncrement:true"`
+	User      User      `gorm:"foreignkey:UserID;constraint:OnUpdate:CASCADE,OnDelete:CASCADE;->"`
+	UserID    int       `gorm:"column:user_id;index"`
+	Provider  string    `gorm:"type:varchar(50);column:provider;uniqueIndex:idx_provider_subject"`
+	Subject   string    `gorm:"type:varchar(255);column:subject;uniqueIndex:idx_provider_subject"`
+	Email     string    `gorm:"type:varchar(255);column:email"`
+	CreatedAt time.Time `gorm:"column:created_at"`
+	UpdatedAt time.Time `gorm:"column:updated_at"`
+}
+
+// TableName name of table
+func (r UserIdentity) TableName() string {
+	return "user_identities"
+}
+
+//////////////////////////
+
+// Requests
+type OAuthCallbackRequest struct {
+	Code  string `form:"code" validate:"required"`
+	State string `form:"state" validate:"required"`
+}
+
+//////////////////////////
+
+// Responses
+type OAuthAuthorizeResponse struct {
+	AuthorizationUrl string `json:"authorization_url"`
+	State            string `json:"state"`
+}
+
+// OAuthState stored in cache between the authorize and the callback request
+type OAuthState struct {
+	Provider     string `json:"provider"`
+	Nonce        string `json:"nonce"`
+	CodeVerifier string `json:"code_verifier"`
+}
+
+//////////////////////////
@@ -4,6 +4,7 @@ import (
 	"encoding/json"
 	"fmt"
 	"strings"
+	"time"
 
 	contextBeego "github.com/beego/beego/v2/server/web/context"
 	"github.com/radyatamaa/dating-apps-api/pkg/zaplogger"
@@ -20,6 +21,15 @@ func NewAccessLogMiddleware(ZapLogger zaplogger.Logger, appVersion string) *Acce
 		AppVersion: appVersion,
 	}
 }
+
+// rawJSON returns the dumped body as a raw JSON field, nil when it's empty.
+func rawJSON(body []byte) interface{} {
+	if len(body) == 0 {
+		return nil
+	}
+	return json.RawMessage(body)
+}
+
 func (m *AccessLogMiddleware) Logger() BodyDumpConfig {
 	return BodyDumpConfig{
 		Skipper: func(ctx *contextBeego.Context) bool {
@@ -29,33 +39,38 @@ func (m *AccessLogMiddleware) Logger() BodyDumpConfig {
 			return false
 		},
 		Handler: func(context *contextBeego.Context, request []byte, response []byte) {
+			fields := []interface{}{
+				zaplogger.FieldAppVersion, m.AppVersion,
+				"host", context.Request.Host,
+				"uri", context.Request.URL.String(),
+				"request", rawJSON(request),
+				"response", rawJSON(response),
+			}
+			if startTime, ok := context.Input.GetData(zaplogger.StartTimeKey).(time.Time); ok {
+				fields = append(fields, zaplogger.FieldLatencyMs, time.Since(startTime).Milliseconds())
+			}
+			// the message is kept short, it's the only part forwarded to slack
+			message := fmt.Sprintf("%s %s %d", context.Request.Method, context.Request.URL.Path, context.ResponseWriter.Status)
+
+			logger := m.ZapLogger.WithContext(context)
 			if context.ResponseWriter.Status > 399 {
-				if errorData, ok := context.Input.GetData("stackTrace").(*zaplogger.ListErrors); ok {
-					m.ZapLogger.Errorf(zaplogger.StdFormatErrorLog,
-						m.AppVersion,
-						context.Request.Host,
-						context.Request.URL.String(),
-						context.ResponseWriter.ResponseWriter.Header().Get("X-REQUEST-ID"),
-						json.RawMessage(request),
-						json.RawMessage(response),
-						fmt.Sprintf("%s %s %s %d %v", errorData.Error, errorData.File, errorData.Function, errorData.Line, errorData.Extra))
-				} else {
-					m.ZapLogger.Errorf(zaplogger.StdFormatErrorLog,
-						m.AppVersion,
-						context.Request.Host,
-						context.Request.URL.String(),
-						context.ResponseWriter.ResponseWriter.Header().Get("X-REQUEST-ID"),
-						json.RawMessage(request),
-						json.RawMessage(response), "")
+				errorData, _ := context.Input.GetData("stackTrace").(*zaplogger.ListErrors)
+				if errorData == nil {
+					errorData = zaplogger.StackTraceFromContext(context.Request.Context())
+				}
+				if errorData != nil {
+					fields = append(fields,
+						zaplogger.FieldError, errorData.Error,
+						"error_file", errorData.File,
+						"error_function", errorData.Function,
+						"error_line", errorData.Line)
+					if errorData.Extra != nil {
+						fields = append(fields, "error_extra", fmt.Sprintf("%v", errorData.Extra))
+					}
 				}
+				logger.Errorw(message, fields...)
 			} else {
-				m.ZapLogger.Infof(zaplogger.StdFormatLog,
-					m.AppVersion,
-					context.Request.Host,
-					context.Request.URL.String(),
-					context.ResponseWriter.ResponseWriter.Header().Get("X-REQUEST-ID"),
-					json.RawMessage(request),
-					json.RawMessage(response))
+				logger.Infow(message, fields...)
 			}
 		},
 	}
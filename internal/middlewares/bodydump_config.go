@@ -9,6 +9,9 @@ import (
 	"io/ioutil"
 	"net"
 	"net/http"
+	"time"
+
+	"github.com/radyatamaa/dating-apps-api/pkg/zaplogger"
 )
 
 
@@ -20,6 +23,30 @@ type (
 		// Handler receives request and response payload.
 		// Required.
 		Handler BodyDumpHandler
+
+		// RedactRequestFields are the JSON paths of the request fields masked before the
+		// handler is called, a path without a dot matches the field at any depth, ex: "password",
+		// a dotted path is anchored at the root and "*" matches any field, ex: "data.*.email".
+		// The url encoded and multipart forms are redacted by field name.
+		// Optional. Default value DefaultRedactRequestFields.
+		RedactRequestFields []string
+
+		// RedactResponseFields are the JSON paths of the response fields masked before the handler is called.
+		// Optional. Default value DefaultRedactResponseFields.
+		RedactResponseFields []string
+
+		// RedactMask replaces the value of the redacted fields.
+		// Optional. Default value DefaultRedactMask.
+		RedactMask string
+
+		// SkipFormFields are the url encoded and multipart form fields never passed to the handler,
+		// the multipart files are never passed to the handler.
+		SkipFormFields []string
+
+		// MaxBodySize is the max size in bytes of the bodies passed to the handler,
+		// a larger body is replaced by a marker.
+		// Optional. Default value DefaultMaxBodySize.
+		MaxBodySize int
 	}
 
 	// BodyDumpHandler receives the request and response payload.
@@ -34,7 +61,11 @@ type (
 var (
 	// DefaultBodyDumpConfig is the default BodyDump middleware config.
 	DefaultBodyDumpConfig = BodyDumpConfig{
-		Skipper: DefaultSkipper,
+		Skipper:              DefaultSkipper,
+		RedactRequestFields:  DefaultRedactRequestFields,
+		RedactResponseFields: DefaultRedactResponseFields,
+		RedactMask:           DefaultRedactMask,
+		MaxBodySize:          DefaultMaxBodySize,
 	}
 )
 
@@ -57,6 +88,20 @@ func BodyDumpWithConfig(config BodyDumpConfig) beego.FilterChain {
 	if config.Skipper == nil {
 		config.Skipper = DefaultBodyDumpConfig.Skipper
 	}
+	if config.RedactRequestFields == nil {
+		config.RedactRequestFields = DefaultBodyDumpConfig.RedactRequestFields
+	}
+	if config.RedactResponseFields == nil {
+		config.RedactResponseFields = DefaultBodyDumpConfig.RedactResponseFields
+	}
+	if config.RedactMask == "" {
+		config.RedactMask = DefaultBodyDumpConfig.RedactMask
+	}
+	if config.MaxBodySize == 0 {
+		config.MaxBodySize = DefaultBodyDumpConfig.MaxBodySize
+	}
+	requestDumper := newBodyDumper(config.RedactRequestFields, config.SkipFormFields, config.RedactMask, config.MaxBodySize)
+	responseDumper := newBodyDumper(config.RedactResponseFields, config.SkipFormFields, config.RedactMask, config.MaxBodySize)
 
 	return func(next beego.FilterFunc) beego.FilterFunc {
 		return func(ctx *beegoContext.Context) {
@@ -65,6 +110,8 @@ func BodyDumpWithConfig(config BodyDumpConfig) beego.FilterChain {
 				return
 			}
 
+			ctx.Input.SetData(zaplogger.StartTimeKey, time.Now())
+
 			var reqBody []byte
 			if ctx.Request.Body != nil { // Read
 				reqBody, _ = ioutil.ReadAll(ctx.Request.Body)
@@ -82,7 +129,9 @@ func BodyDumpWithConfig(config BodyDumpConfig) beego.FilterChain {
 
 
 			// Callback
-			config.Handler(ctx, reqBody, resBody.Bytes())
+			config.Handler(ctx,
+				requestDumper.dump(ctx.Request.Header.Get("Content-Type"), reqBody),
+				responseDumper.dump(writer.Header().Get("Content-Type"), resBody.Bytes()))
 
 			return
 		}
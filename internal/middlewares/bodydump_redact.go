@@ -0,0 +1,233 @@
+package middlewares
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"mime"
+	"mime/multipart"
+	"net/url"
+	"strings"
+)
+
+const (
+	// DefaultRedactMask replaces the value of the redacted fields.
+	DefaultRedactMask = "[REDACTED]"
+	// DefaultMaxBodySize is the max size of a dumped body.
+	DefaultMaxBodySize = 64 << 10
+)
+
+var (
+	// DefaultRedactRequestFields are the secrets and the PII of the request bodies.
+	DefaultRedactRequestFields = []string{
+		"password",
+		"code",
+		"code_verifier",
+		"challenge_token",
+		"token",
+		"email",
+		"latitude",
+		"longitude",
+	}
+
+	// DefaultRedactResponseFields are the secrets and the PII of the response bodies.
+	DefaultRedactResponseFields = []string{
+		"token",
+		"challenge_token",
+		"secret",
+		"otpauth_uri",
+		"backup_codes",
+		"download_url",
+		"authorization_url",
+		"email",
+		"latitude",
+		"longitude",
+		"ip",
+	}
+)
+
+// redactor masks the fields matching the JSON paths, a path without a dot matches
+// the field at any depth and a dotted path is anchored at the root of the body,
+// a "*" segment matches any field. Arrays are traversed, they don't add a segment.
+type redactor struct {
+	anywhere map[string]bool
+	anchored [][]string
+	mask     string
+}
+
+func newRedactor(paths []string, mask string) *redactor {
+	r := &redactor{anywhere: map[string]bool{}, mask: mask}
+	for _, path := range paths {
+		path = strings.ToLower(strings.TrimSpace(path))
+		if path == "" {
+			continue
+		}
+		if strings.Contains(path, ".") {
+			r.anchored = append(r.anchored, strings.Split(path, "."))
+		} else {
+			r.anywhere[path] = true
+		}
+	}
+	return r
+}
+
+func (r *redactor) matches(path []string) bool {
+	if r.anywhere[strings.ToLower(path[len(path)-1])] {
+		return true
+	}
+	for _, anchored := range r.anchored {
+		if len(anchored) != len(path) {
+			continue
+		}
+		matched := true
+		for i := range anchored {
+			if anchored[i] != "*" && anchored[i] != strings.ToLower(path[i]) {
+				matched = false
+				break
+			}
+		}
+		if matched {
+			return true
+		}
+	}
+	return false
+}
+
+func (r *redactor) redact(value interface{}, path []string) {
+	switch v := value.(type) {
+	case map[string]interface{}:
+		for key, child := range v {
+			childPath := append(append(make([]string, 0, len(path)+1), path...), key)
+			if r.matches(childPath) {
+				v[key] = r.mask
+				continue
+			}
+			r.redact(child, childPath)
+		}
+	case []interface{}:
+		for _, child := range v {
+			r.redact(child, path)
+		}
+	}
+}
+
+// bodyDumper returns the dumped body as JSON: redacted JSON and forms,
+// a marker for the text, the binary, the too large and the invalid bodies.
+type bodyDumper struct {
+	redactor    *redactor
+	skipFields  map[string]bool
+	maxBodySize int
+}
+
+func newBodyDumper(redactFields, skipFormFields []string, mask string, maxBodySize int) *bodyDumper {
+	skipFields := make(map[string]bool, len(skipFormFields))
+	for _, field := range skipFormFields {
+		skipFields[strings.ToLower(field)] = true
+	}
+	return &bodyDumper{
+		redactor:    newRedactor(redactFields, mask),
+		skipFields:  skipFields,
+		maxBodySize: maxBodySize,
+	}
+}
+
+func marker(format string, args ...interface{}) []byte {
+	result, _ := json.Marshal(fmt.Sprintf(format, args...))
+	return result
+}
+
+func (d *bodyDumper) dump(contentType string, body []byte) []byte {
+	if len(body) == 0 {
+		return body
+	}
+	if d.maxBodySize > 0 && len(body) > d.maxBodySize {
+		return marker("[TRUNCATED %d bytes]", len(body))
+	}
+
+	mediaType, params, _ := mime.ParseMediaType(contentType)
+	switch {
+	// a JSON body is redacted whatever its media type, the handlers parse a JSON sent as text/plain
+	case mediaType == "application/json" || strings.HasSuffix(mediaType, "+json") || json.Valid(body):
+		return d.dumpJSON(body)
+	case mediaType == "multipart/form-data":
+		return d.dumpMultipart(body, params["boundary"])
+	case mediaType == "application/x-www-form-urlencoded":
+		values, err := url.ParseQuery(string(body))
+		if err != nil {
+			return marker("[INVALID FORM %d bytes]", len(body))
+		}
+		form := make(map[string]interface{}, len(values))
+		for key, value := range values {
+			d.addFormValue(form, key, value...)
+		}
+		return d.marshal(form)
+	case strings.HasPrefix(mediaType, "text/"):
+		// the text can't be redacted, it may carry the fields of a form or a malformed JSON
+		return marker("[TEXT %s %d bytes]", mediaType, len(body))
+	default:
+		return marker("[BINARY %s %d bytes]", mediaType, len(body))
+	}
+}
+
+func (d *bodyDumper) dumpJSON(body []byte) []byte {
+	decoder := json.NewDecoder(bytes.NewReader(body))
+	decoder.UseNumber()
+	var value interface{}
+	if err := decoder.Decode(&value); err != nil {
+		return marker("[INVALID JSON %d bytes]", len(body))
+	}
+	return d.marshal(value)
+}
+
+// dumpMultipart dumps the form fields of the multipart body, the files are never dumped.
+func (d *bodyDumper) dumpMultipart(body []byte, boundary string) []byte {
+	form := make(map[string]interface{})
+	reader := multipart.NewReader(bytes.NewReader(body), boundary)
+	for {
+		part, err := reader.NextPart()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return marker("[INVALID MULTIPART %d bytes]", len(body))
+		}
+
+		if part.FileName() != "" {
+			size, _ := io.Copy(ioutil.Discard, part)
+			form[part.FormName()] = fmt.Sprintf("[BINARY %s %d bytes]", part.FileName(), size)
+			continue
+		}
+		value, err := ioutil.ReadAll(part)
+		if err != nil {
+			return marker("[INVALID MULTIPART %d bytes]", len(body))
+		}
+		d.addFormValue(form, part.FormName(), string(value))
+	}
+	return d.marshal(form)
+}
+
+func (d *bodyDumper) addFormValue(form map[string]interface{}, key string, values ...string) {
+	if d.skipFields[strings.ToLower(key)] {
+		return
+	}
+	if len(values) == 1 {
+		form[key] = values[0]
+		return
+	}
+	list := make([]interface{}, len(values))
+	for i := range values {
+		list[i] = values[i]
+	}
+	form[key] = list
+}
+
+func (d *bodyDumper) marshal(value interface{}) []byte {
+	d.redactor.redact(value, nil)
+	result, err := json.Marshal(value)
+	if err != nil {
+		return marker("[INVALID BODY]")
+	}
+	return result
+}
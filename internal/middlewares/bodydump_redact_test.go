@@ -0,0 +1,124 @@
+package middlewares
+
+import (
+	"bytes"
+	"mime/multipart"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	beegoContext "github.com/beego/beego/v2/server/web/context"
+	"github.com/stretchr/testify/suite"
+)
+
+type BodyDumpRedactTestSuite struct {
+	suite.Suite
+}
+
+func (t *BodyDumpRedactTestSuite) TestBodyDumper_dump() {
+	var multipartBody bytes.Buffer
+	writer := multipart.NewWriter(&multipartBody)
+	_ = writer.WriteField("name", "jane")
+	_ = writer.WriteField("password", "secret")
+	_ = writer.WriteField("bio", "hello")
+	file, _ := writer.CreateFormFile("photo", "photo.jpg")
+	_, _ = file.Write([]byte{0xff, 0xd8, 0xff, 0xe0})
+	_ = writer.Close()
+
+	dumper := newBodyDumper([]string{"password", "data.token", "data.*.email"}, []string{"bio"}, DefaultRedactMask, 1024)
+	tests := []struct {
+		name        string
+		contentType string
+		body        []byte
+		want        string
+	}{
+		{
+			name:        "json field at any depth",
+			contentType: "application/json",
+			body:        []byte(`{"email":"jane@gmail.com","password":"secret","nested":[{"password":"secret"}]}`),
+			want:        `{"email":"jane@gmail.com","nested":[{"password":"[REDACTED]"}],"password":"[REDACTED]"}`,
+		},
+		{
+			name:        "json anchored path and wildcard",
+			contentType: "application/json; charset=utf-8",
+			body:        []byte(`{"code":"OK","data":{"token":"jwt","user":{"email":"jane@gmail.com","id":1}},"token":"root"}`),
+			want:        `{"code":"OK","data":{"token":"[REDACTED]","user":{"email":"[REDACTED]","id":1}},"token":"root"}`,
+		},
+		{
+			name:        "url encoded form",
+			contentType: "application/x-www-form-urlencoded",
+			body:        []byte("name=jane&password=secret&bio=hello"),
+			want:        `{"name":"jane","password":"[REDACTED]"}`,
+		},
+		{
+			name:        "multipart form never dumps the files",
+			contentType: writer.FormDataContentType(),
+			body:        multipartBody.Bytes(),
+			want:        `{"name":"jane","password":"[REDACTED]","photo":"[BINARY photo.jpg 4 bytes]"}`,
+		},
+		{
+			name:        "json sent as text",
+			contentType: "text/plain",
+			body:        []byte(`{"email":"jane@gmail.com","password":"secret"}`),
+			want:        `{"email":"jane@gmail.com","password":"[REDACTED]"}`,
+		},
+		{
+			name:        "text",
+			contentType: "text/plain; charset=utf-8",
+			body:        []byte("email=jane@gmail.com password=secret"),
+			want:        `"[TEXT text/plain 36 bytes]"`,
+		},
+		{
+			name:        "binary",
+			contentType: "application/zip",
+			body:        []byte{0x50, 0x4b, 0x03, 0x04},
+			want:        `"[BINARY application/zip 4 bytes]"`,
+		},
+		{
+			name:        "too large",
+			contentType: "application/json",
+			body:        []byte(`{"bio":"` + strings.Repeat("a", 1024) + `"}`),
+			want:        `"[TRUNCATED 1034 bytes]"`,
+		},
+		{
+			name:        "invalid json",
+			contentType: "application/json",
+			body:        []byte(`{"password":"secret"`),
+			want:        `"[INVALID JSON 20 bytes]"`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func() {
+			t.JSONEq(tt.want, string(dumper.dump(tt.contentType, tt.body)))
+		})
+	}
+}
+
+func (t *BodyDumpRedactTestSuite) TestBodyDumpWithConfig() {
+	var request, response []byte
+	chain := BodyDumpWithConfig(BodyDumpConfig{
+		Handler: func(ctx *beegoContext.Context, req []byte, res []byte) {
+			request, response = req, res
+		},
+	})
+	handler := chain(func(ctx *beegoContext.Context) {
+		ctx.Output.Header("Content-Type", "application/json; charset=utf-8")
+		_ = ctx.Output.Body([]byte(`{"data":{"token":"jwt","expired_at":"2026-01-01"}}`))
+	})
+
+	w := httptest.NewRecorder()
+	r := httptest.NewRequest(http.MethodPost, "/api/v1/user/login", strings.NewReader(`{"email":"jane@gmail.com","password":"secret"}`))
+	r.Header.Set("Content-Type", "application/json")
+	ctx := beegoContext.NewContext()
+	ctx.Reset(w, r)
+	handler(ctx)
+
+	t.JSONEq(`{"email":"[REDACTED]","password":"[REDACTED]"}`, string(request))
+	t.JSONEq(`{"data":{"token":"[REDACTED]","expired_at":"2026-01-01"}}`, string(response))
+	t.JSONEq(`{"data":{"token":"jwt","expired_at":"2026-01-01"}}`, w.Body.String())
+}
+
+func TestBodyDumpRedactTestSuite(t *testing.T) {
+	suite.Run(t, new(BodyDumpRedactTestSuite))
+}
@@ -0,0 +1,301 @@
+package middlewares
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"net/http"
+	"runtime/debug"
+	"strings"
+	"time"
+
+	"github.com/beego/i18n"
+	"github.com/radyatamaa/dating-apps-api/pkg/authuser"
+	"github.com/radyatamaa/dating-apps-api/pkg/jwt"
+	"github.com/radyatamaa/dating-apps-api/pkg/requestinfo"
+	"github.com/radyatamaa/dating-apps-api/pkg/response"
+	"github.com/radyatamaa/dating-apps-api/pkg/zaplogger"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/peer"
+	"google.golang.org/grpc/status"
+)
+
+const (
+	// GrpcRequestIDKey is the metadata of the request id, it's sent back in the header.
+	GrpcRequestIDKey = "x-request-id"
+	// GrpcErrorCodeKey is the trailer of the api error code of a failed call, ex: ERROR-API-029.
+	GrpcErrorCodeKey = "x-error-code"
+)
+
+type (
+	// GrpcRateLimitConfig defines the config for GrpcRateLimit interceptor.
+	GrpcRateLimitConfig struct {
+		RateLimitConfig
+
+		// Routes are the rest routes of the limited full methods, formatted as <METHOD> <path>,
+		// the call is counted with the requests of its route so both apis share the limit.
+		// The methods missing in Routes aren't limited.
+		Routes map[string]string
+	}
+
+	// GrpcAuthConfig defines the config for GrpcAuth interceptor.
+	GrpcAuthConfig struct {
+		// SkipMethods are the full methods called without token,
+		// ex: /dating.v1.UserService/Login.
+		SkipMethods []string
+	}
+)
+
+var (
+	// DefaultGrpcAuthConfig is the default GrpcAuth interceptor config,
+	// the login is called without token like the rest api.
+	DefaultGrpcAuthConfig = GrpcAuthConfig{
+		SkipMethods: []string{
+			"/dating.v1.UserService/Login",
+			"/dating.v1.UserService/VerifyTwoFactorLogin",
+		},
+	}
+
+	// DefaultGrpcRateLimitRoutes are the rest routes of the methods called without token,
+	// they're limited by the client ip like the rest login.
+	DefaultGrpcRateLimitRoutes = map[string]string{
+		"/dating.v1.UserService/Login":                http.MethodPost + " /api/v1/user/login",
+		"/dating.v1.UserService/VerifyTwoFactorLogin": http.MethodPost + " /api/v1/user/login/verify",
+	}
+
+	// grpcHTTPCodes are the grpc codes of the http statuses of the domain errors,
+	// the other statuses are codes.Internal.
+	grpcHTTPCodes = map[int]codes.Code{
+		http.StatusBadRequest:      codes.InvalidArgument,
+		http.StatusUnauthorized:    codes.Unauthenticated,
+		http.StatusForbidden:       codes.PermissionDenied,
+		http.StatusNotFound:        codes.NotFound,
+		http.StatusRequestTimeout:  codes.DeadlineExceeded,
+		http.StatusConflict:        codes.AlreadyExists,
+		http.StatusTooManyRequests: codes.ResourceExhausted,
+	}
+)
+
+// GrpcRequestID returns a unary server interceptor reading the x-request-id metadata or generating it,
+// the context carries the requestinfo of the call and collects its stack trace like the RequestID middleware.
+func GrpcRequestID() grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+		md, _ := metadata.FromIncomingContext(ctx)
+		rid := firstMetadata(md, GrpcRequestIDKey)
+		if rid == "" {
+			rid = generator()
+		}
+		_ = grpc.SetHeader(ctx, metadata.Pairs(GrpcRequestIDKey, rid))
+
+		requestInfo := requestinfo.Info{
+			ID:   rid,
+			Host: firstMetadata(md, ":authority"),
+		}
+		if p, ok := peer.FromContext(ctx); ok {
+			requestInfo.IP = p.Addr.String()
+			if host, _, err := net.SplitHostPort(requestInfo.IP); err == nil {
+				requestInfo.IP = host
+			}
+		}
+		return handler(zaplogger.NewStackTraceContext(requestinfo.NewContext(ctx, requestInfo)), req)
+	}
+}
+
+// GrpcAccessLog returns a unary server interceptor logging the calls.
+func GrpcAccessLog(zapLog zaplogger.Logger) grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+		start := time.Now()
+		resp, err := handler(ctx, req)
+		md, _ := metadata.FromIncomingContext(ctx)
+		zapLog.GrpcMiddlewareAccessLogger(info.FullMethod, time.Since(start), redactMetadata(md), err)
+		return resp, err
+	}
+}
+
+// GrpcRecovery returns a unary server interceptor recovering the panics of the handlers as an internal error.
+func GrpcRecovery(zapLog zaplogger.Logger) grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (resp interface{}, err error) {
+		defer func() {
+			if r := recover(); r != nil {
+				zapLog.Errorw("grpc panic recovered", zaplogger.FieldRequestID, requestinfo.FromContext(ctx).ID,
+					zaplogger.FieldError, fmt.Sprint(r), "stack", string(debug.Stack()))
+				err = fmt.Errorf("grpc: panic in %s: %v", info.FullMethod, r)
+			}
+		}()
+		return handler(ctx, req)
+	}
+}
+
+// GrpcErrorStatus returns a unary server interceptor mapping the errors of the handlers to their grpc status,
+// the message is the translated message of the rest api and the api code is sent in the x-error-code trailer.
+func GrpcErrorStatus() grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+		resp, err := handler(ctx, req)
+		if err == nil {
+			return resp, nil
+		}
+		if _, ok := status.FromError(err); ok {
+			return resp, err
+		}
+
+		code, apiCode := grpcStatus(err)
+		_ = grpc.SetTrailer(ctx, metadata.Pairs(GrpcErrorCodeKey, apiCode))
+		return resp, status.Error(code, response.ErrorCodeText(apiCode, grpcLang(ctx)))
+	}
+}
+
+// GrpcRateLimit returns a unary server interceptor limiting the calls of the methods of routes
+// with the rules of config, keyed by the peer ip.
+func GrpcRateLimit(config RateLimitConfig) grpc.UnaryServerInterceptor {
+	return GrpcRateLimitWithConfig(GrpcRateLimitConfig{RateLimitConfig: config, Routes: DefaultGrpcRateLimitRoutes})
+}
+
+// GrpcRateLimitWithConfig returns a GrpcRateLimit interceptor with config.
+//
+// The interceptor runs after GrpcRequestID for the peer ip of the requestinfo and fails with
+// ResourceExhausted once the limit is reached, the api code is sent in the x-error-code trailer.
+func GrpcRateLimitWithConfig(config GrpcRateLimitConfig) grpc.UnaryServerInterceptor {
+	// Defaults
+	if config.Store == nil {
+		config.Store = NewMemoryRateLimitStore()
+	}
+	if config.KeyBy == "" {
+		config.KeyBy = DefaultRateLimitConfig.KeyBy
+	}
+
+	return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+		route, ok := config.Routes[info.FullMethod]
+		if !ok {
+			return handler(ctx, req)
+		}
+		method, path := route, ""
+		if i := strings.Index(route, " "); i >= 0 {
+			method, path = route[:i], route[i+1:]
+		}
+
+		rule, ok := config.match(method, path)
+		if !ok {
+			return handler(ctx, req)
+		}
+
+		// the calls are unauthenticated so they're counted by the peer ip, with the key of the rest requests
+		key := config.key(rule, requestinfo.FromContext(ctx).IP, "")
+		result, err := config.Store.Allow(ctx, key, rule.Limit, rule.Window)
+		if err != nil {
+			// fail open, an unavailable store must not take the api down
+			return handler(ctx, req)
+		}
+		if !result.Allowed {
+			_ = grpc.SetTrailer(ctx, metadata.Pairs(GrpcErrorCodeKey, response.TooManyRequestsCodeError))
+			return nil, status.Error(codes.ResourceExhausted, response.ErrorCodeText(response.TooManyRequestsCodeError, grpcLang(ctx)))
+		}
+		return handler(ctx, req)
+	}
+}
+
+// GrpcAuth returns a unary server interceptor authenticating the bearer token of the authorization metadata,
+// the context carries the JWT payload and its authuser like the JwtMiddleware.
+func GrpcAuth(jwtAuth jwt.JWT) grpc.UnaryServerInterceptor {
+	return GrpcAuthWithConfig(jwtAuth, DefaultGrpcAuthConfig)
+}
+
+// GrpcAuthWithConfig returns a GrpcAuth interceptor with config.
+func GrpcAuthWithConfig(jwtAuth jwt.JWT, config GrpcAuthConfig) grpc.UnaryServerInterceptor {
+	skip := make(map[string]bool, len(config.SkipMethods))
+	for _, method := range config.SkipMethods {
+		skip[method] = true
+	}
+
+	return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+		if skip[info.FullMethod] {
+			return handler(ctx, req)
+		}
+
+		md, _ := metadata.FromIncomingContext(ctx)
+		token := firstMetadata(md, "authorization")
+		if len(token) > 7 && strings.EqualFold(token[:7], "bearer ") {
+			token = token[7:]
+		}
+
+		authCtx, err := jwtAuth.MiddlewareRPCAuth(ctx, token)
+		if err != nil {
+			apiCode := response.UnauthorizedCodeError
+			switch {
+			case token == "" || jwt.IsMissingToken(err):
+				apiCode = response.MissingTokenCodeError
+			case jwt.IsInvalidToken(err):
+				apiCode = response.InvalidTokenCodeError
+			case jwt.IsExpiredToken(err):
+				apiCode = response.ExpiredTokenCodeError
+			case jwt.IsAuthElsewhere(err):
+				apiCode = response.AuthElseWhereCodeError
+			}
+			return nil, grpcUnauthenticated(ctx, apiCode)
+		}
+
+		payload, _ := jwt.PayloadFromContext(authCtx)
+		user, err := authuser.FromPayload(payload)
+		if err != nil {
+			return nil, grpcUnauthenticated(ctx, response.InvalidTokenCodeError)
+		}
+		return handler(authuser.NewContext(authCtx, user), req)
+	}
+}
+
+// GrpcClientLogger returns a unary client interceptor logging the calls to other services.
+func GrpcClientLogger(zapLog zaplogger.Logger) grpc.UnaryClientInterceptor {
+	return func(ctx context.Context, method string, req, reply interface{}, cc *grpc.ClientConn, invoker grpc.UnaryInvoker, opts ...grpc.CallOption) error {
+		start := time.Now()
+		err := invoker(ctx, method, req, reply, cc, opts...)
+		md, _ := metadata.FromOutgoingContext(ctx)
+		zapLog.GrpcClientInterceptorLogger(method, req, reply, time.Since(start), redactMetadata(md), err)
+		return err
+	}
+}
+
+// grpcUnauthenticated returns the unauthenticated status of the api code.
+func grpcUnauthenticated(ctx context.Context, apiCode string) error {
+	_ = grpc.SetTrailer(ctx, metadata.Pairs(GrpcErrorCodeKey, apiCode))
+	return status.Error(codes.Unauthenticated, response.ErrorCodeText(apiCode, grpcLang(ctx)))
+}
+
+// grpcStatus returns the grpc code of the http status of the domain error of err and its api code,
+// codes.Internal when err isn't mapped.
+func grpcStatus(err error) (codes.Code, string) {
+	domainErr, ok := response.LookupError(err)
+	if !ok {
+		return codes.Internal, response.ServerErrorCode
+	}
+	if code, ok := grpcHTTPCodes[domainErr.HTTPStatus]; ok {
+		return code, domainErr.Code
+	}
+	return codes.Internal, domainErr.Code
+}
+
+// grpcLang returns the language of the accept-language metadata, english by default.
+func grpcLang(ctx context.Context) string {
+	md, _ := metadata.FromIncomingContext(ctx)
+	if lang := firstMetadata(md, "accept-language"); i18n.IsExist(lang) {
+		return lang
+	}
+	return "en"
+}
+
+func firstMetadata(md metadata.MD, key string) string {
+	if values := md.Get(key); len(values) > 0 {
+		return values[0]
+	}
+	return ""
+}
+
+// redactMetadata returns md without the token of the authorization metadata.
+func redactMetadata(md metadata.MD) map[string][]string {
+	if _, ok := md["authorization"]; !ok {
+		return md
+	}
+	redacted := md.Copy()
+	redacted.Set("authorization", "[REDACTED]")
+	return redacted
+}
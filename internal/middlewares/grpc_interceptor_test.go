@@ -0,0 +1,224 @@
+package middlewares
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	pb "github.com/radyatamaa/dating-apps-api/api/proto/v1"
+	"github.com/radyatamaa/dating-apps-api/pkg/authuser"
+	"github.com/radyatamaa/dating-apps-api/pkg/jwt"
+	mockJwt "github.com/radyatamaa/dating-apps-api/pkg/jwt/mocks"
+	"github.com/radyatamaa/dating-apps-api/pkg/requestinfo"
+	"github.com/radyatamaa/dating-apps-api/pkg/response"
+	mockZaplogger "github.com/radyatamaa/dating-apps-api/pkg/zaplogger/mocks"
+	"github.com/stretchr/testify/suite"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"google.golang.org/grpc/test/bufconn"
+	"gorm.io/gorm"
+)
+
+type GrpcInterceptorTestSuite struct {
+	suite.Suite
+}
+
+// swipeServer returns err from SwipeProfile and records the request info and the user of the call.
+type swipeServer struct {
+	pb.UnimplementedSwipeServiceServer
+	err  error
+	info requestinfo.Info
+	user authuser.AuthUser
+}
+
+func (s *swipeServer) SwipeProfile(ctx context.Context, req *pb.SwipeProfileRequest) (*pb.SwipeProfileResponse, error) {
+	s.info = requestinfo.FromContext(ctx)
+	s.user, _ = authuser.FromContext(ctx)
+	if req.GetSwipeType() == "PANIC" {
+		panic("swipe failed")
+	}
+	return &pb.SwipeProfileResponse{}, s.err
+}
+
+func (t *GrpcInterceptorTestSuite) dial(ctrl *gomock.Controller, server *swipeServer, auth *mockJwt.MockJWT) pb.SwipeServiceClient {
+	zapLogger := mockZaplogger.NewMockLogger(ctrl)
+	zapLogger.EXPECT().GrpcMiddlewareAccessLogger("/dating.v1.SwipeService/SwipeProfile", gomock.Any(), gomock.Any(), gomock.Any())
+	zapLogger.EXPECT().Errorw(gomock.Any(), gomock.Any()).AnyTimes()
+
+	listener := bufconn.Listen(1024 * 1024)
+	grpcServer := grpc.NewServer(grpc.ChainUnaryInterceptor(
+		GrpcRequestID(),
+		GrpcAccessLog(zapLogger),
+		GrpcErrorStatus(),
+		GrpcRecovery(zapLogger),
+		GrpcAuth(auth),
+	))
+	pb.RegisterSwipeServiceServer(grpcServer, server)
+	go grpcServer.Serve(listener)
+	t.T().Cleanup(grpcServer.Stop)
+
+	conn, err := grpc.Dial("bufnet",
+		grpc.WithContextDialer(func(ctx context.Context, s string) (net.Conn, error) {
+			return listener.DialContext(ctx)
+		}),
+		grpc.WithTransportCredentials(insecure.NewCredentials()))
+	t.Require().NoError(err)
+	t.T().Cleanup(func() { conn.Close() })
+	return pb.NewSwipeServiceClient(conn)
+}
+
+func (t *GrpcInterceptorTestSuite) TestInterceptors() {
+	tests := []struct {
+		name          string
+		token         string
+		swipeType     string
+		err           error
+		authErr       error
+		wantCode      codes.Code
+		wantErrorCode string
+	}{
+		{
+			name:     "success",
+			token:    "token",
+			wantCode: codes.OK,
+		},
+		{
+			name:          "missing token",
+			authErr:       errors.New("token is missing"),
+			wantCode:      codes.Unauthenticated,
+			wantErrorCode: response.MissingTokenCodeError,
+		},
+		{
+			name:          "limit swipe",
+			token:         "token",
+			err:           fmt.Errorf("swipe: %w", response.ErrLimitSwipeOrLike),
+			wantCode:      codes.InvalidArgument,
+			wantErrorCode: response.LimitSwipeOrLikeErrorCode,
+		},
+		{
+			name:          "not found",
+			token:         "token",
+			err:           gorm.ErrRecordNotFound,
+			wantCode:      codes.InvalidArgument,
+			wantErrorCode: response.DataNotFoundCodeError,
+		},
+		{
+			name:          "account suspended",
+			token:         "token",
+			err:           response.ErrAccountSuspended,
+			wantCode:      codes.PermissionDenied,
+			wantErrorCode: response.AccountSuspendedErrorCode,
+		},
+		{
+			name:          "deadline exceeded",
+			token:         "token",
+			err:           context.DeadlineExceeded,
+			wantCode:      codes.DeadlineExceeded,
+			wantErrorCode: response.RequestTimeoutCodeError,
+		},
+		{
+			name:          "unmapped error",
+			token:         "token",
+			err:           errors.New("connection refused"),
+			wantCode:      codes.Internal,
+			wantErrorCode: response.ServerErrorCode,
+		},
+		{
+			name:          "panic",
+			token:         "token",
+			swipeType:     "PANIC",
+			wantCode:      codes.Internal,
+			wantErrorCode: response.ServerErrorCode,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func() {
+			ctrl := gomock.NewController(t.T())
+			defer ctrl.Finish()
+
+			auth := mockJwt.NewMockJWT(ctrl)
+			auth.EXPECT().MiddlewareRPCAuth(gomock.Any(), tt.token).DoAndReturn(func(ctx context.Context, token string) (context.Context, error) {
+				if tt.authErr != nil {
+					return nil, tt.authErr
+				}
+				return context.WithValue(ctx, "JWT_PAYLOAD", jwt.Payload{"uid": float64(1)}), nil
+			})
+			server := &swipeServer{err: tt.err}
+			client := t.dial(ctrl, server, auth)
+
+			ctx := metadata.AppendToOutgoingContext(context.Background(), GrpcRequestIDKey, "request-1")
+			if tt.token != "" {
+				ctx = metadata.AppendToOutgoingContext(ctx, "authorization", "Bearer "+tt.token)
+			}
+			var header, trailer metadata.MD
+			_, err := client.SwipeProfile(ctx, &pb.SwipeProfileRequest{ProfileId: 2, SwipeType: tt.swipeType},
+				grpc.Header(&header), grpc.Trailer(&trailer))
+
+			t.Equal(tt.wantCode, status.Code(err))
+			t.Equal([]string{"request-1"}, header.Get(GrpcRequestIDKey))
+			if tt.wantErrorCode != "" {
+				t.Equal([]string{tt.wantErrorCode}, trailer.Get(GrpcErrorCodeKey))
+			}
+			if tt.authErr == nil {
+				t.Equal("request-1", server.info.ID)
+				t.Equal("bufconn", server.info.IP)
+				t.Equal(1, server.user.ID)
+			}
+		})
+	}
+}
+
+func (t *GrpcInterceptorTestSuite) TestGrpcAuth_SkipMethods() {
+	ctrl := gomock.NewController(t.T())
+	defer ctrl.Finish()
+
+	interceptor := GrpcAuthWithConfig(mockJwt.NewMockJWT(ctrl), GrpcAuthConfig{
+		SkipMethods: []string{"/dating.v1.UserService/Login"},
+	})
+	resp, err := interceptor(context.Background(), nil, &grpc.UnaryServerInfo{FullMethod: "/dating.v1.UserService/Login"},
+		func(ctx context.Context, req interface{}) (interface{}, error) {
+			return "logged in", nil
+		})
+	t.NoError(err)
+	t.Equal("logged in", resp)
+}
+
+func (t *GrpcInterceptorTestSuite) TestGrpcRateLimit() {
+	store := NewMemoryRateLimitStore()
+	interceptor := GrpcRateLimit(RateLimitConfig{
+		Store: store,
+		Rules: []RateLimitRule{{Method: "POST", Path: "/api/v1/user/login", Limit: 2, Window: time.Minute}},
+	})
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return "logged in", nil
+	}
+	login := &grpc.UnaryServerInfo{FullMethod: "/dating.v1.UserService/Login"}
+	ctx := requestinfo.NewContext(context.Background(), requestinfo.Info{IP: "10.0.0.1"})
+
+	// the rest login of the ip counts in the limit of the grpc login
+	_, err := store.Allow(context.Background(), "POST:/api/v1/user/login:ip:10.0.0.1", 2, time.Minute)
+	t.Require().NoError(err)
+	resp, err := interceptor(ctx, nil, login, handler)
+	t.NoError(err)
+	t.Equal("logged in", resp)
+
+	_, err = interceptor(ctx, nil, login, handler)
+	t.Equal(codes.ResourceExhausted, status.Code(err))
+
+	// another ip and the methods without route aren't limited
+	_, err = interceptor(requestinfo.NewContext(context.Background(), requestinfo.Info{IP: "10.0.0.2"}), nil, login, handler)
+	t.NoError(err)
+	_, err = interceptor(ctx, nil, &grpc.UnaryServerInfo{FullMethod: "/dating.v1.SwipeService/SwipeProfile"}, handler)
+	t.NoError(err)
+}
+
+func TestGrpcInterceptorTestSuite(t *testing.T) {
+	suite.Run(t, new(GrpcInterceptorTestSuite))
+}
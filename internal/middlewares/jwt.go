@@ -4,6 +4,7 @@ import (
 	"net/http"
 	"strings"
 
+	"github.com/radyatamaa/dating-apps-api/pkg/authuser"
 	"github.com/radyatamaa/dating-apps-api/pkg/helper"
 
 	beego "github.com/beego/beego/v2/server/web"
@@ -25,6 +26,12 @@ func NewJwtMiddleware() *JwtConfig {
 		if strings.EqualFold(ctx.Request.URL.Path, "/api/v1/user/register") {
 			return true
 		}
+		if strings.EqualFold(ctx.Request.URL.Path, "/api/v1/user/login/verify") {
+			return true
+		}
+		if strings.HasPrefix(strings.ToLower(ctx.Request.URL.Path), "/api/v1/oauth/") {
+			return true
+		}
 		return false
 	}}
 }
@@ -60,7 +67,14 @@ func (r *JwtConfig) JwtMiddleware(jwtAuth jwt.JWT) beego.FilterChain {
 					return
 				}
 			} else {
-				ctx.Request = middlewareRequest
+				// the usecases read the authenticated user of the payload from the request context
+				payload, _ := jwt.PayloadFromContext(middlewareRequest.Context())
+				user, err := authuser.FromPayload(payload)
+				if err != nil {
+					r.ResponseError(ctx, http.StatusUnauthorized, response.InvalidTokenCodeError, response.ErrorCodeText(response.InvalidTokenCodeError, helper.GetLangVersion(ctx)), err)
+					return
+				}
+				ctx.Request = middlewareRequest.WithContext(authuser.NewContext(middlewareRequest.Context(), user))
 				next(ctx)
 			}
 		}
@@ -0,0 +1,92 @@
+package middlewares
+
+import (
+	"net/http"
+	"strconv"
+	"time"
+
+	beego "github.com/beego/beego/v2/server/web"
+	beegoContext "github.com/beego/beego/v2/server/web/context"
+	"github.com/radyatamaa/dating-apps-api/pkg/metrics"
+)
+
+type (
+	// MetricsConfig defines the config for Metrics middleware.
+	MetricsConfig struct {
+		// Skipper defines a function to skip middleware.
+		Skipper Skipper
+
+		// RouteResolver defines a function which returns the route template of the request,
+		// ex: /api/v1/profile/:id, the raw path is never used as label to keep the cardinality bounded.
+		// Optional. Default value resolves the route from the beego router, unmatched when not found.
+		RouteResolver func(*beegoContext.Context) string
+	}
+)
+
+const unmatchedRoute = "unmatched"
+
+var (
+	// DefaultMetricsConfig is the default Metrics middleware config.
+	DefaultMetricsConfig = MetricsConfig{
+		Skipper:       DefaultSkipper,
+		RouteResolver: routerPattern,
+	}
+)
+
+// Metrics returns a middleware which records the request count, the latency
+// and the in-flight requests per route template and status.
+func Metrics() beego.FilterChain {
+	return MetricsWithConfig(DefaultMetricsConfig)
+}
+
+// MetricsWithConfig returns a Metrics middleware with config.
+func MetricsWithConfig(config MetricsConfig) beego.FilterChain {
+	// Defaults
+	if config.Skipper == nil {
+		config.Skipper = DefaultMetricsConfig.Skipper
+	}
+	if config.RouteResolver == nil {
+		config.RouteResolver = DefaultMetricsConfig.RouteResolver
+	}
+
+	return func(next beego.FilterFunc) beego.FilterFunc {
+		return func(ctx *beegoContext.Context) {
+			if config.Skipper(ctx) {
+				next(ctx)
+				return
+			}
+
+			method := ctx.Request.Method
+			route := config.RouteResolver(ctx)
+			inFlight := metrics.HTTPRequestsInFlight.WithLabelValues(method, route)
+			inFlight.Inc()
+			start := time.Now()
+
+			defer func() {
+				inFlight.Dec()
+
+				status := ctx.ResponseWriter.Status
+				if status == 0 {
+					status = http.StatusOK
+				}
+				code := strconv.Itoa(status)
+				metrics.HTTPRequestsTotal.WithLabelValues(method, route, code).Inc()
+				metrics.HTTPRequestDuration.WithLabelValues(method, route, code).Observe(time.Since(start).Seconds())
+			}()
+
+			next(ctx)
+		}
+	}
+}
+
+// routerPattern resolves the route template of the request from the beego router,
+// the filter chain runs before routing so the router is asked directly.
+func routerPattern(ctx *beegoContext.Context) string {
+	if route, ok := ctx.Input.GetData("RouterPattern").(string); ok && route != "" {
+		return route
+	}
+	if info, ok := beego.BeeApp.Handlers.FindRouter(ctx); ok && info != nil {
+		return info.GetPattern()
+	}
+	return unmatchedRoute
+}
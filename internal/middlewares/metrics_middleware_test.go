@@ -0,0 +1,70 @@
+package middlewares
+
+import (
+	"net/http"
+	"testing"
+
+	beegoContext "github.com/beego/beego/v2/server/web/context"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/radyatamaa/dating-apps-api/pkg/metrics"
+	"github.com/stretchr/testify/suite"
+)
+
+type MetricsMiddlewareTestSuite struct {
+	suite.Suite
+}
+
+func (t *MetricsMiddlewareTestSuite) SetupSuite() {
+}
+
+func (t *MetricsMiddlewareTestSuite) TestMetricsWithConfig() {
+	route := "/api/v1/profile/:id"
+	middleware := MetricsWithConfig(MetricsConfig{
+		RouteResolver: func(*beegoContext.Context) string {
+			return route
+		},
+	})
+
+	tests := []struct {
+		name   string
+		status int
+		want   string
+	}{
+		{
+			name:   "success",
+			status: http.StatusOK,
+			want:   "200",
+		},
+		{
+			name:   "error status",
+			status: http.StatusNotFound,
+			want:   "404",
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func() {
+			before := testutil.ToFloat64(metrics.HTTPRequestsTotal.WithLabelValues(http.MethodGet, route, test.want))
+			ctx, _ := newRateLimitContext(http.MethodGet, "/api/v1/profile/10")
+
+			middleware(func(ctx *beegoContext.Context) {
+				t.Equal(float64(1), testutil.ToFloat64(metrics.HTTPRequestsInFlight.WithLabelValues(http.MethodGet, route)))
+				ctx.ResponseWriter.WriteHeader(test.status)
+			})(ctx)
+
+			t.Equal(before+1, testutil.ToFloat64(metrics.HTTPRequestsTotal.WithLabelValues(http.MethodGet, route, test.want)))
+			t.Equal(float64(0), testutil.ToFloat64(metrics.HTTPRequestsInFlight.WithLabelValues(http.MethodGet, route)))
+		})
+	}
+}
+
+func (t *MetricsMiddlewareTestSuite) TestRouterPattern() {
+	ctx, _ := newRateLimitContext(http.MethodGet, "/api/v1/unknown")
+	t.Equal(unmatchedRoute, routerPattern(ctx))
+
+	ctx.Input.SetData("RouterPattern", "/api/v1/profile/:id")
+	t.Equal("/api/v1/profile/:id", routerPattern(ctx))
+}
+
+func TestMetricsMiddlewareTestSuite(t *testing.T) {
+	suite.Run(t, new(MetricsMiddlewareTestSuite))
+}
@@ -0,0 +1,101 @@
+package middlewares
+
+import (
+	"net/http"
+	"strings"
+
+	beego "github.com/beego/beego/v2/server/web"
+	"github.com/beego/beego/v2/server/web/context"
+	"github.com/radyatamaa/dating-apps-api/pkg/authuser"
+	"github.com/radyatamaa/dating-apps-api/pkg/helper"
+	"github.com/radyatamaa/dating-apps-api/pkg/response"
+)
+
+type (
+	// PermissionRule is the permission required by requests matching Method and Path.
+	PermissionRule struct {
+		// Method of the request, "*" matches any method.
+		Method string
+		// Path of the request, a ":param" segment matches any segment and
+		// a trailing "*" matches any path with that prefix.
+		Path       string
+		Permission string
+	}
+
+	// PermissionConfig defines the config for Permission middleware.
+	PermissionConfig struct {
+		// Skipper defines a function to skip middleware.
+		Skipper Skipper
+
+		// Rules are the permissions of the routes, the first matching rule is used,
+		// a request matching no rule is forbidden.
+		Rules []PermissionRule
+		response.ApiResponse
+	}
+)
+
+// PermissionWithConfig returns a middleware checking the permissions of the JWT payload,
+// it must be chained after JwtMiddleware.
+func PermissionWithConfig(config PermissionConfig) beego.FilterChain {
+	if config.Skipper == nil {
+		config.Skipper = DefaultSkipper
+	}
+
+	return func(next beego.FilterFunc) beego.FilterFunc {
+		return func(ctx *context.Context) {
+			if config.Skipper(ctx) || ctx.Request.Method == http.MethodOptions {
+				next(ctx)
+				return
+			}
+
+			if rule, ok := config.match(ctx); ok && hasPermission(ctx, rule.Permission) {
+				next(ctx)
+				return
+			}
+
+			config.ResponseError(ctx, http.StatusForbidden, response.RequestForbiddenCodeError, response.ErrorCodeText(response.RequestForbiddenCodeError, helper.GetLangVersion(ctx)), response.ErrRequestForbidden)
+		}
+	}
+}
+
+func (r *PermissionConfig) match(ctx *context.Context) (PermissionRule, bool) {
+	for _, rule := range r.Rules {
+		if rule.matches(ctx.Request.Method, ctx.Request.URL.Path) {
+			return rule, true
+		}
+	}
+	return PermissionRule{}, false
+}
+
+func (r PermissionRule) matches(method, path string) bool {
+	if r.Method != "*" && !strings.EqualFold(r.Method, method) {
+		return false
+	}
+
+	patterns := strings.Split(strings.Trim(r.Path, "/"), "/")
+	segments := strings.Split(strings.Trim(path, "/"), "/")
+	for i, pattern := range patterns {
+		if pattern == "*" && i == len(patterns)-1 {
+			return true
+		}
+		if i >= len(segments) {
+			return false
+		}
+		if strings.HasPrefix(pattern, ":") {
+			continue
+		}
+		if !strings.EqualFold(pattern, segments[i]) {
+			return false
+		}
+	}
+	return len(patterns) == len(segments)
+}
+
+// hasPermission reports whether the authenticated user of the request has the permission.
+func hasPermission(ctx *context.Context, permission string) bool {
+	user, err := authuser.FromContext(ctx.Request.Context())
+	if err != nil {
+		return false
+	}
+	return user.HasPermission(permission)
+}
@@ -0,0 +1,83 @@
+package middlewares
+
+import (
+	"net/http"
+	"testing"
+
+	beegoContext "github.com/beego/beego/v2/server/web/context"
+	"github.com/radyatamaa/dating-apps-api/pkg/authuser"
+	"github.com/stretchr/testify/suite"
+)
+
+type PermissionMiddlewareTestSuite struct {
+	suite.Suite
+}
+
+func newPermissionContext(method, path string, permissions []string) (*beegoContext.Context, func() int) {
+	ctx, w := newRateLimitContext(method, path)
+	if permissions != nil {
+		ctx.Request = ctx.Request.WithContext(authuser.NewContext(ctx.Request.Context(), authuser.AuthUser{
+			ID:          1,
+			Permissions: permissions,
+		}))
+	}
+	return ctx, func() int { return w.Code }
+}
+
+func (t *PermissionMiddlewareTestSuite) TestPermissionRule_matches() {
+	tests := []struct {
+		name   string
+		rule   PermissionRule
+		method string
+		path   string
+		want   bool
+	}{
+		{name: "exact", rule: PermissionRule{Method: "GET", Path: "/api/admin/v1/users"}, method: "GET", path: "/api/admin/v1/users", want: true},
+		{name: "param", rule: PermissionRule{Method: "POST", Path: "/api/admin/v1/users/:id/premium"}, method: "POST", path: "/api/admin/v1/users/10/premium", want: true},
+		{name: "prefix", rule: PermissionRule{Method: "*", Path: "/api/admin/v1/reports*"}, method: "PUT", path: "/api/admin/v1/reports/1/status", want: false},
+		{name: "prefix segment", rule: PermissionRule{Method: "*", Path: "/api/admin/v1/reports/*"}, method: "PUT", path: "/api/admin/v1/reports/1/status", want: true},
+		{name: "other method", rule: PermissionRule{Method: "GET", Path: "/api/admin/v1/users/:id"}, method: "DELETE", path: "/api/admin/v1/users/10", want: false},
+		{name: "longer path", rule: PermissionRule{Method: "GET", Path: "/api/admin/v1/users/:id"}, method: "GET", path: "/api/admin/v1/users/10/actions", want: false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func() {
+			t.Equal(tt.want, tt.rule.matches(tt.method, tt.path))
+		})
+	}
+}
+
+func (t *PermissionMiddlewareTestSuite) TestPermissionWithConfig() {
+	chain := PermissionWithConfig(PermissionConfig{
+		Rules: []PermissionRule{
+			{Method: http.MethodGet, Path: "/api/admin/v1/users", Permission: "users:read"},
+		},
+	})
+	called := 0
+	handler := chain(func(ctx *beegoContext.Context) {
+		called++
+	})
+
+	ctx, _ := newPermissionContext(http.MethodGet, "/api/admin/v1/users", []string{"users:read"})
+	handler(ctx)
+	t.Equal(1, called)
+
+	ctx, code := newPermissionContext(http.MethodGet, "/api/admin/v1/users", []string{"reports:manage"})
+	handler(ctx)
+	t.Equal(1, called)
+	t.Equal(http.StatusForbidden, code())
+
+	// routes without a rule are forbidden
+	ctx, code = newPermissionContext(http.MethodDelete, "/api/admin/v1/users", []string{"users:read"})
+	handler(ctx)
+	t.Equal(1, called)
+	t.Equal(http.StatusForbidden, code())
+
+	ctx, code = newPermissionContext(http.MethodGet, "/api/admin/v1/users", nil)
+	handler(ctx)
+	t.Equal(1, called)
+	t.Equal(http.StatusForbidden, code())
+}
+
+func TestPermissionMiddlewareTestSuite(t *testing.T) {
+	suite.Run(t, new(PermissionMiddlewareTestSuite))
+}
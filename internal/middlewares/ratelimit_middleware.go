@@ -0,0 +1,225 @@
+package middlewares
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	beego "github.com/beego/beego/v2/server/web"
+	beegoContext "github.com/beego/beego/v2/server/web/context"
+	"github.com/radyatamaa/dating-apps-api/pkg/authuser"
+	"github.com/radyatamaa/dating-apps-api/pkg/helper"
+	"github.com/radyatamaa/dating-apps-api/pkg/response"
+)
+
+const (
+	// RateLimitKeyByIP counts requests per client ip.
+	RateLimitKeyByIP = "ip"
+	// RateLimitKeyByUID counts requests per JWT uid, falling back to the client ip
+	// for unauthenticated routes.
+	RateLimitKeyByUID = "uid"
+	// RateLimitKeyByIPAndUID counts requests per client ip and JWT uid pair.
+	RateLimitKeyByIPAndUID = "ip_uid"
+)
+
+var ErrInvalidRateLimitRule = errors.New("rate limit rule must be formatted as <limit>/<window>, ex: 10/1m")
+
+type (
+	// RateLimitRule is the limit applied to requests matching Method and Path.
+	RateLimitRule struct {
+		// Method of the request, "*" matches any method.
+		Method string
+		// Path of the request, a trailing "*" matches any path with that prefix.
+		Path   string
+		Limit  int
+		Window time.Duration
+	}
+
+	// RateLimitConfig defines the config for RateLimit middleware.
+	RateLimitConfig struct {
+		// Skipper defines a function to skip middleware.
+		Skipper Skipper
+
+		// Store keeps the counters.
+		// Optional. Default value NewMemoryRateLimitStore().
+		Store RateLimitStore
+
+		// KeyBy defines how a client is identified, one of ip, uid or ip_uid.
+		// Optional. Default value ip.
+		KeyBy string
+
+		// Rules are the per route limits, the first matching rule is used.
+		Rules []RateLimitRule
+
+		// Default is the limit used when no rule matches.
+		// Optional. A zero Limit disables the default limit.
+		Default RateLimitRule
+
+		response.ApiResponse
+	}
+)
+
+var (
+	// DefaultRateLimitConfig is the default RateLimit middleware config.
+	DefaultRateLimitConfig = RateLimitConfig{
+		Skipper: DefaultSkipper,
+		KeyBy:   RateLimitKeyByIP,
+	}
+)
+
+// RateLimit returns a RateLimit middleware which applies the same limit to every request.
+func RateLimit(store RateLimitStore, limit RateLimitRule) beego.FilterChain {
+	c := DefaultRateLimitConfig
+	c.Store = store
+	c.Default = limit
+	return RateLimitWithConfig(c)
+}
+
+// RateLimitWithConfig returns a RateLimit middleware with config.
+//
+// The middleware sets the RateLimit-Limit, RateLimit-Remaining and RateLimit-Reset
+// headers and aborts with 429 Too Many Requests once the limit is reached.
+func RateLimitWithConfig(config RateLimitConfig) beego.FilterChain {
+	// Defaults
+	if config.Skipper == nil {
+		config.Skipper = DefaultRateLimitConfig.Skipper
+	}
+	if config.Store == nil {
+		config.Store = NewMemoryRateLimitStore()
+	}
+	if config.KeyBy == "" {
+		config.KeyBy = DefaultRateLimitConfig.KeyBy
+	}
+
+	return func(next beego.FilterFunc) beego.FilterFunc {
+		return func(ctx *beegoContext.Context) {
+			if config.Skipper(ctx) || ctx.Request.Method == http.MethodOptions {
+				next(ctx)
+				return
+			}
+
+			rule, ok := config.match(ctx.Request.Method, ctx.Request.URL.Path)
+			if !ok {
+				next(ctx)
+				return
+			}
+
+			uid := ""
+			if user, err := authuser.FromContext(ctx.Request.Context()); err == nil {
+				uid = strconv.Itoa(user.ID)
+			}
+			key := config.key(rule, ctx.Input.IP(), uid)
+			result, err := config.Store.Allow(ctx.Request.Context(), key, rule.Limit, rule.Window)
+			if err != nil {
+				// fail open, an unavailable store must not take the api down
+				next(ctx)
+				return
+			}
+
+			header := ctx.ResponseWriter.Header()
+			reset := strconv.Itoa(int(math.Ceil(result.Reset.Seconds())))
+			header.Set("RateLimit-Limit", strconv.Itoa(result.Limit))
+			header.Set("RateLimit-Remaining", strconv.Itoa(result.Remaining))
+			header.Set("RateLimit-Reset", reset)
+
+			if !result.Allowed {
+				header.Set("Retry-After", reset)
+				config.ResponseError(ctx, http.StatusTooManyRequests, response.TooManyRequestsCodeError, response.ErrorCodeText(response.TooManyRequestsCodeError, helper.GetLangVersion(ctx)), nil)
+				return
+			}
+			next(ctx)
+		}
+	}
+}
+
+// match returns the first rule matching the method and path of the request or the default limit.
+func (r *RateLimitConfig) match(method, path string) (RateLimitRule, bool) {
+	for _, rule := range r.Rules {
+		if rule.matches(method, path) {
+			return rule, true
+		}
+	}
+	if r.Default.Limit > 0 {
+		return RateLimitRule{Method: "*", Path: "*", Limit: r.Default.Limit, Window: r.Default.Window}, true
+	}
+	return RateLimitRule{}, false
+}
+
+// key returns the counter key of the rule for the client ip and uid, uid is empty when unauthenticated.
+func (r *RateLimitConfig) key(rule RateLimitRule, ip, uid string) string {
+	return fmt.Sprintf("%s:%s:%s", rule.Method, rule.Path, r.identity(ip, uid))
+}
+
+// identity returns the client identifier according to KeyBy.
+func (r *RateLimitConfig) identity(ip, uid string) string {
+	switch r.KeyBy {
+	case RateLimitKeyByUID:
+		if uid != "" {
+			return "uid:" + uid
+		}
+		return "ip:" + ip
+	case RateLimitKeyByIPAndUID:
+		return "ip:" + ip + ":uid:" + uid
+	default:
+		return "ip:" + ip
+	}
+}
+
+func (r RateLimitRule) matches(method, path string) bool {
+	if r.Method != "*" && !strings.EqualFold(r.Method, method) {
+		return false
+	}
+	if strings.HasSuffix(r.Path, "*") {
+		return strings.HasPrefix(strings.ToLower(path), strings.ToLower(strings.TrimSuffix(r.Path, "*")))
+	}
+	return strings.EqualFold(r.Path, path)
+}
+
+// ParseRateLimit parses a limit formatted as <limit>/<window>, ex: 10/1m or 1000/1h.
+func ParseRateLimit(value string) (RateLimitRule, error) {
+	parts := strings.SplitN(strings.TrimSpace(value), "/", 2)
+	if len(parts) != 2 {
+		return RateLimitRule{}, ErrInvalidRateLimitRule
+	}
+	limit, err := strconv.Atoi(strings.TrimSpace(parts[0]))
+	if err != nil || limit <= 0 {
+		return RateLimitRule{}, ErrInvalidRateLimitRule
+	}
+	window, err := time.ParseDuration(strings.TrimSpace(parts[1]))
+	if err != nil || window <= 0 {
+		return RateLimitRule{}, ErrInvalidRateLimitRule
+	}
+	return RateLimitRule{Method: "*", Path: "*", Limit: limit, Window: window}, nil
+}
+
+// ParseRateLimitRoutes parses per route limits separated by ";", each formatted as
+// <METHOD>:<path>=<limit>/<window>, ex: POST:/api/v1/user/register=5/1m;*:/api/v1/swipe/*=60/1m
+func ParseRateLimitRoutes(value string) ([]RateLimitRule, error) {
+	rules := make([]RateLimitRule, 0)
+	for _, item := range strings.Split(value, ";") {
+		item = strings.TrimSpace(item)
+		if item == "" {
+			continue
+		}
+		route := strings.SplitN(item, "=", 2)
+		if len(route) != 2 {
+			return nil, fmt.Errorf("invalid rate limit route %q: %w", item, ErrInvalidRateLimitRule)
+		}
+		rule, err := ParseRateLimit(route[1])
+		if err != nil {
+			return nil, fmt.Errorf("invalid rate limit route %q: %w", item, err)
+		}
+		methodPath := strings.SplitN(strings.TrimSpace(route[0]), ":", 2)
+		if len(methodPath) != 2 {
+			return nil, fmt.Errorf("invalid rate limit route %q: %w", item, ErrInvalidRateLimitRule)
+		}
+		rule.Method = strings.ToUpper(strings.TrimSpace(methodPath[0]))
+		rule.Path = strings.TrimSpace(methodPath[1])
+		rules = append(rules, rule)
+	}
+	return rules, nil
+}
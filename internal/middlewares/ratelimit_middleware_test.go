@@ -0,0 +1,141 @@
+package middlewares
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	beegoContext "github.com/beego/beego/v2/server/web/context"
+	"github.com/radyatamaa/dating-apps-api/pkg/atomiccache"
+	"github.com/stretchr/testify/suite"
+)
+
+type RateLimitMiddlewareTestSuite struct {
+	suite.Suite
+}
+
+func (t *RateLimitMiddlewareTestSuite) SetupSuite() {
+}
+
+func newRateLimitContext(method, path string) (*beegoContext.Context, *httptest.ResponseRecorder) {
+	w := httptest.NewRecorder()
+	r := httptest.NewRequest(method, path, nil)
+	ctx := beegoContext.NewContext()
+	ctx.Reset(w, r)
+	return ctx, w
+}
+
+func (t *RateLimitMiddlewareTestSuite) TestMemoryRateLimitStore_Allow() {
+	store := NewMemoryRateLimitStore()
+
+	for i := 0; i < 3; i++ {
+		result, err := store.Allow(context.TODO(), "key", 3, time.Minute)
+		t.NoError(err)
+		t.True(result.Allowed)
+		t.Equal(2-i, result.Remaining)
+	}
+
+	result, err := store.Allow(context.TODO(), "key", 3, time.Minute)
+	t.NoError(err)
+	t.False(result.Allowed)
+	t.Equal(0, result.Remaining)
+
+	result, err = store.Allow(context.TODO(), "other", 3, time.Minute)
+	t.NoError(err)
+	t.True(result.Allowed)
+}
+
+func (t *RateLimitMiddlewareTestSuite) TestCacheRateLimitStore_Allow() {
+	store := NewCacheRateLimitStore(atomiccache.NewMemoryCache())
+
+	for i := 0; i < 3; i++ {
+		result, err := store.Allow(context.TODO(), "key", 3, time.Minute)
+		t.NoError(err)
+		t.True(result.Allowed)
+		t.Equal(2-i, result.Remaining)
+	}
+
+	result, err := store.Allow(context.TODO(), "key", 3, time.Minute)
+	t.NoError(err)
+	t.False(result.Allowed)
+	t.Equal(0, result.Remaining)
+}
+
+func (t *RateLimitMiddlewareTestSuite) TestParseRateLimitRoutes() {
+	tests := []struct {
+		name    string
+		value   string
+		want    []RateLimitRule
+		wantErr bool
+	}{
+		{
+			name:  "success",
+			value: "POST:/api/v1/user/register=5/1m; *:/api/v1/swipe/*=60/1h",
+			want: []RateLimitRule{
+				{Method: "POST", Path: "/api/v1/user/register", Limit: 5, Window: time.Minute},
+				{Method: "*", Path: "/api/v1/swipe/*", Limit: 60, Window: time.Hour},
+			},
+		},
+		{
+			name:  "empty",
+			value: "",
+			want:  []RateLimitRule{},
+		},
+		{
+			name:    "missing window",
+			value:   "POST:/api/v1/user/register=5",
+			wantErr: true,
+		},
+		{
+			name:    "missing method",
+			value:   "/api/v1/user/register=5/1m",
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func() {
+			got, err := ParseRateLimitRoutes(tt.value)
+			if tt.wantErr {
+				t.Error(err)
+				return
+			}
+			t.NoError(err)
+			t.Equal(tt.want, got)
+		})
+	}
+}
+
+func (t *RateLimitMiddlewareTestSuite) TestRateLimitWithConfig() {
+	chain := RateLimitWithConfig(RateLimitConfig{
+		Rules: []RateLimitRule{
+			{Method: http.MethodPost, Path: "/api/v1/user/register", Limit: 1, Window: time.Minute},
+		},
+	})
+	called := 0
+	handler := chain(func(ctx *beegoContext.Context) {
+		called++
+	})
+
+	ctx, w := newRateLimitContext(http.MethodPost, "/api/v1/user/register")
+	handler(ctx)
+	t.Equal(1, called)
+	t.Equal("1", w.Header().Get("RateLimit-Limit"))
+	t.Equal("0", w.Header().Get("RateLimit-Remaining"))
+
+	ctx, w = newRateLimitContext(http.MethodPost, "/api/v1/user/register")
+	handler(ctx)
+	t.Equal(1, called)
+	t.Equal(http.StatusTooManyRequests, w.Code)
+	t.NotEmpty(w.Header().Get("Retry-After"))
+
+	// routes without a rule and without a default limit are not limited
+	ctx, _ = newRateLimitContext(http.MethodPost, "/api/v1/user/login")
+	handler(ctx)
+	t.Equal(2, called)
+}
+
+func TestRateLimitMiddlewareTestSuite(t *testing.T) {
+	suite.Run(t, new(RateLimitMiddlewareTestSuite))
+}
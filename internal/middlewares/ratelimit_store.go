@@ -0,0 +1,152 @@
+package middlewares
+
+import (
+	"context"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/radyatamaa/dating-apps-api/pkg/atomiccache"
+)
+
+type (
+	// RateLimitStore keeps the request counters used by the RateLimit middleware.
+	RateLimitStore interface {
+		// Allow records a hit for key and reports whether it is still within limit
+		// for the given window.
+		Allow(ctx context.Context, key string, limit int, window time.Duration) (RateLimitResult, error)
+	}
+
+	// RateLimitResult is the state of a counter after a hit has been recorded.
+	RateLimitResult struct {
+		Allowed   bool
+		Limit     int
+		Remaining int
+		// Reset is the time left until the current window rolls over.
+		Reset time.Duration
+	}
+
+	memoryRateLimitStore struct {
+		mu       sync.Mutex
+		counters map[string]*slidingWindow
+		lastGC   time.Time
+	}
+
+	slidingWindow struct {
+		start    time.Time
+		window   time.Duration
+		previous int
+		current  int
+	}
+
+	cacheRateLimitStore struct {
+		cache  atomiccache.Cache
+		prefix string
+	}
+)
+
+// NewMemoryRateLimitStore returns a RateLimitStore which keeps sliding window
+// counters in process memory. Counters are not shared between replicas.
+func NewMemoryRateLimitStore() RateLimitStore {
+	return &memoryRateLimitStore{
+		counters: make(map[string]*slidingWindow),
+		lastGC:   time.Now(),
+	}
+}
+
+// Allow implements RateLimitStore using a sliding window counter, the previous
+// window is weighted by how much of it still overlaps the sliding window.
+func (s *memoryRateLimitStore) Allow(ctx context.Context, key string, limit int, window time.Duration) (RateLimitResult, error) {
+	now := time.Now()
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.gc(now)
+
+	counter, ok := s.counters[key]
+	if !ok || counter.window != window {
+		counter = &slidingWindow{start: now.Truncate(window), window: window}
+		s.counters[key] = counter
+	}
+	counter.roll(now)
+
+	elapsed := now.Sub(counter.start)
+	weight := float64(window-elapsed) / float64(window)
+	estimated := int(float64(counter.previous)*weight) + counter.current
+
+	result := RateLimitResult{
+		Limit: limit,
+		Reset: window - elapsed,
+	}
+	if estimated >= limit {
+		return result, nil
+	}
+
+	counter.current++
+	result.Allowed = true
+	result.Remaining = limit - estimated - 1
+	return result, nil
+}
+
+// gc drops counters which have not been touched for two windows.
+func (s *memoryRateLimitStore) gc(now time.Time) {
+	if now.Sub(s.lastGC) < time.Minute {
+		return
+	}
+	s.lastGC = now
+	for key, counter := range s.counters {
+		if now.Sub(counter.start) >= 2*counter.window {
+			delete(s.counters, key)
+		}
+	}
+}
+
+func (w *slidingWindow) roll(now time.Time) {
+	start := now.Truncate(w.window)
+	switch {
+	case start.Equal(w.start):
+		return
+	case start.Sub(w.start) == w.window:
+		w.previous = w.current
+	default:
+		w.previous = 0
+	}
+	w.current = 0
+	w.start = start
+}
+
+// NewCacheRateLimitStore returns a RateLimitStore backed by an atomic cache,
+// usually the redis cache so that counters are shared between replicas.
+// The counters use a fixed window keyed by the window start.
+func NewCacheRateLimitStore(c atomiccache.Cache) RateLimitStore {
+	return &cacheRateLimitStore{
+		cache:  c,
+		prefix: "ratelimit:",
+	}
+}
+
+// Allow implements RateLimitStore, the hit is counted with one atomic increment
+// so the concurrent hits of the replicas are all counted.
+func (s *cacheRateLimitStore) Allow(ctx context.Context, key string, limit int, window time.Duration) (RateLimitResult, error) {
+	now := time.Now()
+	start := now.Truncate(window)
+	cacheKey := s.prefix + key + ":" + strconv.FormatInt(start.Unix(), 10)
+
+	result := RateLimitResult{
+		Limit: limit,
+		Reset: start.Add(window).Sub(now),
+	}
+
+	count, err := s.cache.Incr(ctx, cacheKey, window)
+	if err != nil {
+		return result, err
+	}
+
+	if count > limit {
+		return result, nil
+	}
+	result.Allowed = true
+	result.Remaining = limit - count
+	return result, nil
+}
@@ -4,6 +4,9 @@ import (
 	beego "github.com/beego/beego/v2/server/web"
 	beegoContext "github.com/beego/beego/v2/server/web/context"
 	"github.com/google/uuid"
+	"github.com/radyatamaa/dating-apps-api/pkg/helper"
+	"github.com/radyatamaa/dating-apps-api/pkg/requestinfo"
+	"github.com/radyatamaa/dating-apps-api/pkg/zaplogger"
 )
 
 type (
@@ -29,7 +32,8 @@ var (
 	}
 )
 
-// RequestID returns a X-Request-ID middleware.
+// RequestID returns a X-Request-ID middleware, the request context carries the requestinfo
+// of the request and collects its stack trace.
 func RequestID() beego.FilterChain {
 	return RequestIDWithConfig(DefaultRequestIDConfig)
 }
@@ -58,6 +62,12 @@ func RequestIDWithConfig(config RequestIDConfig) beego.FilterChain  {
 				rid = config.Generator()
 			}
 			res.Header().Set("X-REQUEST-ID", rid)
+			ctx.Request = req.WithContext(zaplogger.NewStackTraceContext(requestinfo.NewContext(req.Context(), requestinfo.Info{
+				ID:     rid,
+				IP:     ctx.Input.IP(),
+				Host:   req.Host,
+				Scheme: helper.GetHttpOrHttps(ctx),
+			})))
 			if config.RequestIDHandler != nil {
 				config.RequestIDHandler(ctx, rid)
 			}
@@ -0,0 +1,106 @@
+package middlewares
+
+import (
+	"net/http"
+
+	beego "github.com/beego/beego/v2/server/web"
+	beegoContext "github.com/beego/beego/v2/server/web/context"
+	"github.com/radyatamaa/dating-apps-api/pkg/tracing"
+	"github.com/radyatamaa/dating-apps-api/pkg/zaplogger"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/propagation"
+	semconv "go.opentelemetry.io/otel/semconv/v1.10.0"
+	"go.opentelemetry.io/otel/trace"
+)
+
+type (
+	// TracingConfig defines the config for Tracing middleware.
+	TracingConfig struct {
+		// Skipper defines a function to skip middleware.
+		Skipper Skipper
+
+		// ServerName is the name of the server set on the spans.
+		ServerName string
+
+		// RouteResolver defines a function which returns the route template of the request,
+		// the span name is the method and the route template, ex: GET /api/v1/profile/:id.
+		// Optional. Default value resolves the route from the beego router, unmatched when not found.
+		RouteResolver func(*beegoContext.Context) string
+	}
+)
+
+const (
+	// AttributeRequestID is the span attribute linking the trace to the X-REQUEST-ID header.
+	AttributeRequestID = attribute.Key("http.request_id")
+	// AttributeErrorCode is the span attribute of the api error code, ex: ERROR-API-001.
+	AttributeErrorCode = attribute.Key("app.error_code")
+)
+
+var (
+	// DefaultTracingConfig is the default Tracing middleware config.
+	DefaultTracingConfig = TracingConfig{
+		Skipper:       DefaultSkipper,
+		RouteResolver: routerPattern,
+	}
+)
+
+// Tracing returns a middleware which starts the server span of the request.
+func Tracing() beego.FilterChain {
+	return TracingWithConfig(DefaultTracingConfig)
+}
+
+// TracingWithConfig returns a Tracing middleware with config.
+// The W3C trace context of the incoming request is continued and propagated back in the
+// response headers, the request context carries the span so the usecases and the GORM
+// queries start child spans, it must run after RequestID.
+func TracingWithConfig(config TracingConfig) beego.FilterChain {
+	// Defaults
+	if config.Skipper == nil {
+		config.Skipper = DefaultTracingConfig.Skipper
+	}
+	if config.RouteResolver == nil {
+		config.RouteResolver = DefaultTracingConfig.RouteResolver
+	}
+
+	return func(next beego.FilterFunc) beego.FilterFunc {
+		return func(ctx *beegoContext.Context) {
+			if config.Skipper(ctx) {
+				next(ctx)
+				return
+			}
+
+			req := ctx.Request
+			res := ctx.ResponseWriter.ResponseWriter
+			propagator := otel.GetTextMapPropagator()
+			route := config.RouteResolver(ctx)
+
+			parent := propagator.Extract(req.Context(), propagation.HeaderCarrier(req.Header))
+			spanCtx, span := tracing.Tracer().Start(parent, req.Method+" "+route,
+				trace.WithSpanKind(trace.SpanKindServer),
+				trace.WithAttributes(semconv.HTTPServerAttributesFromHTTPRequest(config.ServerName, route, req)...),
+				trace.WithAttributes(AttributeRequestID.String(res.Header().Get("X-REQUEST-ID"))),
+			)
+			defer span.End()
+
+			propagator.Inject(spanCtx, propagation.HeaderCarrier(res.Header()))
+			ctx.Request = req.WithContext(spanCtx)
+
+			next(ctx)
+
+			status := ctx.ResponseWriter.Status
+			if status == 0 {
+				status = http.StatusOK
+			}
+			span.SetAttributes(semconv.HTTPAttributesFromHTTPStatusCode(status)...)
+			span.SetStatus(semconv.SpanStatusFromHTTPStatusCodeAndSpanKind(status, trace.SpanKindServer))
+			if errorCode, ok := ctx.Input.GetData(zaplogger.ErrorCodeKey).(string); ok {
+				span.SetAttributes(AttributeErrorCode.String(errorCode))
+				if status > 499 {
+					span.SetStatus(codes.Error, errorCode)
+				}
+			}
+		}
+	}
+}
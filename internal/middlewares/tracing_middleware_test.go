@@ -0,0 +1,92 @@
+package middlewares
+
+import (
+	"net/http"
+	"testing"
+
+	beegoContext "github.com/beego/beego/v2/server/web/context"
+	"github.com/radyatamaa/dating-apps-api/pkg/tracing"
+	"github.com/stretchr/testify/suite"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/propagation"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+)
+
+type TracingMiddlewareTestSuite struct {
+	suite.Suite
+	recorder *tracetest.SpanRecorder
+}
+
+func (t *TracingMiddlewareTestSuite) SetupSuite() {
+	t.recorder = tracetest.NewSpanRecorder()
+	otel.SetTracerProvider(sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(t.recorder)))
+	otel.SetTextMapPropagator(propagation.TraceContext{})
+}
+
+func (t *TracingMiddlewareTestSuite) TestTracingWithConfig() {
+	traceId := "4bf92f3577b34da6a3ce929d0e0e4736"
+	middleware := TracingWithConfig(TracingConfig{
+		RouteResolver: func(*beegoContext.Context) string {
+			return "/api/v1/profile/:id"
+		},
+	})
+
+	tests := []struct {
+		name        string
+		traceparent string
+		status      int
+		wantStatus  codes.Code
+	}{
+		{
+			name:        "continue incoming trace",
+			traceparent: "00-" + traceId + "-00f067aa0ba902b7-01",
+			status:      http.StatusOK,
+			wantStatus:  codes.Unset,
+		},
+		{
+			name:       "new trace with server error",
+			status:     http.StatusInternalServerError,
+			wantStatus: codes.Error,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func() {
+			ctx, w := newRateLimitContext(http.MethodGet, "/api/v1/profile/10")
+			if test.traceparent != "" {
+				ctx.Request.Header.Set("traceparent", test.traceparent)
+			}
+			w.Header().Set("X-REQUEST-ID", "request-id")
+
+			var handlerTraceId string
+			middleware(func(ctx *beegoContext.Context) {
+				handlerTraceId = tracing.TraceID(ctx.Request.Context())
+				ctx.ResponseWriter.WriteHeader(test.status)
+			})(ctx)
+
+			spans := t.recorder.Ended()
+			span := spans[len(spans)-1]
+			t.Equal("GET /api/v1/profile/:id", span.Name())
+			t.Equal(test.wantStatus, span.Status().Code)
+			t.Equal(span.SpanContext().TraceID().String(), handlerTraceId)
+			t.Contains(w.Header().Get("traceparent"), handlerTraceId)
+			if test.traceparent != "" {
+				t.Equal(traceId, handlerTraceId)
+			}
+
+			found := false
+			for _, attr := range span.Attributes() {
+				if attr.Key == AttributeRequestID {
+					t.Equal("request-id", attr.Value.AsString())
+					found = true
+				}
+			}
+			t.True(found)
+		})
+	}
+}
+
+func TestTracingMiddlewareTestSuite(t *testing.T) {
+	suite.Run(t, new(TracingMiddlewareTestSuite))
+}
@@ -0,0 +1,119 @@
+package v1
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/radyatamaa/dating-apps-api/internal/moderation"
+	"github.com/radyatamaa/dating-apps-api/pkg/database/paginator"
+	"github.com/radyatamaa/dating-apps-api/pkg/validator"
+
+	beego "github.com/beego/beego/v2/server/web"
+	"github.com/radyatamaa/dating-apps-api/internal"
+	"github.com/radyatamaa/dating-apps-api/internal/domain"
+	"github.com/radyatamaa/dating-apps-api/pkg/response"
+	"github.com/radyatamaa/dating-apps-api/pkg/zaplogger"
+)
+
+type ModerationHandler struct {
+	ZapLogger zaplogger.Logger
+	internal.BaseController
+	response.ApiResponse
+	Usecase moderation.UseCase
+}
+
+func NewModerationHandler(useCase moderation.UseCase, zapLogger zaplogger.Logger) {
+	pHandler := &ModerationHandler{
+		ZapLogger: zapLogger,
+		Usecase:   useCase,
+	}
+	beego.Router("/api/admin/v1/users/:id/actions", pHandler, "get:GetActions;post:TakeAction")
+}
+
+func (h *ModerationHandler) Prepare() {
+	// check user access when needed
+	h.SetLangVersion()
+}
+
+// TakeAction
+// @Title TakeAction
+// @Tags Admin
+// @Summary TakeAction warns, suspends, bans or restores the user
+// @Produce json
+// @Security ApiKeyAuth
+// @Param Accept-Language header string false "lang"
+// @Param id path int true "user id"
+// @Success 200 {object} swagger.BaseResponse{errors=[]object,data=object}
+// @Failure 400 {object} swagger.BadRequestErrorValidationResponse{errors=[]swagger.ValidationErrors,data=object}
+// @Failure 403 {object} swagger.BaseResponse{errors=[]object,data=object}
+// @Failure 408 {object} swagger.RequestTimeoutResponse{errors=[]object,data=object}
+// @Failure 500 {object} swagger.InternalServerErrorResponse{errors=[]object,data=object}
+// @Param body body domain.ModerationActionRequest true "request payload"
+// @Router /admin/v1/users/{id}/actions [post]
+func (h *ModerationHandler) TakeAction() {
+	userId, err := strconv.Atoi(h.Ctx.Input.Param(":id"))
+	if err != nil {
+		h.Ctx.Input.SetData("stackTrace", h.ZapLogger.SetMessageLog(err))
+		h.ResponseError(h.Ctx, http.StatusBadRequest, response.PathParamInvalidCode, response.ErrorCodeText(response.PathParamInvalidCode, h.Locale.Lang), err)
+		return
+	}
+
+	var request domain.ModerationActionRequest
+	if err := h.BindJSON(&request); err != nil {
+		h.Ctx.Input.SetData("stackTrace", h.ZapLogger.SetMessageLog(err))
+		h.ResponseError(h.Ctx, http.StatusBadRequest, response.ApiValidationCodeError, response.ErrorCodeText(response.ApiValidationCodeError, h.Locale.Lang), err)
+		return
+	}
+	if err := validator.Validate.ValidateStruct(&request); err != nil {
+		h.Ctx.Input.SetData("stackTrace", h.ZapLogger.SetMessageLog(err))
+		h.ResponseError(h.Ctx, http.StatusBadRequest, response.ApiValidationCodeError, response.ErrorCodeText(response.ApiValidationCodeError, h.Locale.Lang), err)
+		return
+	}
+
+	err = h.Usecase.TakeAction(h.Ctx.Request.Context(), userId, request)
+	if err != nil {
+		h.Fail(err)
+		return
+	}
+	h.Ok(h.Ctx, h.Tr("message.success"), nil)
+	return
+}
+
+// GetActions
+// @Title GetActions
+// @Tags Admin
+// @Summary GetActions returns the moderation history of the user
+// @Produce json
+// @Security ApiKeyAuth
+// @Param Accept-Language header string false "lang"
+// @Param id path int true "user id"
+// @Success 200 {object} swagger.BaseResponse{errors=[]object,data=domain.ModerationActionResponsePaginationResponse}
+// @Failure 400 {object} swagger.BadRequestErrorValidationResponse{errors=[]swagger.ValidationErrors,data=object}
+// @Failure 403 {object} swagger.BaseResponse{errors=[]object,data=object}
+// @Failure 408 {object} swagger.RequestTimeoutResponse{errors=[]object,data=object}
+// @Failure 500 {object} swagger.InternalServerErrorResponse{errors=[]object,data=object}
+// @Param pageSize query int false "page size"
+// @Param page query int false "page"
+// @Router /admin/v1/users/{id}/actions [get]
+func (h *ModerationHandler) GetActions() {
+	userId, err := strconv.Atoi(h.Ctx.Input.Param(":id"))
+	if err != nil {
+		h.Ctx.Input.SetData("stackTrace", h.ZapLogger.SetMessageLog(err))
+		h.ResponseError(h.Ctx, http.StatusBadRequest, response.PathParamInvalidCode, response.ErrorCodeText(response.PathParamInvalidCode, h.Locale.Lang), err)
+		return
+	}
+	pageSize, page, err := paginator.PaginationQueryParamValidation(h.Ctx.Input.Query("pageSize"), h.Ctx.Input.Query("page"))
+	if err != nil {
+		h.ResponseError(h.Ctx, http.StatusBadRequest, response.QueryParamInvalidCode, response.ErrorCodeText(response.QueryParamInvalidCode, h.Locale.Lang), err)
+		return
+	}
+	limit, page, offset := paginator.Pagination(page, pageSize)
+
+	result, err := h.Usecase.GetActions(h.Ctx.Request.Context(), userId, page, limit, offset)
+	if err != nil {
+		h.Fail(err)
+		return
+	}
+	h.Ok(h.Ctx, h.Tr("message.success"), result)
+	return
+}
@@ -0,0 +1,16 @@
+package moderation
+
+import (
+	"context"
+	"github.com/radyatamaa/dating-apps-api/internal/domain"
+	"github.com/radyatamaa/dating-apps-api/pkg/database"
+	"github.com/radyatamaa/dating-apps-api/pkg/database/paginator"
+)
+
+// MysqlRepository Repository Interface, moderation actions are append only
+type MysqlRepository interface {
+	FetchWithFilterAndPagination(ctx context.Context, limit int, offset int, query *database.Query, model interface{}) (*paginator.Paginator, error)
+	SingleWithFilter(ctx context.Context, query *database.Query, model interface{}) error
+	FetchWithFilter(ctx context.Context, query *database.Query, model interface{}) (interface{}, error)
+	Store(ctx context.Context, data domain.ModerationAction) (domain.ModerationAction, error)
+}
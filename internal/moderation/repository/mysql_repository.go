@@ -0,0 +1,32 @@
+package repository
+
+import (
+	"context"
+	"github.com/radyatamaa/dating-apps-api/internal/moderation"
+
+	"github.com/radyatamaa/dating-apps-api/internal/domain"
+	baseRepository "github.com/radyatamaa/dating-apps-api/pkg/database/repository"
+	"github.com/radyatamaa/dating-apps-api/pkg/zaplogger"
+	"gorm.io/gorm"
+)
+
+type mysqlRepository struct {
+	baseRepository.Repository
+	zapLogger zaplogger.Logger
+}
+
+func NewMysqlRepository(db *gorm.DB, zapLogger zaplogger.Logger) moderation.MysqlRepository {
+	return &mysqlRepository{
+		Repository: baseRepository.New(db, domain.ModerationAction{}),
+		zapLogger:  zapLogger,
+	}
+}
+
+func (c mysqlRepository) Store(ctx context.Context, data domain.ModerationAction) (domain.ModerationAction, error) {
+
+	err := c.Create(ctx, &data)
+	if err != nil {
+		return data, err
+	}
+	return data, nil
+}
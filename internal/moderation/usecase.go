@@ -0,0 +1,13 @@
+package moderation
+
+import (
+	"context"
+
+	"github.com/radyatamaa/dating-apps-api/internal/domain"
+)
+
+// UseCase Interface
+type UseCase interface {
+	TakeAction(ctx context.Context, userId int, request domain.ModerationActionRequest) error
+	GetActions(ctx context.Context, userId int, page, limit, offset int) (*domain.ModerationActionResponsePaginationResponse, error)
+}
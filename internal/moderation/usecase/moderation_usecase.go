@@ -0,0 +1,205 @@
+package usecase
+
+import (
+	"context"
+	"database/sql"
+	"time"
+
+	"github.com/radyatamaa/dating-apps-api/internal/audit"
+	"github.com/radyatamaa/dating-apps-api/internal/domain"
+	"github.com/radyatamaa/dating-apps-api/internal/moderation"
+	"github.com/radyatamaa/dating-apps-api/internal/report"
+	"github.com/radyatamaa/dating-apps-api/internal/user"
+	"github.com/radyatamaa/dating-apps-api/pkg/authuser"
+	"github.com/radyatamaa/dating-apps-api/pkg/database"
+	"github.com/radyatamaa/dating-apps-api/pkg/jwt"
+	"github.com/radyatamaa/dating-apps-api/pkg/requestinfo"
+	"github.com/radyatamaa/dating-apps-api/pkg/tracing"
+	"github.com/radyatamaa/dating-apps-api/pkg/zaplogger"
+)
+
+const defaultSuspendDays = 7
+
+type moderationUseCase struct {
+	zapLogger                 zaplogger.Logger
+	contextTimeout            time.Duration
+	txManager                 database.TxManager
+	jwtAuth                   jwt.JWT
+	mysqlModerationRepository moderation.MysqlRepository
+	mysqlUserRepository       user.MysqlRepository
+	mysqlReportRepository     report.MysqlRepository
+	auditUseCase              audit.UseCase
+}
+
+func NewModerationUseCase(timeout time.Duration,
+	txManager database.TxManager,
+	mysqlModerationRepository moderation.MysqlRepository,
+	mysqlUserRepository user.MysqlRepository,
+	mysqlReportRepository report.MysqlRepository,
+	jwtAuth jwt.JWT,
+	auditUseCase audit.UseCase,
+	zapLogger zaplogger.Logger) moderation.UseCase {
+	return &moderationUseCase{
+		mysqlModerationRepository: mysqlModerationRepository,
+		mysqlUserRepository:       mysqlUserRepository,
+		mysqlReportRepository:     mysqlReportRepository,
+		jwtAuth:                   jwtAuth,
+		auditUseCase:              auditUseCase,
+		contextTimeout:            timeout,
+		txManager:                 txManager,
+		zapLogger:                 zapLogger,
+	}
+}
+
+/////////////////// TakeAction
+
+// actionUserFields returns the fields of the user updated by the action.
+func actionUserFields(action domain.ModerationAction) map[string]interface{} {
+	switch action.Action {
+	case domain.ModerationActionSuspend:
+		return map[string]interface{}{"suspended_until": action.ExpiresAt}
+	case domain.ModerationActionBan:
+		return map[string]interface{}{"banned_at": action.CreatedAt}
+	case domain.ModerationActionRestore:
+		return map[string]interface{}{"hidden_at": nil, "suspended_until": nil, "banned_at": nil}
+	default:
+		return nil
+	}
+}
+
+func (r moderationUseCase) TakeAction(ctx context.Context, userId int, request domain.ModerationActionRequest) error {
+	ctx, cancel := context.WithTimeout(ctx, r.contextTimeout)
+	defer cancel()
+	ctx, span := tracing.Start(ctx, "moderationUseCase.TakeAction")
+	defer span.End()
+
+	authUser, err := authuser.FromContext(ctx)
+	if err != nil {
+		zaplogger.SetStackTrace(ctx, r.zapLogger.SetMessageLog(err))
+		return err
+	}
+	actorId := int64(authUser.ID)
+
+	var userSingle domain.User
+	if err := r.mysqlUserRepository.SingleWithFilter(ctx, database.NewQuery().
+		Select("id", "hidden_at", "suspended_until", "banned_at").Where(database.Eq("id", userId)), &userSingle); err != nil {
+		zaplogger.SetStackTrace(ctx, r.zapLogger.SetMessageLog(err))
+		return err
+	}
+
+	now := time.Now()
+	action := domain.ModerationAction{
+		UserID:    userId,
+		ActorID:   sql.NullInt64{Int64: actorId, Valid: true},
+		Action:    request.Action,
+		Reason:    request.Reason,
+		CreatedAt: now,
+	}
+	if request.ReportID != 0 {
+		if err := r.mysqlReportRepository.SingleWithFilter(ctx, database.NewQuery().
+			Select("id").Where(database.Eq("id", request.ReportID), database.Eq("reported_user_id", userId)), &domain.Report{}); err != nil {
+			zaplogger.SetStackTrace(ctx, r.zapLogger.SetMessageLog(err))
+			return err
+		}
+		action.ReportID = sql.NullInt64{Int64: int64(request.ReportID), Valid: true}
+	}
+	if request.Action == domain.ModerationActionSuspend {
+		suspendDays := request.SuspendDays
+		if suspendDays == 0 {
+			suspendDays = defaultSuspendDays
+		}
+		action.ExpiresAt = sql.NullTime{Time: now.AddDate(0, 0, suspendDays), Valid: true}
+	}
+
+	err = r.txManager.WithinTransaction(ctx, func(ctx context.Context) error {
+		if values := actionUserFields(action); values != nil {
+			fields := []string{"updated_at"}
+			for field := range values {
+				fields = append(fields, field)
+			}
+			values["updated_at"] = now
+			if err := r.mysqlUserRepository.UpdateSelectedField(ctx, fields, values, userId); err != nil {
+				return err
+			}
+		}
+
+		if _, err := r.mysqlModerationRepository.Store(ctx, action); err != nil {
+			return err
+		}
+
+		if action.ReportID.Valid {
+			return r.mysqlReportRepository.UpdateSelectedField(ctx, []string{"status", "reviewed_by", "reviewed_at", "updated_at"}, map[string]interface{}{
+				"status":      domain.ReportStatusActioned,
+				"reviewed_by": action.ActorID,
+				"reviewed_at": now,
+				"updated_at":  now,
+			}, request.ReportID)
+		}
+		return nil
+	})
+	if err != nil {
+		zaplogger.SetStackTrace(ctx, r.zapLogger.SetMessageLog(err))
+		return err
+	}
+
+	// the diff holds the moderation fields of the user changed by the action
+	before := map[string]interface{}{"moderation_action": nil}
+	after := map[string]interface{}{"moderation_action": action.Action}
+	userFields := map[string]interface{}{
+		"hidden_at":       userSingle.HiddenAt,
+		"suspended_until": userSingle.SuspendedUntil,
+		"banned_at":       userSingle.BannedAt,
+	}
+	for field, value := range actionUserFields(action) {
+		before[field] = userFields[field]
+		after[field] = value
+	}
+	r.auditUseCase.Record(ctx, domain.AuditEntry{
+		Action:     domain.AuditActionModerationAction,
+		TargetType: domain.AuditTargetUser,
+		TargetID:   userId,
+		Before:     before,
+		After:      after,
+	})
+
+	// sign out the user, the login is refused while suspended or banned
+	if request.Action == domain.ModerationActionSuspend || request.Action == domain.ModerationActionBan {
+		if err = r.jwtAuth.Ctx(ctx).DestroyIdentity(requestinfo.FromContext(ctx).Host, userId); err != nil {
+			zaplogger.SetStackTrace(ctx, r.zapLogger.SetMessageLog(err))
+			return err
+		}
+	}
+
+	return nil
+}
+
+//////////////////
+
+/////////////////// GetActions
+
+func (r moderationUseCase) GetActions(ctx context.Context, userId int, page, limit, offset int) (*domain.ModerationActionResponsePaginationResponse, error) {
+	ctx, cancel := context.WithTimeout(ctx, r.contextTimeout)
+	defer cancel()
+	ctx, span := tracing.Start(ctx, "moderationUseCase.GetActions")
+	defer span.End()
+
+	var entity []domain.ModerationAction
+	fetchActions, err := r.mysqlModerationRepository.FetchWithFilterAndPagination(ctx, limit, offset, database.NewQuery().
+		Where(database.Eq("user_id", userId)).OrderBy(database.Desc("id")), &entity)
+	if err != nil {
+		zaplogger.SetStackTrace(ctx, r.zapLogger.SetMessageLog(err))
+		return nil, err
+	}
+
+	datas := make([]domain.ModerationActionResponse, 0)
+	records := fetchActions.Records.(*[]domain.ModerationAction)
+	if records != nil {
+		for _, e := range *records {
+			datas = append(datas, domain.FromModerationActionToModerationActionResponse(e))
+		}
+	}
+
+	return domain.ToModerationActionResponsePaginationResponse(datas, page, limit, offset, fetchActions), nil
+}
+
+//////////////////
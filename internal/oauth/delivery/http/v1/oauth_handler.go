@@ -0,0 +1,94 @@
+package v1
+
+import (
+	"net/http"
+
+	"github.com/radyatamaa/dating-apps-api/internal/oauth"
+	"github.com/radyatamaa/dating-apps-api/pkg/validator"
+
+	beego "github.com/beego/beego/v2/server/web"
+	"github.com/radyatamaa/dating-apps-api/internal"
+	"github.com/radyatamaa/dating-apps-api/internal/domain"
+	"github.com/radyatamaa/dating-apps-api/pkg/response"
+	"github.com/radyatamaa/dating-apps-api/pkg/zaplogger"
+)
+
+type OAuthHandler struct {
+	ZapLogger zaplogger.Logger
+	internal.BaseController
+	response.ApiResponse
+	Usecase oauth.UseCase
+}
+
+func NewOAuthHandler(useCase oauth.UseCase, zapLogger zaplogger.Logger) {
+	pHandler := &OAuthHandler{
+		ZapLogger: zapLogger,
+		Usecase:   useCase,
+	}
+	beego.Router("/api/v1/oauth/:provider/authorize", pHandler, "get:Authorize")
+	beego.Router("/api/v1/oauth/:provider/callback", pHandler, "get:Callback")
+}
+
+func (h *OAuthHandler) Prepare() {
+	// check user access when needed
+	h.SetLangVersion()
+}
+
+// Authorize
+// @Title Authorize
+// @Tags OAuth
+// @Summary Authorize returns the url of the provider login page, the provider redirects back to the callback
+// @Produce json
+// @Param Accept-Language header string false "lang"
+// @Param provider path string true "provider name, ex: google"
+// @Success 200 {object} swagger.BaseResponse{errors=[]object,data=domain.OAuthAuthorizeResponse}
+// @Failure 400 {object} swagger.BadRequestErrorValidationResponse{errors=[]swagger.ValidationErrors,data=object}
+// @Failure 408 {object} swagger.RequestTimeoutResponse{errors=[]object,data=object}
+// @Failure 500 {object} swagger.InternalServerErrorResponse{errors=[]object,data=object}
+// @Router /v1/oauth/{provider}/authorize [get]
+func (h *OAuthHandler) Authorize() {
+	result, err := h.Usecase.Authorize(h.Ctx.Request.Context(), h.Ctx.Input.Param(":provider"))
+	if err != nil {
+		h.Fail(err)
+		return
+	}
+	h.Ok(h.Ctx, h.Tr("message.success"), result)
+	return
+}
+
+// Callback
+// @Title Callback
+// @Tags OAuth
+// @Summary Callback logs in with the authorization code of the provider, the account is created on the first login
+// @Produce json
+// @Param Accept-Language header string false "lang"
+// @Param provider path string true "provider name, ex: google"
+// @Param code query string true "authorization code"
+// @Param state query string true "state returned by authorize"
+// @Success 200 {object} swagger.BaseResponse{errors=[]object,data=domain.LoginResponse}
+// @Failure 400 {object} swagger.BadRequestErrorValidationResponse{errors=[]swagger.ValidationErrors,data=object}
+// @Failure 408 {object} swagger.RequestTimeoutResponse{errors=[]object,data=object}
+// @Failure 500 {object} swagger.InternalServerErrorResponse{errors=[]object,data=object}
+// @Router /v1/oauth/{provider}/callback [get]
+func (h *OAuthHandler) Callback() {
+	var request domain.OAuthCallbackRequest
+
+	if err := h.BindForm(&request); err != nil {
+		h.Ctx.Input.SetData("stackTrace", h.ZapLogger.SetMessageLog(err))
+		h.ResponseError(h.Ctx, http.StatusBadRequest, response.ApiValidationCodeError, response.ErrorCodeText(response.ApiValidationCodeError, h.Locale.Lang), err)
+		return
+	}
+	if err := validator.Validate.ValidateStruct(&request); err != nil {
+		h.Ctx.Input.SetData("stackTrace", h.ZapLogger.SetMessageLog(err))
+		h.ResponseError(h.Ctx, http.StatusBadRequest, response.ApiValidationCodeError, response.ErrorCodeText(response.ApiValidationCodeError, h.Locale.Lang), err)
+		return
+	}
+
+	result, err := h.Usecase.Callback(h.Ctx.Request.Context(), h.Ctx.Input.Param(":provider"), request)
+	if err != nil {
+		h.Fail(err)
+		return
+	}
+	h.Ok(h.Ctx, h.Tr("message.success"), result)
+	return
+}
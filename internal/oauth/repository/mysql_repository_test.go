@@ -0,0 +1,19 @@
+package repository
+
+import (
+	"testing"
+
+	"github.com/radyatamaa/dating-apps-api/internal/domain"
+	"github.com/radyatamaa/dating-apps-api/pkg/database/repository/repositorytest"
+	"github.com/stretchr/testify/suite"
+	"gorm.io/gorm"
+)
+
+func TestOAuthMysqlRepositoryTestSuite(t *testing.T) {
+	suite.Run(t, &repositorytest.Suite{
+		New: func(db *gorm.DB) repositorytest.Repository {
+			return NewMysqlRepository(db, nil).(repositorytest.Repository)
+		},
+		Model: domain.UserIdentity{},
+	})
+}
@@ -0,0 +1,13 @@
+package oauth
+
+import (
+	"context"
+
+	"github.com/radyatamaa/dating-apps-api/internal/domain"
+)
+
+// UseCase Interface
+type UseCase interface {
+	Authorize(ctx context.Context, provider string) (*domain.OAuthAuthorizeResponse, error)
+	Callback(ctx context.Context, provider string, request domain.OAuthCallbackRequest) (*domain.LoginResponse, error)
+}
@@ -0,0 +1,266 @@
+package usecase
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/beego/beego/v2/client/cache"
+	"github.com/radyatamaa/dating-apps-api/internal/domain"
+	"github.com/radyatamaa/dating-apps-api/internal/oauth"
+	"github.com/radyatamaa/dating-apps-api/internal/profile"
+	"github.com/radyatamaa/dating-apps-api/internal/user"
+	"github.com/radyatamaa/dating-apps-api/pkg/database"
+	"github.com/radyatamaa/dating-apps-api/pkg/event"
+	"github.com/radyatamaa/dating-apps-api/pkg/metrics"
+	"github.com/radyatamaa/dating-apps-api/pkg/oidc"
+	"github.com/radyatamaa/dating-apps-api/pkg/response"
+	"github.com/radyatamaa/dating-apps-api/pkg/tracing"
+	"github.com/radyatamaa/dating-apps-api/pkg/zaplogger"
+	"gorm.io/gorm"
+)
+
+const (
+	oauthStateExpire = 10 * time.Minute
+	oauthStateKey    = "oauth:state:%s"
+)
+
+type oauthUseCase struct {
+	zapLogger              zaplogger.Logger
+	contextTimeout         time.Duration
+	txManager              database.TxManager
+	publisher              event.Publisher
+	cache                  cache.Cache
+	providers              oidc.Registry
+	userUseCase            user.UseCase
+	mysqlOAuthRepository   oauth.MysqlRepository
+	mysqlUserRepository    user.MysqlRepository
+	mysqlProfileRepository profile.MysqlRepository
+}
+
+func NewOAuthUseCase(timeout time.Duration,
+	txManager database.TxManager,
+	publisher event.Publisher,
+	mysqlOAuthRepository oauth.MysqlRepository,
+	mysqlUserRepository user.MysqlRepository,
+	mysqlProfileRepository profile.MysqlRepository,
+	userUseCase user.UseCase,
+	providers oidc.Registry,
+	cache cache.Cache,
+	zapLogger zaplogger.Logger) oauth.UseCase {
+	return &oauthUseCase{
+		mysqlOAuthRepository:   mysqlOAuthRepository,
+		mysqlUserRepository:    mysqlUserRepository,
+		mysqlProfileRepository: mysqlProfileRepository,
+		userUseCase:            userUseCase,
+		providers:              providers,
+		contextTimeout:         timeout,
+		txManager:              txManager,
+		publisher:              publisher,
+		cache:                  cache,
+		zapLogger:              zapLogger,
+	}
+}
+
+/////////////////// Authorize
+
+func (r oauthUseCase) Authorize(ctx context.Context, provider string) (*domain.OAuthAuthorizeResponse, error) {
+	ctx, cancel := context.WithTimeout(ctx, r.contextTimeout)
+	defer cancel()
+	ctx, span := tracing.Start(ctx, "oauthUseCase.Authorize")
+	defer span.End()
+
+	oidcProvider, err := r.providers.Get(provider)
+	if err != nil {
+		zaplogger.SetStackTrace(ctx, r.zapLogger.SetMessageLog(response.ErrOAuthProviderNotFound))
+		return nil, response.ErrOAuthProviderNotFound
+	}
+
+	var state, nonce, codeVerifier string
+	for _, value := range []*string{&state, &nonce, &codeVerifier} {
+		if *value, err = oidc.GenerateRandom(); err != nil {
+			zaplogger.SetStackTrace(ctx, r.zapLogger.SetMessageLog(err))
+			return nil, err
+		}
+	}
+
+	oauthState, err := json.Marshal(domain.OAuthState{
+		Provider:     oidcProvider.Name(),
+		Nonce:        nonce,
+		CodeVerifier: codeVerifier,
+	})
+	if err != nil {
+		zaplogger.SetStackTrace(ctx, r.zapLogger.SetMessageLog(err))
+		return nil, err
+	}
+	if err = r.cache.Put(ctx, fmt.Sprintf(oauthStateKey, state), string(oauthState), oauthStateExpire); err != nil {
+		zaplogger.SetStackTrace(ctx, r.zapLogger.SetMessageLog(err))
+		return nil, err
+	}
+
+	authorizationUrl, err := oidcProvider.AuthCodeURL(ctx, state, nonce, oidc.CodeChallengeS256(codeVerifier))
+	if err != nil {
+		zaplogger.SetStackTrace(ctx, r.zapLogger.SetMessageLog(err))
+		return nil, err
+	}
+
+	return &domain.OAuthAuthorizeResponse{
+		AuthorizationUrl: authorizationUrl,
+		State:            state,
+	}, nil
+}
+
+//////////////////
+
+/////////////////// Callback
+
+// popState returns the state stored by Authorize, a state can only be used once.
+func (r oauthUseCase) popState(ctx context.Context, state string) (*domain.OAuthState, error) {
+	key := fmt.Sprintf(oauthStateKey, state)
+
+	value, err := r.cache.Get(ctx, key)
+	if err != nil || value == nil {
+		return nil, response.ErrInvalidOAuthState
+	}
+	if err = r.cache.Delete(ctx, key); err != nil {
+		return nil, err
+	}
+
+	var raw []byte
+	switch v := value.(type) {
+	case []byte:
+		raw = v
+	case string:
+		raw = []byte(v)
+	default:
+		return nil, response.ErrInvalidOAuthState
+	}
+
+	oauthState := new(domain.OAuthState)
+	if err = json.Unmarshal(raw, oauthState); err != nil {
+		return nil, response.ErrInvalidOAuthState
+	}
+	return oauthState, nil
+}
+
+// linkUser returns the user of the identity, the identity is linked to the user with the
+// same verified email or to a new user when it's the first login with the provider.
+func (r oauthUseCase) linkUser(ctx context.Context, provider string, claims *oidc.Claims) (int, error) {
+	var identity domain.UserIdentity
+	err := r.mysqlOAuthRepository.SingleWithFilter(ctx, database.NewQuery().
+		Where(database.Eq("provider", provider), database.Eq("subject", claims.Subject)), &identity)
+	if err == nil {
+		return identity.UserID, nil
+	}
+	if err != gorm.ErrRecordNotFound {
+		return 0, err
+	}
+
+	// the email is only trusted when verified by the provider, otherwise anyone could
+	// take over an account by creating a provider account with the same email
+	if claims.Email == "" || !claims.EmailVerified {
+		return 0, response.ErrOAuthEmailNotVerified
+	}
+
+	var existing domain.User
+	err = r.mysqlUserRepository.SingleWithFilter(ctx, database.NewQuery().
+		Select("id").Where(database.Eq("email", claims.Email)), &existing)
+	if err != nil && err != gorm.ErrRecordNotFound {
+		return 0, err
+	}
+
+	userId := existing.ID
+	registered := userId == 0
+	if err = r.txManager.WithinTransaction(ctx, func(ctx context.Context) error {
+		if userId == 0 {
+			userSingle, err := r.mysqlUserRepository.Store(ctx, domain.User{Email: claims.Email})
+			if err != nil {
+				return err
+			}
+			userId = userSingle.ID
+
+			name := claims.Name
+			if name == "" {
+				name = strings.Split(claims.Email, "@")[0]
+			}
+			if _, err = r.mysqlProfileRepository.Store(ctx, domain.Profile{
+				UserID: userId,
+				Name:   name,
+				Photo:  claims.Picture,
+			}); err != nil {
+				return err
+			}
+		}
+
+		_, err = r.mysqlOAuthRepository.Store(ctx, domain.UserIdentity{
+			UserID:   userId,
+			Provider: provider,
+			Subject:  claims.Subject,
+			Email:    claims.Email,
+		})
+		if err != nil || !registered {
+			return err
+		}
+
+		return event.PublishNew(ctx, r.publisher, domain.EventUserRegistered, strconv.Itoa(userId), domain.UserRegisteredPayload{
+			UserID:   userId,
+			Email:    claims.Email,
+			Provider: provider,
+		})
+	}); err != nil {
+		return 0, err
+	}
+	if registered {
+		metrics.RegistrationsTotal.WithLabelValues(provider).Inc()
+	}
+
+	return userId, nil
+}
+
+func (r oauthUseCase) Callback(ctx context.Context, provider string, request domain.OAuthCallbackRequest) (*domain.LoginResponse, error) {
+	ctx, cancel := context.WithTimeout(ctx, r.contextTimeout)
+	defer cancel()
+	ctx, span := tracing.Start(ctx, "oauthUseCase.Callback")
+	defer span.End()
+
+	oidcProvider, err := r.providers.Get(provider)
+	if err != nil {
+		zaplogger.SetStackTrace(ctx, r.zapLogger.SetMessageLog(response.ErrOAuthProviderNotFound))
+		return nil, response.ErrOAuthProviderNotFound
+	}
+
+	oauthState, err := r.popState(ctx, request.State)
+	if err != nil {
+		zaplogger.SetStackTrace(ctx, r.zapLogger.SetMessageLog(err))
+		return nil, err
+	}
+	if oauthState.Provider != oidcProvider.Name() {
+		zaplogger.SetStackTrace(ctx, r.zapLogger.SetMessageLog(response.ErrInvalidOAuthState))
+		return nil, response.ErrInvalidOAuthState
+	}
+
+	token, err := oidcProvider.Exchange(ctx, request.Code, oauthState.CodeVerifier)
+	if err != nil {
+		zaplogger.SetStackTrace(ctx, r.zapLogger.SetMessageLog(err))
+		return nil, fmt.Errorf("%w: %v", response.ErrOAuthLoginFailed, err)
+	}
+
+	claims, err := oidcProvider.VerifyIDToken(ctx, token.IDToken, oauthState.Nonce)
+	if err != nil {
+		zaplogger.SetStackTrace(ctx, r.zapLogger.SetMessageLog(err))
+		return nil, fmt.Errorf("%w: %v", response.ErrOAuthLoginFailed, err)
+	}
+
+	userId, err := r.linkUser(ctx, oidcProvider.Name(), claims)
+	if err != nil {
+		zaplogger.SetStackTrace(ctx, r.zapLogger.SetMessageLog(err))
+		return nil, err
+	}
+
+	return r.userUseCase.LoginWithUserId(ctx, userId)
+}
+
+//////////////////
@@ -0,0 +1,194 @@
+package usecase
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/beego/beego/v2/client/cache"
+	"github.com/golang/mock/gomock"
+	"github.com/radyatamaa/dating-apps-api/internal/domain"
+	"github.com/radyatamaa/dating-apps-api/internal/domain/mocks"
+	"github.com/radyatamaa/dating-apps-api/pkg/database"
+	mockDatabase "github.com/radyatamaa/dating-apps-api/pkg/database/mocks"
+	"github.com/radyatamaa/dating-apps-api/pkg/event"
+	mockEvent "github.com/radyatamaa/dating-apps-api/pkg/event/mocks"
+	"github.com/radyatamaa/dating-apps-api/pkg/oidc"
+	"github.com/radyatamaa/dating-apps-api/pkg/oidc/oidctest"
+	"github.com/radyatamaa/dating-apps-api/pkg/requestinfo"
+	"github.com/radyatamaa/dating-apps-api/pkg/response"
+	mockZaplogger "github.com/radyatamaa/dating-apps-api/pkg/zaplogger/mocks"
+	"github.com/stretchr/testify/suite"
+	"gorm.io/gorm"
+)
+
+type OAuthUseCaseTestSuite struct {
+	suite.Suite
+	issuer *oidctest.Issuer
+}
+
+func (t *OAuthUseCaseTestSuite) SetupSuite() {
+	t.issuer = oidctest.NewIssuer()
+}
+
+func (t *OAuthUseCaseTestSuite) TearDownSuite() {
+	t.issuer.Close()
+}
+
+type fields struct {
+	zapLogger              *mockZaplogger.MockLogger
+	contextTimeout         time.Duration
+	cache                  cache.Cache
+	txManager              *mockDatabase.MockTxManager
+	publisher              *mockEvent.MockPublisher
+	userUseCase            *mocks.MockUserUseCase
+	mysqlOAuthRepository   *mocks.OAuthMysqlRepository
+	mysqlUserRepository    *mocks.UserMysqlRepository
+	mysqlProfileRepository *mocks.ProfileMysqlRepository
+}
+
+func toField(ctrl *gomock.Controller) fields {
+	return fields{
+		zapLogger:              mockZaplogger.NewMockLogger(ctrl),
+		contextTimeout:         time.Second * 30,
+		cache:                  cache.NewMemoryCache(),
+		txManager:              mockDatabase.NewMockTxManager(ctrl),
+		publisher:              mockEvent.NewMockPublisher(ctrl),
+		userUseCase:            mocks.NewMockUserUseCase(ctrl),
+		mysqlOAuthRepository:   mocks.NewOAuthMysqlRepository(ctrl),
+		mysqlUserRepository:    mocks.NewUserMysqlRepository(ctrl),
+		mysqlProfileRepository: mocks.NewProfileMysqlRepository(ctrl),
+	}
+}
+
+func newRequestContext(host string) context.Context {
+	return requestinfo.NewContext(context.TODO(), requestinfo.Info{ID: "request-id", Host: host})
+}
+
+func (t *OAuthUseCaseTestSuite) TestOAuthUseCase_Callback() {
+	provider, err := oidc.NewProvider(oidc.Config{
+		Name:        "fake",
+		Issuer:      t.issuer.URL,
+		ClientID:    "client-id",
+		RedirectURL: "http://localhost:8082/api/v1/oauth/fake/callback",
+	})
+	t.NoError(err)
+
+	tests := []struct {
+		name         string
+		user         oidctest.User
+		invalidState bool
+		mock         func(fields fields)
+		want         *domain.LoginResponse
+		wantErr      error
+	}{
+		{
+			name: "success existing identity",
+			user: oidctest.User{Subject: "1", Email: "john@mail.com", EmailVerified: true},
+			mock: func(fields fields) {
+				fields.mysqlOAuthRepository.EXPECT().SingleWithFilter(gomock.Any(), database.NewQuery().
+					Where(database.Eq("provider", "fake"), database.Eq("subject", "1")), gomock.Any()).
+					DoAndReturn(func(ctx context.Context, query *database.Query, model interface{}) error {
+						model.(*domain.UserIdentity).UserID = 10
+						return nil
+					})
+				fields.userUseCase.EXPECT().LoginWithUserId(gomock.Any(), 10).Return(&domain.LoginResponse{Token: "token"}, nil)
+			},
+			want: &domain.LoginResponse{Token: "token"},
+		},
+		{
+			name: "success new user",
+			user: oidctest.User{Subject: "3", Email: "jack@mail.com", EmailVerified: true, Name: "Jack"},
+			mock: func(fields fields) {
+				fields.mysqlOAuthRepository.EXPECT().SingleWithFilter(gomock.Any(), database.NewQuery().
+					Where(database.Eq("provider", "fake"), database.Eq("subject", "3")), gomock.Any()).
+					Return(gorm.ErrRecordNotFound)
+				fields.mysqlUserRepository.EXPECT().SingleWithFilter(gomock.Any(), database.NewQuery().
+					Select("id").Where(database.Eq("email", "jack@mail.com")), gomock.Any()).
+					Return(gorm.ErrRecordNotFound)
+				fields.txManager.EXPECT().WithinTransaction(gomock.Any(), gomock.Any()).
+					DoAndReturn(func(ctx context.Context, fn func(ctx context.Context) error) error {
+						return fn(ctx)
+					})
+				fields.mysqlUserRepository.EXPECT().Store(gomock.Any(), domain.User{Email: "jack@mail.com"}).
+					Return(domain.User{ID: 11, Email: "jack@mail.com"}, nil)
+				fields.mysqlProfileRepository.EXPECT().Store(gomock.Any(), domain.Profile{UserID: 11, Name: "Jack"}).
+					Return(domain.Profile{ID: 12, UserID: 11, Name: "Jack"}, nil)
+				fields.mysqlOAuthRepository.EXPECT().Store(gomock.Any(), domain.UserIdentity{
+					UserID:   11,
+					Provider: "fake",
+					Subject:  "3",
+					Email:    "jack@mail.com",
+				}).Return(domain.UserIdentity{ID: 13}, nil)
+				fields.publisher.EXPECT().Publish(gomock.Any(), gomock.Any()).
+					DoAndReturn(func(ctx context.Context, events ...event.Event) error {
+						var payload domain.UserRegisteredPayload
+						t.NoError(events[0].Decode(&payload))
+						t.Equal(domain.EventUserRegistered, events[0].Name)
+						t.Equal(domain.UserRegisteredPayload{UserID: 11, Email: "jack@mail.com", Provider: "fake"}, payload)
+						return nil
+					})
+				fields.userUseCase.EXPECT().LoginWithUserId(gomock.Any(), 11).Return(&domain.LoginResponse{Token: "token"}, nil)
+			},
+			want: &domain.LoginResponse{Token: "token"},
+		},
+		{
+			name: "email not verified",
+			user: oidctest.User{Subject: "2", Email: "jane@mail.com", EmailVerified: false},
+			mock: func(fields fields) {
+				fields.mysqlOAuthRepository.EXPECT().SingleWithFilter(gomock.Any(), database.NewQuery().
+					Where(database.Eq("provider", "fake"), database.Eq("subject", "2")), gomock.Any()).
+					Return(gorm.ErrRecordNotFound)
+				fields.zapLogger.EXPECT().SetMessageLog(response.ErrOAuthEmailNotVerified)
+			},
+			wantErr: response.ErrOAuthEmailNotVerified,
+		},
+		{
+			name:         "invalid state",
+			user:         oidctest.User{Subject: "1", Email: "john@mail.com", EmailVerified: true},
+			invalidState: true,
+			mock: func(fields fields) {
+				fields.zapLogger.EXPECT().SetMessageLog(response.ErrInvalidOAuthState)
+			},
+			wantErr: response.ErrInvalidOAuthState,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func() {
+			ctrl := gomock.NewController(t.T())
+			defer ctrl.Finish()
+
+			f := toField(ctrl)
+			tt.mock(f)
+			t.issuer.SetUser(tt.user)
+
+			r := NewOAuthUseCase(f.contextTimeout, f.txManager, f.publisher, f.mysqlOAuthRepository, f.mysqlUserRepository, f.mysqlProfileRepository,
+				f.userUseCase, oidc.Registry{"fake": provider}, f.cache, f.zapLogger)
+
+			authorize, err := r.Authorize(newRequestContext("localhost:8082"), "fake")
+			t.NoError(err)
+
+			code, state, err := t.issuer.Authorize(authorize.AuthorizationUrl)
+			t.NoError(err)
+			t.Equal(authorize.State, state)
+			if tt.invalidState {
+				state = "invalid"
+			}
+
+			got, err := r.Callback(newRequestContext("localhost:8082"), "fake", domain.OAuthCallbackRequest{
+				Code:  code,
+				State: state,
+			})
+			if tt.wantErr != nil {
+				t.ErrorIs(err, tt.wantErr)
+				return
+			}
+			t.NoError(err)
+			t.Equal(tt.want, got)
+		})
+	}
+}
+
+func TestOAuthUseCaseTestSuite(t *testing.T) {
+	suite.Run(t, new(OAuthUseCaseTestSuite))
+}
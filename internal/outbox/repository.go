@@ -0,0 +1,17 @@
+package outbox
+
+import (
+	"context"
+	"time"
+
+	"github.com/radyatamaa/dating-apps-api/internal/domain"
+)
+
+// MysqlRepository Repository Interface, the events are stored in the transaction of the context
+type MysqlRepository interface {
+	Store(ctx context.Context, data ...domain.OutboxEvent) error
+	FetchUnpublished(ctx context.Context, limit int, maxAttempts int) ([]domain.OutboxEvent, error)
+	MarkPublished(ctx context.Context, ids []int, publishedAt time.Time) error
+	MarkFailed(ctx context.Context, ids []int, lastError string) error
+	DeletePublishedBefore(ctx context.Context, publishedBefore time.Time) (int64, error)
+}
@@ -0,0 +1,87 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"github.com/radyatamaa/dating-apps-api/internal/domain"
+	"github.com/radyatamaa/dating-apps-api/internal/outbox"
+	"github.com/radyatamaa/dating-apps-api/pkg/database"
+	baseRepository "github.com/radyatamaa/dating-apps-api/pkg/database/repository"
+	"github.com/radyatamaa/dating-apps-api/pkg/zaplogger"
+	"gorm.io/gorm"
+)
+
+type mysqlRepository struct {
+	baseRepository.Repository
+	zapLogger zaplogger.Logger
+}
+
+func NewMysqlRepository(db *gorm.DB, zapLogger zaplogger.Logger) outbox.MysqlRepository {
+	return &mysqlRepository{
+		Repository: baseRepository.New(db, domain.OutboxEvent{}),
+		zapLogger:  zapLogger,
+	}
+}
+
+func (c mysqlRepository) Store(ctx context.Context, data ...domain.OutboxEvent) error {
+	if len(data) == 0 {
+		return nil
+	}
+	return c.Create(ctx, &data)
+}
+
+// FetchUnpublished returns the unpublished events with less than maxAttempts attempts, oldest first.
+// The events of a key with a dead event (maxAttempts reached) are not returned, relaying them
+// would deliver the events of the key out of order, the events without a key are never blocked.
+// The relay reads the primary so an event published by the last run isn't read again from a replica.
+func (c mysqlRepository) FetchUnpublished(ctx context.Context, limit int, maxAttempts int) ([]domain.OutboxEvent, error) {
+	var data []domain.OutboxEvent
+
+	db := database.FromContext(database.WithPrimary(ctx), c.DB())
+	deadKeys := db.Session(&gorm.Session{NewDB: true}).Model(&domain.OutboxEvent{}).
+		Select("event_key").
+		Where("published_at IS NULL AND attempts >= ? AND event_key IS NOT NULL", maxAttempts)
+	err := db.
+		Where("published_at IS NULL AND attempts < ? AND (event_key IS NULL OR event_key NOT IN (?))", maxAttempts, deadKeys).
+		Order("id ASC").
+		Limit(limit).
+		Find(&data).Error
+	if err != nil {
+		return nil, err
+	}
+	return data, nil
+}
+
+func (c mysqlRepository) MarkPublished(ctx context.Context, ids []int, publishedAt time.Time) error {
+	if len(ids) == 0 {
+		return nil
+	}
+	return database.FromContext(ctx, c.DB()).Model(&domain.OutboxEvent{}).
+		Where("id IN ?", ids).
+		Updates(map[string]interface{}{
+			"published_at": publishedAt,
+			"last_error":   "",
+		}).Error
+}
+
+func (c mysqlRepository) MarkFailed(ctx context.Context, ids []int, lastError string) error {
+	if len(ids) == 0 {
+		return nil
+	}
+	return database.FromContext(ctx, c.DB()).Model(&domain.OutboxEvent{}).
+		Where("id IN ?", ids).
+		Updates(map[string]interface{}{
+			"attempts":   gorm.Expr("attempts + 1"),
+			"last_error": lastError,
+		}).Error
+}
+
+func (c mysqlRepository) DeletePublishedBefore(ctx context.Context, publishedBefore time.Time) (int64, error) {
+
+	result := database.FromContext(ctx, c.DB()).Where("published_at IS NOT NULL AND published_at < ?", publishedBefore).Delete(&domain.OutboxEvent{})
+	if result.Error != nil {
+		return 0, result.Error
+	}
+	return result.RowsAffected, nil
+}
@@ -0,0 +1,56 @@
+package repository
+
+import (
+	"context"
+	"regexp"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/radyatamaa/dating-apps-api/internal/domain"
+	"github.com/radyatamaa/dating-apps-api/pkg/database/repository/repositorytest"
+	"github.com/stretchr/testify/require"
+	"github.com/stretchr/testify/suite"
+	"gorm.io/driver/mysql"
+	"gorm.io/gorm"
+	"gorm.io/gorm/logger"
+)
+
+func TestOutboxMysqlRepositoryTestSuite(t *testing.T) {
+	suite.Run(t, &repositorytest.Suite{
+		New: func(db *gorm.DB) repositorytest.Repository {
+			return NewMysqlRepository(db, nil).(repositorytest.Repository)
+		},
+		Model: domain.OutboxEvent{},
+	})
+}
+
+// TestFetchUnpublished_DeadEventWithoutKey checks the guards of the NULL keys, a dead event without a key
+// must neither empty the dead keys of NOT IN nor hide the events without a key.
+func TestFetchUnpublished_DeadEventWithoutKey(t *testing.T) {
+	sqlDB, mock, err := sqlmock.New()
+	require.NoError(t, err)
+	defer sqlDB.Close()
+	db, err := gorm.Open(mysql.New(mysql.Config{Conn: sqlDB, SkipInitializeWithVersion: true}), &gorm.Config{
+		SkipDefaultTransaction: true,
+		Logger:                 logger.Default.LogMode(logger.Silent),
+	})
+	require.NoError(t, err)
+
+	// the dead event without a key and the events of a dead key are filtered out by the query,
+	// the pending events with and without a key are left
+	mock.ExpectQuery(regexp.QuoteMeta("SELECT * FROM `outbox_events` WHERE published_at IS NULL AND attempts < ? AND "+
+		"(event_key IS NULL OR event_key NOT IN (SELECT `event_key` FROM `outbox_events` WHERE published_at IS NULL AND attempts >= ? AND event_key IS NOT NULL)) "+
+		"ORDER BY id ASC LIMIT 10")).
+		WithArgs(5, 5).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "event_id", "name", "event_key", "attempts"}).
+			AddRow(3, "event-3", "user.registered", nil, 0).
+			AddRow(4, "event-4", "match.created", "a", 1))
+
+	events, err := NewMysqlRepository(db, nil).FetchUnpublished(context.TODO(), 10, 5)
+	require.NoError(t, err)
+	require.Len(t, events, 2)
+	require.Equal(t, []int{3, 4}, []int{events[0].ID, events[1].ID})
+	require.Equal(t, "", events[0].EventKey)
+	require.Equal(t, "a", events[1].EventKey)
+	require.NoError(t, mock.ExpectationsWereMet())
+}
@@ -0,0 +1,16 @@
+package outbox
+
+import (
+	"context"
+	"time"
+
+	"github.com/radyatamaa/dating-apps-api/pkg/event"
+)
+
+// UseCase Interface, Publish stores the events in the outbox, the transaction of the context when
+// there is one, and Relay publishes the stored events to the broker.
+type UseCase interface {
+	event.Publisher
+	Relay(ctx context.Context) (int, error)
+	PurgePublishedEvents(ctx context.Context, publishedBefore time.Time) (int64, error)
+}
@@ -0,0 +1,110 @@
+package usecase
+
+import (
+	"context"
+	"time"
+
+	"github.com/radyatamaa/dating-apps-api/internal/domain"
+	"github.com/radyatamaa/dating-apps-api/internal/outbox"
+	"github.com/radyatamaa/dating-apps-api/pkg/event"
+	"github.com/radyatamaa/dating-apps-api/pkg/metrics"
+	"github.com/radyatamaa/dating-apps-api/pkg/tracing"
+	"github.com/radyatamaa/dating-apps-api/pkg/zaplogger"
+)
+
+type outboxUseCase struct {
+	zapLogger             zaplogger.Logger
+	mysqlOutboxRepository outbox.MysqlRepository
+	broker                event.Broker
+	batchSize             int
+	maxAttempts           int
+}
+
+func NewOutboxUseCase(mysqlOutboxRepository outbox.MysqlRepository,
+	broker event.Broker,
+	batchSize int,
+	maxAttempts int,
+	zapLogger zaplogger.Logger) outbox.UseCase {
+	return &outboxUseCase{
+		mysqlOutboxRepository: mysqlOutboxRepository,
+		broker:                broker,
+		batchSize:             batchSize,
+		maxAttempts:           maxAttempts,
+		zapLogger:             zapLogger,
+	}
+}
+
+/////////////////// Publish
+
+// Publish stores the events in the outbox, in the transaction of the context when there is one
+// so the events are only relayed when the change of the usecase is committed.
+func (r outboxUseCase) Publish(ctx context.Context, events ...event.Event) error {
+	ctx, span := tracing.Start(ctx, "outboxUseCase.Publish")
+	defer span.End()
+
+	data := make([]domain.OutboxEvent, 0, len(events))
+	for _, e := range events {
+		data = append(data, domain.FromEventToOutboxEvent(e))
+	}
+	return r.mysqlOutboxRepository.Store(ctx, data...)
+}
+
+//////////////////
+
+/////////////////// Relay
+
+// Relay publishes a batch of the unpublished events to the broker in the order they occurred and
+// returns the number published. A failed event is retried by the next run until maxAttempts, the
+// next events of its key are left for the next run to keep the order of the key. An event reaching
+// maxAttempts is dead: it stays in the outbox with its last error and blocks its key until it's
+// fixed or deleted, it's counted as dead in the outbox metric to be alerted on.
+func (r outboxUseCase) Relay(ctx context.Context) (int, error) {
+	ctx, span := tracing.Start(ctx, "outboxUseCase.Relay")
+	defer span.End()
+
+	outboxEvents, err := r.mysqlOutboxRepository.FetchUnpublished(ctx, r.batchSize, r.maxAttempts)
+	if err != nil {
+		return 0, err
+	}
+
+	published := make([]int, 0, len(outboxEvents))
+	failedKeys := make(map[string]bool)
+	for _, outboxEvent := range outboxEvents {
+		if failedKeys[outboxEvent.EventKey] {
+			continue
+		}
+		if err := r.broker.Publish(ctx, outboxEvent.ToEvent()); err != nil {
+			failedKeys[outboxEvent.EventKey] = true
+			metrics.OutboxRelayedTotal.WithLabelValues("failed").Inc()
+			r.zapLogger.Errorw("failed relay outbox event", "event_id", outboxEvent.EventID, "name", outboxEvent.Name, zaplogger.FieldError, err)
+			if err := r.mysqlOutboxRepository.MarkFailed(ctx, []int{outboxEvent.ID}, err.Error()); err != nil {
+				r.zapLogger.Errorw("failed mark outbox event failed", "event_id", outboxEvent.EventID, zaplogger.FieldError, err)
+				continue
+			}
+			if outboxEvent.Attempts+1 >= r.maxAttempts {
+				metrics.OutboxRelayedTotal.WithLabelValues("dead").Inc()
+				r.zapLogger.Errorw("outbox event reached max attempts, the events of its key are blocked", "event_id", outboxEvent.EventID, "name", outboxEvent.Name, "key", outboxEvent.EventKey)
+			}
+			continue
+		}
+		published = append(published, outboxEvent.ID)
+	}
+
+	if err := r.mysqlOutboxRepository.MarkPublished(ctx, published, time.Now()); err != nil {
+		return 0, err
+	}
+	metrics.OutboxRelayedTotal.WithLabelValues("published").Add(float64(len(published)))
+
+	return len(published), nil
+}
+
+//////////////////
+
+/////////////////// PurgePublishedEvents
+
+// PurgePublishedEvents deletes the events published before publishedBefore and returns the number deleted.
+func (r outboxUseCase) PurgePublishedEvents(ctx context.Context, publishedBefore time.Time) (int64, error) {
+	return r.mysqlOutboxRepository.DeletePublishedBefore(ctx, publishedBefore)
+}
+
+//////////////////
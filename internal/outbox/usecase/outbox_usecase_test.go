@@ -0,0 +1,152 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/radyatamaa/dating-apps-api/internal/domain"
+	"github.com/radyatamaa/dating-apps-api/internal/domain/mocks"
+	"github.com/radyatamaa/dating-apps-api/pkg/event"
+	mockEvent "github.com/radyatamaa/dating-apps-api/pkg/event/mocks"
+	mockZaplogger "github.com/radyatamaa/dating-apps-api/pkg/zaplogger/mocks"
+	"github.com/stretchr/testify/suite"
+)
+
+type OutboxUseCaseTestSuite struct {
+	suite.Suite
+}
+
+type fields struct {
+	zapLogger             *mockZaplogger.MockLogger
+	mysqlOutboxRepository *mocks.OutboxMysqlRepository
+	broker                *mockEvent.MockBroker
+}
+
+func toField(ctrl *gomock.Controller) fields {
+	return fields{
+		zapLogger:             mockZaplogger.NewMockLogger(ctrl),
+		mysqlOutboxRepository: mocks.NewOutboxMysqlRepository(ctrl),
+		broker:                mockEvent.NewMockBroker(ctrl),
+	}
+}
+
+func (t *OutboxUseCaseTestSuite) TestOutboxUseCase_Publish() {
+	ctrl := gomock.NewController(t.T())
+	defer ctrl.Finish()
+
+	f := toField(ctrl)
+	e, err := event.New(domain.EventUserRegistered, "1", domain.UserRegisteredPayload{UserID: 1, Provider: "password"})
+	t.NoError(err)
+
+	f.mysqlOutboxRepository.EXPECT().Store(gomock.Any(), domain.FromEventToOutboxEvent(e)).Return(nil)
+
+	r := NewOutboxUseCase(f.mysqlOutboxRepository, f.broker, 100, 10, f.zapLogger)
+	t.NoError(r.Publish(context.TODO(), e))
+}
+
+func (t *OutboxUseCaseTestSuite) TestOutboxUseCase_Relay() {
+	outboxEvents := []domain.OutboxEvent{
+		{ID: 1, EventID: "a", Name: domain.EventUserRegistered, EventKey: "1", Payload: `{"user_id":1}`},
+		{ID: 2, EventID: "b", Name: domain.EventMatchCreated, EventKey: "2", Payload: `{"user_id":2}`},
+		{ID: 3, EventID: "c", Name: domain.EventPremiumActivated, EventKey: "1", Payload: `{"user_id":1}`},
+	}
+
+	tests := []struct {
+		name      string
+		mock      func(fields fields)
+		want      int
+		wantError bool
+	}{
+		{
+			name: "success",
+			mock: func(fields fields) {
+				fields.mysqlOutboxRepository.EXPECT().FetchUnpublished(gomock.Any(), 100, 10).Return(outboxEvents, nil)
+				for _, outboxEvent := range outboxEvents {
+					fields.broker.EXPECT().Publish(gomock.Any(), outboxEvent.ToEvent()).Return(nil)
+				}
+				fields.mysqlOutboxRepository.EXPECT().MarkPublished(gomock.Any(), []int{1, 2, 3}, gomock.Any()).Return(nil)
+			},
+			want: 3,
+		},
+		{
+			name: "failed event holds the next events of its key",
+			mock: func(fields fields) {
+				fields.mysqlOutboxRepository.EXPECT().FetchUnpublished(gomock.Any(), 100, 10).Return(outboxEvents, nil)
+				fields.broker.EXPECT().Publish(gomock.Any(), outboxEvents[0].ToEvent()).Return(errors.New("broker unavailable"))
+				fields.zapLogger.EXPECT().Errorw("failed relay outbox event", gomock.Any())
+				fields.mysqlOutboxRepository.EXPECT().MarkFailed(gomock.Any(), []int{1}, "broker unavailable").Return(nil)
+				fields.broker.EXPECT().Publish(gomock.Any(), outboxEvents[1].ToEvent()).Return(nil)
+				fields.mysqlOutboxRepository.EXPECT().MarkPublished(gomock.Any(), []int{2}, gomock.Any()).Return(nil)
+			},
+			want: 1,
+		},
+		{
+			name: "event reaching max attempts is dead",
+			mock: func(fields fields) {
+				dead := outboxEvents[1]
+				dead.Attempts = 9
+				fields.mysqlOutboxRepository.EXPECT().FetchUnpublished(gomock.Any(), 100, 10).Return([]domain.OutboxEvent{dead}, nil)
+				fields.broker.EXPECT().Publish(gomock.Any(), dead.ToEvent()).Return(errors.New("broker unavailable"))
+				fields.zapLogger.EXPECT().Errorw("failed relay outbox event", gomock.Any())
+				fields.mysqlOutboxRepository.EXPECT().MarkFailed(gomock.Any(), []int{2}, "broker unavailable").Return(nil)
+				fields.zapLogger.EXPECT().Errorw("outbox event reached max attempts, the events of its key are blocked", gomock.Any())
+				fields.mysqlOutboxRepository.EXPECT().MarkPublished(gomock.Any(), []int{}, gomock.Any()).Return(nil)
+			},
+			want: 0,
+		},
+		{
+			name: "nothing to relay",
+			mock: func(fields fields) {
+				fields.mysqlOutboxRepository.EXPECT().FetchUnpublished(gomock.Any(), 100, 10).Return(nil, nil)
+				fields.mysqlOutboxRepository.EXPECT().MarkPublished(gomock.Any(), []int{}, gomock.Any()).Return(nil)
+			},
+			want: 0,
+		},
+		{
+			name: "error FetchUnpublished",
+			mock: func(fields fields) {
+				fields.mysqlOutboxRepository.EXPECT().FetchUnpublished(gomock.Any(), 100, 10).Return(nil, errors.New("context deadline exceeded"))
+			},
+			wantError: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func() {
+			ctrl := gomock.NewController(t.T())
+			defer ctrl.Finish()
+
+			f := toField(ctrl)
+			tt.mock(f)
+
+			r := NewOutboxUseCase(f.mysqlOutboxRepository, f.broker, 100, 10, f.zapLogger)
+			got, err := r.Relay(context.TODO())
+			if tt.wantError {
+				t.Error(err)
+				return
+			}
+			t.NoError(err)
+			t.Equal(tt.want, got)
+		})
+	}
+}
+
+func (t *OutboxUseCaseTestSuite) TestOutboxUseCase_PurgePublishedEvents() {
+	ctrl := gomock.NewController(t.T())
+	defer ctrl.Finish()
+
+	f := toField(ctrl)
+	publishedBefore := time.Now()
+	f.mysqlOutboxRepository.EXPECT().DeletePublishedBefore(gomock.Any(), publishedBefore).Return(int64(2), nil)
+
+	r := NewOutboxUseCase(f.mysqlOutboxRepository, f.broker, 100, 10, f.zapLogger)
+	got, err := r.PurgePublishedEvents(context.TODO(), publishedBefore)
+	t.NoError(err)
+	t.Equal(int64(2), got)
+}
+
+func TestOutboxUseCaseTestSuite(t *testing.T) {
+	suite.Run(t, new(OutboxUseCaseTestSuite))
+}
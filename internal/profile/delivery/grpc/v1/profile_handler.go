@@ -0,0 +1,84 @@
+package v1
+
+import (
+	"context"
+	"strconv"
+
+	pb "github.com/radyatamaa/dating-apps-api/api/proto/v1"
+	"github.com/radyatamaa/dating-apps-api/internal/domain"
+	"github.com/radyatamaa/dating-apps-api/internal/profile"
+	"github.com/radyatamaa/dating-apps-api/pkg/database/paginator"
+	"github.com/radyatamaa/dating-apps-api/pkg/validator"
+	"github.com/radyatamaa/dating-apps-api/pkg/zaplogger"
+	"google.golang.org/grpc"
+)
+
+// ProfileHandler is the grpc api of profile.UseCase, the errors are mapped to their status by the interceptors.
+type ProfileHandler struct {
+	pb.UnimplementedProfileServiceServer
+	ZapLogger zaplogger.Logger
+	Usecase   profile.UseCase
+}
+
+func NewProfileHandler(server *grpc.Server, useCase profile.UseCase, zapLogger zaplogger.Logger) {
+	pb.RegisterProfileServiceServer(server, &ProfileHandler{
+		ZapLogger: zapLogger,
+		Usecase:   useCase,
+	})
+}
+
+func (h *ProfileHandler) GetProfiles(ctx context.Context, req *pb.GetProfilesRequest) (*pb.GetProfilesResponse, error) {
+	// the zero page and page size are the defaults like the rest api without query params
+	pageSize, page, err := paginator.PaginationQueryParamValidation(strconv.FormatInt(req.GetPageSize(), 10), strconv.FormatInt(req.GetPage(), 10))
+	if err != nil {
+		return nil, err
+	}
+	limit, page, offset := paginator.Pagination(page, pageSize)
+
+	result, err := h.Usecase.GetProfiles(ctx, page, limit, offset, req.GetLatitude(), req.GetLongitude())
+	if err != nil {
+		return nil, err
+	}
+
+	profiles := make([]*pb.Profile, 0, len(result.Data))
+	for _, item := range result.Data {
+		profiles = append(profiles, &pb.Profile{
+			Id:       int64(item.Id),
+			Name:     item.Name,
+			Photo:    item.Photo,
+			Age:      int64(item.Age),
+			Bio:      item.Bio,
+			Verified: item.Verified,
+			Distance: item.Distance,
+		})
+	}
+	return &pb.GetProfilesResponse{
+		Data: profiles,
+		Paginator: &pb.Paginator{
+			CurrentPage:  int64(result.Paginator.CurrentPage),
+			LimitPerPage: int64(result.Paginator.PerPage),
+			BackPage:     int64(result.Paginator.PreviousPage),
+			NextPage:     int64(result.Paginator.NextPage),
+			TotalRecords: int64(result.Paginator.TotalRecords),
+			TotalPages:   int64(result.Paginator.TotalPages),
+			TotalExact:   result.Paginator.TotalExact,
+			HasNext:      result.Paginator.HasNext,
+		},
+	}, nil
+}
+
+func (h *ProfileHandler) UpdateLiveLocation(ctx context.Context, req *pb.UpdateLiveLocationRequest) (*pb.UpdateLiveLocationResponse, error) {
+	request := domain.UpdateLiveLocationProfilesRequest{
+		Longitude: req.GetLongitude(),
+		Latitude:  req.GetLatitude(),
+	}
+	if err := validator.Validate.ValidateStruct(&request); err != nil {
+		zaplogger.SetStackTrace(ctx, h.ZapLogger.SetMessageLog(err))
+		return nil, err
+	}
+
+	if err := h.Usecase.UpdateLiveLocationProfiles(ctx, request); err != nil {
+		return nil, err
+	}
+	return &pb.UpdateLiveLocationResponse{}, nil
+}
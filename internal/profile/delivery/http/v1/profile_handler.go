@@ -1,8 +1,6 @@
 package v1
 
 import (
-	"context"
-	"errors"
 	beego "github.com/beego/beego/v2/server/web"
 	"github.com/radyatamaa/dating-apps-api/internal"
 	"github.com/radyatamaa/dating-apps-api/internal/domain"
@@ -58,13 +56,9 @@ func (h *ProfileHandler) GetProfiles() {
 	}
 	limit, page, offset := paginator.Pagination(page, pageSize)
 
-	result, err := h.Usecase.GetProfiles(h.Ctx, page, limit, offset,h.Ctx.Input.Query("latitude"),h.Ctx.Input.Query("longitude"))
+	result, err := h.Usecase.GetProfiles(h.Ctx.Request.Context(), page, limit, offset,h.Ctx.Input.Query("latitude"),h.Ctx.Input.Query("longitude"))
 	if err != nil {
-		if errors.Is(err, context.DeadlineExceeded) {
-			h.ResponseError(h.Ctx, http.StatusRequestTimeout, response.RequestTimeoutCodeError, response.ErrorCodeText(response.RequestTimeoutCodeError, h.Locale.Lang), err)
-			return
-		}
-		h.ResponseError(h.Ctx, http.StatusInternalServerError, response.ServerErrorCode, response.ErrorCodeText(response.ServerErrorCode, h.Locale.Lang), err)
+		h.Fail(err)
 		return
 	}
 	h.Ok(h.Ctx, h.Tr("message.success"), result)
@@ -98,13 +92,9 @@ func (h *ProfileHandler) UpdateLiveLocationProfiles() {
 		return
 	}
 
-	err := h.Usecase.UpdateLiveLocationProfiles(h.Ctx, request)
+	err := h.Usecase.UpdateLiveLocationProfiles(h.Ctx.Request.Context(), request)
 	if err != nil {
-		if errors.Is(err, context.DeadlineExceeded) {
-			h.ResponseError(h.Ctx, http.StatusRequestTimeout, response.RequestTimeoutCodeError, response.ErrorCodeText(response.RequestTimeoutCodeError, h.Locale.Lang), err)
-			return
-		}
-		h.ResponseError(h.Ctx, http.StatusInternalServerError, response.ServerErrorCode, response.ErrorCodeText(response.ServerErrorCode, h.Locale.Lang), err)
+		h.Fail(err)
 		return
 	}
 	h.Ok(h.Ctx, h.Tr("message.success"), nil)
@@ -3,123 +3,50 @@ package repository
 import (
 	"context"
 	"github.com/radyatamaa/dating-apps-api/internal/profile"
-	"strings"
+	"time"
 
 	"github.com/radyatamaa/dating-apps-api/internal/domain"
-	"github.com/radyatamaa/dating-apps-api/pkg/database/paginator"
+	"github.com/radyatamaa/dating-apps-api/pkg/database"
+	baseRepository "github.com/radyatamaa/dating-apps-api/pkg/database/repository"
 	"github.com/radyatamaa/dating-apps-api/pkg/zaplogger"
 	"gorm.io/gorm"
 )
 
 type mysqlRepository struct {
+	baseRepository.Repository
 	zapLogger zaplogger.Logger
-	db        *gorm.DB
 }
 
 func NewMysqlRepository(db *gorm.DB, zapLogger zaplogger.Logger) profile.MysqlRepository {
 	return &mysqlRepository{
-		db:        db,
-		zapLogger: zapLogger,
+		Repository: baseRepository.New(db, domain.Profile{}, domain.User{}),
+		zapLogger:  zapLogger,
 	}
 }
 
-func (c mysqlRepository) DB() *gorm.DB {
-	return c.db
-}
-
-func (c mysqlRepository) FetchWithFilterAndPagination(ctx context.Context, limit int, offset int, order string, fields, associate, filter []string, model interface{}, args ...interface{}) (*paginator.Paginator, error) {
-	p := paginator.NewPaginator(c.db, offset, limit, model)
-	if err := p.FindWithFilter(ctx, order, fields, associate, filter, args...).Select(strings.Join(fields, ",")).Error; err != nil {
-		return p, err
-	}
-	return p, nil
-}
-
-func (c mysqlRepository) FetchWithFilter(ctx context.Context, limit int, offset int, order string, fields, associate, filter []string, model interface{}, args ...interface{}) (interface{}, error) {
-	p := paginator.NewPaginator(c.db, offset, limit, model)
-	if err := p.FindWithFilter(ctx, order, fields, associate, filter, args).Select(strings.Join(fields, ",")).Error; err != nil {
-		return nil, err
-	}
-	return model, nil
-}
-
-func (c mysqlRepository) SingleWithFilter(ctx context.Context, fields, associate, filter []string, model interface{}, args ...interface{}) error {
-
-	db := c.db.WithContext(ctx)
-
-	if len(fields) > 0 {
-		db = db.Select(strings.Join(fields, ","))
-	}
-	if len(associate) > 0 {
-		for _, v := range associate {
-			db.Joins(v)
-		}
-	}
-
-	if len(filter) > 0 && len(args) == len(filter) {
-		for i := range filter {
-			db = db.Where(filter[i], args[i])
-		}
-	}
-
-	if err := db.First(model).Error; err != nil {
-		return err
-	}
-	return nil
-}
-
 func (c mysqlRepository) Update(ctx context.Context, data domain.Profile) error {
-
-	err := c.db.WithContext(ctx).Updates(&data).Error
-	if err != nil {
-		return err
-	}
-	return nil
-}
-
-func (c mysqlRepository) UpdateSelectedField(ctx context.Context, field []string, values map[string]interface{}, id int) error {
-
-	return c.db.WithContext(ctx).Table(domain.Profile{}.TableName()).Select(field).Where("id =?", id).Updates(values).Error
+	return c.Updates(ctx, &data)
 }
 
 func (c mysqlRepository) Store(ctx context.Context, data domain.Profile) (domain.Profile, error) {
 
-	err := c.db.WithContext(ctx).Create(&data).Error
+	err := c.Create(ctx, &data)
 	if err != nil {
 		return data, err
 	}
 	return data, nil
 }
 
-func (c mysqlRepository) Delete(ctx context.Context, id int) (int, error) {
-
-	err := c.db.WithContext(ctx).Exec("delete from "+domain.Profile{}.TableName()+" where id =?", id).Error
-	if err != nil {
-		return id, err
-	}
-	return id, nil
-}
-
-func (c mysqlRepository) SoftDelete(ctx context.Context, id int) (int, error) {
-	var data domain.Profile
+// RecomputeRecommendationScores sets the score of every profile to the number of likes received since
+// likedSince, plus 2 with a photo and 1 with a bio, and returns the number of profiles updated.
+func (c mysqlRepository) RecomputeRecommendationScores(ctx context.Context, likedSince time.Time) (int64, error) {
 
-	err := c.db.WithContext(ctx).Where("id = ?", id).Delete(&data).Error
-	if err != nil {
-		return id, err
-	}
-	return id, nil
-}
-
-func (c mysqlRepository) UpdateSelectedFieldWithTx(ctx context.Context, tx *gorm.DB, field []string, values map[string]interface{}, id int) error {
-
-	return tx.WithContext(ctx).Table(domain.Profile{}.TableName()).Select(field).Where("id =?", id).Updates(values).Error
-}
-
-func (c mysqlRepository) StoreWithTx(ctx context.Context, tx *gorm.DB, data domain.Profile) (int, error) {
-
-	err := tx.WithContext(ctx).Create(&data).Error
-	if err != nil {
-		return data.ID, err
+	result := database.FromContext(ctx, c.DB()).Exec(`UPDATE profile SET recommendation_score =
+		(SELECT COUNT(*) FROM swipes WHERE swipes.profile_id = profile.id AND swipes.swipe_type = 'LIKE' AND swipes.updated_at >= ?) +
+		CASE WHEN profile.photo <> '' THEN 2 ELSE 0 END +
+		CASE WHEN profile.bio <> '' THEN 1 ELSE 0 END`, likedSince)
+	if result.Error != nil {
+		return 0, result.Error
 	}
-	return data.ID, nil
+	return result.RowsAffected, nil
 }
@@ -1,12 +1,16 @@
 package profile
 
 import (
-	beegoContext "github.com/beego/beego/v2/server/web/context"
+	"context"
+	"time"
+
 	"github.com/radyatamaa/dating-apps-api/internal/domain"
 )
 
 // UseCase Interface
 type UseCase interface {
-	GetProfiles(beegoCtx *beegoContext.Context, page, limit, offset int,latitude,longitude string)(*domain.GetProfilesResponsePaginationResponse, error)
-	UpdateLiveLocationProfiles(beegoCtx *beegoContext.Context, request domain.UpdateLiveLocationProfilesRequest) error
+	GetProfiles(ctx context.Context, page, limit, offset int,latitude,longitude string)(*domain.GetProfilesResponsePaginationResponse, error)
+	UpdateLiveLocationProfiles(ctx context.Context, request domain.UpdateLiveLocationProfilesRequest) error
+	CleanOrphanedPhotos(ctx context.Context, dir string, modifiedBefore time.Time) (int, error)
+	RecomputeRecommendationScores(ctx context.Context, likedSince time.Time) (int64, error)
 }
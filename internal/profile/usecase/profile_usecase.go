@@ -2,23 +2,31 @@ package usecase
 
 import (
 	"context"
-	"fmt"
-	beegoContext "github.com/beego/beego/v2/server/web/context"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+	"github.com/radyatamaa/dating-apps-api/internal/block"
 	"github.com/radyatamaa/dating-apps-api/internal/domain"
 	"github.com/radyatamaa/dating-apps-api/internal/profile"
 	"github.com/radyatamaa/dating-apps-api/internal/swipe"
+	"github.com/radyatamaa/dating-apps-api/pkg/authuser"
+	"github.com/radyatamaa/dating-apps-api/pkg/database"
 	"github.com/radyatamaa/dating-apps-api/pkg/database/paginator"
 	"github.com/radyatamaa/dating-apps-api/pkg/helper"
-	"github.com/radyatamaa/dating-apps-api/pkg/jwt"
+	"github.com/radyatamaa/dating-apps-api/pkg/tracing"
 	"github.com/radyatamaa/dating-apps-api/pkg/zaplogger"
 	"time"
 )
 
+const orphanedPhotoBatchSize = 100
+
 type profileUseCase struct {
 	zapLogger                  zaplogger.Logger
 	contextTimeout             time.Duration
 	mysqlProfileRepository    profile.MysqlRepository
 	mysqlSwipeRepository    swipe.MysqlRepository
+	mysqlBlockRepository    block.MysqlRepository
 }
 
 
@@ -26,9 +34,11 @@ type profileUseCase struct {
 func NewProfileUseCase(timeout time.Duration,
 	mysqlProfileRepository    profile.MysqlRepository,
 	mysqlSwipeRepository    swipe.MysqlRepository,
+	mysqlBlockRepository    block.MysqlRepository,
 	zapLogger zaplogger.Logger) profile.UseCase {
 	return &profileUseCase{
 		mysqlSwipeRepository:mysqlSwipeRepository,
+		mysqlBlockRepository:mysqlBlockRepository,
 		mysqlProfileRepository:    mysqlProfileRepository,
 		contextTimeout:             timeout,
 		zapLogger:                  zapLogger,
@@ -36,39 +46,41 @@ func NewProfileUseCase(timeout time.Duration,
 }
 
 /////////////////// GetProfiles
-func (r profileUseCase) fetchProfileWithFilterAndPagination(ctx context.Context, limit, offset int, fields []string,filter []string, order string, args ...interface{}) (*paginator.Paginator, error) {
+func (r profileUseCase) fetchProfileWithFilterAndPagination(ctx context.Context, limit, offset int, query *database.Query) (*paginator.Paginator, error) {
+	ctx, span := tracing.Start(ctx, "profileUseCase.fetchProfileWithFilterAndPagination")
+	defer span.End()
+
 	var entity []domain.ProfileQueryWithUser
 	paging, err := r.mysqlProfileRepository.FetchWithFilterAndPagination(
 		ctx,
 		limit,
 		offset,
-		order,
-		fields,
-		[]string{
-			"INNER JOIN users ON users.id = profile.id",
-		},
-		filter,
-		&entity, args...,
+		// deleted accounts are hidden until they are purged, moderated accounts until they are restored
+		query.Join(database.InnerJoin("users",
+			database.EqColumn("users.id", "profile.user_id"),
+			database.IsNull("users.deleted_at"),
+			database.IsNull("users.hidden_at"),
+			database.IsNull("users.banned_at"),
+			database.Or(database.IsNull("users.suspended_until"), database.Lt("users.suspended_until", time.Now())),
+		)),
+		&entity,
 	)
 	if err != nil {
+		tracing.RecordError(span, err)
 		return nil, err
 	}
 
 	return paging, nil
 }
-func (r profileUseCase) fetchSwipeWithFilter(ctx context.Context, limit, offset int, filter []string, args ...interface{}) ([]domain.Swipe, error) {
+func (r profileUseCase) fetchSwipeWithFilter(ctx context.Context, criteria ...database.Criterion) ([]domain.Swipe, error) {
+	ctx, span := tracing.Start(ctx, "profileUseCase.fetchSwipeWithFilter")
+	defer span.End()
 
 	if data, err := r.mysqlSwipeRepository.FetchWithFilter(
 		ctx,
-		limit,
-		offset,
-		"id ASC",
-		[]string{
-			"*",
-		},
-		[]string{},
-		filter,
-		&[]domain.Swipe{}, args); err != nil {
+		database.NewQuery().Where(criteria...).OrderBy(database.Asc("id")),
+		&[]domain.Swipe{}); err != nil {
+		tracing.RecordError(span, err)
 		return nil, err
 	} else {
 		if result, ok := data.(*[]domain.Swipe); !ok {
@@ -78,53 +90,68 @@ func (r profileUseCase) fetchSwipeWithFilter(ctx context.Context, limit, offset
 		}
 	}
 }
-func (p profileUseCase) GetProfiles(beegoCtx *beegoContext.Context, page, limit, offset int,latitude,longitude string) (*domain.GetProfilesResponsePaginationResponse, error) {
-	ctx, cancel := context.WithTimeout(beegoCtx.Request.Context(), p.contextTimeout)
+func (p profileUseCase) GetProfiles(ctx context.Context, page, limit, offset int,latitude,longitude string) (*domain.GetProfilesResponsePaginationResponse, error) {
+	ctx, cancel := context.WithTimeout(ctx, p.contextTimeout)
 	defer cancel()
+	ctx, span := tracing.Start(ctx, "profileUseCase.GetProfiles")
+	defer span.End()
 
-	userLogin := beegoCtx.Request.Context().Value("JWT_PAYLOAD").(jwt.Payload)
+	authUser, err := authuser.FromContext(ctx)
+	if err != nil {
+		zaplogger.SetStackTrace(ctx, p.zapLogger.SetMessageLog(err))
+		return nil, err
+	}
 
-	fetchSwipes, err := p.fetchSwipeWithFilter(ctx, 0, 0,
-		[]string{"user_id = ?"},
-		userLogin["uid"].(float64))
+	fetchSwipes, err := p.fetchSwipeWithFilter(ctx, database.Eq("user_id", authUser.ID))
 	if err != nil {
-		beegoCtx.Input.SetData("stackTrace", p.zapLogger.SetMessageLog(err))
+		zaplogger.SetStackTrace(ctx, p.zapLogger.SetMessageLog(err))
 		return nil, err
 	}
 
-	excludeProfileId := []int{int(userLogin["profile_id"].(float64))}
+	excludeProfileId := []int{authUser.ProfileID}
 	for i := range fetchSwipes {
 		if fetchSwipes[i].UpdatedAt.Format(helper.DateFormatDefault) == time.Now().Format(helper.DateFormatDefault) ||
-			fetchSwipes[i].SwipeType == "LIKE"{
+			fetchSwipes[i].SwipeType == "LIKE" || fetchSwipes[i].SwipeType == "UNMATCH"{
 			excludeProfileId = append(excludeProfileId,fetchSwipes[i].ProfileID)
 		}
 	}
 
-	filters := make([]string,0)
-	args := make([]interface{}, 0)
-	fields := []string{
+	// blocks hide both users from each other
+	blockedUserIds, err := p.mysqlBlockRepository.FetchBlockedUserIds(ctx, authUser.ID)
+	if err != nil {
+		zaplogger.SetStackTrace(ctx, p.zapLogger.SetMessageLog(err))
+		return nil, err
+	}
+
+	// the feed pages through the whole profile table, its total is estimated instead of counted
+	// and the exact count of a small feed is cached
+	query := database.NewQuery().Select(
 		"profile.*",
 		"users.premium_expires_at",
-	}
-	order := "RAND()"
+	).Total(database.TotalEstimate).CacheTotal()
 	if len(excludeProfileId) > 0 {
-		filters = append(filters,"profile.id not in (?)")
-		args = append(args,excludeProfileId)
+		query.Where(database.NotIn("profile.id", excludeProfileId))
+	}
+	if len(blockedUserIds) > 0 {
+		query.Where(database.NotIn("profile.user_id", blockedUserIds))
 	}
 
 	if latitude != "" && longitude != "" {
-		fields = append(fields,fmt.Sprintf(`(6371 * 
- 		acos(cos(radians(%s)) * 
-		cos(radians(latitude)) * 
-		cos(radians(longitude) - 
-		radians(%s)) + 
-		sin(radians(%s)) * sin(radians(latitude)))) AS distance`,latitude,longitude,latitude))
-		order = "distance"
+		// the coordinates of the request are bound, never formatted into the sql
+		query.SelectExpr("distance", `6371 *
+		acos(cos(radians(?)) *
+		cos(radians(profile.latitude)) *
+		cos(radians(profile.longitude) -
+		radians(?)) +
+		sin(radians(?)) * sin(radians(profile.latitude)))`, latitude, longitude, latitude).
+			OrderBy(database.Asc("distance"))
+	} else {
+		query.OrderBy(database.Desc("profile.recommendation_score"), database.Random())
 	}
 
-	fetchProfiles, err := p.fetchProfileWithFilterAndPagination(ctx, limit, offset,fields,filters , order, args...)
+	fetchProfiles, err := p.fetchProfileWithFilterAndPagination(ctx, limit, offset, query)
 	if err != nil {
-		beegoCtx.Input.SetData("stackTrace", p.zapLogger.SetMessageLog(err))
+		zaplogger.SetStackTrace(ctx, p.zapLogger.SetMessageLog(err))
 		return nil, err
 	}
 
@@ -136,19 +163,25 @@ func (p profileUseCase) GetProfiles(beegoCtx *beegoContext.Context, page, limit,
 		}
 	}
 
-	result := domain.ToGetProfilesResponsePaginationResponsee(datas, page, limit, offset, int(fetchProfiles.Total))
+	result := domain.ToGetProfilesResponsePaginationResponsee(datas, page, limit, offset, fetchProfiles)
 
 	return result,nil
 }
 //////////////////
 
-func (r profileUseCase) UpdateLiveLocationProfiles(beegoCtx *beegoContext.Context, request domain.UpdateLiveLocationProfilesRequest) error {
-	ctx, cancel := context.WithTimeout(beegoCtx.Request.Context(), r.contextTimeout)
+func (r profileUseCase) UpdateLiveLocationProfiles(ctx context.Context, request domain.UpdateLiveLocationProfilesRequest) error {
+	ctx, cancel := context.WithTimeout(ctx, r.contextTimeout)
 	defer cancel()
+	ctx, span := tracing.Start(ctx, "profileUseCase.UpdateLiveLocationProfiles")
+	defer span.End()
 
-	userLogin := beegoCtx.Request.Context().Value("JWT_PAYLOAD").(jwt.Payload)
+	authUser, err := authuser.FromContext(ctx)
+	if err != nil {
+		zaplogger.SetStackTrace(ctx, r.zapLogger.SetMessageLog(err))
+		return err
+	}
 
-	err := r.mysqlProfileRepository.UpdateSelectedField(ctx,[]string{
+	err = r.mysqlProfileRepository.UpdateSelectedField(ctx,[]string{
 		"longitude",
 		"latitude",
 		"updated_at",
@@ -156,11 +189,89 @@ func (r profileUseCase) UpdateLiveLocationProfiles(beegoCtx *beegoContext.Contex
 		"longitude": request.Longitude,
 		"latitude": request.Latitude,
 		"updated_at": time.Now(),
-	},int(userLogin["uid"].(float64)))
+	},authUser.ID)
 	if err != nil {
-		beegoCtx.Input.SetData("stackTrace", r.zapLogger.SetMessageLog(err))
+		zaplogger.SetStackTrace(ctx, r.zapLogger.SetMessageLog(err))
 		return err
 	}
 
 	return nil
-}
\ No newline at end of file
+}
+
+/////////////////// CleanOrphanedPhotos
+// CleanOrphanedPhotos removes the files of dir modified before modifiedBefore which aren't the photo
+// of a profile, ex: the photo replaced by an update, and returns the number removed. The files
+// modified after modifiedBefore are kept as their profile could still be stored.
+func (r profileUseCase) CleanOrphanedPhotos(ctx context.Context, dir string, modifiedBefore time.Time) (int, error) {
+	ctx, span := tracing.Start(ctx, "profileUseCase.CleanOrphanedPhotos")
+	defer span.End()
+
+	entries, err := os.ReadDir(dir)
+	if os.IsNotExist(err) {
+		return 0, nil
+	}
+	if err != nil {
+		return 0, err
+	}
+
+	names := make([]string, 0, len(entries))
+	for _, entry := range entries {
+		if !entry.Type().IsRegular() || strings.HasPrefix(entry.Name(), ".") {
+			continue
+		}
+		info, err := entry.Info()
+		if err != nil {
+			continue
+		}
+		if info.ModTime().Before(modifiedBefore) {
+			names = append(names, entry.Name())
+		}
+	}
+
+	removed := 0
+	for start := 0; start < len(names); start += orphanedPhotoBatchSize {
+		end := start + orphanedPhotoBatchSize
+		if end > len(names) {
+			end = len(names)
+		}
+		batch := names[start:end]
+
+		criteria := make([]database.Criterion, 0, len(batch))
+		for _, name := range batch {
+			criteria = append(criteria, database.Like("photo", "%/"+helper.StoragePath+"/"+name))
+		}
+		var profiles []domain.Profile
+		if _, err := r.mysqlProfileRepository.FetchWithFilter(ctx, database.NewQuery().
+			Select("photo").
+			Where(database.Or(criteria...)), &profiles); err != nil {
+			return removed, err
+		}
+
+		used := make(map[string]bool, len(profiles))
+		for i := range profiles {
+			used[path.Base(profiles[i].Photo)] = true
+		}
+		for _, name := range batch {
+			if used[name] {
+				continue
+			}
+			if err := os.Remove(filepath.Join(dir, name)); err != nil && !os.IsNotExist(err) {
+				return removed, err
+			}
+			removed++
+		}
+	}
+
+	return removed, nil
+}
+//////////////////
+
+/////////////////// RecomputeRecommendationScores
+// RecomputeRecommendationScores recomputes the score ordering the feed from the likes received since likedSince.
+func (r profileUseCase) RecomputeRecommendationScores(ctx context.Context, likedSince time.Time) (int64, error) {
+	ctx, span := tracing.Start(ctx, "profileUseCase.RecomputeRecommendationScores")
+	defer span.End()
+
+	return r.mysqlProfileRepository.RecomputeRecommendationScores(ctx, likedSince)
+}
+//////////////////
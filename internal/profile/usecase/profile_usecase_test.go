@@ -0,0 +1,124 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/radyatamaa/dating-apps-api/internal/domain"
+	"github.com/radyatamaa/dating-apps-api/internal/domain/mocks"
+	"github.com/radyatamaa/dating-apps-api/pkg/database"
+	"github.com/radyatamaa/dating-apps-api/pkg/helper"
+	mockZaplogger "github.com/radyatamaa/dating-apps-api/pkg/zaplogger/mocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/suite"
+)
+
+type ProfileUseCaseTestSuite struct {
+	suite.Suite
+}
+
+type fields struct {
+	zapLogger              *mockZaplogger.MockLogger
+	contextTimeout         time.Duration
+	mysqlProfileRepository *mocks.ProfileMysqlRepository
+	mysqlSwipeRepository   *mocks.SwipeMysqlRepository
+	mysqlBlockRepository   *mocks.BlockMysqlRepository
+}
+
+func toField(ctrl *gomock.Controller) fields {
+	return fields{
+		zapLogger:              mockZaplogger.NewMockLogger(ctrl),
+		contextTimeout:         time.Second * 30,
+		mysqlProfileRepository: mocks.NewProfileMysqlRepository(ctrl),
+		mysqlSwipeRepository:   mocks.NewSwipeMysqlRepository(ctrl),
+		mysqlBlockRepository:   mocks.NewBlockMysqlRepository(ctrl),
+	}
+}
+
+func (t *ProfileUseCaseTestSuite) TestProfileUseCase_CleanOrphanedPhotos() {
+	modifiedBefore := time.Now().Add(-24 * time.Hour)
+	query := database.NewQuery().
+		Select("photo").
+		Where(database.Or(
+			database.Like("photo", "%/"+helper.StoragePath+"/orphaned.jpeg"),
+			database.Like("photo", "%/"+helper.StoragePath+"/used.jpeg"),
+		))
+
+	tests := []struct {
+		name      string
+		fields    func(ctrl *gomock.Controller) fields
+		want      int
+		wantFiles []string
+		wantErr   assert.ErrorAssertionFunc
+	}{
+		{
+			name:    "success",
+			wantErr: assert.NoError,
+			fields: func(ctrl *gomock.Controller) fields {
+				fields := toField(ctrl)
+				fields.mysqlProfileRepository.EXPECT().FetchWithFilter(gomock.Any(), query, gomock.Any()).
+					SetArg(2, []domain.Profile{{Photo: "http://localhost:8082/" + helper.StoragePath + "/used.jpeg"}}).
+					Return(nil, nil)
+				return fields
+			},
+			want:      1,
+			wantFiles: []string{".gitkeep", "recent.jpeg", "used.jpeg"},
+		},
+		{
+			name:    "error FetchWithFilter",
+			wantErr: assert.Error,
+			fields: func(ctrl *gomock.Controller) fields {
+				fields := toField(ctrl)
+				fields.mysqlProfileRepository.EXPECT().FetchWithFilter(gomock.Any(), query, gomock.Any()).
+					Return(nil, errors.New("context deadline exceeded"))
+				return fields
+			},
+			want:      0,
+			wantFiles: []string{".gitkeep", "orphaned.jpeg", "recent.jpeg", "used.jpeg"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func() {
+			ctrl := gomock.NewController(t.T())
+			defer ctrl.Finish()
+
+			dir := t.T().TempDir()
+			for _, name := range []string{".gitkeep", "orphaned.jpeg", "used.jpeg", "recent.jpeg"} {
+				t.Require().NoError(os.WriteFile(filepath.Join(dir, name), []byte("jpeg"), 0644))
+				if name != "recent.jpeg" {
+					t.Require().NoError(os.Chtimes(filepath.Join(dir, name), modifiedBefore.Add(-time.Hour), modifiedBefore.Add(-time.Hour)))
+				}
+			}
+
+			fields := tt.fields(ctrl)
+			r := NewProfileUseCase(fields.contextTimeout, fields.mysqlProfileRepository, fields.mysqlSwipeRepository, fields.mysqlBlockRepository, fields.zapLogger)
+			got, err := r.CleanOrphanedPhotos(context.TODO(), dir, modifiedBefore)
+			tt.wantErr(t.T(), err)
+			t.Equal(tt.want, got)
+
+			entries, err := os.ReadDir(dir)
+			t.Require().NoError(err)
+			files := make([]string, 0, len(entries))
+			for _, entry := range entries {
+				files = append(files, entry.Name())
+			}
+			t.Equal(tt.wantFiles, files)
+		})
+	}
+
+	t.Run("without dir", func() {
+		r := NewProfileUseCase(time.Second, nil, nil, nil, nil)
+		got, err := r.CleanOrphanedPhotos(context.TODO(), filepath.Join(t.T().TempDir(), "storage"), modifiedBefore)
+		t.NoError(err)
+		t.Equal(0, got)
+	})
+}
+
+func TestProfileUseCaseTestSuite(t *testing.T) {
+	suite.Run(t, new(ProfileUseCaseTestSuite))
+}
@@ -0,0 +1,190 @@
+package v1
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/radyatamaa/dating-apps-api/internal/report"
+	"github.com/radyatamaa/dating-apps-api/pkg/database/paginator"
+	"github.com/radyatamaa/dating-apps-api/pkg/validator"
+
+	beego "github.com/beego/beego/v2/server/web"
+	"github.com/radyatamaa/dating-apps-api/internal"
+	"github.com/radyatamaa/dating-apps-api/internal/domain"
+	"github.com/radyatamaa/dating-apps-api/pkg/response"
+	"github.com/radyatamaa/dating-apps-api/pkg/zaplogger"
+)
+
+type ReportHandler struct {
+	ZapLogger zaplogger.Logger
+	internal.BaseController
+	response.ApiResponse
+	Usecase report.UseCase
+}
+
+func NewReportHandler(useCase report.UseCase, zapLogger zaplogger.Logger) {
+	pHandler := &ReportHandler{
+		ZapLogger: zapLogger,
+		Usecase:   useCase,
+	}
+	beego.Router("/api/v1/report", pHandler, "post:CreateReport")
+	beego.Router("/api/admin/v1/reports", pHandler, "get:GetReports")
+	beego.Router("/api/admin/v1/reports/:id", pHandler, "get:GetReport")
+	beego.Router("/api/admin/v1/reports/:id/status", pHandler, "put:UpdateReportStatus")
+}
+
+func (h *ReportHandler) Prepare() {
+	// check user access when needed
+	h.SetLangVersion()
+}
+
+// CreateReport
+// @Title CreateReport
+// @Tags Report
+// @Summary CreateReport reports an abusive profile to the moderation queue
+// @Produce json
+// @Security ApiKeyAuth
+// @Param Accept-Language header string false "lang"
+// @Success 200 {object} swagger.BaseResponse{errors=[]object,data=object}
+// @Failure 400 {object} swagger.BadRequestErrorValidationResponse{errors=[]swagger.ValidationErrors,data=object}
+// @Failure 408 {object} swagger.RequestTimeoutResponse{errors=[]object,data=object}
+// @Failure 500 {object} swagger.InternalServerErrorResponse{errors=[]object,data=object}
+// @Param body body domain.CreateReportRequest true "request payload"
+// @Router /v1/report [post]
+func (h *ReportHandler) CreateReport() {
+	var request domain.CreateReportRequest
+
+	if err := h.BindJSON(&request); err != nil {
+		h.Ctx.Input.SetData("stackTrace", h.ZapLogger.SetMessageLog(err))
+		h.ResponseError(h.Ctx, http.StatusBadRequest, response.ApiValidationCodeError, response.ErrorCodeText(response.ApiValidationCodeError, h.Locale.Lang), err)
+		return
+	}
+	if err := validator.Validate.ValidateStruct(&request); err != nil {
+		h.Ctx.Input.SetData("stackTrace", h.ZapLogger.SetMessageLog(err))
+		h.ResponseError(h.Ctx, http.StatusBadRequest, response.ApiValidationCodeError, response.ErrorCodeText(response.ApiValidationCodeError, h.Locale.Lang), err)
+		return
+	}
+
+	err := h.Usecase.CreateReport(h.Ctx.Request.Context(), request)
+	if err != nil {
+		h.Fail(err)
+		return
+	}
+	h.Ok(h.Ctx, h.Tr("message.success"), nil)
+	return
+}
+
+// GetReports
+// @Title GetReports
+// @Tags Admin
+// @Summary GetReports lists the moderation queue
+// @Produce json
+// @Security ApiKeyAuth
+// @Param Accept-Language header string false "lang"
+// @Success 200 {object} swagger.BaseResponse{errors=[]object,data=domain.ReportResponsePaginationResponse}
+// @Failure 400 {object} swagger.BadRequestErrorValidationResponse{errors=[]swagger.ValidationErrors,data=object}
+// @Failure 403 {object} swagger.BaseResponse{errors=[]object,data=object}
+// @Failure 408 {object} swagger.RequestTimeoutResponse{errors=[]object,data=object}
+// @Failure 500 {object} swagger.InternalServerErrorResponse{errors=[]object,data=object}
+// @Param pageSize query int false "page size"
+// @Param page query int false "page"
+// @Param status query string false "OPEN, REVIEWING, ACTIONED or DISMISSED"
+// @Router /admin/v1/reports [get]
+func (h *ReportHandler) GetReports() {
+	pageSize, page, err := paginator.PaginationQueryParamValidation(h.Ctx.Input.Query("pageSize"), h.Ctx.Input.Query("page"))
+	if err != nil {
+		h.ResponseError(h.Ctx, http.StatusBadRequest, response.QueryParamInvalidCode, response.ErrorCodeText(response.QueryParamInvalidCode, h.Locale.Lang), err)
+		return
+	}
+	status := h.Ctx.Input.Query("status")
+	switch status {
+	case "", domain.ReportStatusOpen, domain.ReportStatusReviewing, domain.ReportStatusActioned, domain.ReportStatusDismissed:
+	default:
+		h.ResponseError(h.Ctx, http.StatusBadRequest, response.QueryParamInvalidCode, response.ErrorCodeText(response.QueryParamInvalidCode, h.Locale.Lang), response.ErrQueryParamInvalid)
+		return
+	}
+	limit, page, offset := paginator.Pagination(page, pageSize)
+
+	result, err := h.Usecase.GetReports(h.Ctx.Request.Context(), page, limit, offset, status)
+	if err != nil {
+		h.Fail(err)
+		return
+	}
+	h.Ok(h.Ctx, h.Tr("message.success"), result)
+	return
+}
+
+// GetReport
+// @Title GetReport
+// @Tags Admin
+// @Summary GetReport
+// @Produce json
+// @Security ApiKeyAuth
+// @Param Accept-Language header string false "lang"
+// @Param id path int true "report id"
+// @Success 200 {object} swagger.BaseResponse{errors=[]object,data=domain.ReportResponse}
+// @Failure 400 {object} swagger.BadRequestErrorValidationResponse{errors=[]swagger.ValidationErrors,data=object}
+// @Failure 403 {object} swagger.BaseResponse{errors=[]object,data=object}
+// @Failure 408 {object} swagger.RequestTimeoutResponse{errors=[]object,data=object}
+// @Failure 500 {object} swagger.InternalServerErrorResponse{errors=[]object,data=object}
+// @Router /admin/v1/reports/{id} [get]
+func (h *ReportHandler) GetReport() {
+	id, err := strconv.Atoi(h.Ctx.Input.Param(":id"))
+	if err != nil {
+		h.Ctx.Input.SetData("stackTrace", h.ZapLogger.SetMessageLog(err))
+		h.ResponseError(h.Ctx, http.StatusBadRequest, response.PathParamInvalidCode, response.ErrorCodeText(response.PathParamInvalidCode, h.Locale.Lang), err)
+		return
+	}
+
+	result, err := h.Usecase.GetReport(h.Ctx.Request.Context(), id)
+	if err != nil {
+		h.Fail(err)
+		return
+	}
+	h.Ok(h.Ctx, h.Tr("message.success"), result)
+	return
+}
+
+// UpdateReportStatus
+// @Title UpdateReportStatus
+// @Tags Admin
+// @Summary UpdateReportStatus triages the report
+// @Produce json
+// @Security ApiKeyAuth
+// @Param Accept-Language header string false "lang"
+// @Param id path int true "report id"
+// @Success 200 {object} swagger.BaseResponse{errors=[]object,data=object}
+// @Failure 400 {object} swagger.BadRequestErrorValidationResponse{errors=[]swagger.ValidationErrors,data=object}
+// @Failure 403 {object} swagger.BaseResponse{errors=[]object,data=object}
+// @Failure 408 {object} swagger.RequestTimeoutResponse{errors=[]object,data=object}
+// @Failure 500 {object} swagger.InternalServerErrorResponse{errors=[]object,data=object}
+// @Param body body domain.UpdateReportStatusRequest true "request payload"
+// @Router /admin/v1/reports/{id}/status [put]
+func (h *ReportHandler) UpdateReportStatus() {
+	id, err := strconv.Atoi(h.Ctx.Input.Param(":id"))
+	if err != nil {
+		h.Ctx.Input.SetData("stackTrace", h.ZapLogger.SetMessageLog(err))
+		h.ResponseError(h.Ctx, http.StatusBadRequest, response.PathParamInvalidCode, response.ErrorCodeText(response.PathParamInvalidCode, h.Locale.Lang), err)
+		return
+	}
+
+	var request domain.UpdateReportStatusRequest
+	if err := h.BindJSON(&request); err != nil {
+		h.Ctx.Input.SetData("stackTrace", h.ZapLogger.SetMessageLog(err))
+		h.ResponseError(h.Ctx, http.StatusBadRequest, response.ApiValidationCodeError, response.ErrorCodeText(response.ApiValidationCodeError, h.Locale.Lang), err)
+		return
+	}
+	if err := validator.Validate.ValidateStruct(&request); err != nil {
+		h.Ctx.Input.SetData("stackTrace", h.ZapLogger.SetMessageLog(err))
+		h.ResponseError(h.Ctx, http.StatusBadRequest, response.ApiValidationCodeError, response.ErrorCodeText(response.ApiValidationCodeError, h.Locale.Lang), err)
+		return
+	}
+
+	err = h.Usecase.UpdateReportStatus(h.Ctx.Request.Context(), id, request)
+	if err != nil {
+		h.Fail(err)
+		return
+	}
+	h.Ok(h.Ctx, h.Tr("message.success"), nil)
+	return
+}
@@ -0,0 +1,52 @@
+package repository
+
+import (
+	"context"
+	"github.com/radyatamaa/dating-apps-api/internal/report"
+
+	"github.com/radyatamaa/dating-apps-api/internal/domain"
+	"github.com/radyatamaa/dating-apps-api/pkg/database"
+	baseRepository "github.com/radyatamaa/dating-apps-api/pkg/database/repository"
+	"github.com/radyatamaa/dating-apps-api/pkg/zaplogger"
+	"gorm.io/gorm"
+)
+
+type mysqlRepository struct {
+	baseRepository.Repository
+	zapLogger zaplogger.Logger
+}
+
+func NewMysqlRepository(db *gorm.DB, zapLogger zaplogger.Logger) report.MysqlRepository {
+	return &mysqlRepository{
+		Repository: baseRepository.New(db, domain.Report{}),
+		zapLogger:  zapLogger,
+	}
+}
+
+func (c mysqlRepository) Update(ctx context.Context, data domain.Report) error {
+	return c.Updates(ctx, &data)
+}
+
+func (c mysqlRepository) Store(ctx context.Context, data domain.Report) (domain.Report, error) {
+
+	err := c.Create(ctx, &data)
+	if err != nil {
+		return data, err
+	}
+	return data, nil
+}
+
+// CountDistinctReporters returns the number of distinct users who reported the user with the status.
+func (c mysqlRepository) CountDistinctReporters(ctx context.Context, reportedUserId int, status []string) (int64, error) {
+	var count int64
+
+	err := database.FromContext(ctx, c.DB()).Model(&domain.Report{}).
+		Where("reported_user_id = ?", reportedUserId).
+		Where("status in (?)", status).
+		Distinct("reporter_id").
+		Count(&count).Error
+	if err != nil {
+		return 0, err
+	}
+	return count, nil
+}
@@ -0,0 +1,15 @@
+package report
+
+import (
+	"context"
+
+	"github.com/radyatamaa/dating-apps-api/internal/domain"
+)
+
+// UseCase Interface
+type UseCase interface {
+	CreateReport(ctx context.Context, request domain.CreateReportRequest) error
+	GetReports(ctx context.Context, page, limit, offset int, status string) (*domain.ReportResponsePaginationResponse, error)
+	GetReport(ctx context.Context, id int) (*domain.ReportResponse, error)
+	UpdateReportStatus(ctx context.Context, id int, request domain.UpdateReportStatusRequest) error
+}
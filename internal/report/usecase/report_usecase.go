@@ -0,0 +1,259 @@
+package usecase
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"time"
+
+	"github.com/radyatamaa/dating-apps-api/internal/audit"
+	"github.com/radyatamaa/dating-apps-api/internal/domain"
+	"github.com/radyatamaa/dating-apps-api/internal/moderation"
+	"github.com/radyatamaa/dating-apps-api/internal/profile"
+	"github.com/radyatamaa/dating-apps-api/internal/report"
+	"github.com/radyatamaa/dating-apps-api/internal/user"
+	"github.com/radyatamaa/dating-apps-api/pkg/authuser"
+	"github.com/radyatamaa/dating-apps-api/pkg/database"
+	"github.com/radyatamaa/dating-apps-api/pkg/response"
+	"github.com/radyatamaa/dating-apps-api/pkg/tracing"
+	"github.com/radyatamaa/dating-apps-api/pkg/zaplogger"
+	"gorm.io/gorm"
+)
+
+// activeReportStatus are the status of the reports waiting in the moderation queue.
+var activeReportStatus = []string{domain.ReportStatusOpen, domain.ReportStatusReviewing}
+
+type reportUseCase struct {
+	zapLogger                 zaplogger.Logger
+	contextTimeout            time.Duration
+	txManager                 database.TxManager
+	autoHideThreshold         int
+	mysqlReportRepository     report.MysqlRepository
+	mysqlProfileRepository    profile.MysqlRepository
+	mysqlUserRepository       user.MysqlRepository
+	mysqlModerationRepository moderation.MysqlRepository
+	auditUseCase              audit.UseCase
+}
+
+// NewReportUseCase autoHideThreshold is the number of distinct reporters hiding a user
+// until the reports are reviewed, 0 disables the auto hide.
+func NewReportUseCase(timeout time.Duration,
+	txManager database.TxManager,
+	mysqlReportRepository report.MysqlRepository,
+	mysqlProfileRepository profile.MysqlRepository,
+	mysqlUserRepository user.MysqlRepository,
+	mysqlModerationRepository moderation.MysqlRepository,
+	autoHideThreshold int,
+	auditUseCase audit.UseCase,
+	zapLogger zaplogger.Logger) report.UseCase {
+	return &reportUseCase{
+		mysqlReportRepository:     mysqlReportRepository,
+		mysqlProfileRepository:    mysqlProfileRepository,
+		mysqlUserRepository:       mysqlUserRepository,
+		mysqlModerationRepository: mysqlModerationRepository,
+		autoHideThreshold:         autoHideThreshold,
+		auditUseCase:              auditUseCase,
+		contextTimeout:            timeout,
+		txManager:                 txManager,
+		zapLogger:                 zapLogger,
+	}
+}
+
+func (r reportUseCase) singleReportWithFilter(ctx context.Context, criteria ...database.Criterion) (*domain.Report, error) {
+	var entity domain.Report
+	if err := r.mysqlReportRepository.SingleWithFilter(ctx, database.NewQuery().Where(criteria...), &entity); err != nil {
+		return nil, err
+	}
+	return &entity, nil
+}
+
+/////////////////// CreateReport
+
+// autoHide hides the user from the other users when the distinct reporters reach the threshold.
+func (r reportUseCase) autoHide(ctx context.Context, userId int) error {
+	if r.autoHideThreshold <= 0 {
+		return nil
+	}
+
+	// the report just stored is counted
+	ctx = database.WithPrimary(ctx)
+	count, err := r.mysqlReportRepository.CountDistinctReporters(ctx, userId, activeReportStatus)
+	if err != nil {
+		return err
+	}
+	if count < int64(r.autoHideThreshold) {
+		return nil
+	}
+
+	var userSingle domain.User
+	if err = r.mysqlUserRepository.SingleWithFilter(ctx, database.NewQuery().
+		Select("id", "hidden_at").Where(database.Eq("id", userId)), &userSingle); err != nil {
+		return err
+	}
+	if userSingle.HiddenAt.Valid {
+		return nil
+	}
+
+	now := time.Now()
+	return r.txManager.WithinTransaction(ctx, func(ctx context.Context) error {
+		if err := r.mysqlUserRepository.UpdateSelectedField(ctx, []string{"hidden_at", "updated_at"}, map[string]interface{}{
+			"hidden_at":  now,
+			"updated_at": now,
+		}, userId); err != nil {
+			return err
+		}
+		_, err := r.mysqlModerationRepository.Store(ctx, domain.ModerationAction{
+			UserID: userId,
+			Action: domain.ModerationActionHide,
+			Reason: fmt.Sprintf("reported by %d users", count),
+		})
+		return err
+	})
+}
+
+func (r reportUseCase) CreateReport(ctx context.Context, request domain.CreateReportRequest) error {
+	ctx, cancel := context.WithTimeout(ctx, r.contextTimeout)
+	defer cancel()
+	ctx, span := tracing.Start(ctx, "reportUseCase.CreateReport")
+	defer span.End()
+
+	authUser, err := authuser.FromContext(ctx)
+	if err != nil {
+		zaplogger.SetStackTrace(ctx, r.zapLogger.SetMessageLog(err))
+		return err
+	}
+	userId := authUser.ID
+
+	var reportedProfile domain.Profile
+	if err := r.mysqlProfileRepository.SingleWithFilter(ctx, database.NewQuery().
+		Select("id", "user_id").Where(database.Eq("id", request.ProfileID)), &reportedProfile); err != nil {
+		zaplogger.SetStackTrace(ctx, r.zapLogger.SetMessageLog(err))
+		return err
+	}
+	if reportedProfile.UserID == userId {
+		zaplogger.SetStackTrace(ctx, r.zapLogger.SetMessageLog(response.ErrCannotReportSelf))
+		return response.ErrCannotReportSelf
+	}
+
+	// a user has a single report of another user in the queue
+	_, err = r.singleReportWithFilter(ctx, database.Eq("reporter_id", userId), database.Eq("reported_user_id", reportedProfile.UserID),
+		database.In("status", activeReportStatus))
+	if err == nil {
+		return nil
+	}
+	if err != gorm.ErrRecordNotFound {
+		zaplogger.SetStackTrace(ctx, r.zapLogger.SetMessageLog(err))
+		return err
+	}
+
+	if _, err = r.mysqlReportRepository.Store(ctx, request.ToReport(userId, reportedProfile.UserID)); err != nil {
+		zaplogger.SetStackTrace(ctx, r.zapLogger.SetMessageLog(err))
+		return err
+	}
+
+	if err = r.autoHide(ctx, reportedProfile.UserID); err != nil {
+		zaplogger.SetStackTrace(ctx, r.zapLogger.SetMessageLog(err))
+		return err
+	}
+
+	return nil
+}
+
+//////////////////
+
+/////////////////// GetReports
+
+func (r reportUseCase) GetReports(ctx context.Context, page, limit, offset int, status string) (*domain.ReportResponsePaginationResponse, error) {
+	ctx, cancel := context.WithTimeout(ctx, r.contextTimeout)
+	defer cancel()
+	ctx, span := tracing.Start(ctx, "reportUseCase.GetReports")
+	defer span.End()
+
+	query := database.NewQuery().OrderBy(database.Asc("id")).CacheTotal()
+	if status != "" {
+		query.Where(database.Eq("status", status))
+	}
+
+	var entity []domain.Report
+	fetchReports, err := r.mysqlReportRepository.FetchWithFilterAndPagination(ctx, limit, offset, query, &entity)
+	if err != nil {
+		zaplogger.SetStackTrace(ctx, r.zapLogger.SetMessageLog(err))
+		return nil, err
+	}
+
+	datas := make([]domain.ReportResponse, 0)
+	records := fetchReports.Records.(*[]domain.Report)
+	if records != nil {
+		for _, e := range *records {
+			datas = append(datas, domain.FromReportToReportResponse(e))
+		}
+	}
+
+	return domain.ToReportResponsePaginationResponse(datas, page, limit, offset, fetchReports), nil
+}
+
+//////////////////
+
+/////////////////// GetReport
+
+func (r reportUseCase) GetReport(ctx context.Context, id int) (*domain.ReportResponse, error) {
+	ctx, cancel := context.WithTimeout(ctx, r.contextTimeout)
+	defer cancel()
+	ctx, span := tracing.Start(ctx, "reportUseCase.GetReport")
+	defer span.End()
+
+	reportSingle, err := r.singleReportWithFilter(ctx, database.Eq("id", id))
+	if err != nil {
+		zaplogger.SetStackTrace(ctx, r.zapLogger.SetMessageLog(err))
+		return nil, err
+	}
+
+	result := domain.FromReportToReportResponse(*reportSingle)
+	return &result, nil
+}
+
+//////////////////
+
+/////////////////// UpdateReportStatus
+
+func (r reportUseCase) UpdateReportStatus(ctx context.Context, id int, request domain.UpdateReportStatusRequest) error {
+	ctx, cancel := context.WithTimeout(ctx, r.contextTimeout)
+	defer cancel()
+	ctx, span := tracing.Start(ctx, "reportUseCase.UpdateReportStatus")
+	defer span.End()
+
+	authUser, err := authuser.FromContext(ctx)
+	if err != nil {
+		zaplogger.SetStackTrace(ctx, r.zapLogger.SetMessageLog(err))
+		return err
+	}
+
+	reportSingle, err := r.singleReportWithFilter(ctx, database.Eq("id", id))
+	if err != nil {
+		zaplogger.SetStackTrace(ctx, r.zapLogger.SetMessageLog(err))
+		return err
+	}
+
+	now := time.Now()
+	if err := r.mysqlReportRepository.UpdateSelectedField(ctx, []string{"status", "reviewed_by", "reviewed_at", "updated_at"}, map[string]interface{}{
+		"status":      request.Status,
+		"reviewed_by": sql.NullInt64{Int64: int64(authUser.ID), Valid: true},
+		"reviewed_at": now,
+		"updated_at":  now,
+	}, id); err != nil {
+		zaplogger.SetStackTrace(ctx, r.zapLogger.SetMessageLog(err))
+		return err
+	}
+
+	r.auditUseCase.Record(ctx, domain.AuditEntry{
+		Action:     domain.AuditActionReportUpdate,
+		TargetType: domain.AuditTargetReport,
+		TargetID:   id,
+		Before:     map[string]interface{}{"status": reportSingle.Status},
+		After:      map[string]interface{}{"status": request.Status},
+	})
+
+	return nil
+}
+
+//////////////////
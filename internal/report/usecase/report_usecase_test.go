@@ -0,0 +1,143 @@
+package usecase
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/radyatamaa/dating-apps-api/internal/domain"
+	"github.com/radyatamaa/dating-apps-api/internal/domain/mocks"
+	"github.com/radyatamaa/dating-apps-api/pkg/database"
+	"github.com/radyatamaa/dating-apps-api/pkg/authuser"
+	"github.com/radyatamaa/dating-apps-api/pkg/response"
+	mockZaplogger "github.com/radyatamaa/dating-apps-api/pkg/zaplogger/mocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/suite"
+	"gorm.io/gorm"
+)
+
+type ReportUseCaseTestSuite struct {
+	suite.Suite
+}
+
+type fields struct {
+	zapLogger                 *mockZaplogger.MockLogger
+	contextTimeout            time.Duration
+	autoHideThreshold         int
+	mysqlReportRepository     *mocks.ReportMysqlRepository
+	mysqlProfileRepository    *mocks.ProfileMysqlRepository
+	mysqlUserRepository       *mocks.UserMysqlRepository
+	mysqlModerationRepository *mocks.ModerationMysqlRepository
+}
+
+func toField(ctrl *gomock.Controller) fields {
+	return fields{
+		zapLogger:                 mockZaplogger.NewMockLogger(ctrl),
+		contextTimeout:            time.Second * 30,
+		autoHideThreshold:         5,
+		mysqlReportRepository:     mocks.NewReportMysqlRepository(ctrl),
+		mysqlProfileRepository:    mocks.NewProfileMysqlRepository(ctrl),
+		mysqlUserRepository:       mocks.NewUserMysqlRepository(ctrl),
+		mysqlModerationRepository: mocks.NewModerationMysqlRepository(ctrl),
+	}
+}
+
+func (t *ReportUseCaseTestSuite) TestReportUseCase_CreateReport() {
+	mockUserLogin := authuser.AuthUser{ID: 1, Email: "test@gmail.com", ProfileID: 1}
+	ctx := authuser.NewContext(context.TODO(), mockUserLogin)
+
+	type args struct {
+		ctx      context.Context
+		request  domain.CreateReportRequest
+	}
+	tests := []struct {
+		name    string
+		fields  func(ctrl *gomock.Controller) fields
+		args    args
+		wantErr assert.ErrorAssertionFunc
+	}{
+		{
+			name:    "success below auto hide threshold",
+			wantErr: assert.NoError,
+			fields: func(ctrl *gomock.Controller) fields {
+				fields := toField(ctrl)
+				fields.mysqlProfileRepository.EXPECT().SingleWithFilter(gomock.Any(), database.NewQuery().Select("id", "user_id").Where(database.Eq("id", 2)), gomock.Any()).
+					SetArg(2, domain.Profile{ID: 2, UserID: 2}).Return(nil)
+				fields.mysqlReportRepository.EXPECT().SingleWithFilter(gomock.Any(), database.NewQuery().Where(database.Eq("reporter_id", 1), database.Eq("reported_user_id", 2),
+					database.In("status", activeReportStatus)), gomock.Any()).
+					Return(gorm.ErrRecordNotFound)
+				fields.mysqlReportRepository.EXPECT().Store(gomock.Any(), domain.Report{
+					ReporterID:     1,
+					ReportedUserID: 2,
+					ProfileID:      2,
+					Reason:         "SPAM",
+					Status:         domain.ReportStatusOpen,
+				}).Return(domain.Report{ID: 1}, nil)
+				fields.mysqlReportRepository.EXPECT().CountDistinctReporters(gomock.Any(), 2, activeReportStatus).Return(int64(1), nil)
+				return fields
+			},
+			args: args{
+				ctx:      ctx,
+				request:  domain.CreateReportRequest{ProfileID: 2, Reason: "SPAM"},
+			},
+		},
+		{
+			name:    "success already reported",
+			wantErr: assert.NoError,
+			fields: func(ctrl *gomock.Controller) fields {
+				fields := toField(ctrl)
+				fields.mysqlProfileRepository.EXPECT().SingleWithFilter(gomock.Any(), database.NewQuery().Select("id", "user_id").Where(database.Eq("id", 2)), gomock.Any()).
+					SetArg(2, domain.Profile{ID: 2, UserID: 2}).Return(nil)
+				fields.mysqlReportRepository.EXPECT().SingleWithFilter(gomock.Any(), database.NewQuery().Where(database.Eq("reporter_id", 1), database.Eq("reported_user_id", 2),
+					database.In("status", activeReportStatus)), gomock.Any()).
+					SetArg(2, domain.Report{ID: 1, ReporterID: 1, ReportedUserID: 2, Status: domain.ReportStatusOpen}).Return(nil)
+				return fields
+			},
+			args: args{
+				ctx:      ctx,
+				request:  domain.CreateReportRequest{ProfileID: 2, Reason: "SPAM"},
+			},
+		},
+		{
+			name: "error report self",
+			wantErr: func(t assert.TestingT, err error, i ...interface{}) bool {
+				return assert.ErrorIs(t, err, response.ErrCannotReportSelf)
+			},
+			fields: func(ctrl *gomock.Controller) fields {
+				fields := toField(ctrl)
+				fields.mysqlProfileRepository.EXPECT().SingleWithFilter(gomock.Any(), database.NewQuery().Select("id", "user_id").Where(database.Eq("id", 1)), gomock.Any()).
+					SetArg(2, domain.Profile{ID: 1, UserID: 1}).Return(nil)
+				fields.zapLogger.EXPECT().SetMessageLog(response.ErrCannotReportSelf)
+				return fields
+			},
+			args: args{
+				ctx:      ctx,
+				request:  domain.CreateReportRequest{ProfileID: 1, Reason: "SPAM"},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func() {
+			ctrl := gomock.NewController(t.T())
+			defer ctrl.Finish()
+
+			fields := tt.fields(ctrl)
+			r := reportUseCase{
+				zapLogger:                 fields.zapLogger,
+				contextTimeout:            fields.contextTimeout,
+				autoHideThreshold:         fields.autoHideThreshold,
+				mysqlReportRepository:     fields.mysqlReportRepository,
+				mysqlProfileRepository:    fields.mysqlProfileRepository,
+				mysqlUserRepository:       fields.mysqlUserRepository,
+				mysqlModerationRepository: fields.mysqlModerationRepository,
+			}
+			err := r.CreateReport(tt.args.ctx, tt.args.request)
+			tt.wantErr(t.T(), err)
+		})
+	}
+}
+
+func TestReportUseCaseTestSuite(t *testing.T) {
+	suite.Run(t, new(ReportUseCaseTestSuite))
+}
@@ -0,0 +1,57 @@
+package v1
+
+import (
+	"context"
+
+	pb "github.com/radyatamaa/dating-apps-api/api/proto/v1"
+	"github.com/radyatamaa/dating-apps-api/internal/domain"
+	"github.com/radyatamaa/dating-apps-api/internal/swipe"
+	"github.com/radyatamaa/dating-apps-api/pkg/validator"
+	"github.com/radyatamaa/dating-apps-api/pkg/zaplogger"
+	"google.golang.org/grpc"
+)
+
+// SwipeHandler is the grpc api of swipe.UseCase, the errors are mapped to their status by the interceptors.
+type SwipeHandler struct {
+	pb.UnimplementedSwipeServiceServer
+	ZapLogger zaplogger.Logger
+	Usecase   swipe.UseCase
+}
+
+func NewSwipeHandler(server *grpc.Server, useCase swipe.UseCase, zapLogger zaplogger.Logger) {
+	pb.RegisterSwipeServiceServer(server, &SwipeHandler{
+		ZapLogger: zapLogger,
+		Usecase:   useCase,
+	})
+}
+
+func (h *SwipeHandler) SwipeProfile(ctx context.Context, req *pb.SwipeProfileRequest) (*pb.SwipeProfileResponse, error) {
+	request := domain.SwipeProfileRequest{
+		ProfileID: int(req.GetProfileId()),
+		SwipeType: req.GetSwipeType(),
+	}
+	if err := validator.Validate.ValidateStruct(&request); err != nil {
+		zaplogger.SetStackTrace(ctx, h.ZapLogger.SetMessageLog(err))
+		return nil, err
+	}
+
+	if err := h.Usecase.SwipeProfile(ctx, request); err != nil {
+		return nil, err
+	}
+	return &pb.SwipeProfileResponse{}, nil
+}
+
+func (h *SwipeHandler) Unmatch(ctx context.Context, req *pb.UnmatchRequest) (*pb.UnmatchResponse, error) {
+	request := domain.UnmatchRequest{
+		ProfileID: int(req.GetProfileId()),
+	}
+	if err := validator.Validate.ValidateStruct(&request); err != nil {
+		zaplogger.SetStackTrace(ctx, h.ZapLogger.SetMessageLog(err))
+		return nil, err
+	}
+
+	if err := h.Usecase.Unmatch(ctx, request); err != nil {
+		return nil, err
+	}
+	return &pb.UnmatchResponse{}, nil
+}
@@ -1,11 +1,13 @@
 package swipe
 
 import (
-	beegoContext "github.com/beego/beego/v2/server/web/context"
+	"context"
+
 	"github.com/radyatamaa/dating-apps-api/internal/domain"
 )
 
 // UseCase Interface
 type UseCase interface {
-	SwipeProfile(beegoCtx *beegoContext.Context, request domain.SwipeProfileRequest) error
+	SwipeProfile(ctx context.Context, request domain.SwipeProfileRequest) error
+	Unmatch(ctx context.Context, request domain.UnmatchRequest) error
 }
\ No newline at end of file
@@ -2,83 +2,141 @@ package usecase
 
 import (
 	"context"
-	beegoContext "github.com/beego/beego/v2/server/web/context"
+	"github.com/radyatamaa/dating-apps-api/internal/block"
 	"github.com/radyatamaa/dating-apps-api/internal/domain"
+	"github.com/radyatamaa/dating-apps-api/internal/profile"
 	"github.com/radyatamaa/dating-apps-api/internal/swipe"
 	"github.com/radyatamaa/dating-apps-api/internal/user"
-	"github.com/radyatamaa/dating-apps-api/pkg/database/paginator"
-	"github.com/radyatamaa/dating-apps-api/pkg/helper"
-	"github.com/radyatamaa/dating-apps-api/pkg/jwt"
+	"github.com/radyatamaa/dating-apps-api/pkg/authuser"
+	"github.com/radyatamaa/dating-apps-api/pkg/database"
+	"github.com/radyatamaa/dating-apps-api/pkg/event"
+	"github.com/radyatamaa/dating-apps-api/pkg/metrics"
 	"github.com/radyatamaa/dating-apps-api/pkg/response"
+	"github.com/radyatamaa/dating-apps-api/pkg/tracing"
 	"github.com/radyatamaa/dating-apps-api/pkg/zaplogger"
+	"gorm.io/gorm"
+	"strconv"
 	"time"
 )
 
 type swipeUseCase struct {
 	zapLogger                  zaplogger.Logger
 	contextTimeout             time.Duration
+	txManager                  database.TxManager
+	publisher                  event.Publisher
 	mysqlSwipeRepository    swipe.MysqlRepository
 	mysqlUserRepository    user.MysqlRepository
+	mysqlProfileRepository    profile.MysqlRepository
+	mysqlBlockRepository    block.MysqlRepository
 }
 
 func NewSwipeUseCase(timeout time.Duration,
+	txManager database.TxManager,
+	publisher event.Publisher,
 	mysqlSwipeRepository    swipe.MysqlRepository,
 	mysqlUserRepository    user.MysqlRepository,
+	mysqlProfileRepository    profile.MysqlRepository,
+	mysqlBlockRepository    block.MysqlRepository,
 	zapLogger zaplogger.Logger) swipe.UseCase {
 	return &swipeUseCase{
 		mysqlSwipeRepository:    mysqlSwipeRepository,
 		mysqlUserRepository:mysqlUserRepository,
+		mysqlProfileRepository:mysqlProfileRepository,
+		mysqlBlockRepository:mysqlBlockRepository,
 		contextTimeout:             timeout,
+		txManager:                  txManager,
+		publisher:                  publisher,
 		zapLogger:                  zapLogger,
 	}
 }
 /////////////////// SwipeProfile
-func (a swipeUseCase) singleUserWithFilter(ctx context.Context, filter []string, args ...interface{}) (*domain.User, error) {
+func (a swipeUseCase) singleUserWithFilter(ctx context.Context, criteria ...database.Criterion) (*domain.User, error) {
 	var entity domain.User
 	if err := a.mysqlUserRepository.SingleWithFilter(
 		ctx,
-		[]string{
-			"*",
-		},
-		[]string{},
-		filter,
-		&entity, args...); err != nil {
+		database.NewQuery().Where(criteria...),
+		&entity); err != nil {
 		return nil, err
 	}
 	return &entity, nil
 }
-func (r swipeUseCase) fetchSwipeWithFilterAndPagination(ctx context.Context, limit, offset int, filter []string, order string, args ...interface{}) (*paginator.Paginator, error) {
-	var entity []domain.Swipe
-	paging, err := r.mysqlSwipeRepository.FetchWithFilterAndPagination(
+func (a swipeUseCase) singleProfileWithFilter(ctx context.Context, criteria ...database.Criterion) (*domain.Profile, error) {
+	var entity domain.Profile
+	if err := a.mysqlProfileRepository.SingleWithFilter(
 		ctx,
-		limit,
-		offset,
-		order,
-		[]string{
-			"*",
-		},
-		[]string{},
-		filter,
-		&entity, args...,
-	)
-	if err != nil {
+		database.NewQuery().
+			Select("id", "user_id").
+			Where(criteria...),
+		&entity); err != nil {
+		return nil, err
+	}
+	return &entity, nil
+}
+func (a swipeUseCase) singleSwipeWithFilter(ctx context.Context, criteria ...database.Criterion) (*domain.Swipe, error) {
+	var entity domain.Swipe
+	if err := a.mysqlSwipeRepository.SingleWithFilter(
+		ctx,
+		database.NewQuery().Where(criteria...),
+		&entity); err != nil {
 		return nil, err
 	}
+	return &entity, nil
+}
+// isBlocked reports whether either user blocked the other.
+func (a swipeUseCase) isBlocked(ctx context.Context, userId, otherUserId int) (bool, error) {
+	blockedUserIds, err := a.mysqlBlockRepository.FetchBlockedUserIds(ctx, userId)
+	if err != nil {
+		return false, err
+	}
+	for i := range blockedUserIds {
+		if blockedUserIds[i] == otherUserId {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+// isNewMatch reports whether the owner of the profile liked the profile of the user
+// and the user didn't already like the profile.
+func (a swipeUseCase) isNewMatch(ctx context.Context, userId, userProfileId int, profileSingle *domain.Profile) (bool, error) {
+	_, err := a.singleSwipeWithFilter(ctx,
+		database.Eq("user_id", profileSingle.UserID),
+		database.Eq("profile_id", userProfileId),
+		database.Eq("swipe_type", "LIKE"))
+	if err == gorm.ErrRecordNotFound {
+		return false, nil
+	}
+	if err != nil {
+		return false, err
+	}
 
-	return paging, nil
+	_, err = a.singleSwipeWithFilter(ctx,
+		database.Eq("user_id", userId),
+		database.Eq("profile_id", profileSingle.ID),
+		database.Eq("swipe_type", "LIKE"))
+	if err == gorm.ErrRecordNotFound {
+		return true, nil
+	}
+	return false, err
 }
-func (s swipeUseCase) checkDailySwipeQuota(beegoCtx *beegoContext.Context,userId int) (bool,error) {
-	fetchSwipes, err := s.fetchSwipeWithFilterAndPagination(beegoCtx.Request.Context(), 1, 0,
-		[]string{"user_id = ?","DATE(updated_at) = DATE(?)"},
-		"id ASC",
-		userId,
-		time.Now().Format(helper.DateFormatDefault))
+func (s swipeUseCase) checkDailySwipeQuota(ctx context.Context,userId int) (bool,error) {
+	// the quota counts the swipes just stored on the primary without the count cache,
+	// a lagging replica or a cached total would let the user exceed it
+	// the bounds of the day keep the index of updated_at usable, unlike DATE(updated_at)
+	now := time.Now()
+	startOfDay := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, now.Location())
+	countSwipes, err := s.mysqlSwipeRepository.Count(database.WithPrimary(ctx),
+		database.NewQuery().
+			Where(
+				database.Eq("user_id", userId),
+				database.Gte("updated_at", startOfDay),
+				database.Lt("updated_at", startOfDay.AddDate(0, 0, 1)),
+			))
 	if err != nil {
-		beegoCtx.Input.SetData("stackTrace", s.zapLogger.SetMessageLog(err))
+		zaplogger.SetStackTrace(ctx, s.zapLogger.SetMessageLog(err))
 		return false, err
 	}
 
-	if  fetchSwipes.Total >= 10 {
+	if  countSwipes >= 10 {
 		return true, nil
 	}
 
@@ -86,36 +144,134 @@ func (s swipeUseCase) checkDailySwipeQuota(beegoCtx *beegoContext.Context,userId
 
 
 }
-func (s swipeUseCase) SwipeProfile(beegoCtx *beegoContext.Context, request domain.SwipeProfileRequest) error {
-	ctx, cancel := context.WithTimeout(beegoCtx.Request.Context(), s.contextTimeout)
+func (s swipeUseCase) SwipeProfile(ctx context.Context, request domain.SwipeProfileRequest) error {
+	ctx, cancel := context.WithTimeout(ctx, s.contextTimeout)
 	defer cancel()
-	beegoCtx.Request.WithContext(ctx)
+	ctx, span := tracing.Start(ctx, "swipeUseCase.SwipeProfile")
+	defer span.End()
 
-	userLogin := beegoCtx.Request.Context().Value("JWT_PAYLOAD").(jwt.Payload)
+	authUser, err := authuser.FromContext(ctx)
+	if err != nil {
+		zaplogger.SetStackTrace(ctx, s.zapLogger.SetMessageLog(err))
+		return err
+	}
 
-	userSingle, err := s.singleUserWithFilter(ctx, []string{"id = ?"}, userLogin["uid"].(float64))
+	userSingle, err := s.singleUserWithFilter(ctx, database.Eq("id", authUser.ID))
 	if err != nil {
-		beegoCtx.Input.SetData("stackTrace", s.zapLogger.SetMessageLog(err))
+		zaplogger.SetStackTrace(ctx, s.zapLogger.SetMessageLog(err))
 		return err
 	}
 
+	profileSingle, err := s.singleProfileWithFilter(ctx, database.Eq("id", request.ProfileID))
+	if err != nil {
+		zaplogger.SetStackTrace(ctx, s.zapLogger.SetMessageLog(err))
+		return err
+	}
+
+	// a blocked profile is reported as not found to not reveal the block
+	blocked, err := s.isBlocked(ctx, userSingle.ID, profileSingle.UserID)
+	if err != nil {
+		zaplogger.SetStackTrace(ctx, s.zapLogger.SetMessageLog(err))
+		return err
+	}
+	if blocked {
+		zaplogger.SetStackTrace(ctx, s.zapLogger.SetMessageLog(gorm.ErrRecordNotFound))
+		return gorm.ErrRecordNotFound
+	}
+
 	if !domain.IsPremium(userSingle.PremiumExpiresAt) {
-		checkDailySwipeQuota,err := s.checkDailySwipeQuota(beegoCtx,userSingle.ID)
+		checkDailySwipeQuota,err := s.checkDailySwipeQuota(ctx,userSingle.ID)
 		if err != nil {
 			return err
 		}
 
 		if checkDailySwipeQuota {
-			beegoCtx.Input.SetData("stackTrace", s.zapLogger.SetMessageLog(response.ErrLimitSwipeOrLike))
+			metrics.SwipeQuotaRejectionsTotal.Inc()
+			zaplogger.SetStackTrace(ctx, s.zapLogger.SetMessageLog(response.ErrLimitSwipeOrLike))
 			return response.ErrLimitSwipeOrLike
 		}
 	}
 
-	if err = s.mysqlSwipeRepository.Upsert(ctx, []string{"user_id", "profile_id"}, []domain.Swipe{request.ToSwipe(userSingle.ID)}...); err != nil {
-		beegoCtx.Input.SetData("stackTrace", s.zapLogger.SetMessageLog(err))
+	if err = s.txManager.WithinTransaction(ctx, func(ctx context.Context) error {
+		// a LIKE creates a match when the other user already liked back and the user didn't like before
+		matched := false
+		if request.SwipeType == "LIKE" {
+			matched, err = s.isNewMatch(ctx, userSingle.ID, authUser.ProfileID, profileSingle)
+			if err != nil {
+				return err
+			}
+		}
+
+		if err := s.mysqlSwipeRepository.Upsert(ctx, []string{"user_id", "profile_id"}, []domain.Swipe{request.ToSwipe(userSingle.ID)}...); err != nil {
+			return err
+		}
+		if !matched {
+			return nil
+		}
+
+		return event.PublishNew(ctx, s.publisher, domain.EventMatchCreated, strconv.Itoa(userSingle.ID), domain.MatchCreatedPayload{
+			UserID:           userSingle.ID,
+			ProfileID:        profileSingle.ID,
+			MatchedUserID:    profileSingle.UserID,
+			MatchedProfileID: authUser.ProfileID,
+		})
+	}); err != nil {
+		zaplogger.SetStackTrace(ctx, s.zapLogger.SetMessageLog(err))
+		return err
+	}
+	metrics.SwipesTotal.WithLabelValues(request.SwipeType).Inc()
+
+	return nil
+}
+//////////////////
+
+/////////////////// Unmatch
+
+func (s swipeUseCase) Unmatch(ctx context.Context, request domain.UnmatchRequest) error {
+	ctx, cancel := context.WithTimeout(ctx, s.contextTimeout)
+	defer cancel()
+	ctx, span := tracing.Start(ctx, "swipeUseCase.Unmatch")
+	defer span.End()
+
+	authUser, err := authuser.FromContext(ctx)
+	if err != nil {
+		zaplogger.SetStackTrace(ctx, s.zapLogger.SetMessageLog(err))
+		return err
+	}
+	userId := authUser.ID
+
+	profileSingle, err := s.singleProfileWithFilter(ctx, database.Eq("id", request.ProfileID))
+	if err != nil {
+		zaplogger.SetStackTrace(ctx, s.zapLogger.SetMessageLog(err))
+		return err
+	}
+
+	// a match is a LIKE in both directions
+	matchFilters := [][]database.Criterion{
+		{database.Eq("user_id", userId), database.Eq("profile_id", profileSingle.ID)},
+		{database.Eq("user_id", profileSingle.UserID), database.Eq("profile_id", authUser.ProfileID)},
+	}
+	for _, criteria := range matchFilters {
+		if _, err = s.singleSwipeWithFilter(ctx, append(criteria, database.Eq("swipe_type", "LIKE"))...); err != nil {
+			if err == gorm.ErrRecordNotFound {
+				err = response.ErrMatchNotFound
+			}
+			zaplogger.SetStackTrace(ctx, s.zapLogger.SetMessageLog(err))
+			return err
+		}
+	}
+
+	if err = s.mysqlSwipeRepository.Upsert(ctx, []string{"user_id", "profile_id"}, []domain.Swipe{{
+		UserID:    userId,
+		ProfileID: profileSingle.ID,
+		SwipeType: "UNMATCH",
+	}}...); err != nil {
+		zaplogger.SetStackTrace(ctx, s.zapLogger.SetMessageLog(err))
 		return err
 	}
+	metrics.SwipesTotal.WithLabelValues("UNMATCH").Inc()
 
 	return nil
 }
-//////////////////
\ No newline at end of file
+
+//////////////////
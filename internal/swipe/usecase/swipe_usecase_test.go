@@ -0,0 +1,109 @@
+package usecase
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/beego/beego/v2/client/cache"
+	"github.com/golang/mock/gomock"
+	"github.com/radyatamaa/dating-apps-api/internal/domain"
+	"github.com/radyatamaa/dating-apps-api/internal/domain/mocks"
+	swipeRepository "github.com/radyatamaa/dating-apps-api/internal/swipe/repository"
+	"github.com/radyatamaa/dating-apps-api/pkg/authuser"
+	mockDatabase "github.com/radyatamaa/dating-apps-api/pkg/database/mocks"
+	"github.com/radyatamaa/dating-apps-api/pkg/database/paginator"
+	"github.com/radyatamaa/dating-apps-api/pkg/response"
+	mockZaplogger "github.com/radyatamaa/dating-apps-api/pkg/zaplogger/mocks"
+	"github.com/stretchr/testify/suite"
+	"gorm.io/driver/mysql"
+	"gorm.io/gorm"
+	"gorm.io/gorm/logger"
+)
+
+type SwipeUseCaseTestSuite struct {
+	suite.Suite
+}
+
+type fields struct {
+	zapLogger              *mockZaplogger.MockLogger
+	contextTimeout         time.Duration
+	txManager              *mockDatabase.MockTxManager
+	mysqlUserRepository    *mocks.UserMysqlRepository
+	mysqlProfileRepository *mocks.ProfileMysqlRepository
+	mysqlBlockRepository   *mocks.BlockMysqlRepository
+}
+
+func toField(ctrl *gomock.Controller) fields {
+	return fields{
+		zapLogger:              mockZaplogger.NewMockLogger(ctrl),
+		contextTimeout:         time.Second * 30,
+		txManager:              mockDatabase.NewMockTxManager(ctrl),
+		mysqlUserRepository:    mocks.NewUserMysqlRepository(ctrl),
+		mysqlProfileRepository: mocks.NewProfileMysqlRepository(ctrl),
+		mysqlBlockRepository:   mocks.NewBlockMysqlRepository(ctrl),
+	}
+}
+
+// TestSwipeUseCase_SwipeProfile_DailyQuota swipes within the TTL of the count cache,
+// the quota must count the swipes just stored instead of a cached total.
+func (t *SwipeUseCaseTestSuite) TestSwipeUseCase_SwipeProfile_DailyQuota() {
+	paginator.UseCountCache(cache.NewMemoryCache(), time.Minute)
+	t.T().Cleanup(func() { paginator.UseCountCache(nil, 0) })
+
+	ctrl := gomock.NewController(t.T())
+	defer ctrl.Finish()
+
+	sqlDB, mock, err := sqlmock.New()
+	t.Require().NoError(err)
+	defer sqlDB.Close()
+	db, err := gorm.Open(mysql.New(mysql.Config{Conn: sqlDB, SkipInitializeWithVersion: true}), &gorm.Config{
+		SkipDefaultTransaction: true,
+		Logger:                 logger.Default.LogMode(logger.Silent),
+	})
+	t.Require().NoError(err)
+
+	f := toField(ctrl)
+	f.mysqlUserRepository.EXPECT().SingleWithFilter(gomock.Any(), gomock.Any(), gomock.Any()).
+		DoAndReturn(func(ctx context.Context, query interface{}, model interface{}) error {
+			*model.(*domain.User) = domain.User{ID: 1}
+			return nil
+		}).Times(11)
+	f.mysqlBlockRepository.EXPECT().FetchBlockedUserIds(gomock.Any(), 1).Return(nil, nil).Times(11)
+	f.txManager.EXPECT().WithinTransaction(gomock.Any(), gomock.Any()).
+		DoAndReturn(func(ctx context.Context, fn func(ctx context.Context) error) error {
+			return fn(ctx)
+		}).Times(10)
+	f.zapLogger.EXPECT().SetMessageLog(response.ErrLimitSwipeOrLike)
+
+	r := NewSwipeUseCase(f.contextTimeout, f.txManager, nil, swipeRepository.NewMysqlRepository(db, nil),
+		f.mysqlUserRepository, f.mysqlProfileRepository, f.mysqlBlockRepository, f.zapLogger)
+	ctx := authuser.NewContext(context.TODO(), authuser.AuthUser{ID: 1, ProfileID: 1})
+
+	for i := 0; i < 11; i++ {
+		profileId := i + 2
+		f.mysqlProfileRepository.EXPECT().SingleWithFilter(gomock.Any(), gomock.Any(), gomock.Any()).
+			DoAndReturn(func(ctx context.Context, query interface{}, model interface{}) error {
+				*model.(*domain.Profile) = domain.Profile{ID: profileId, UserID: profileId}
+				return nil
+			})
+		mock.ExpectQuery("SELECT count\\(\\*\\) FROM `swipes`").
+			WillReturnRows(sqlmock.NewRows([]string{"count(*)"}).AddRow(i))
+		if i < 10 {
+			mock.ExpectExec("INSERT INTO `swipes`").WillReturnResult(sqlmock.NewResult(int64(i+1), 1))
+		}
+
+		err := r.SwipeProfile(ctx, domain.SwipeProfileRequest{ProfileID: profileId, SwipeType: "PASS"})
+		if i < 10 {
+			t.NoError(err)
+			continue
+		}
+		t.ErrorIs(err, response.ErrLimitSwipeOrLike)
+	}
+	t.NoError(mock.ExpectationsWereMet())
+}
+
+func TestSwipeUseCaseTestSuite(t *testing.T) {
+	suite.Run(t, new(SwipeUseCaseTestSuite))
+}
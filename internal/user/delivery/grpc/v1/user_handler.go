@@ -0,0 +1,123 @@
+package v1
+
+import (
+	"context"
+
+	pb "github.com/radyatamaa/dating-apps-api/api/proto/v1"
+	"github.com/radyatamaa/dating-apps-api/internal/domain"
+	"github.com/radyatamaa/dating-apps-api/internal/user"
+	"github.com/radyatamaa/dating-apps-api/pkg/validator"
+	"github.com/radyatamaa/dating-apps-api/pkg/zaplogger"
+	"google.golang.org/grpc"
+)
+
+// UserHandler is the grpc api of user.UseCase, the errors are mapped to their status by the interceptors.
+type UserHandler struct {
+	pb.UnimplementedUserServiceServer
+	ZapLogger zaplogger.Logger
+	Usecase   user.UseCase
+}
+
+func NewUserHandler(server *grpc.Server, useCase user.UseCase, zapLogger zaplogger.Logger) {
+	pb.RegisterUserServiceServer(server, &UserHandler{
+		ZapLogger: zapLogger,
+		Usecase:   useCase,
+	})
+}
+
+func (h *UserHandler) Login(ctx context.Context, req *pb.LoginRequest) (*pb.LoginResponse, error) {
+	request := domain.LoginRequest{
+		Email:    req.GetEmail(),
+		Password: req.GetPassword(),
+	}
+	if err := validator.Validate.ValidateStruct(&request); err != nil {
+		zaplogger.SetStackTrace(ctx, h.ZapLogger.SetMessageLog(err))
+		return nil, err
+	}
+
+	result, err := h.Usecase.Login(ctx, request)
+	if err != nil {
+		return nil, err
+	}
+	return toLoginResponse(result), nil
+}
+
+func (h *UserHandler) VerifyTwoFactorLogin(ctx context.Context, req *pb.VerifyTwoFactorLoginRequest) (*pb.LoginResponse, error) {
+	request := domain.VerifyTwoFactorLoginRequest{
+		ChallengeToken: req.GetChallengeToken(),
+		Code:           req.GetCode(),
+	}
+	if err := validator.Validate.ValidateStruct(&request); err != nil {
+		zaplogger.SetStackTrace(ctx, h.ZapLogger.SetMessageLog(err))
+		return nil, err
+	}
+
+	result, err := h.Usecase.VerifyTwoFactorLogin(ctx, request)
+	if err != nil {
+		return nil, err
+	}
+	return toLoginResponse(result), nil
+}
+
+func (h *UserHandler) PurchasePremium(ctx context.Context, req *pb.PurchasePremiumRequest) (*pb.PurchasePremiumResponse, error) {
+	if err := h.Usecase.PurchasePremiumUpdateStatus(ctx); err != nil {
+		return nil, err
+	}
+	return &pb.PurchasePremiumResponse{}, nil
+}
+
+func (h *UserHandler) EnrollTwoFactor(ctx context.Context, req *pb.EnrollTwoFactorRequest) (*pb.EnrollTwoFactorResponse, error) {
+	result, err := h.Usecase.EnrollTwoFactor(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return &pb.EnrollTwoFactorResponse{
+		Secret:     result.Secret,
+		OtpauthUri: result.OtpAuthUri,
+	}, nil
+}
+
+func (h *UserHandler) ConfirmTwoFactor(ctx context.Context, req *pb.ConfirmTwoFactorRequest) (*pb.ConfirmTwoFactorResponse, error) {
+	request := domain.ConfirmTwoFactorRequest{
+		Code: req.GetCode(),
+	}
+	if err := validator.Validate.ValidateStruct(&request); err != nil {
+		zaplogger.SetStackTrace(ctx, h.ZapLogger.SetMessageLog(err))
+		return nil, err
+	}
+
+	result, err := h.Usecase.ConfirmTwoFactor(ctx, request)
+	if err != nil {
+		return nil, err
+	}
+	return &pb.ConfirmTwoFactorResponse{
+		BackupCodes: result.BackupCodes,
+	}, nil
+}
+
+func (h *UserHandler) DeleteAccount(ctx context.Context, req *pb.DeleteAccountRequest) (*pb.DeleteAccountResponse, error) {
+	if err := h.Usecase.DeleteAccount(ctx); err != nil {
+		return nil, err
+	}
+	return &pb.DeleteAccountResponse{}, nil
+}
+
+func toLoginResponse(result *domain.LoginResponse) *pb.LoginResponse {
+	return &pb.LoginResponse{
+		Token:     result.Token,
+		ExpiredAt: result.ExpiredAt,
+		User: &pb.UserLogin{
+			Id:        int64(result.User.Id),
+			Email:     result.User.Email,
+			Name:      result.User.Name,
+			Photo:     result.User.Photo,
+			Age:       int64(result.User.Age),
+			Bio:       result.User.Bio,
+			Longitude: result.User.Longitude,
+			Latitude:  result.User.Latitude,
+			Verified:  result.User.Verified,
+		},
+		TwoFactorRequired: result.TwoFactorRequired,
+		ChallengeToken:    result.ChallengeToken,
+	}
+}
@@ -1,7 +1,6 @@
 package v1
 
 import (
-	"context"
 	"errors"
 	"github.com/radyatamaa/dating-apps-api/internal/user"
 	"github.com/radyatamaa/dating-apps-api/pkg/helper"
@@ -14,7 +13,6 @@ import (
 	"github.com/radyatamaa/dating-apps-api/internal/domain"
 	"github.com/radyatamaa/dating-apps-api/pkg/response"
 	"github.com/radyatamaa/dating-apps-api/pkg/zaplogger"
-	"gorm.io/gorm"
 )
 
 type UserHandler struct {
@@ -32,6 +30,10 @@ func NewUserHandler(useCase user.UseCase, zapLogger zaplogger.Logger) {
 	beego.Router("/api/v1/user/login", pHandler, "post:Login")
 	beego.Router("/api/v1/user/register", pHandler, "post:Register")
 	beego.Router("/api/v1/user/purchase-premium", pHandler, "post:PurchasePremiumUpdateStatus")
+	beego.Router("/api/v1/user/login/verify", pHandler, "post:VerifyTwoFactorLogin")
+	beego.Router("/api/v1/user/2fa/enroll", pHandler, "post:EnrollTwoFactor")
+	beego.Router("/api/v1/user/2fa/confirm", pHandler, "post:ConfirmTwoFactor")
+	beego.Router("/api/v1/user/me", pHandler, "delete:DeleteAccount")
 }
 
 func (h *UserHandler) Prepare() {
@@ -65,21 +67,9 @@ func (h *UserHandler) Login() {
 		return
 	}
 
-	result, err := h.Usecase.Login(h.Ctx, request)
+	result, err := h.Usecase.Login(h.Ctx.Request.Context(), request)
 	if err != nil {
-		if errors.Is(err, response.ErrInvalidEmailPassword) {
-			h.ResponseError(h.Ctx, http.StatusBadRequest, response.InvalidEmailPasswordErrorCode, response.ErrorCodeText(response.InvalidEmailPasswordErrorCode, h.Locale.Lang), err)
-			return
-		}
-		if errors.Is(err, context.DeadlineExceeded) {
-			h.ResponseError(h.Ctx, http.StatusRequestTimeout, response.RequestTimeoutCodeError, response.ErrorCodeText(response.RequestTimeoutCodeError, h.Locale.Lang), err)
-			return
-		}
-		if errors.Is(err, gorm.ErrRecordNotFound) {
-			h.ResponseError(h.Ctx, http.StatusBadRequest, response.DataNotFoundCodeError, response.ErrorCodeText(response.DataNotFoundCodeError, h.Locale.Lang), err)
-			return
-		}
-		h.ResponseError(h.Ctx, http.StatusInternalServerError, response.ServerErrorCode, response.ErrorCodeText(response.ServerErrorCode, h.Locale.Lang), err)
+		h.Fail(err)
 		return
 	}
 	h.Ok(h.Ctx, h.Tr("message.success"), result)
@@ -143,13 +133,9 @@ func (h *UserHandler) Register() {
 		return
 	}
 
-	err = h.Usecase.Register(h.Ctx, request)
+	err = h.Usecase.Register(h.Ctx.Request.Context(), request)
 	if err != nil {
-		if errors.Is(err, context.DeadlineExceeded) {
-			h.ResponseError(h.Ctx, http.StatusRequestTimeout, response.RequestTimeoutCodeError, response.ErrorCodeText(response.RequestTimeoutCodeError, h.Locale.Lang), err)
-			return
-		}
-		h.ResponseError(h.Ctx, http.StatusInternalServerError, response.ServerErrorCode, response.ErrorCodeText(response.ServerErrorCode, h.Locale.Lang), err)
+		h.Fail(err)
 		return
 	}
 	h.Ok(h.Ctx, h.Tr("message.success"), nil)
@@ -170,19 +156,129 @@ func (h *UserHandler) Register() {
 // @Failure 500 {object} swagger.InternalServerErrorResponse{errors=[]object,data=object}
 // @Router /v1/user/purchase-premium [post]
 func (h *UserHandler) PurchasePremiumUpdateStatus() {
-	err := h.Usecase.PurchasePremiumUpdateStatus(h.Ctx)
+	err := h.Usecase.PurchasePremiumUpdateStatus(h.Ctx.Request.Context())
 	if err != nil {
-		if errors.Is(err, context.DeadlineExceeded) {
-			h.ResponseError(h.Ctx, http.StatusRequestTimeout, response.RequestTimeoutCodeError, response.ErrorCodeText(response.RequestTimeoutCodeError, h.Locale.Lang), err)
-			return
-		}
-		if errors.Is(err, gorm.ErrRecordNotFound) {
-			h.ResponseError(h.Ctx, http.StatusBadRequest, response.DataNotFoundCodeError, response.ErrorCodeText(response.DataNotFoundCodeError, h.Locale.Lang), err)
-			return
-		}
-		h.ResponseError(h.Ctx, http.StatusInternalServerError, response.ServerErrorCode, response.ErrorCodeText(response.ServerErrorCode, h.Locale.Lang), err)
+		h.Fail(err)
 		return
 	}
 	h.Ok(h.Ctx, h.Tr("message.success"), nil)
 	return
-}
\ No newline at end of file
+}
+
+
+// VerifyTwoFactorLogin
+// @Title VerifyTwoFactorLogin
+// @Tags User
+// @Summary VerifyTwoFactorLogin exchange the login challenge token and the authenticator or backup code for the access token
+// @Produce json
+// @Param Accept-Language header string false "lang"
+// @Success 200 {object} swagger.BaseResponse{errors=[]object,data=domain.LoginResponse}
+// @Failure 400 {object} swagger.BadRequestErrorValidationResponse{errors=[]swagger.ValidationErrors,data=object}
+// @Failure 408 {object} swagger.RequestTimeoutResponse{errors=[]object,data=object}
+// @Failure 500 {object} swagger.InternalServerErrorResponse{errors=[]object,data=object}
+// @Param body body domain.VerifyTwoFactorLoginRequest true "request payload"
+// @Router /v1/user/login/verify [post]
+func (h *UserHandler) VerifyTwoFactorLogin() {
+	var request domain.VerifyTwoFactorLoginRequest
+
+	if err := h.BindJSON(&request); err != nil {
+		h.Ctx.Input.SetData("stackTrace", h.ZapLogger.SetMessageLog(err))
+		h.ResponseError(h.Ctx, http.StatusBadRequest, response.ApiValidationCodeError, response.ErrorCodeText(response.ApiValidationCodeError, h.Locale.Lang), err)
+		return
+	}
+	if err := validator.Validate.ValidateStruct(&request); err != nil {
+		h.Ctx.Input.SetData("stackTrace", h.ZapLogger.SetMessageLog(err))
+		h.ResponseError(h.Ctx, http.StatusBadRequest, response.ApiValidationCodeError, response.ErrorCodeText(response.ApiValidationCodeError, h.Locale.Lang), err)
+		return
+	}
+
+	result, err := h.Usecase.VerifyTwoFactorLogin(h.Ctx.Request.Context(), request)
+	if err != nil {
+		h.Fail(err)
+		return
+	}
+	h.Ok(h.Ctx, h.Tr("message.success"), result)
+	return
+}
+
+
+// EnrollTwoFactor
+// @Title EnrollTwoFactor
+// @Tags User
+// @Summary EnrollTwoFactor generate the authenticator secret, 2fa is enabled after ConfirmTwoFactor
+// @Produce json
+// @Security ApiKeyAuth
+// @Param Accept-Language header string false "lang"
+// @Success 200 {object} swagger.BaseResponse{errors=[]object,data=domain.EnrollTwoFactorResponse}
+// @Failure 400 {object} swagger.BadRequestErrorValidationResponse{errors=[]swagger.ValidationErrors,data=object}
+// @Failure 408 {object} swagger.RequestTimeoutResponse{errors=[]object,data=object}
+// @Failure 500 {object} swagger.InternalServerErrorResponse{errors=[]object,data=object}
+// @Router /v1/user/2fa/enroll [post]
+func (h *UserHandler) EnrollTwoFactor() {
+	result, err := h.Usecase.EnrollTwoFactor(h.Ctx.Request.Context())
+	if err != nil {
+		h.Fail(err)
+		return
+	}
+	h.Ok(h.Ctx, h.Tr("message.success"), result)
+	return
+}
+
+
+// ConfirmTwoFactor
+// @Title ConfirmTwoFactor
+// @Tags User
+// @Summary ConfirmTwoFactor enable 2fa with the first authenticator code, the backup codes are only returned once
+// @Produce json
+// @Security ApiKeyAuth
+// @Param Accept-Language header string false "lang"
+// @Success 200 {object} swagger.BaseResponse{errors=[]object,data=domain.ConfirmTwoFactorResponse}
+// @Failure 400 {object} swagger.BadRequestErrorValidationResponse{errors=[]swagger.ValidationErrors,data=object}
+// @Failure 408 {object} swagger.RequestTimeoutResponse{errors=[]object,data=object}
+// @Failure 500 {object} swagger.InternalServerErrorResponse{errors=[]object,data=object}
+// @Param body body domain.ConfirmTwoFactorRequest true "request payload"
+// @Router /v1/user/2fa/confirm [post]
+func (h *UserHandler) ConfirmTwoFactor() {
+	var request domain.ConfirmTwoFactorRequest
+
+	if err := h.BindJSON(&request); err != nil {
+		h.Ctx.Input.SetData("stackTrace", h.ZapLogger.SetMessageLog(err))
+		h.ResponseError(h.Ctx, http.StatusBadRequest, response.ApiValidationCodeError, response.ErrorCodeText(response.ApiValidationCodeError, h.Locale.Lang), err)
+		return
+	}
+	if err := validator.Validate.ValidateStruct(&request); err != nil {
+		h.Ctx.Input.SetData("stackTrace", h.ZapLogger.SetMessageLog(err))
+		h.ResponseError(h.Ctx, http.StatusBadRequest, response.ApiValidationCodeError, response.ErrorCodeText(response.ApiValidationCodeError, h.Locale.Lang), err)
+		return
+	}
+
+	result, err := h.Usecase.ConfirmTwoFactor(h.Ctx.Request.Context(), request)
+	if err != nil {
+		h.Fail(err)
+		return
+	}
+	h.Ok(h.Ctx, h.Tr("message.success"), result)
+	return
+}
+
+// DeleteAccount
+// @Title DeleteAccount
+// @Tags User
+// @Summary DeleteAccount deletes the account of the user login, the data is purged after the grace period
+// @Produce json
+// @Security ApiKeyAuth
+// @Param Accept-Language header string false "lang"
+// @Success 200 {object} swagger.BaseResponse{errors=[]object,data=object}
+// @Failure 400 {object} swagger.BadRequestErrorValidationResponse{errors=[]swagger.ValidationErrors,data=object}
+// @Failure 408 {object} swagger.RequestTimeoutResponse{errors=[]object,data=object}
+// @Failure 500 {object} swagger.InternalServerErrorResponse{errors=[]object,data=object}
+// @Router /v1/user/me [delete]
+func (h *UserHandler) DeleteAccount() {
+	err := h.Usecase.DeleteAccount(h.Ctx.Request.Context())
+	if err != nil {
+		h.Fail(err)
+		return
+	}
+	h.Ok(h.Ctx, h.Tr("message.success"), nil)
+	return
+}
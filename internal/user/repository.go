@@ -3,21 +3,21 @@ package user
 import (
 	"context"
 	"github.com/radyatamaa/dating-apps-api/internal/domain"
+	"github.com/radyatamaa/dating-apps-api/pkg/database"
 	"github.com/radyatamaa/dating-apps-api/pkg/database/paginator"
-	"gorm.io/gorm"
+	"time"
 )
 
 // MysqlRepository Repository Interface
 type MysqlRepository interface {
-	FetchWithFilterAndPagination(ctx context.Context, limit int, offset int, order string, fields, associate, filter []string, model interface{}, args ...interface{}) (*paginator.Paginator, error)
-	SingleWithFilter(ctx context.Context, fields, associate, filter []string, model interface{}, args ...interface{}) error
-	FetchWithFilter(ctx context.Context, limit int, offset int, order string, fields, associate, filter []string, model interface{}, args ...interface{}) (interface{}, error)
+	FetchWithFilterAndPagination(ctx context.Context, limit int, offset int, query *database.Query, model interface{}) (*paginator.Paginator, error)
+	SingleWithFilter(ctx context.Context, query *database.Query, model interface{}) error
+	FetchWithFilter(ctx context.Context, query *database.Query, model interface{}) (interface{}, error)
 	Update(ctx context.Context, data domain.User) error
 	UpdateSelectedField(ctx context.Context, field []string, values map[string]interface{}, id int) error
-	UpdateSelectedFieldWithTx(ctx context.Context, tx *gorm.DB, field []string, values map[string]interface{}, id int) error
 	Store(ctx context.Context, data domain.User) (domain.User, error)
-	StoreWithTx(ctx context.Context, tx *gorm.DB, data domain.User) (int, error)
 	Delete(ctx context.Context, id int) (int, error)
 	SoftDelete(ctx context.Context, id int) (int, error)
-	DB() *gorm.DB
+	FetchSoftDeleted(ctx context.Context, deletedBefore time.Time, limit int) ([]domain.User, error)
+	UpdateBackupCodes(ctx context.Context, id int, current string, remaining string) (bool, error)
 }
@@ -3,123 +3,66 @@ package repository
 import (
 	"context"
 	"github.com/radyatamaa/dating-apps-api/internal/user"
-	"strings"
+	"time"
 
 	"github.com/radyatamaa/dating-apps-api/internal/domain"
-	"github.com/radyatamaa/dating-apps-api/pkg/database/paginator"
+	"github.com/radyatamaa/dating-apps-api/pkg/database"
+	baseRepository "github.com/radyatamaa/dating-apps-api/pkg/database/repository"
 	"github.com/radyatamaa/dating-apps-api/pkg/zaplogger"
 	"gorm.io/gorm"
 )
 
 type mysqlRepository struct {
+	baseRepository.Repository
 	zapLogger zaplogger.Logger
-	db        *gorm.DB
 }
 
 func NewMysqlRepository(db *gorm.DB, zapLogger zaplogger.Logger) user.MysqlRepository {
 	return &mysqlRepository{
-		db:        db,
-		zapLogger: zapLogger,
+		Repository: baseRepository.New(db, domain.User{}, domain.Profile{}),
+		zapLogger:  zapLogger,
 	}
 }
 
-func (c mysqlRepository) DB() *gorm.DB {
-	return c.db
-}
-
-func (c mysqlRepository) FetchWithFilterAndPagination(ctx context.Context, limit int, offset int, order string, fields, associate, filter []string, model interface{}, args ...interface{}) (*paginator.Paginator, error) {
-	p := paginator.NewPaginator(c.db, offset, limit, model)
-	if err := p.FindWithFilter(ctx, order, fields, associate, filter, args...).Select(strings.Join(fields, ",")).Error; err != nil {
-		return p, err
-	}
-	return p, nil
-}
-
-func (c mysqlRepository) FetchWithFilter(ctx context.Context, limit int, offset int, order string, fields, associate, filter []string, model interface{}, args ...interface{}) (interface{}, error) {
-	p := paginator.NewPaginator(c.db, offset, limit, model)
-	if err := p.FindWithFilter(ctx, order, fields, associate, filter, args).Select(strings.Join(fields, ",")).Error; err != nil {
-		return nil, err
-	}
-	return model, nil
-}
-
-func (c mysqlRepository) SingleWithFilter(ctx context.Context, fields, associate, filter []string, model interface{}, args ...interface{}) error {
-
-	db := c.db.WithContext(ctx)
-
-	if len(fields) > 0 {
-		db = db.Select(strings.Join(fields, ","))
-	}
-	if len(associate) > 0 {
-		for _, v := range associate {
-			db.Joins(v)
-		}
-	}
-
-	if len(filter) > 0 && len(args) == len(filter) {
-		for i := range filter {
-			db = db.Where(filter[i], args[i])
-		}
-	}
-
-	if err := db.First(model).Error; err != nil {
-		return err
-	}
-	return nil
-}
-
 func (c mysqlRepository) Update(ctx context.Context, data domain.User) error {
-
-	err := c.db.WithContext(ctx).Updates(&data).Error
-	if err != nil {
-		return err
-	}
-	return nil
-}
-
-func (c mysqlRepository) UpdateSelectedField(ctx context.Context, field []string, values map[string]interface{}, id int) error {
-
-	return c.db.WithContext(ctx).Table(domain.User{}.TableName()).Select(field).Where("id =?", id).Updates(values).Error
+	return c.Updates(ctx, &data)
 }
 
 func (c mysqlRepository) Store(ctx context.Context, data domain.User) (domain.User, error) {
 
-	err := c.db.WithContext(ctx).Create(&data).Error
+	err := c.Create(ctx, &data)
 	if err != nil {
 		return data, err
 	}
 	return data, nil
 }
 
-func (c mysqlRepository) Delete(ctx context.Context, id int) (int, error) {
-
-	err := c.db.WithContext(ctx).Exec("delete from "+domain.User{}.TableName()+" where id =?", id).Error
-	if err != nil {
-		return id, err
-	}
-	return id, nil
-}
-
-func (c mysqlRepository) SoftDelete(ctx context.Context, id int) (int, error) {
-	var data domain.User
+// FetchSoftDeleted returns the users soft deleted before deletedBefore, oldest first.
+func (c mysqlRepository) FetchSoftDeleted(ctx context.Context, deletedBefore time.Time, limit int) ([]domain.User, error) {
+	var data []domain.User
 
-	err := c.db.WithContext(ctx).Where("id = ?", id).Delete(&data).Error
+	err := database.FromContext(ctx, c.DB()).Unscoped().
+		Where("deleted_at IS NOT NULL AND deleted_at < ?", deletedBefore).
+		Order("deleted_at ASC").
+		Limit(limit).
+		Find(&data).Error
 	if err != nil {
-		return id, err
+		return nil, err
 	}
-	return id, nil
-}
-
-func (c mysqlRepository) UpdateSelectedFieldWithTx(ctx context.Context, tx *gorm.DB, field []string, values map[string]interface{}, id int) error {
-
-	return tx.WithContext(ctx).Table(domain.User{}.TableName()).Select(field).Where("id =?", id).Updates(values).Error
+	return data, nil
 }
 
-func (c mysqlRepository) StoreWithTx(ctx context.Context, tx *gorm.DB, data domain.User) (int, error) {
-
-	err := tx.WithContext(ctx).Create(&data).Error
-	if err != nil {
-		return data.ID, err
+// UpdateBackupCodes replaces the backup codes of the user when they're still current,
+// it reports false when they were changed meanwhile, ex: a backup code consumed by a concurrent login.
+func (c mysqlRepository) UpdateBackupCodes(ctx context.Context, id int, current string, remaining string) (bool, error) {
+	result := database.FromContext(ctx, c.DB()).Model(&domain.User{}).
+		Where("id = ? AND two_factor_backup_codes = ?", id, current).
+		Updates(map[string]interface{}{
+			"two_factor_backup_codes": remaining,
+			"updated_at":              time.Now(),
+		})
+	if result.Error != nil {
+		return false, result.Error
 	}
-	return data.ID, nil
+	return result.RowsAffected == 1, nil
 }
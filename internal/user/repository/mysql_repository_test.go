@@ -8,6 +8,9 @@ import (
 	"github.com/DATA-DOG/go-sqlmock"
 	"github.com/radyatamaa/dating-apps-api/internal/domain"
 	"github.com/radyatamaa/dating-apps-api/internal/user"
+	"github.com/radyatamaa/dating-apps-api/pkg/database"
+	baseRepository "github.com/radyatamaa/dating-apps-api/pkg/database/repository"
+	"github.com/radyatamaa/dating-apps-api/pkg/database/repository/repositorytest"
 	"github.com/radyatamaa/dating-apps-api/pkg/helper"
 	"github.com/radyatamaa/dating-apps-api/pkg/zaplogger"
 	"github.com/stretchr/testify/assert"
@@ -79,15 +82,9 @@ func (t *MysqlRepositoryTestSuite) TestNewMysqlRepository() {
 
 func (t *MysqlRepositoryTestSuite) TestFetchWithFilter() {
 	type args struct {
-		ctx       context.Context
-		limit     int
-		offset    int
-		order     string
-		fields    []string
-		associate []string
-		filter    []string
-		model     interface{}
-		args      []interface{}
+		ctx    context.Context
+		query  *database.Query
+		model  interface{}
 	}
 	tests := []struct {
 		name    string
@@ -111,31 +108,19 @@ func (t *MysqlRepositoryTestSuite) TestFetchWithFilter() {
 
 				row, fieldsDomain := helper.GetValueAndColumnStructToDriverValue(mockDomain)
 
-				mockDB.ExpectQuery(regexp.QuoteMeta("SELECT * FROM `users` WHERE users.id = (?) ORDER BY users.id ASC LIMIT 10")).
+				mockDB.ExpectQuery(regexp.QuoteMeta("SELECT * FROM `users` WHERE `users`.`id` = ? AND `users`.`deleted_at` IS NULL ORDER BY `users`.`id` LIMIT 10")).
 					WithArgs(1).WillReturnRows(
 					sqlmock.NewRows(fieldsDomain).AddRow(row...))
 
-				mockDB.ExpectQuery(regexp.QuoteMeta("SELECT count(*) FROM `users` WHERE users.id = (?) LIMIT 10")).
-					WithArgs(1).WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(1))
-
 				return fields
 			},
 			args: args{
 				ctx:    context.TODO(),
-				limit:  10,
-				offset: 0,
-				order:  "users.id ASC",
-				fields: []string{
-					"*",
-				},
-				associate: []string{},
-				filter: []string{
-					"users.id = ?",
-				},
+				query: database.NewQuery().
+					Where(database.Eq("users.id", 1)).
+					OrderBy(database.Asc("users.id")).
+					Limit(10),
 				model: &domain.User{},
-				args: []interface{}{
-					1,
-				},
 			},
 			wantErr: assert.NoError,
 		},
@@ -155,33 +140,21 @@ func (t *MysqlRepositoryTestSuite) TestFetchWithFilter() {
 
 				row, fieldsDomain := helper.GetValueAndColumnStructToDriverValue(mockDomain)
 
-				mockDB.ExpectQuery(regexp.QuoteMeta("SELECT * FROM `users` INNER JOIN profile on profile.user_id = users.id WHERE users.id = (?) ORDER BY users.id ASC LIMIT 10")).
+				mockDB.ExpectQuery(regexp.QuoteMeta("SELECT `users`.* FROM `users` INNER JOIN `profile` ON `profile`.`user_id` = `users`.`id` WHERE `users`.`id` = ? AND `users`.`deleted_at` IS NULL ORDER BY `users`.`id` LIMIT 10")).
 					WithArgs(1).WillReturnRows(
 					sqlmock.NewRows(fieldsDomain).AddRow(row...))
 
-				mockDB.ExpectQuery(regexp.QuoteMeta("SELECT count(*) FROM `users` INNER JOIN profile on profile.user_id = users.id WHERE users.id = (?) LIMIT 10")).
-					WithArgs(1).WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(1))
-
 				return fields
 			},
 			args: args{
 				ctx:    context.TODO(),
-				limit:  10,
-				offset: 0,
-				order:  "users.id ASC",
-				fields: []string{
-					"*",
-				},
-				associate: []string{
-					"INNER JOIN profile on profile.user_id = users.id",
-				},
-				filter: []string{
-					"users.id = ?",
-				},
+				query: database.NewQuery().
+					Select("users.*").
+					Join(database.InnerJoin("profile", database.EqColumn("profile.user_id", "users.id"))).
+					Where(database.Eq("users.id", 1)).
+					OrderBy(database.Asc("users.id")).
+					Limit(10),
 				model: &domain.User{},
-				args: []interface{}{
-					1,
-				},
 			},
 			wantErr: assert.NoError,
 		},
@@ -192,7 +165,6 @@ func (t *MysqlRepositoryTestSuite) TestFetchWithFilter() {
 					zapLogger: zaplogger.NewZapLogger("", ""),
 					db:        t.DB,
 				}
-
 				mockDB := t.mock
 
 				mockDomain := domain.User{}
@@ -200,105 +172,66 @@ func (t *MysqlRepositoryTestSuite) TestFetchWithFilter() {
 				err := faker.FakeData(&mockDomain)
 				t.NoError(err)
 
-				mockDB.ExpectQuery(regexp.QuoteMeta("SELECT * FROM `users` WHERE users.id = (?) ORDER BY users.id ASC LIMIT 10")).
+				mockDB.ExpectQuery(regexp.QuoteMeta("SELECT * FROM `users` WHERE `users`.`id` = ? AND `users`.`deleted_at` IS NULL ORDER BY `users`.`id` LIMIT 10")).
 					WithArgs(1).WillReturnError(errors.New("context deadline exceeded"))
 
 				return fields
 			},
 			args: args{
 				ctx:    context.TODO(),
-				limit:  10,
-				offset: 0,
-				order:  "users.id ASC",
-				fields: []string{
-					"*",
-				},
-				associate: []string{},
-				filter: []string{
-					"users.id = ?",
-				},
+				query: database.NewQuery().
+					Where(database.Eq("users.id", 1)).
+					OrderBy(database.Asc("users.id")).
+					Limit(10),
 				model: &domain.User{},
-				args: []interface{}{
-					1,
-				},
 			},
 			wantErr: func(t assert.TestingT, err error, i ...interface{}) bool {
 				return assert.EqualError(t, err, "context deadline exceeded")
 			},
 		},
 		{
-			name: "count context deadline exceeded error",
+			name: "malformed filter error",
 			fields: func(args *args) fields {
 				fields := fields{
 					zapLogger: zaplogger.NewZapLogger("", ""),
 					db:        t.DB,
 				}
-				mockDB := t.mock
-
-				mockDomain := domain.User{}
-
-				err := faker.FakeData(&mockDomain)
-				t.NoError(err)
-
-				row, fieldsDomain := helper.GetValueAndColumnStructToDriverValue(mockDomain)
-				mockDB.ExpectQuery(regexp.QuoteMeta("SELECT * FROM `users` WHERE users.id = (?) ORDER BY users.id ASC LIMIT 10")).
-					WithArgs(1).WillReturnRows(
-					sqlmock.NewRows(fieldsDomain).AddRow(row...))
-
-				mockDB.ExpectQuery(regexp.QuoteMeta("SELECT count(*) FROM `users` WHERE users.id = (?) LIMIT 10")).
-					WithArgs(1).WillReturnError(context.DeadlineExceeded)
-
+				// the query is refused before reaching the database
 				return fields
 			},
 			args: args{
 				ctx:    context.TODO(),
-				limit:  10,
-				offset: 0,
-				order:  "users.id ASC",
-				fields: []string{
-					"*",
-				},
-				associate: []string{},
-				filter: []string{
-					"users.id = ?",
-				},
+				query: database.NewQuery().
+					Where(database.Eq("users.id = 1 OR 1", 1)),
 				model: &domain.User{},
-				args: []interface{}{
-					1,
-				},
 			},
 			wantErr: func(t assert.TestingT, err error, i ...interface{}) bool {
-				return assert.EqualError(t, err, "context deadline exceeded")
+				return assert.ErrorIs(t, err, database.ErrUnknownColumn)
 			},
 		},
 	}
 
 	for _, tt := range tests {
-		t.Run(tt.name,  func() {
+		t.Run(tt.name, func() {
 			fields := tt.fields(&tt.args)
 			c := mysqlRepository{
 				zapLogger: fields.zapLogger,
-				db:        fields.db,
+				Repository: baseRepository.New(fields.db, domain.User{}, domain.Profile{}),
 			}
-			_,err := c.FetchWithFilter(tt.args.ctx, tt.args.limit, tt.args.offset, tt.args.order, tt.args.fields, tt.args.associate, tt.args.filter, tt.args.model, tt.args.args...)
+			_, err := c.FetchWithFilter(tt.args.ctx, tt.args.query, tt.args.model)
 			tt.wantErr(t.T(), err,
-				fmt.Sprintf("FetchWithFilter(%v, %v, %v, %v, %v, %v, %v, %v, %v)",
-					tt.args.ctx, tt.args.limit, tt.args.offset, tt.args.order, tt.args.fields, tt.args.associate, tt.args.filter, tt.args.model, tt.args.args))
+				fmt.Sprintf("FetchWithFilter(%v, %v, %v)", tt.args.ctx, tt.args.query, tt.args.model))
 		})
 	}
 }
 
 func (t *MysqlRepositoryTestSuite) TestFetchWithFilterAndPagination() {
 	type args struct {
-		ctx       context.Context
-		limit     int
-		offset    int
-		order     string
-		fields    []string
-		associate []string
-		filter    []string
-		model     interface{}
-		args      []interface{}
+		ctx    context.Context
+		limit  int
+		offset int
+		query  *database.Query
+		model  interface{}
 	}
 	tests := []struct {
 		name    string
@@ -322,11 +255,11 @@ func (t *MysqlRepositoryTestSuite) TestFetchWithFilterAndPagination() {
 
 				row, fieldsDomain := helper.GetValueAndColumnStructToDriverValue(mockDomain)
 
-				mockDB.ExpectQuery(regexp.QuoteMeta("SELECT * FROM `users` WHERE users.id = ? ORDER BY users.id ASC LIMIT 10")).
+				mockDB.ExpectQuery(regexp.QuoteMeta("SELECT * FROM `users` WHERE `users`.`id` = ? AND `users`.`deleted_at` IS NULL ORDER BY `users`.`id` LIMIT 11")).
 					WithArgs(1).WillReturnRows(
 					sqlmock.NewRows(fieldsDomain).AddRow(row...))
 
-				mockDB.ExpectQuery(regexp.QuoteMeta("SELECT count(*) FROM `users` WHERE users.id = ? LIMIT 10")).
+				mockDB.ExpectQuery(regexp.QuoteMeta("SELECT count(*) FROM `users` WHERE `users`.`id` = ? AND `users`.`deleted_at` IS NULL")).
 					WithArgs(1).WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(1))
 
 				return fields
@@ -335,18 +268,10 @@ func (t *MysqlRepositoryTestSuite) TestFetchWithFilterAndPagination() {
 				ctx:    context.TODO(),
 				limit:  10,
 				offset: 0,
-				order:  "users.id ASC",
-				fields: []string{
-					"*",
-				},
-				associate: []string{},
-				filter: []string{
-					"users.id = ?",
-				},
+				query: database.NewQuery().
+					Where(database.Eq("users.id", 1)).
+					OrderBy(database.Asc("users.id")),
 				model: &domain.User{},
-				args: []interface{}{
-					1,
-				},
 			},
 			wantErr: assert.NoError,
 		},
@@ -366,11 +291,11 @@ func (t *MysqlRepositoryTestSuite) TestFetchWithFilterAndPagination() {
 
 				row, fieldsDomain := helper.GetValueAndColumnStructToDriverValue(mockDomain)
 
-				mockDB.ExpectQuery(regexp.QuoteMeta("SELECT * FROM `users` INNER JOIN profile on profile.user_id = users.id WHERE users.id = ? ORDER BY users.id ASC LIMIT 10")).
+				mockDB.ExpectQuery(regexp.QuoteMeta("SELECT `users`.* FROM `users` INNER JOIN `profile` ON `profile`.`user_id` = `users`.`id` WHERE `users`.`id` = ? AND `users`.`deleted_at` IS NULL ORDER BY `users`.`id` LIMIT 11")).
 					WithArgs(1).WillReturnRows(
 					sqlmock.NewRows(fieldsDomain).AddRow(row...))
 
-				mockDB.ExpectQuery(regexp.QuoteMeta("SELECT count(*) FROM `users` INNER JOIN profile on profile.user_id = users.id WHERE users.id = ? LIMIT 10")).
+				mockDB.ExpectQuery(regexp.QuoteMeta("SELECT count(*) FROM `users` INNER JOIN `profile` ON `profile`.`user_id` = `users`.`id` WHERE `users`.`id` = ? AND `users`.`deleted_at` IS NULL")).
 					WithArgs(1).WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(1))
 
 				return fields
@@ -379,20 +304,12 @@ func (t *MysqlRepositoryTestSuite) TestFetchWithFilterAndPagination() {
 				ctx:    context.TODO(),
 				limit:  10,
 				offset: 0,
-				order:  "users.id ASC",
-				fields: []string{
-					"*",
-				},
-				associate: []string{
-					"INNER JOIN profile on profile.user_id = users.id",
-				},
-				filter: []string{
-					"users.id = ?",
-				},
+				query: database.NewQuery().
+					Select("users.*").
+					Join(database.InnerJoin("profile", database.EqColumn("profile.user_id", "users.id"))).
+					Where(database.Eq("users.id", 1)).
+					OrderBy(database.Asc("users.id")),
 				model: &domain.User{},
-				args: []interface{}{
-					1,
-				},
 			},
 			wantErr: assert.NoError,
 		},
@@ -403,7 +320,6 @@ func (t *MysqlRepositoryTestSuite) TestFetchWithFilterAndPagination() {
 					zapLogger: zaplogger.NewZapLogger("", ""),
 					db:        t.DB,
 				}
-
 				mockDB := t.mock
 
 				mockDomain := domain.User{}
@@ -411,7 +327,7 @@ func (t *MysqlRepositoryTestSuite) TestFetchWithFilterAndPagination() {
 				err := faker.FakeData(&mockDomain)
 				t.NoError(err)
 
-				mockDB.ExpectQuery(regexp.QuoteMeta("SELECT * FROM `users` WHERE users.id = ? ORDER BY users.id ASC LIMIT 10")).
+				mockDB.ExpectQuery(regexp.QuoteMeta("SELECT * FROM `users` WHERE `users`.`id` = ? AND `users`.`deleted_at` IS NULL ORDER BY `users`.`id` LIMIT 11")).
 					WithArgs(1).WillReturnError(errors.New("context deadline exceeded"))
 
 				return fields
@@ -420,18 +336,10 @@ func (t *MysqlRepositoryTestSuite) TestFetchWithFilterAndPagination() {
 				ctx:    context.TODO(),
 				limit:  10,
 				offset: 0,
-				order:  "users.id ASC",
-				fields: []string{
-					"*",
-				},
-				associate: []string{},
-				filter: []string{
-					"users.id = ?",
-				},
+				query: database.NewQuery().
+					Where(database.Eq("users.id", 1)).
+					OrderBy(database.Asc("users.id")),
 				model: &domain.User{},
-				args: []interface{}{
-					1,
-				},
 			},
 			wantErr: func(t assert.TestingT, err error, i ...interface{}) bool {
 				return assert.EqualError(t, err, "context deadline exceeded")
@@ -452,11 +360,12 @@ func (t *MysqlRepositoryTestSuite) TestFetchWithFilterAndPagination() {
 				t.NoError(err)
 
 				row, fieldsDomain := helper.GetValueAndColumnStructToDriverValue(mockDomain)
-				mockDB.ExpectQuery(regexp.QuoteMeta("SELECT * FROM `users` WHERE users.id = ? ORDER BY users.id ASC LIMIT 10")).
+
+				mockDB.ExpectQuery(regexp.QuoteMeta("SELECT * FROM `users` WHERE `users`.`id` = ? AND `users`.`deleted_at` IS NULL ORDER BY `users`.`id` LIMIT 11")).
 					WithArgs(1).WillReturnRows(
 					sqlmock.NewRows(fieldsDomain).AddRow(row...))
 
-				mockDB.ExpectQuery(regexp.QuoteMeta("SELECT count(*) FROM `users` WHERE users.id = ? LIMIT 10")).
+				mockDB.ExpectQuery(regexp.QuoteMeta("SELECT count(*) FROM `users` WHERE `users`.`id` = ? AND `users`.`deleted_at` IS NULL")).
 					WithArgs(1).WillReturnError(context.DeadlineExceeded)
 
 				return fields
@@ -465,48 +374,58 @@ func (t *MysqlRepositoryTestSuite) TestFetchWithFilterAndPagination() {
 				ctx:    context.TODO(),
 				limit:  10,
 				offset: 0,
-				order:  "users.id ASC",
-				fields: []string{
-					"*",
-				},
-				associate: []string{},
-				filter: []string{
-					"users.id = ?",
-				},
+				query: database.NewQuery().
+					Where(database.Eq("users.id", 1)).
+					OrderBy(database.Asc("users.id")),
 				model: &domain.User{},
-				args: []interface{}{
-					1,
-				},
 			},
 			wantErr: func(t assert.TestingT, err error, i ...interface{}) bool {
 				return assert.EqualError(t, err, "context deadline exceeded")
 			},
 		},
+		{
+			name: "malformed filter error",
+			fields: func(args *args) fields {
+				fields := fields{
+					zapLogger: zaplogger.NewZapLogger("", ""),
+					db:        t.DB,
+				}
+				// the query is refused before reaching the database
+				return fields
+			},
+			args: args{
+				ctx:    context.TODO(),
+				limit:  10,
+				offset: 0,
+				query: database.NewQuery().
+					Where(database.Eq("users.id = 1 OR 1", 1)),
+				model: &domain.User{},
+			},
+			wantErr: func(t assert.TestingT, err error, i ...interface{}) bool {
+				return assert.ErrorIs(t, err, database.ErrUnknownColumn)
+			},
+		},
 	}
 
 	for _, tt := range tests {
-		t.Run(tt.name,  func() {
+		t.Run(tt.name, func() {
 			fields := tt.fields(&tt.args)
 			c := mysqlRepository{
 				zapLogger: fields.zapLogger,
-				db:        fields.db,
+				Repository: baseRepository.New(fields.db, domain.User{}, domain.Profile{}),
 			}
-			_,err := c.FetchWithFilterAndPagination(tt.args.ctx, tt.args.limit, tt.args.offset, tt.args.order, tt.args.fields, tt.args.associate, tt.args.filter, tt.args.model, tt.args.args...)
+			_, err := c.FetchWithFilterAndPagination(tt.args.ctx, tt.args.limit, tt.args.offset, tt.args.query, tt.args.model)
 			tt.wantErr(t.T(), err,
-				fmt.Sprintf("FetchWithFilterAndPagination(%v, %v, %v, %v, %v, %v, %v, %v, %v)",
-					tt.args.ctx, tt.args.limit, tt.args.offset, tt.args.order, tt.args.fields, tt.args.associate, tt.args.filter, tt.args.model, tt.args.args))
+				fmt.Sprintf("FetchWithFilterAndPagination(%v, %v, %v, %v, %v)", tt.args.ctx, tt.args.limit, tt.args.offset, tt.args.query, tt.args.model))
 		})
 	}
 }
 
 func (t *MysqlRepositoryTestSuite) TestSingleWithFilter() {
 	type args struct {
-		ctx       context.Context
-		fields    []string
-		associate []string
-		filter    []string
-		model     interface{}
-		args      []interface{}
+		ctx    context.Context
+		query  *database.Query
+		model  interface{}
 	}
 	tests := []struct {
 		name    string
@@ -530,26 +449,17 @@ func (t *MysqlRepositoryTestSuite) TestSingleWithFilter() {
 
 				row, fieldsDomain := helper.GetValueAndColumnStructToDriverValue(mockDomain)
 
-				mockDB.ExpectQuery(regexp.QuoteMeta("SELECT * FROM `users` WHERE users.id = ? ORDER BY `users`.`id` LIMIT 1")).
+				mockDB.ExpectQuery(regexp.QuoteMeta("SELECT * FROM `users` WHERE `users`.`id` = ? AND `users`.`deleted_at` IS NULL ORDER BY `users`.`id` LIMIT 1")).
 					WithArgs(1).WillReturnRows(
 					sqlmock.NewRows(fieldsDomain).AddRow(row...))
 
-
 				return fields
 			},
 			args: args{
 				ctx:    context.TODO(),
-				fields: []string{
-					"*",
-				},
-				associate: []string{},
-				filter: []string{
-					"users.id = ?",
-				},
+				query: database.NewQuery().
+					Where(database.Eq("users.id", 1)),
 				model: &domain.User{},
-				args: []interface{}{
-					1,
-				},
 			},
 			wantErr: assert.NoError,
 		},
@@ -569,7 +479,7 @@ func (t *MysqlRepositoryTestSuite) TestSingleWithFilter() {
 
 				row, fieldsDomain := helper.GetValueAndColumnStructToDriverValue(mockDomain)
 
-				mockDB.ExpectQuery(regexp.QuoteMeta("SELECT * FROM `users` INNER JOIN profile on profile.user_id = users.id WHERE users.id = ? ORDER BY `users`.`id` LIMIT 1")).
+				mockDB.ExpectQuery(regexp.QuoteMeta("SELECT `users`.* FROM `users` INNER JOIN `profile` ON `profile`.`user_id` = `users`.`id` WHERE `users`.`id` = ? AND `users`.`deleted_at` IS NULL ORDER BY `users`.`id` LIMIT 1")).
 					WithArgs(1).WillReturnRows(
 					sqlmock.NewRows(fieldsDomain).AddRow(row...))
 
@@ -577,19 +487,11 @@ func (t *MysqlRepositoryTestSuite) TestSingleWithFilter() {
 			},
 			args: args{
 				ctx:    context.TODO(),
-				fields: []string{
-					"*",
-				},
-				associate: []string{
-					"INNER JOIN profile on profile.user_id = users.id",
-				},
-				filter: []string{
-					"users.id = ?",
-				},
+				query: database.NewQuery().
+					Select("users.*").
+					Join(database.InnerJoin("profile", database.EqColumn("profile.user_id", "users.id"))).
+					Where(database.Eq("users.id", 1)),
 				model: &domain.User{},
-				args: []interface{}{
-					1,
-				},
 			},
 			wantErr: assert.NoError,
 		},
@@ -600,7 +502,6 @@ func (t *MysqlRepositoryTestSuite) TestSingleWithFilter() {
 					zapLogger: zaplogger.NewZapLogger("", ""),
 					db:        t.DB,
 				}
-
 				mockDB := t.mock
 
 				mockDomain := domain.User{}
@@ -608,41 +509,53 @@ func (t *MysqlRepositoryTestSuite) TestSingleWithFilter() {
 				err := faker.FakeData(&mockDomain)
 				t.NoError(err)
 
-				mockDB.ExpectQuery(regexp.QuoteMeta("SELECT * FROM `users` WHERE users.id = ? ORDER BY `users`.`id` LIMIT 1")).
+				mockDB.ExpectQuery(regexp.QuoteMeta("SELECT * FROM `users` WHERE `users`.`id` = ? AND `users`.`deleted_at` IS NULL ORDER BY `users`.`id` LIMIT 1")).
 					WithArgs(1).WillReturnError(errors.New("context deadline exceeded"))
 
 				return fields
 			},
 			args: args{
 				ctx:    context.TODO(),
-				fields: []string{
-					"*",
-				},
-				associate: []string{},
-				filter: []string{
-					"users.id = ?",
-				},
+				query: database.NewQuery().
+					Where(database.Eq("users.id", 1)),
 				model: &domain.User{},
-				args: []interface{}{
-					1,
-				},
 			},
 			wantErr: func(t assert.TestingT, err error, i ...interface{}) bool {
 				return assert.EqualError(t, err, "context deadline exceeded")
 			},
 		},
+		{
+			name: "malformed filter error",
+			fields: func(args *args) fields {
+				fields := fields{
+					zapLogger: zaplogger.NewZapLogger("", ""),
+					db:        t.DB,
+				}
+				// the query is refused before reaching the database
+				return fields
+			},
+			args: args{
+				ctx:    context.TODO(),
+				query: database.NewQuery().
+					Where(database.Eq("users.id = 1 OR 1", 1)),
+				model: &domain.User{},
+			},
+			wantErr: func(t assert.TestingT, err error, i ...interface{}) bool {
+				return assert.ErrorIs(t, err, database.ErrUnknownColumn)
+			},
+		},
 	}
 
 	for _, tt := range tests {
-		t.Run(tt.name,  func() {
+		t.Run(tt.name, func() {
 			fields := tt.fields(&tt.args)
 			c := mysqlRepository{
 				zapLogger: fields.zapLogger,
-				db:        fields.db,
+				Repository: baseRepository.New(fields.db, domain.User{}, domain.Profile{}),
 			}
-			err := c.SingleWithFilter(tt.args.ctx, tt.args.fields, tt.args.associate, tt.args.filter, tt.args.model, tt.args.args...)
+			err := c.SingleWithFilter(tt.args.ctx, tt.args.query, tt.args.model)
 			tt.wantErr(t.T(), err,
-				fmt.Sprintf("SingleWithFilter(%v, %v, %v, %v, %v, %v)", tt.args.ctx, tt.args.fields, tt.args.associate, tt.args.filter, tt.args.model, tt.args.args))
+				fmt.Sprintf("SingleWithFilter(%v, %v, %v)", tt.args.ctx, tt.args.query, tt.args.model))
 		})
 	}
 }
@@ -674,8 +587,8 @@ func (t *MysqlRepositoryTestSuite) TestUpdate() {
 				args.data = mockDomain
 
 				mockDB.ExpectBegin()
-				mockDB.ExpectExec(regexp.QuoteMeta("UPDATE `users` SET `password_hash`=?, `email`=?, `premium_expires_at`=?, `created_at`=?, `updated_at`=? WHERE `id` = ?")).
-					WithArgs(mockDomain.PasswordHash, mockDomain.Email, mockDomain.PremiumExpiresAt, mockDomain.CreatedAt, mockDomain.UpdatedAt, mockDomain.ID).
+				mockDB.ExpectExec(regexp.QuoteMeta("UPDATE `users` SET `password_hash`=?, `email`=?, `premium_expires_at`=?, `two_factor_enabled`=?, `two_factor_secret`=?, `two_factor_backup_codes`=?, `created_at`=?, `updated_at`=? WHERE `id` = ?")).
+					WithArgs(mockDomain.PasswordHash, mockDomain.Email, mockDomain.PremiumExpiresAt, mockDomain.TwoFactorEnabled, mockDomain.TwoFactorSecret, mockDomain.TwoFactorBackupCodes, mockDomain.CreatedAt, mockDomain.UpdatedAt, mockDomain.ID).
 					WillReturnResult(sqlmock.NewResult(1, 1))
 				mockDB.ExpectCommit()
 
@@ -703,8 +616,8 @@ func (t *MysqlRepositoryTestSuite) TestUpdate() {
 				args.data = mockDomain
 
 				mockDB.ExpectBegin()
-				mockDB.ExpectExec(regexp.QuoteMeta("UPDATE `users` SET `password_hash`=?, `email`=?, `premium_expires_at`=?, `created_at`=?, `updated_at`=? WHERE `id` = ?")).
-					WithArgs(mockDomain.PasswordHash, mockDomain.Email, mockDomain.PremiumExpiresAt, mockDomain.CreatedAt, mockDomain.UpdatedAt, mockDomain.ID).
+				mockDB.ExpectExec(regexp.QuoteMeta("UPDATE `users` SET `password_hash`=?, `email`=?, `premium_expires_at`=?, `two_factor_enabled`=?, `two_factor_secret`=?, `two_factor_backup_codes`=?, `created_at`=?, `updated_at`=? WHERE `id` = ?")).
+					WithArgs(mockDomain.PasswordHash, mockDomain.Email, mockDomain.PremiumExpiresAt, mockDomain.TwoFactorEnabled, mockDomain.TwoFactorSecret, mockDomain.TwoFactorBackupCodes, mockDomain.CreatedAt, mockDomain.UpdatedAt, mockDomain.ID).
 					WillReturnError(errors.New("context deadline exceeded"))
 				mockDB.ExpectCommit()
 
@@ -722,7 +635,7 @@ func (t *MysqlRepositoryTestSuite) TestUpdate() {
 			fields := tt.fields(&tt.args)
 			c := mysqlRepository{
 				zapLogger: fields.zapLogger,
-				db:        fields.db,
+				Repository: baseRepository.New(fields.db, domain.User{}, domain.Profile{}),
 			}
 			err := c.Update(tt.args.ctx, tt.args.data)
 			tt.wantErr(t.T(), err,
@@ -819,7 +732,7 @@ func (t *MysqlRepositoryTestSuite) TestUpdateSelectedField() {
 			fields := tt.fields(&tt.args)
 			c := mysqlRepository{
 				zapLogger: fields.zapLogger,
-				db:        fields.db,
+				Repository: baseRepository.New(fields.db, domain.User{}, domain.Profile{}),
 			}
 			err := c.UpdateSelectedField(tt.args.ctx, tt.args.fields, tt.args.values, tt.args.id)
 			tt.wantErr(t.T(), err,
@@ -856,8 +769,8 @@ func (t *MysqlRepositoryTestSuite) TestStore() {
 				args.data = mockDomain
 
 				mockDB.ExpectBegin()
-				mockDB.ExpectExec(regexp.QuoteMeta("INSERT INTO `users` (`password_hash`,`email`,`premium_expires_at`,`created_at`,`updated_at`,`id`) VALUES (?,?,?,?,?,?)")).
-					WithArgs(sqlmock.AnyArg(), mockDomain.Email,sqlmock.AnyArg(), sqlmock.AnyArg(), sqlmock.AnyArg(), mockDomain.ID).
+				mockDB.ExpectExec(regexp.QuoteMeta("INSERT INTO `users` (`password_hash`,`email`,`role`,`premium_expires_at`,`two_factor_enabled`,`two_factor_secret`,`two_factor_backup_codes`,`hidden_at`,`suspended_until`,`banned_at`,`created_at`,`updated_at`,`deleted_at`,`id`) VALUES (?,?,?,?,?,?,?,?,?,?,?,?,?,?)")).
+					WithArgs(sqlmock.AnyArg(), mockDomain.Email, sqlmock.AnyArg(), sqlmock.AnyArg(), mockDomain.TwoFactorEnabled, mockDomain.TwoFactorSecret, mockDomain.TwoFactorBackupCodes, sqlmock.AnyArg(), sqlmock.AnyArg(), sqlmock.AnyArg(), sqlmock.AnyArg(), sqlmock.AnyArg(), sqlmock.AnyArg(), mockDomain.ID).
 					WillReturnResult(sqlmock.NewResult(1, 1))
 				mockDB.ExpectCommit()
 
@@ -886,8 +799,8 @@ func (t *MysqlRepositoryTestSuite) TestStore() {
 				args.data = mockDomain
 
 				mockDB.ExpectBegin()
-				mockDB.ExpectExec(regexp.QuoteMeta("INSERT INTO `users` (`password_hash`,`email`,`premium_expires_at`,`created_at`,`updated_at`,`id`) VALUES (?,?,?,?,?,?)")).
-					WithArgs(sqlmock.AnyArg(), mockDomain.Email,sqlmock.AnyArg(), sqlmock.AnyArg(), sqlmock.AnyArg(), mockDomain.ID).
+				mockDB.ExpectExec(regexp.QuoteMeta("INSERT INTO `users` (`password_hash`,`email`,`role`,`premium_expires_at`,`two_factor_enabled`,`two_factor_secret`,`two_factor_backup_codes`,`hidden_at`,`suspended_until`,`banned_at`,`created_at`,`updated_at`,`deleted_at`,`id`) VALUES (?,?,?,?,?,?,?,?,?,?,?,?,?,?)")).
+					WithArgs(sqlmock.AnyArg(), mockDomain.Email, sqlmock.AnyArg(), sqlmock.AnyArg(), mockDomain.TwoFactorEnabled, mockDomain.TwoFactorSecret, mockDomain.TwoFactorBackupCodes, sqlmock.AnyArg(), sqlmock.AnyArg(), sqlmock.AnyArg(), sqlmock.AnyArg(), sqlmock.AnyArg(), sqlmock.AnyArg(), mockDomain.ID).
 					WillReturnError(errors.New("context deadline exceeded"))
 				mockDB.ExpectCommit()
 
@@ -906,7 +819,7 @@ func (t *MysqlRepositoryTestSuite) TestStore() {
 			fields := tt.fields(&tt.args)
 			c := mysqlRepository{
 				zapLogger: fields.zapLogger,
-				db:        fields.db,
+				Repository: baseRepository.New(fields.db, domain.User{}, domain.Profile{}),
 			}
 			_, err := c.Store(tt.args.ctx, tt.args.data)
 			tt.wantErr(t.T(), err,
@@ -918,3 +831,12 @@ func (t *MysqlRepositoryTestSuite) TestStore() {
 func TestUserMysqlRepositoryTestSuite(t *testing.T) {
 	suite.Run(t, new(MysqlRepositoryTestSuite))
 }
+
+func TestUserMysqlRepositoryContractTestSuite(t *testing.T) {
+	suite.Run(t, &repositorytest.Suite{
+		New: func(db *gorm.DB) repositorytest.Repository {
+			return NewMysqlRepository(db, nil)
+		},
+		Model: domain.User{},
+	})
+}
@@ -1,13 +1,22 @@
 package user
 
 import (
-	beegoContext "github.com/beego/beego/v2/server/web/context"
+	"context"
+	"time"
+
 	"github.com/radyatamaa/dating-apps-api/internal/domain"
 )
 
 // UseCase Interface
 type UseCase interface {
-	Login(beegoCtx *beegoContext.Context, request domain.LoginRequest)(*domain.LoginResponse, error)
-	Register(beegoCtx *beegoContext.Context, request domain.RegisterRequest) error
-	PurchasePremiumUpdateStatus(beegoCtx *beegoContext.Context) error
+	Login(ctx context.Context, request domain.LoginRequest)(*domain.LoginResponse, error)
+	LoginWithUserId(ctx context.Context, userId int)(*domain.LoginResponse, error)
+	Register(ctx context.Context, request domain.RegisterRequest) error
+	PurchasePremiumUpdateStatus(ctx context.Context) error
+	VerifyTwoFactorLogin(ctx context.Context, request domain.VerifyTwoFactorLoginRequest)(*domain.LoginResponse, error)
+	EnrollTwoFactor(ctx context.Context)(*domain.EnrollTwoFactorResponse, error)
+	DeleteAccount(ctx context.Context) error
+	PurgeDeletedUsers(ctx context.Context, deletedBefore time.Time) ([]int, error)
+	ExpirePremiumUsers(ctx context.Context, now time.Time) (int, error)
+	ConfirmTwoFactor(ctx context.Context, request domain.ConfirmTwoFactorRequest)(*domain.ConfirmTwoFactorResponse, error)
 }
\ No newline at end of file
@@ -2,97 +2,218 @@ package usecase
 
 import (
 	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"github.com/radyatamaa/dating-apps-api/internal/audit"
 	"github.com/radyatamaa/dating-apps-api/internal/profile"
 	"github.com/radyatamaa/dating-apps-api/internal/user"
+	"strconv"
 	"time"
 
-	beegoContext "github.com/beego/beego/v2/server/web/context"
+	"github.com/beego/beego/v2/client/cache"
+	"github.com/google/uuid"
 	"github.com/radyatamaa/dating-apps-api/internal/domain"
+	"github.com/radyatamaa/dating-apps-api/pkg/helper"
+	"github.com/radyatamaa/dating-apps-api/pkg/atomiccache"
+	"github.com/radyatamaa/dating-apps-api/pkg/authuser"
+	"github.com/radyatamaa/dating-apps-api/pkg/database"
+	"github.com/radyatamaa/dating-apps-api/pkg/event"
 	"github.com/radyatamaa/dating-apps-api/pkg/jwt"
+	"github.com/radyatamaa/dating-apps-api/pkg/metrics"
+	"github.com/radyatamaa/dating-apps-api/pkg/requestinfo"
 	"github.com/radyatamaa/dating-apps-api/pkg/response"
+	"github.com/radyatamaa/dating-apps-api/pkg/tracing"
+	"github.com/radyatamaa/dating-apps-api/pkg/totp"
 	"github.com/radyatamaa/dating-apps-api/pkg/zaplogger"
 	"golang.org/x/crypto/bcrypt"
 	"gorm.io/gorm"
 )
 
+const (
+	twoFactorIssuer          = "DatingApps"
+	twoFactorChallengeExpire = 5 * time.Minute
+	twoFactorMaxAttempts     = 5
+	twoFactorBackupCodes     = 10
+
+	twoFactorChallengeKey = "2fa:challenge:%s"
+	twoFactorAttemptKey   = "2fa:attempt:%s"
+	twoFactorVerifyKey    = "2fa:verify:%s"
+	twoFactorUsedCodeKey  = "2fa:used:%d:%d"
+
+	purgeBatchSize = 100
+	expireBatchSize = 100
+)
+
 type userUseCase struct {
 	zapLogger                  zaplogger.Logger
 	jwtAuth                    jwt.JWT
 	expireToken                int
 	contextTimeout             time.Duration
+	txManager                 database.TxManager
+	publisher                 event.Publisher
+	cache                      cache.Cache
+	atomicCache                atomiccache.Cache
 	mysqlUserRepository    user.MysqlRepository
 	mysqlProfileRepository profile.MysqlRepository
+	auditUseCase           audit.UseCase
 }
 
 
 
 func NewUserUseCase(timeout time.Duration,
+	txManager database.TxManager,
+	publisher event.Publisher,
 	mysqlUserRepository    user.MysqlRepository,
 	mysqlProfileRepository profile.MysqlRepository,
 	jwtAuth jwt.JWT,
 	expireToken int,
+	cache cache.Cache,
+	atomicCache atomiccache.Cache,
+	auditUseCase audit.UseCase,
 	zapLogger zaplogger.Logger) user.UseCase {
 	return &userUseCase{
 		mysqlUserRepository:    mysqlUserRepository,
 		mysqlProfileRepository: mysqlProfileRepository,
 		contextTimeout:             timeout,
+		txManager:                 txManager,
+		publisher:                 publisher,
 		zapLogger:                  zapLogger,
 		jwtAuth:                    jwtAuth,
 		expireToken:                expireToken,
+		cache:                      cache,
+		atomicCache:                atomicCache,
+		auditUseCase:               auditUseCase,
 	}
 }
 
 /////////////////// Login
-func (a userUseCase) singleUserWithFilter(ctx context.Context, filter []string, args ...interface{}) (*domain.UserQueryWithProfile, error) {
+// singleUserWithFilter reads the account from the primary, the logins and the two factor
+// confirmation read it right after its register or update.
+func (a userUseCase) singleUserWithFilter(ctx context.Context, criteria ...database.Criterion) (*domain.UserQueryWithProfile, error) {
 	var entity domain.UserQueryWithProfile
 	if err := a.mysqlUserRepository.SingleWithFilter(
-		ctx,
-		[]string{
-			"users.*",
-			"profile.id as profile_id",
-			"profile.name as name",
-			"profile.photo as photo",
-			"profile.age as age",
-			"profile.bio as bio",
-			"profile.longitude as longitude",
-			"profile.latitude as latitude",
-		},
-		[]string{
-			"INNER JOIN profile ON profile.user_id = users.id",
-		},
-		filter,
-		&entity, args...); err != nil {
+		database.WithPrimary(ctx),
+		database.NewQuery().
+			Select(
+				"users.*",
+				"profile.id as profile_id",
+				"profile.name as name",
+				"profile.photo as photo",
+				"profile.age as age",
+				"profile.bio as bio",
+				"profile.longitude as longitude",
+				"profile.latitude as latitude",
+			).
+			Join(database.InnerJoin("profile", database.EqColumn("profile.user_id", "users.id"))).
+			Where(criteria...),
+		&entity); err != nil {
 		return nil, err
 	}
 	return &entity, nil
 }
-func (a userUseCase) Login(beegoCtx *beegoContext.Context, request domain.LoginRequest) (*domain.LoginResponse, error) {
-	ctx, cancel := context.WithTimeout(beegoCtx.Request.Context(), a.contextTimeout)
+func (a userUseCase) Login(ctx context.Context, request domain.LoginRequest) (*domain.LoginResponse, error) {
+	ctx, cancel := context.WithTimeout(ctx, a.contextTimeout)
 	defer cancel()
+	ctx, span := tracing.Start(ctx, "userUseCase.Login")
+	defer span.End()
 
-	res := new(domain.LoginResponse)
-
-	userSingle, err := a.singleUserWithFilter(ctx, []string{"email = ?"}, request.Email)
+	userSingle, err := a.singleUserWithFilter(ctx, database.Eq("users.email", request.Email))
 	if err != nil {
 		if err == gorm.ErrRecordNotFound {
-			beegoCtx.Input.SetData("stackTrace", a.zapLogger.SetMessageLog(response.ErrInvalidEmailPassword))
+			zaplogger.SetStackTrace(ctx, a.zapLogger.SetMessageLog(response.ErrInvalidEmailPassword))
 			return nil, response.ErrInvalidEmailPassword
 		}
-		beegoCtx.Input.SetData("stackTrace", a.zapLogger.SetMessageLog(err))
+		zaplogger.SetStackTrace(ctx, a.zapLogger.SetMessageLog(err))
 		return nil, err
 	}
 
 	if err = bcrypt.CompareHashAndPassword([]byte(userSingle.PasswordHash), []byte(request.Password)); err != nil {
-		beegoCtx.Input.SetData("stackTrace", a.zapLogger.SetMessageLog(response.ErrInvalidEmailPassword))
+		a.auditUseCase.Record(ctx, domain.AuditEntry{
+			Action:     domain.AuditActionLoginFailed,
+			TargetType: domain.AuditTargetUser,
+			TargetID:   userSingle.ID,
+		})
+		zaplogger.SetStackTrace(ctx, a.zapLogger.SetMessageLog(response.ErrInvalidEmailPassword))
 		return nil, response.ErrInvalidEmailPassword
 	}
 
-	token, err := a.jwtAuth.Ctx(ctx).GenerateToken(jwt.Payload{"uid": userSingle.ID, "email": userSingle.Email, "profile_id": userSingle.ProfileId}, beegoCtx.Request.Host, a.expireToken)
+	return a.loginUser(ctx, userSingle)
+}
+
+// checkAccountRestriction refuses the login of a banned or suspended user.
+func checkAccountRestriction(userSingle *domain.UserQueryWithProfile) error {
+	if userSingle.BannedAt.Valid {
+		return response.ErrAccountBanned
+	}
+	if domain.IsSuspended(userSingle.SuspendedUntil) {
+		return response.ErrAccountSuspended
+	}
+	return nil
+}
+
+// loginUser returns the token of the user or a challenge when two factor authentication is enabled.
+func (a userUseCase) loginUser(ctx context.Context, userSingle *domain.UserQueryWithProfile) (*domain.LoginResponse, error) {
+	if err := checkAccountRestriction(userSingle); err != nil {
+		zaplogger.SetStackTrace(ctx, a.zapLogger.SetMessageLog(err))
+		return nil, err
+	}
+
+	res := new(domain.LoginResponse)
+
+	if userSingle.TwoFactorEnabled {
+		challengeToken := uuid.New().String()
+		if err := a.cache.Put(ctx, fmt.Sprintf(twoFactorChallengeKey, challengeToken), userSingle.ID, twoFactorChallengeExpire); err != nil {
+			zaplogger.SetStackTrace(ctx, a.zapLogger.SetMessageLog(err))
+			return nil, err
+		}
+		res.TwoFactorRequired = true
+		res.ChallengeToken = challengeToken
+		res.ExpiredAt = time.Now().Add(twoFactorChallengeExpire).String()
+		return res, nil
+	}
+
+	return a.generateLoginResponse(ctx, userSingle)
+}
+
+// LoginWithUserId logs in a user already authenticated by another method, ex: social login.
+func (a userUseCase) LoginWithUserId(ctx context.Context, userId int) (*domain.LoginResponse, error) {
+	ctx, cancel := context.WithTimeout(ctx, a.contextTimeout)
+	defer cancel()
+	ctx, span := tracing.Start(ctx, "userUseCase.LoginWithUserId")
+	defer span.End()
+
+	userSingle, err := a.singleUserWithFilter(ctx, database.Eq("users.id", userId))
+	if err != nil {
+		zaplogger.SetStackTrace(ctx, a.zapLogger.SetMessageLog(err))
+		return nil, err
+	}
+
+	return a.loginUser(ctx, userSingle)
+}
+
+func (a userUseCase) generateLoginResponse(ctx context.Context, userSingle *domain.UserQueryWithProfile) (*domain.LoginResponse, error) {
+	token, err := a.jwtAuth.Ctx(ctx).GenerateToken(jwt.Payload{
+		"uid":         userSingle.ID,
+		"email":       userSingle.Email,
+		"profile_id":  userSingle.ProfileId,
+		"role":        userSingle.Role,
+		"permissions": domain.PermissionsOfRole(userSingle.Role),
+	}, requestinfo.FromContext(ctx).Host, a.expireToken)
 	if err != nil {
-		beegoCtx.Input.SetData("stackTrace", a.zapLogger.SetMessageLog(err))
+		zaplogger.SetStackTrace(ctx, a.zapLogger.SetMessageLog(err))
 		return nil, err
 	}
 
+	a.auditUseCase.Record(ctx, domain.AuditEntry{
+		ActorID:    userSingle.ID,
+		Action:     domain.AuditActionLogin,
+		TargetType: domain.AuditTargetUser,
+		TargetID:   userSingle.ID,
+	})
+
+	res := new(domain.LoginResponse)
 	res.Token = token.Token
 	res.ExpiredAt = token.ExpiredAt.String()
 	res.User = domain.FromUserToUserLogin(userSingle)
@@ -101,51 +222,477 @@ func (a userUseCase) Login(beegoCtx *beegoContext.Context, request domain.LoginR
 }
 //////////////////
 
-func (r userUseCase) Register(beegoCtx *beegoContext.Context, request domain.RegisterRequest) error {
-	ctx, cancel := context.WithTimeout(beegoCtx.Request.Context(), r.contextTimeout)
+/////////////////// VerifyTwoFactorLogin
+func cacheValueToInt(value interface{}) int {
+	switch v := value.(type) {
+	case int:
+		return v
+	case int64:
+		return int(v)
+	case []byte:
+		parse, _ := strconv.Atoi(string(v))
+		return parse
+	case string:
+		parse, _ := strconv.Atoi(v)
+		return parse
+	default:
+		return 0
+	}
+}
+
+// useTwoFactorStep records the time step of a valid authenticator code, the code is used once
+// and SET NX rejects the concurrent requests replaying it.
+func (a userUseCase) useTwoFactorStep(ctx context.Context, userId int, step int64) error {
+	usedKey := fmt.Sprintf(twoFactorUsedCodeKey, userId, step)
+	unused, err := a.atomicCache.SetNX(ctx, usedKey, time.Duration(totp.DefaultPeriod*(2*totp.DefaultSkew+1))*time.Second)
+	if err != nil {
+		return err
+	}
+	if !unused {
+		return response.ErrInvalidTwoFactorCode
+	}
+	return nil
+}
+
+// validateTwoFactorCode checks code against the authenticator and then against the backup codes,
+// a matched backup code is consumed.
+func (a userUseCase) validateTwoFactorCode(ctx context.Context, userSingle *domain.UserQueryWithProfile, code string) error {
+	if step, ok := totp.Validate(code, userSingle.TwoFactorSecret, time.Now()); ok {
+		return a.useTwoFactorStep(ctx, userSingle.ID, step)
+	}
+
+	var backupCodes []string
+	if userSingle.TwoFactorBackupCodes != "" {
+		if err := json.Unmarshal([]byte(userSingle.TwoFactorBackupCodes), &backupCodes); err != nil {
+			return err
+		}
+	}
+	for i := range backupCodes {
+		if bcrypt.CompareHashAndPassword([]byte(backupCodes[i]), []byte(code)) != nil {
+			continue
+		}
+		remaining, err := json.Marshal(append(backupCodes[:i:i], backupCodes[i+1:]...))
+		if err != nil {
+			return err
+		}
+		// the backup codes are updated when they're unchanged, a concurrent login consumed the code otherwise
+		consumed, err := a.mysqlUserRepository.UpdateBackupCodes(ctx, userSingle.ID, userSingle.TwoFactorBackupCodes, string(remaining))
+		if err != nil {
+			return err
+		}
+		if !consumed {
+			return response.ErrInvalidTwoFactorCode
+		}
+		return nil
+	}
+
+	return response.ErrInvalidTwoFactorCode
+}
+func (a userUseCase) VerifyTwoFactorLogin(ctx context.Context, request domain.VerifyTwoFactorLoginRequest) (*domain.LoginResponse, error) {
+	ctx, cancel := context.WithTimeout(ctx, a.contextTimeout)
+	defer cancel()
+	ctx, span := tracing.Start(ctx, "userUseCase.VerifyTwoFactorLogin")
+	defer span.End()
+
+	challengeKey := fmt.Sprintf(twoFactorChallengeKey, request.ChallengeToken)
+	attemptKey := fmt.Sprintf(twoFactorAttemptKey, request.ChallengeToken)
+	verifyKey := fmt.Sprintf(twoFactorVerifyKey, request.ChallengeToken)
+
+	// the challenge is verified by one request at a time, SET NX rejects the concurrent requests before their
+	// code is checked so two valid codes can't both get a token. The challenge is released after a wrong code
+	// for the next attempt and kept until it expires once it's consumed.
+	held, err := a.atomicCache.SetNX(ctx, verifyKey, a.contextTimeout)
+	if err != nil {
+		zaplogger.SetStackTrace(ctx, a.zapLogger.SetMessageLog(err))
+		return nil, err
+	}
+	if !held {
+		zaplogger.SetStackTrace(ctx, a.zapLogger.SetMessageLog(response.ErrInvalidChallengeToken))
+		return nil, response.ErrInvalidChallengeToken
+	}
+	consumed := false
+	defer func() {
+		if !consumed {
+			_ = a.atomicCache.Delete(context.WithoutCancel(ctx), verifyKey)
+		}
+	}()
+
+	value, err := a.cache.Get(ctx, challengeKey)
+	if err != nil || cacheValueToInt(value) == 0 {
+		zaplogger.SetStackTrace(ctx, a.zapLogger.SetMessageLog(response.ErrInvalidChallengeToken))
+		return nil, response.ErrInvalidChallengeToken
+	}
+
+	// every attempt is counted before its code is checked so the concurrent guesses can't exceed twoFactorMaxAttempts,
+	// the challenge is burned after too many attempts and the user has to login again
+	attempt, err := a.atomicCache.Incr(ctx, attemptKey, twoFactorChallengeExpire)
+	if err != nil {
+		zaplogger.SetStackTrace(ctx, a.zapLogger.SetMessageLog(err))
+		return nil, err
+	}
+	if attempt > twoFactorMaxAttempts {
+		_ = a.cache.Delete(ctx, challengeKey)
+		zaplogger.SetStackTrace(ctx, a.zapLogger.SetMessageLog(response.ErrInvalidChallengeToken))
+		return nil, response.ErrInvalidChallengeToken
+	}
+
+	userSingle, err := a.singleUserWithFilter(ctx, database.Eq("users.id", cacheValueToInt(value)))
+	if err != nil {
+		zaplogger.SetStackTrace(ctx, a.zapLogger.SetMessageLog(err))
+		return nil, err
+	}
+
+	if err = a.validateTwoFactorCode(ctx, userSingle, request.Code); err != nil {
+		if err == response.ErrInvalidTwoFactorCode && attempt >= twoFactorMaxAttempts {
+			_ = a.cache.Delete(ctx, challengeKey)
+		}
+		zaplogger.SetStackTrace(ctx, a.zapLogger.SetMessageLog(err))
+		return nil, err
+	}
+
+	if err = a.cache.Delete(ctx, challengeKey); err != nil {
+		zaplogger.SetStackTrace(ctx, a.zapLogger.SetMessageLog(err))
+		return nil, err
+	}
+	consumed = true
+
+	if err = checkAccountRestriction(userSingle); err != nil {
+		zaplogger.SetStackTrace(ctx, a.zapLogger.SetMessageLog(err))
+		return nil, err
+	}
+
+	return a.generateLoginResponse(ctx, userSingle)
+}
+//////////////////
+
+/////////////////// EnrollTwoFactor
+func (r userUseCase) EnrollTwoFactor(ctx context.Context) (*domain.EnrollTwoFactorResponse, error) {
+	ctx, cancel := context.WithTimeout(ctx, r.contextTimeout)
 	defer cancel()
+	ctx, span := tracing.Start(ctx, "userUseCase.EnrollTwoFactor")
+	defer span.End()
 
-	if err := r.mysqlUserRepository.DB().Transaction(func(tx *gorm.DB) error {
-		userId,err := r.mysqlUserRepository.StoreWithTx(ctx,tx,request.ToUser())
+	authUser, err := authuser.FromContext(ctx)
+	if err != nil {
+		zaplogger.SetStackTrace(ctx, r.zapLogger.SetMessageLog(err))
+		return nil, err
+	}
+
+	userSingle, err := r.singleUserWithFilter(ctx, database.Eq("users.id", authUser.ID))
+	if err != nil {
+		zaplogger.SetStackTrace(ctx, r.zapLogger.SetMessageLog(err))
+		return nil, err
+	}
+
+	if userSingle.TwoFactorEnabled {
+		zaplogger.SetStackTrace(ctx, r.zapLogger.SetMessageLog(response.ErrTwoFactorAlreadyEnabled))
+		return nil, response.ErrTwoFactorAlreadyEnabled
+	}
+
+	secret, err := totp.GenerateSecret()
+	if err != nil {
+		zaplogger.SetStackTrace(ctx, r.zapLogger.SetMessageLog(err))
+		return nil, err
+	}
+
+	err = r.mysqlUserRepository.UpdateSelectedField(ctx, []string{"two_factor_secret", "updated_at"}, map[string]interface{}{
+		"two_factor_secret": secret,
+		"updated_at":        time.Now(),
+	}, userSingle.ID)
+	if err != nil {
+		zaplogger.SetStackTrace(ctx, r.zapLogger.SetMessageLog(err))
+		return nil, err
+	}
+
+	return &domain.EnrollTwoFactorResponse{
+		Secret:     secret,
+		OtpAuthUri: totp.KeyURI(twoFactorIssuer, userSingle.Email, secret),
+	}, nil
+}
+//////////////////
+
+/////////////////// ConfirmTwoFactor
+func generateBackupCodes() (codes []string, hashes []string, err error) {
+	for i := 0; i < twoFactorBackupCodes; i++ {
+		random := make([]byte, 5)
+		if _, err = rand.Read(random); err != nil {
+			return nil, nil, err
+		}
+		code := hex.EncodeToString(random)
+		hash, err := bcrypt.GenerateFromPassword([]byte(code), 10)
+		if err != nil {
+			return nil, nil, err
+		}
+		codes = append(codes, code)
+		hashes = append(hashes, string(hash))
+	}
+	return codes, hashes, nil
+}
+func (r userUseCase) ConfirmTwoFactor(ctx context.Context, request domain.ConfirmTwoFactorRequest) (*domain.ConfirmTwoFactorResponse, error) {
+	ctx, cancel := context.WithTimeout(ctx, r.contextTimeout)
+	defer cancel()
+	ctx, span := tracing.Start(ctx, "userUseCase.ConfirmTwoFactor")
+	defer span.End()
+
+	authUser, err := authuser.FromContext(ctx)
+	if err != nil {
+		zaplogger.SetStackTrace(ctx, r.zapLogger.SetMessageLog(err))
+		return nil, err
+	}
+
+	userSingle, err := r.singleUserWithFilter(ctx, database.Eq("users.id", authUser.ID))
+	if err != nil {
+		zaplogger.SetStackTrace(ctx, r.zapLogger.SetMessageLog(err))
+		return nil, err
+	}
+
+	if userSingle.TwoFactorEnabled {
+		zaplogger.SetStackTrace(ctx, r.zapLogger.SetMessageLog(response.ErrTwoFactorAlreadyEnabled))
+		return nil, response.ErrTwoFactorAlreadyEnabled
+	}
+	if userSingle.TwoFactorSecret == "" {
+		zaplogger.SetStackTrace(ctx, r.zapLogger.SetMessageLog(response.ErrTwoFactorNotEnrolled))
+		return nil, response.ErrTwoFactorNotEnrolled
+	}
+	step, ok := totp.Validate(request.Code, userSingle.TwoFactorSecret, time.Now())
+	if !ok {
+		zaplogger.SetStackTrace(ctx, r.zapLogger.SetMessageLog(response.ErrInvalidTwoFactorCode))
+		return nil, response.ErrInvalidTwoFactorCode
+	}
+	// the confirming code can't be replayed at the next login
+	if err = r.useTwoFactorStep(ctx, userSingle.ID, step); err != nil {
+		zaplogger.SetStackTrace(ctx, r.zapLogger.SetMessageLog(err))
+		return nil, err
+	}
+
+	codes, hashes, err := generateBackupCodes()
+	if err != nil {
+		zaplogger.SetStackTrace(ctx, r.zapLogger.SetMessageLog(err))
+		return nil, err
+	}
+	backupCodes, err := json.Marshal(hashes)
+	if err != nil {
+		zaplogger.SetStackTrace(ctx, r.zapLogger.SetMessageLog(err))
+		return nil, err
+	}
+
+	err = r.mysqlUserRepository.UpdateSelectedField(ctx, []string{"two_factor_enabled", "two_factor_backup_codes", "updated_at"}, map[string]interface{}{
+		"two_factor_enabled":      true,
+		"two_factor_backup_codes": string(backupCodes),
+		"updated_at":              time.Now(),
+	}, userSingle.ID)
+	if err != nil {
+		zaplogger.SetStackTrace(ctx, r.zapLogger.SetMessageLog(err))
+		return nil, err
+	}
+
+	r.auditUseCase.Record(ctx, domain.AuditEntry{
+		Action:     domain.AuditActionTwoFactorEnable,
+		TargetType: domain.AuditTargetUser,
+		TargetID:   userSingle.ID,
+		Before:     map[string]interface{}{"two_factor_enabled": false},
+		After:      map[string]interface{}{"two_factor_enabled": true},
+	})
+
+	return &domain.ConfirmTwoFactorResponse{BackupCodes: codes}, nil
+}
+//////////////////
+
+func (r userUseCase) Register(ctx context.Context, request domain.RegisterRequest) error {
+	ctx, cancel := context.WithTimeout(ctx, r.contextTimeout)
+	defer cancel()
+	ctx, span := tracing.Start(ctx, "userUseCase.Register")
+	defer span.End()
+
+	if err := r.txManager.WithinTransaction(ctx, func(ctx context.Context) error {
+		userSingle,err := r.mysqlUserRepository.Store(ctx,request.ToUser())
+		if err != nil {
+			zaplogger.SetStackTrace(ctx, r.zapLogger.SetMessageLog(err))
+			return err
+		}
+
+		_,err = r.mysqlProfileRepository.Store(ctx, request.ToProfile(userSingle.ID))
 		if err != nil {
-			beegoCtx.Input.SetData("stackTrace", r.zapLogger.SetMessageLog(err))
+			zaplogger.SetStackTrace(ctx, r.zapLogger.SetMessageLog(err))
 			return err
 		}
 
-		_,err = r.mysqlProfileRepository.StoreWithTx(ctx,tx,request.ToProfile(userId))
+		err = event.PublishNew(ctx, r.publisher, domain.EventUserRegistered, strconv.Itoa(userSingle.ID), domain.UserRegisteredPayload{
+			UserID:   userSingle.ID,
+			Email:    userSingle.Email,
+			Provider: "password",
+		})
 		if err != nil {
-			beegoCtx.Input.SetData("stackTrace", r.zapLogger.SetMessageLog(err))
+			zaplogger.SetStackTrace(ctx, r.zapLogger.SetMessageLog(err))
 			return err
 		}
 		return nil
 	}); err != nil {
 		return err
 	}
+	metrics.RegistrationsTotal.WithLabelValues("password").Inc()
 
 	return nil
 }
 
-func (r userUseCase) PurchasePremiumUpdateStatus(beegoCtx *beegoContext.Context) error {
-	ctx, cancel := context.WithTimeout(beegoCtx.Request.Context(), r.contextTimeout)
+func (r userUseCase) PurchasePremiumUpdateStatus(ctx context.Context) error {
+	ctx, cancel := context.WithTimeout(ctx, r.contextTimeout)
 	defer cancel()
+	ctx, span := tracing.Start(ctx, "userUseCase.PurchasePremiumUpdateStatus")
+	defer span.End()
 
-	userLogin := beegoCtx.Request.Context().Value("JWT_PAYLOAD").(jwt.Payload)
+	authUser, err := authuser.FromContext(ctx)
+	if err != nil {
+		zaplogger.SetStackTrace(ctx, r.zapLogger.SetMessageLog(err))
+		return err
+	}
 
-	userSingle, err := r.singleUserWithFilter(ctx, []string{"users.id = ?"}, userLogin["uid"].(float64))
+	userSingle, err := r.singleUserWithFilter(ctx, database.Eq("users.id", authUser.ID))
 	if err != nil {
-		beegoCtx.Input.SetData("stackTrace", r.zapLogger.SetMessageLog(err))
+		zaplogger.SetStackTrace(ctx, r.zapLogger.SetMessageLog(err))
 		return err
 	}
 
-	err = r.mysqlUserRepository.UpdateSelectedField(ctx,[]string{"premium_expires_at","updated_at"}, map[string]interface{}{
-		"premium_expires_at" : time.Now().AddDate(0,1,0),
-		"updated_at" : time.Now(),
-	},userSingle.ID)
+	premiumExpiresAt := time.Now().AddDate(0,1,0)
+	err = r.txManager.WithinTransaction(ctx, func(ctx context.Context) error {
+		err := r.mysqlUserRepository.UpdateSelectedField(ctx,[]string{"premium_expires_at","updated_at"}, map[string]interface{}{
+			"premium_expires_at" : premiumExpiresAt,
+			"updated_at" : time.Now(),
+		},userSingle.ID)
+		if err != nil {
+			return err
+		}
+
+		return event.PublishNew(ctx, r.publisher, domain.EventPremiumActivated, strconv.Itoa(userSingle.ID), domain.PremiumActivatedPayload{
+			UserID:           userSingle.ID,
+			PremiumExpiresAt: premiumExpiresAt,
+		})
+	})
+	if err != nil {
+		zaplogger.SetStackTrace(ctx, r.zapLogger.SetMessageLog(err))
+		return err
+	}
+
+	metrics.PremiumPurchasesTotal.Inc()
+
+	r.auditUseCase.Record(ctx, domain.AuditEntry{
+		Action:     domain.AuditActionPremiumPurchase,
+		TargetType: domain.AuditTargetUser,
+		TargetID:   userSingle.ID,
+		Before:     map[string]interface{}{"premium_expires_at": userSingle.PremiumExpiresAt},
+		After:      map[string]interface{}{"premium_expires_at": premiumExpiresAt},
+	})
+
+	return nil
+}
+
+/////////////////// DeleteAccount
+func (r userUseCase) DeleteAccount(ctx context.Context) error {
+	ctx, cancel := context.WithTimeout(ctx, r.contextTimeout)
+	defer cancel()
+	ctx, span := tracing.Start(ctx, "userUseCase.DeleteAccount")
+	defer span.End()
+
+	authUser, err := authuser.FromContext(ctx)
 	if err != nil {
-		beegoCtx.Input.SetData("stackTrace", r.zapLogger.SetMessageLog(err))
+		zaplogger.SetStackTrace(ctx, r.zapLogger.SetMessageLog(err))
 		return err
 	}
 
+	// the account is hidden right away and purged by PurgeDeletedUsers after the grace period
+	if _, err := r.mysqlUserRepository.SoftDelete(ctx, authUser.ID); err != nil {
+		zaplogger.SetStackTrace(ctx, r.zapLogger.SetMessageLog(err))
+		return err
+	}
+
+	if err := r.jwtAuth.Ctx(ctx).DestroyContextToken(ctx); err != nil {
+		zaplogger.SetStackTrace(ctx, r.zapLogger.SetMessageLog(err))
+		return err
+	}
+
+	r.auditUseCase.Record(ctx, domain.AuditEntry{
+		Action:     domain.AuditActionAccountDelete,
+		TargetType: domain.AuditTargetUser,
+		TargetID:   authUser.ID,
+	})
 
 	return nil
-}
\ No newline at end of file
+}
+//////////////////
+
+/////////////////// ExpirePremiumUsers
+// ExpirePremiumUsers clears the premium of a batch of the users whose premium expired before now,
+// each with its premium.expired event, and returns the number expired.
+func (r userUseCase) ExpirePremiumUsers(ctx context.Context, now time.Time) (int, error) {
+	ctx, span := tracing.Start(ctx, "userUseCase.ExpirePremiumUsers")
+	defer span.End()
+
+	var users []domain.User
+	if _, err := r.mysqlUserRepository.FetchWithFilter(ctx, database.NewQuery().
+		Select("id", "premium_expires_at").
+		Where(database.Lt("premium_expires_at", now)).
+		OrderBy(database.Asc("premium_expires_at")).
+		Limit(expireBatchSize), &users); err != nil {
+		return 0, err
+	}
+
+	expired := 0
+	for i := range users {
+		err := r.txManager.WithinTransaction(ctx, func(ctx context.Context) error {
+			err := r.mysqlUserRepository.UpdateSelectedField(ctx, []string{"premium_expires_at", "updated_at"}, map[string]interface{}{
+				"premium_expires_at": nil,
+				"updated_at":         now,
+			}, users[i].ID)
+			if err != nil {
+				return err
+			}
+
+			return event.PublishNew(ctx, r.publisher, domain.EventPremiumExpired, strconv.Itoa(users[i].ID), domain.PremiumExpiredPayload{
+				UserID:    users[i].ID,
+				ExpiredAt: users[i].PremiumExpiresAt.Time,
+			})
+		})
+		if err != nil {
+			return expired, err
+		}
+		expired++
+	}
+
+	return expired, nil
+}
+//////////////////
+
+/////////////////// PurgeDeletedUsers
+// PurgeDeletedUsers hard deletes the users soft deleted before deletedBefore with their photo,
+// the profile, swipes and identities are removed by the foreign key cascade.
+// The ids of the purged users are returned.
+func (r userUseCase) PurgeDeletedUsers(ctx context.Context, deletedBefore time.Time) ([]int, error) {
+	users, err := r.mysqlUserRepository.FetchSoftDeleted(ctx, deletedBefore, purgeBatchSize)
+	if err != nil {
+		return nil, err
+	}
+
+	purged := make([]int, 0, len(users))
+	for i := range users {
+		var userProfile domain.Profile
+		err := r.mysqlProfileRepository.SingleWithFilter(ctx, database.NewQuery().
+			Select("id", "photo").Where(database.Eq("user_id", users[i].ID)), &userProfile)
+		if err != nil && err != gorm.ErrRecordNotFound {
+			return purged, err
+		}
+
+		if _, err := r.mysqlUserRepository.Delete(ctx, users[i].ID); err != nil {
+			return purged, err
+		}
+		purged = append(purged, users[i].ID)
+
+		if userProfile.Photo != "" {
+			if err := helper.DeleteFileJpeg(userProfile.Photo); err != nil {
+				r.zapLogger.Warnw("failed delete photo of purged user", "photo", userProfile.Photo, zaplogger.FieldUserID, users[i].ID, zaplogger.FieldError, err)
+			}
+		}
+	}
+
+	return purged, nil
+}
+//////////////////
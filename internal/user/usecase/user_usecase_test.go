@@ -2,23 +2,32 @@ package usecase
 
 import (
 	"context"
+	"database/sql"
 	"errors"
 	"fmt"
-	beegoContext "github.com/beego/beego/v2/server/web/context"
-	beegoMock "github.com/beego/beego/v2/server/web/mock"
+	"github.com/beego/beego/v2/client/cache"
 	"github.com/golang/mock/gomock"
+	"github.com/radyatamaa/dating-apps-api/internal/domain"
+	"github.com/radyatamaa/dating-apps-api/internal/audit"
 	"github.com/radyatamaa/dating-apps-api/internal/domain/mocks"
 	"github.com/radyatamaa/dating-apps-api/internal/profile"
 	"github.com/radyatamaa/dating-apps-api/internal/user"
+	"github.com/radyatamaa/dating-apps-api/pkg/database"
+	"github.com/radyatamaa/dating-apps-api/pkg/event"
+	mockDatabase "github.com/radyatamaa/dating-apps-api/pkg/database/mocks"
+	mockEvent "github.com/radyatamaa/dating-apps-api/pkg/event/mocks"
+	"github.com/radyatamaa/dating-apps-api/pkg/atomiccache"
+	"github.com/radyatamaa/dating-apps-api/pkg/authuser"
 	"github.com/radyatamaa/dating-apps-api/pkg/jwt"
 	mockJwt "github.com/radyatamaa/dating-apps-api/pkg/jwt/mocks"
+	"github.com/radyatamaa/dating-apps-api/pkg/response"
+	"github.com/radyatamaa/dating-apps-api/pkg/totp"
 	"github.com/radyatamaa/dating-apps-api/pkg/zaplogger"
 	mockZaplogger "github.com/radyatamaa/dating-apps-api/pkg/zaplogger/mocks"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/suite"
-	"net/http"
-	"net/http/httptest"
-	"net/url"
+	"golang.org/x/crypto/bcrypt"
+	"gorm.io/gorm"
 	"reflect"
 	"testing"
 	"time"
@@ -36,8 +45,13 @@ type fields struct {
 	jwtAuth                   *mockJwt.MockJWT
 	expireToken                int
 	contextTimeout             time.Duration
+	cache                      cache.Cache
+	atomicCache                *atomiccache.MemoryCache
+	txManager              *mockDatabase.MockTxManager
+	publisher              *mockEvent.MockPublisher
 	mysqlUserRepository    *mocks.UserMysqlRepository
 	mysqlProfileRepository *mocks.ProfileMysqlRepository
+	auditUseCase           *mocks.MockAuditUseCase
 }
 
 func toField(ctrl *gomock.Controller) fields {
@@ -46,8 +60,13 @@ func toField(ctrl *gomock.Controller) fields {
 		jwtAuth: 						  mockJwt.NewMockJWT(ctrl),
 		expireToken: 					  86400,
 		contextTimeout:                   time.Second * 30,
+		cache:                            cache.NewMemoryCache(),
+		atomicCache:                      atomiccache.NewMemoryCache(),
+		txManager:                        mockDatabase.NewMockTxManager(ctrl),
+		publisher:                        mockEvent.NewMockPublisher(ctrl),
 		mysqlUserRepository:              mocks.NewUserMysqlRepository(ctrl),
 		mysqlProfileRepository:      	  mocks.NewProfileMysqlRepository(ctrl),
+		auditUseCase:                     mocks.NewMockAuditUseCase(ctrl),
 	}
 }
 
@@ -60,8 +79,10 @@ func (t *UserUseCaseTestSuite) TestNewCustomerUseCase() {
 		jwtAuth                    jwt.JWT
 		expireToken                int
 		contextTimeout             time.Duration
+		cache                      cache.Cache
 		mysqlUserRepository    user.MysqlRepository
 		mysqlProfileRepository profile.MysqlRepository
+		auditUseCase           audit.UseCase
 	}
 	tests := []struct {
 		name string
@@ -75,15 +96,17 @@ func (t *UserUseCaseTestSuite) TestNewCustomerUseCase() {
 				jwtAuth: 						  mockJwt.NewMockJWT(ctrl),
 				expireToken: 					  86400,
 				contextTimeout:                   time.Second * 30,
+				cache:                            nil,
 				mysqlUserRepository:              mocks.NewUserMysqlRepository(ctrl),
 				mysqlProfileRepository:      	  mocks.NewProfileMysqlRepository(ctrl),
+				auditUseCase:                     mocks.NewMockAuditUseCase(ctrl),
 			},
-			want: NewUserUseCase(time.Second * 30,mocks.NewUserMysqlRepository(ctrl),mocks.NewProfileMysqlRepository(ctrl),mockJwt.NewMockJWT(ctrl),86400,mockZaplogger.NewMockLogger(ctrl)),
+			want: NewUserUseCase(time.Second * 30,nil,nil,mocks.NewUserMysqlRepository(ctrl),mocks.NewProfileMysqlRepository(ctrl),mockJwt.NewMockJWT(ctrl),86400,nil,nil,mocks.NewMockAuditUseCase(ctrl),mockZaplogger.NewMockLogger(ctrl)),
 		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func() {
-			if got := NewUserUseCase(tt.args.contextTimeout,tt.args.mysqlUserRepository,tt.args.mysqlProfileRepository,tt.args.jwtAuth,tt.args.expireToken,tt.args.zapLogger); !reflect.DeepEqual(got, tt.want) {
+			if got := NewUserUseCase(tt.args.contextTimeout,nil,nil,tt.args.mysqlUserRepository,tt.args.mysqlProfileRepository,tt.args.jwtAuth,tt.args.expireToken,tt.args.cache,nil,tt.args.auditUseCase,tt.args.zapLogger); !reflect.DeepEqual(got, tt.want) {
 				t.Errorf(errors.New("failed"), "NewUserUseCase() = %v, want %v", got, tt.want)
 			}
 		})
@@ -91,21 +114,11 @@ func (t *UserUseCaseTestSuite) TestNewCustomerUseCase() {
 }
 
 func (t *UserUseCaseTestSuite) TestUserUseCase_PurchasePremiumUpdateStatus() {
-	mockUserLogin := jwt.Payload{"uid": float64(1), "email": "test@gmail.com", "profile_id": float64(1)}
-	req := http.Request{}
-	req.WithContext(context.Background())
-	contextBeego, _ := beegoMock.NewMockContext(&req)
-	ctx := context.TODO()
-	ctx = context.WithValue(ctx, "JWT_PAYLOAD", mockUserLogin)
-	uri := url.URL{
-		Scheme: "http",
-		Host:   "localhost:8080",
-		Path:   "/api/v1/user/purchase-premium",
-	}
-	contextBeego.Request = httptest.NewRequest(http.MethodPost,uri.String(),nil).WithContext(ctx)
+	mockUserLogin := authuser.AuthUser{ID: 1, Email: "test@gmail.com", ProfileID: 1}
+	ctx := authuser.NewContext(context.TODO(), mockUserLogin)
 
 	type args struct {
-		beegoCtx   *beegoContext.Context
+		ctx        context.Context
 		token      string
 		userId int
 		fields []string
@@ -122,13 +135,24 @@ func (t *UserUseCaseTestSuite) TestUserUseCase_PurchasePremiumUpdateStatus() {
 			wantErr: assert.NoError,
 			fields: func(args *args,ctrl *gomock.Controller) fields {
 				fields := toField(ctrl)
-				fields.mysqlUserRepository.EXPECT().SingleWithFilter(gomock.Any(),gomock.Any(),gomock.Any(),gomock.Any(),gomock.Any(),gomock.Any()).Return(nil)
+				fields.mysqlUserRepository.EXPECT().SingleWithFilter(gomock.Any(),gomock.Any(),gomock.Any()).Return(nil)
+				fields.txManager.EXPECT().WithinTransaction(gomock.Any(), gomock.Any()).
+					DoAndReturn(func(ctx context.Context, fn func(ctx context.Context) error) error {
+						return fn(ctx)
+					})
 				fields.mysqlUserRepository.EXPECT().UpdateSelectedField(gomock.Any(), []string{"premium_expires_at","updated_at"},gomock.Any(),args.userId).Return(nil)
+				fields.publisher.EXPECT().Publish(gomock.Any(), gomock.Any()).
+					DoAndReturn(func(ctx context.Context, events ...event.Event) error {
+						t.Equal(domain.EventPremiumActivated, events[0].Name)
+						t.Equal("0", events[0].Key)
+						return nil
+					})
+				fields.auditUseCase.EXPECT().Record(gomock.Any(), gomock.Any())
 
 				return fields
 			},
 			args: args{
-				beegoCtx:   contextBeego,
+				ctx:        ctx,
 				userId: 0,
 				token:      "aaa",
 				values: map[string]interface{}{
@@ -144,12 +168,12 @@ func (t *UserUseCaseTestSuite) TestUserUseCase_PurchasePremiumUpdateStatus() {
 			},
 			fields: func(args *args,ctrl *gomock.Controller) fields {
 				fields := toField(ctrl)
-				fields.mysqlUserRepository.EXPECT().SingleWithFilter(gomock.Any(),gomock.Any(),gomock.Any(),gomock.Any(),gomock.Any(),gomock.Any()).Return(errors.New("context deadline exceeded"))
+				fields.mysqlUserRepository.EXPECT().SingleWithFilter(gomock.Any(),gomock.Any(),gomock.Any()).Return(errors.New("context deadline exceeded"))
 				fields.zapLogger.EXPECT().SetMessageLog(errors.New("context deadline exceeded"))
 				return fields
 			},
 			args: args{
-				beegoCtx:   contextBeego,
+				ctx:        ctx,
 				userId: 0,
 				token:      "aaa",
 				values: map[string]interface{}{
@@ -165,13 +189,17 @@ func (t *UserUseCaseTestSuite) TestUserUseCase_PurchasePremiumUpdateStatus() {
 			},
 			fields: func(args *args,ctrl *gomock.Controller) fields {
 				fields := toField(ctrl)
-				fields.mysqlUserRepository.EXPECT().SingleWithFilter(gomock.Any(),gomock.Any(),gomock.Any(),gomock.Any(),gomock.Any(),gomock.Any()).Return(nil)
+				fields.mysqlUserRepository.EXPECT().SingleWithFilter(gomock.Any(),gomock.Any(),gomock.Any()).Return(nil)
+				fields.txManager.EXPECT().WithinTransaction(gomock.Any(), gomock.Any()).
+					DoAndReturn(func(ctx context.Context, fn func(ctx context.Context) error) error {
+						return fn(ctx)
+					})
 				fields.mysqlUserRepository.EXPECT().UpdateSelectedField(gomock.Any(), []string{"premium_expires_at","updated_at"},gomock.Any(),args.userId).Return(errors.New("context deadline exceeded"))
 				fields.zapLogger.EXPECT().SetMessageLog(errors.New("context deadline exceeded"))
 				return fields
 			},
 			args: args{
-				beegoCtx:   contextBeego,
+				ctx:        ctx,
 				userId: 0,
 				token:      "aaa",
 				values: map[string]interface{}{
@@ -193,17 +221,391 @@ func (t *UserUseCaseTestSuite) TestUserUseCase_PurchasePremiumUpdateStatus() {
 				jwtAuth                  :                       fields.jwtAuth,
 				expireToken             :                       fields.expireToken,
 				contextTimeout           :                       fields.contextTimeout,
+				cache                    :                       fields.cache,
+				txManager                :                       fields.txManager,
+				publisher                :                       fields.publisher,
 				mysqlUserRepository   :                       fields.mysqlUserRepository,
 				mysqlProfileRepository :                       fields.mysqlProfileRepository,
+				auditUseCase           :                       fields.auditUseCase,
 			}
-			err := r.PurchasePremiumUpdateStatus(tt.args.beegoCtx)
-			if !tt.wantErr(t.T(), err, fmt.Sprintf("PurchasePremiumUpdateStatus(%v)", tt.args.beegoCtx)) {
+			err := r.PurchasePremiumUpdateStatus(tt.args.ctx)
+			if !tt.wantErr(t.T(), err, fmt.Sprintf("PurchasePremiumUpdateStatus(%v)", tt.args.ctx)) {
 				return
 			}
 		})
 	}
 }
 
+func (t *UserUseCaseTestSuite) TestUserUseCase_ConfirmTwoFactor() {
+	mockUserLogin := authuser.AuthUser{ID: 1, Email: "test@gmail.com", ProfileID: 1}
+	ctx := authuser.NewContext(context.TODO(), mockUserLogin)
+
+	secret, err := totp.GenerateSecret()
+	t.NoError(err)
+	code, err := totp.GenerateCode(secret, time.Now())
+	t.NoError(err)
+
+	type args struct {
+		ctx     context.Context
+		request domain.ConfirmTwoFactorRequest
+	}
+	tests := []struct {
+		name    string
+		fields  func(args *args, ctrl *gomock.Controller) fields
+		args    args
+		wantErr assert.ErrorAssertionFunc
+	}{
+		{
+			name:    "success",
+			wantErr: assert.NoError,
+			fields: func(args *args, ctrl *gomock.Controller) fields {
+				fields := toField(ctrl)
+				fields.mysqlUserRepository.EXPECT().SingleWithFilter(gomock.Any(), gomock.Any(), gomock.Any()).
+					SetArg(2, domain.UserQueryWithProfile{ID: 1, TwoFactorSecret: secret}).Return(nil)
+				fields.mysqlUserRepository.EXPECT().UpdateSelectedField(gomock.Any(), []string{"two_factor_enabled", "two_factor_backup_codes", "updated_at"}, gomock.Any(), 1).Return(nil)
+				fields.auditUseCase.EXPECT().Record(gomock.Any(), domain.AuditEntry{
+					Action:     domain.AuditActionTwoFactorEnable,
+					TargetType: domain.AuditTargetUser,
+					TargetID:   1,
+					Before:     map[string]interface{}{"two_factor_enabled": false},
+					After:      map[string]interface{}{"two_factor_enabled": true},
+				})
+				return fields
+			},
+			args: args{
+				ctx:     ctx,
+				request: domain.ConfirmTwoFactorRequest{Code: code},
+			},
+		},
+		{
+			name: "error not enrolled",
+			wantErr: func(t assert.TestingT, err error, i ...interface{}) bool {
+				return assert.ErrorIs(t, err, response.ErrTwoFactorNotEnrolled)
+			},
+			fields: func(args *args, ctrl *gomock.Controller) fields {
+				fields := toField(ctrl)
+				fields.mysqlUserRepository.EXPECT().SingleWithFilter(gomock.Any(), gomock.Any(), gomock.Any()).
+					SetArg(2, domain.UserQueryWithProfile{ID: 1}).Return(nil)
+				fields.zapLogger.EXPECT().SetMessageLog(response.ErrTwoFactorNotEnrolled)
+				return fields
+			},
+			args: args{
+				ctx:     ctx,
+				request: domain.ConfirmTwoFactorRequest{Code: code},
+			},
+		},
+		{
+			name: "error invalid code",
+			wantErr: func(t assert.TestingT, err error, i ...interface{}) bool {
+				return assert.ErrorIs(t, err, response.ErrInvalidTwoFactorCode)
+			},
+			fields: func(args *args, ctrl *gomock.Controller) fields {
+				fields := toField(ctrl)
+				fields.mysqlUserRepository.EXPECT().SingleWithFilter(gomock.Any(), gomock.Any(), gomock.Any()).
+					SetArg(2, domain.UserQueryWithProfile{ID: 1, TwoFactorSecret: secret}).Return(nil)
+				fields.zapLogger.EXPECT().SetMessageLog(response.ErrInvalidTwoFactorCode)
+				return fields
+			},
+			args: args{
+				ctx:     ctx,
+				request: domain.ConfirmTwoFactorRequest{Code: "abcdef"},
+			},
+		},
+		{
+			name: "error code replayed",
+			wantErr: func(t assert.TestingT, err error, i ...interface{}) bool {
+				return assert.ErrorIs(t, err, response.ErrInvalidTwoFactorCode)
+			},
+			fields: func(args *args, ctrl *gomock.Controller) fields {
+				fields := toField(ctrl)
+				step, _ := totp.Validate(code, secret, time.Now())
+				_, _ = fields.atomicCache.SetNX(context.TODO(), fmt.Sprintf(twoFactorUsedCodeKey, 1, step), time.Minute)
+				fields.mysqlUserRepository.EXPECT().SingleWithFilter(gomock.Any(), gomock.Any(), gomock.Any()).
+					SetArg(2, domain.UserQueryWithProfile{ID: 1, TwoFactorSecret: secret}).Return(nil)
+				fields.zapLogger.EXPECT().SetMessageLog(response.ErrInvalidTwoFactorCode)
+				return fields
+			},
+			args: args{
+				ctx:     ctx,
+				request: domain.ConfirmTwoFactorRequest{Code: code},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func() {
+			ctrl := gomock.NewController(t.T())
+			defer ctrl.Finish()
+
+			fields := tt.fields(&tt.args, ctrl)
+			r := userUseCase{
+				zapLogger:              fields.zapLogger,
+				jwtAuth:                fields.jwtAuth,
+				expireToken:            fields.expireToken,
+				contextTimeout:         fields.contextTimeout,
+				cache:                  fields.cache,
+				atomicCache:            fields.atomicCache,
+				mysqlUserRepository:    fields.mysqlUserRepository,
+				mysqlProfileRepository: fields.mysqlProfileRepository,
+				auditUseCase:           fields.auditUseCase,
+			}
+			got, err := r.ConfirmTwoFactor(tt.args.ctx, tt.args.request)
+			if !tt.wantErr(t.T(), err, fmt.Sprintf("ConfirmTwoFactor(%v)", tt.args.request)) {
+				return
+			}
+			if err == nil {
+				t.Len(got.BackupCodes, twoFactorBackupCodes)
+			}
+		})
+	}
+}
+
+func (t *UserUseCaseTestSuite) TestUserUseCase_VerifyTwoFactorLogin() {
+	secret, err := totp.GenerateSecret()
+	t.NoError(err)
+	code, err := totp.GenerateCode(secret, time.Now())
+	t.NoError(err)
+	step, _ := totp.Validate(code, secret, time.Now())
+	backupCode, err := bcrypt.GenerateFromPassword([]byte("backup-code"), bcrypt.MinCost)
+	t.NoError(err)
+	backupCodes := `["` + string(backupCode) + `"]`
+
+	const challengeToken = "challenge"
+	challengeKey := fmt.Sprintf(twoFactorChallengeKey, challengeToken)
+	attemptKey := fmt.Sprintf(twoFactorAttemptKey, challengeToken)
+	verifyKey := fmt.Sprintf(twoFactorVerifyKey, challengeToken)
+
+	tests := []struct {
+		name          string
+		fields        func(ctrl *gomock.Controller) fields
+		request       domain.VerifyTwoFactorLoginRequest
+		wantErr       error
+		wantChallenge bool
+	}{
+		{
+			name:    "totp code replayed",
+			request: domain.VerifyTwoFactorLoginRequest{ChallengeToken: challengeToken, Code: code},
+			wantErr: response.ErrInvalidTwoFactorCode,
+			fields: func(ctrl *gomock.Controller) fields {
+				fields := toField(ctrl)
+				_, _ = fields.atomicCache.SetNX(context.TODO(), fmt.Sprintf(twoFactorUsedCodeKey, 1, step), time.Minute)
+				fields.mysqlUserRepository.EXPECT().SingleWithFilter(gomock.Any(), gomock.Any(), gomock.Any()).
+					SetArg(2, domain.UserQueryWithProfile{ID: 1, TwoFactorSecret: secret}).Return(nil)
+				fields.zapLogger.EXPECT().SetMessageLog(response.ErrInvalidTwoFactorCode)
+				return fields
+			},
+			wantChallenge: true,
+		},
+		{
+			name:    "backup code consumed by a concurrent login",
+			request: domain.VerifyTwoFactorLoginRequest{ChallengeToken: challengeToken, Code: "backup-code"},
+			wantErr: response.ErrInvalidTwoFactorCode,
+			fields: func(ctrl *gomock.Controller) fields {
+				fields := toField(ctrl)
+				fields.mysqlUserRepository.EXPECT().SingleWithFilter(gomock.Any(), gomock.Any(), gomock.Any()).
+					SetArg(2, domain.UserQueryWithProfile{ID: 1, TwoFactorSecret: secret, TwoFactorBackupCodes: backupCodes}).Return(nil)
+				fields.mysqlUserRepository.EXPECT().UpdateBackupCodes(gomock.Any(), 1, backupCodes, "[]").Return(false, nil)
+				fields.zapLogger.EXPECT().SetMessageLog(response.ErrInvalidTwoFactorCode)
+				return fields
+			},
+			wantChallenge: true,
+		},
+		{
+			name:    "challenge verified by a concurrent request",
+			request: domain.VerifyTwoFactorLoginRequest{ChallengeToken: challengeToken, Code: code},
+			wantErr: response.ErrInvalidChallengeToken,
+			fields: func(ctrl *gomock.Controller) fields {
+				fields := toField(ctrl)
+				_, _ = fields.atomicCache.SetNX(context.TODO(), verifyKey, time.Minute)
+				fields.zapLogger.EXPECT().SetMessageLog(response.ErrInvalidChallengeToken)
+				return fields
+			},
+			wantChallenge: true,
+		},
+		{
+			name:    "too many attempts",
+			request: domain.VerifyTwoFactorLoginRequest{ChallengeToken: challengeToken, Code: code},
+			wantErr: response.ErrInvalidChallengeToken,
+			fields: func(ctrl *gomock.Controller) fields {
+				fields := toField(ctrl)
+				for i := 0; i < twoFactorMaxAttempts; i++ {
+					_, _ = fields.atomicCache.Incr(context.TODO(), attemptKey, time.Minute)
+				}
+				fields.zapLogger.EXPECT().SetMessageLog(response.ErrInvalidChallengeToken)
+				return fields
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func() {
+			ctrl := gomock.NewController(t.T())
+			defer ctrl.Finish()
+
+			fields := tt.fields(ctrl)
+			t.NoError(fields.cache.Put(context.TODO(), challengeKey, 1, time.Minute))
+			r := userUseCase{
+				zapLogger:              fields.zapLogger,
+				contextTimeout:         fields.contextTimeout,
+				cache:                  fields.cache,
+				atomicCache:            fields.atomicCache,
+				mysqlUserRepository:    fields.mysqlUserRepository,
+				mysqlProfileRepository: fields.mysqlProfileRepository,
+			}
+			_, err := r.VerifyTwoFactorLogin(context.TODO(), tt.request)
+			t.ErrorIs(err, tt.wantErr)
+			exist, _ := fields.cache.IsExist(context.TODO(), challengeKey)
+			t.Equal(tt.wantChallenge, exist)
+			if tt.wantChallenge && tt.wantErr == response.ErrInvalidTwoFactorCode {
+				// the challenge is released for the next attempt
+				released, _ := fields.atomicCache.SetNX(context.TODO(), verifyKey, time.Minute)
+				t.True(released)
+			}
+		})
+	}
+}
+
+func (t *UserUseCaseTestSuite) TestUserUseCase_PurgeDeletedUsers() {
+	deletedBefore := time.Now().Add(-720 * time.Hour)
+
+	tests := []struct {
+		name    string
+		fields  func(ctrl *gomock.Controller) fields
+		want    []int
+		wantErr assert.ErrorAssertionFunc
+	}{
+		{
+			name:    "success",
+			wantErr: assert.NoError,
+			fields: func(ctrl *gomock.Controller) fields {
+				fields := toField(ctrl)
+				fields.mysqlUserRepository.EXPECT().FetchSoftDeleted(gomock.Any(), deletedBefore, purgeBatchSize).
+					Return([]domain.User{{ID: 1}, {ID: 2}}, nil)
+				fields.mysqlProfileRepository.EXPECT().SingleWithFilter(gomock.Any(), database.NewQuery().Select("id", "photo").Where(database.Eq("user_id", 1)), gomock.Any()).
+					SetArg(2, domain.Profile{ID: 1, Photo: "https://cdn.example.com/photo.jpeg"}).Return(nil)
+				fields.mysqlProfileRepository.EXPECT().SingleWithFilter(gomock.Any(), database.NewQuery().Select("id", "photo").Where(database.Eq("user_id", 2)), gomock.Any()).
+					Return(gorm.ErrRecordNotFound)
+				fields.mysqlUserRepository.EXPECT().Delete(gomock.Any(), 1).Return(1, nil)
+				fields.mysqlUserRepository.EXPECT().Delete(gomock.Any(), 2).Return(2, nil)
+				return fields
+			},
+			want: []int{1, 2},
+		},
+		{
+			name:    "error delete",
+			wantErr: assert.Error,
+			fields: func(ctrl *gomock.Controller) fields {
+				fields := toField(ctrl)
+				fields.mysqlUserRepository.EXPECT().FetchSoftDeleted(gomock.Any(), deletedBefore, purgeBatchSize).
+					Return([]domain.User{{ID: 1}}, nil)
+				fields.mysqlProfileRepository.EXPECT().SingleWithFilter(gomock.Any(), database.NewQuery().Select("id", "photo").Where(database.Eq("user_id", 1)), gomock.Any()).
+					Return(nil)
+				fields.mysqlUserRepository.EXPECT().Delete(gomock.Any(), 1).Return(1, errors.New("context deadline exceeded"))
+				return fields
+			},
+			want: []int{},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func() {
+			ctrl := gomock.NewController(t.T())
+			defer ctrl.Finish()
+
+			fields := tt.fields(ctrl)
+			r := userUseCase{
+				zapLogger:              fields.zapLogger,
+				contextTimeout:         fields.contextTimeout,
+				mysqlUserRepository:    fields.mysqlUserRepository,
+				mysqlProfileRepository: fields.mysqlProfileRepository,
+			}
+			got, err := r.PurgeDeletedUsers(context.TODO(), deletedBefore)
+			if !tt.wantErr(t.T(), err, fmt.Sprintf("PurgeDeletedUsers(%v)", deletedBefore)) {
+				return
+			}
+			t.Equal(tt.want, got)
+		})
+	}
+}
+
+
+func (t *UserUseCaseTestSuite) TestUserUseCase_ExpirePremiumUsers() {
+	now := time.Now()
+	query := database.NewQuery().
+		Select("id", "premium_expires_at").
+		Where(database.Lt("premium_expires_at", now)).
+		OrderBy(database.Asc("premium_expires_at")).
+		Limit(expireBatchSize)
+	users := []domain.User{
+		{ID: 1, PremiumExpiresAt: sql.NullTime{Time: now.Add(-time.Hour), Valid: true}},
+		{ID: 2, PremiumExpiresAt: sql.NullTime{Time: now.Add(-time.Minute), Valid: true}},
+	}
+	withinTransaction := func(ctx context.Context, fn func(ctx context.Context) error) error {
+		return fn(ctx)
+	}
+	expireFields := []string{"premium_expires_at", "updated_at"}
+	expireValues := map[string]interface{}{"premium_expires_at": nil, "updated_at": now}
+
+	tests := []struct {
+		name    string
+		fields  func(ctrl *gomock.Controller) fields
+		want    int
+		wantErr assert.ErrorAssertionFunc
+	}{
+		{
+			name:    "success",
+			wantErr: assert.NoError,
+			fields: func(ctrl *gomock.Controller) fields {
+				fields := toField(ctrl)
+				fields.mysqlUserRepository.EXPECT().FetchWithFilter(gomock.Any(), query, gomock.Any()).
+					SetArg(2, users).Return(nil, nil)
+				fields.txManager.EXPECT().WithinTransaction(gomock.Any(), gomock.Any()).DoAndReturn(withinTransaction).Times(2)
+				fields.mysqlUserRepository.EXPECT().UpdateSelectedField(gomock.Any(), expireFields, expireValues, 1).Return(nil)
+				fields.mysqlUserRepository.EXPECT().UpdateSelectedField(gomock.Any(), expireFields, expireValues, 2).Return(nil)
+				fields.publisher.EXPECT().Publish(gomock.Any(), gomock.Any()).
+					DoAndReturn(func(ctx context.Context, events ...event.Event) error {
+						var payload domain.PremiumExpiredPayload
+						t.NoError(events[0].Decode(&payload))
+						t.Equal(domain.EventPremiumExpired, events[0].Name)
+						return nil
+					}).Times(2)
+				return fields
+			},
+			want: 2,
+		},
+		{
+			name:    "error update",
+			wantErr: assert.Error,
+			fields: func(ctrl *gomock.Controller) fields {
+				fields := toField(ctrl)
+				fields.mysqlUserRepository.EXPECT().FetchWithFilter(gomock.Any(), query, gomock.Any()).
+					SetArg(2, users).Return(nil, nil)
+				fields.txManager.EXPECT().WithinTransaction(gomock.Any(), gomock.Any()).DoAndReturn(withinTransaction)
+				fields.mysqlUserRepository.EXPECT().UpdateSelectedField(gomock.Any(), expireFields, expireValues, 1).
+					Return(errors.New("context deadline exceeded"))
+				return fields
+			},
+			want: 0,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func() {
+			ctrl := gomock.NewController(t.T())
+			defer ctrl.Finish()
+
+			fields := tt.fields(ctrl)
+			r := userUseCase{
+				zapLogger:           fields.zapLogger,
+				contextTimeout:      fields.contextTimeout,
+				txManager:           fields.txManager,
+				publisher:           fields.publisher,
+				mysqlUserRepository: fields.mysqlUserRepository,
+			}
+			got, err := r.ExpirePremiumUsers(context.TODO(), now)
+			if !tt.wantErr(t.T(), err, fmt.Sprintf("ExpirePremiumUsers(%v)", now)) {
+				return
+			}
+			t.Equal(tt.want, got)
+		})
+	}
+}
 
 func TestUserUseCaseTestSuite(t *testing.T) {
 	suite.Run(t, new(UserUseCaseTestSuite))
@@ -1,23 +1,40 @@
 package main
 
 import (
+	"context"
 	"fmt"
 	"log"
+	"net"
 	"net/http"
+	"os"
+	"os/signal"
 	"runtime"
-	"strings"
+	"syscall"
 	"time"
 
+	"github.com/radyatamaa/dating-apps-api/internal/config"
 	"github.com/radyatamaa/dating-apps-api/internal/domain"
+	"github.com/radyatamaa/dating-apps-api/migrations"
+	"github.com/radyatamaa/dating-apps-api/pkg/event"
+	"github.com/radyatamaa/dating-apps-api/pkg/event/kafka"
+	"github.com/radyatamaa/dating-apps-api/pkg/health"
 	"github.com/radyatamaa/dating-apps-api/pkg/helper"
 	"github.com/radyatamaa/dating-apps-api/pkg/jwt"
+	"github.com/radyatamaa/dating-apps-api/pkg/metrics"
+	"github.com/radyatamaa/dating-apps-api/pkg/migration"
+	"github.com/radyatamaa/dating-apps-api/pkg/oidc"
+	"github.com/radyatamaa/dating-apps-api/pkg/scheduler"
+	"github.com/radyatamaa/dating-apps-api/pkg/tracing"
 	"github.com/radyatamaa/dating-apps-api/pkg/validator"
 
 	"github.com/beego/beego/v2/client/cache"
 	_ "github.com/beego/beego/v2/client/cache/redis"
 
 	"github.com/radyatamaa/dating-apps-api/internal"
+	"github.com/radyatamaa/dating-apps-api/pkg/atomiccache"
 	"github.com/radyatamaa/dating-apps-api/pkg/database"
+	"github.com/radyatamaa/dating-apps-api/pkg/database/paginator"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
 
 	beego "github.com/beego/beego/v2/server/web"
 	beegoContext "github.com/beego/beego/v2/server/web/context"
@@ -38,6 +55,33 @@ import (
 	swipeHandler "github.com/radyatamaa/dating-apps-api/internal/swipe/delivery/http/v1"
 	swipeUsecase "github.com/radyatamaa/dating-apps-api/internal/swipe/usecase"
 	swipeRepository "github.com/radyatamaa/dating-apps-api/internal/swipe/repository"
+
+	oauthHandler "github.com/radyatamaa/dating-apps-api/internal/oauth/delivery/http/v1"
+	oauthUsecase "github.com/radyatamaa/dating-apps-api/internal/oauth/usecase"
+	oauthRepository "github.com/radyatamaa/dating-apps-api/internal/oauth/repository"
+
+	dataExportHandler "github.com/radyatamaa/dating-apps-api/internal/dataexport/delivery/http/v1"
+	dataExportUsecase "github.com/radyatamaa/dating-apps-api/internal/dataexport/usecase"
+	dataExportRepository "github.com/radyatamaa/dating-apps-api/internal/dataexport/repository"
+
+	blockHandler "github.com/radyatamaa/dating-apps-api/internal/block/delivery/http/v1"
+	blockUsecase "github.com/radyatamaa/dating-apps-api/internal/block/usecase"
+	blockRepository "github.com/radyatamaa/dating-apps-api/internal/block/repository"
+
+	reportHandler "github.com/radyatamaa/dating-apps-api/internal/report/delivery/http/v1"
+	reportUsecase "github.com/radyatamaa/dating-apps-api/internal/report/usecase"
+	reportRepository "github.com/radyatamaa/dating-apps-api/internal/report/repository"
+
+	moderationHandler "github.com/radyatamaa/dating-apps-api/internal/moderation/delivery/http/v1"
+	moderationUsecase "github.com/radyatamaa/dating-apps-api/internal/moderation/usecase"
+	adminHandler "github.com/radyatamaa/dating-apps-api/internal/admin/delivery/http/v1"
+	adminUsecase "github.com/radyatamaa/dating-apps-api/internal/admin/usecase"
+	auditHandler "github.com/radyatamaa/dating-apps-api/internal/audit/delivery/http/v1"
+	auditRepository "github.com/radyatamaa/dating-apps-api/internal/audit/repository"
+	auditUsecase "github.com/radyatamaa/dating-apps-api/internal/audit/usecase"
+	moderationRepository "github.com/radyatamaa/dating-apps-api/internal/moderation/repository"
+	outboxRepository "github.com/radyatamaa/dating-apps-api/internal/outbox/repository"
+	outboxUsecase "github.com/radyatamaa/dating-apps-api/internal/outbox/usecase"
 )
 
 // @title Dating App Api V1
@@ -56,34 +100,95 @@ func main() {
 	if err != nil {
 		panic(err)
 	}
-	// token expired
-	tokenExpired := beego.AppConfig.DefaultInt64("tokenExpired", 86400)
-	// global execution timeout
-	serverTimeout := beego.AppConfig.DefaultInt64("serverTimeout", 60)
-	// global execution timeout
-	requestTimeout := beego.AppConfig.DefaultInt("executionTimeout", 5)
+	// typed config from app.ini, overridden by the environment variables and the secret files
+	cfg, err := config.Loader{
+		Source:  beego.AppConfig,
+		RunMode: beego.BConfig.RunMode,
+	}.Load()
+	if err != nil {
+		panic(err)
+	}
 	// global execution timeout to second
-	timeoutContext := time.Duration(requestTimeout) * time.Second
-	// web hook to slack error log
-	slackWebHookUrl := beego.AppConfig.DefaultString("slackWebhookUrlLog", "")
-	// app version
-	appVersion := beego.AppConfig.DefaultString("version", "1")
-	// log path
-	logPath := beego.AppConfig.DefaultString("logPath", "./logs/api.log")
-	// redis connection config
-	redisConnectionConfig := beego.AppConfig.DefaultString("redisBeegoConConfig", `{"conn":"127.0.0.1:6379"}`)
-	// jwt secret key
-	jwtSecretKey := beego.AppConfig.DefaultString("jwtSecretKey", "secret")
-	// init data
-	initDataDummyProfileSeeder := beego.AppConfig.DefaultString("initDataDummyProfileSeeder", "true")
+	timeoutContext := cfg.App.ExecutionTimeoutDuration()
+
+	// tracing initialization
+	tracerProvider, err := tracing.NewTracerProvider(context.Background(), tracing.Config{
+		ServiceName:    cfg.Tracing.ServiceName,
+		ServiceVersion: cfg.App.Version,
+		Exporter:       cfg.Tracing.Exporter,
+		Endpoint:       cfg.Tracing.Endpoint,
+		Insecure:       cfg.Tracing.Insecure,
+		SampleRatio:    cfg.Tracing.SampleRatio,
+	})
+	if err != nil {
+		panic(err)
+	}
 
 	// database initialization
-	db := database.DB()
+	db, err := database.Open(database.Config{
+		Driver:                cfg.Database.Driver,
+		Host:                  cfg.Database.Host,
+		Port:                  cfg.Database.Port,
+		Name:                  cfg.Database.Name,
+		Username:              cfg.Database.Username,
+		Password:              cfg.Database.Password,
+		Options:               cfg.Database.Options,
+		Debug:                 cfg.Database.Debug,
+		MaxOpenConnection:     cfg.Database.MaxOpenConn,
+		MaxIdleConnection:     cfg.Database.MaxIdleConn,
+		MaxLifeTimeConnection: cfg.Database.MaxLifeTimeConn,
+		MaxIdleTimeConnection: cfg.Database.MaxIdleTimeConn,
+		Replicas:              cfg.Database.Replicas,
+		ReplicaMaxLag:         cfg.Database.ReplicaMaxLag,
+		ReplicaCheckInterval:  cfg.Database.ReplicaCheckInterval,
+	})
+	if err != nil {
+		panic(err)
+	}
+
+	// versioned sql migrations
+	dialect, err := migration.Dialect(db)
+	if err != nil {
+		panic(err)
+	}
+	migrator, err := migration.NewMigrator(db, migrations.FS, dialect)
+	if err != nil {
+		panic(err)
+	}
+	if len(os.Args) > 1 && os.Args[1] == "migrate" {
+		os.Exit(runMigrate(context.Background(), migrator, os.Args[2:], os.Stdout))
+	}
+	if cfg.Migration.AutoApply {
+		if _, err := migrator.Up(context.Background(), 0); err != nil {
+			panic(err)
+		}
+	}
+	pending, err := migrator.Pending(context.Background())
+	if err != nil {
+		panic(err)
+	}
+	if len(pending) > 0 {
+		panic(fmt.Sprintf("%d pending database migrations, run ./dating-apps-api migrate up or set [migration] autoApply=true", len(pending)))
+	}
+
+	if err := db.Use(tracing.GormPlugin{}); err != nil {
+		panic(err)
+	}
+	if cfg.Metrics.Enabled {
+		if err := db.Use(metrics.GormPlugin{}); err != nil {
+			panic(err)
+		}
+		sqlDB, err := db.DB()
+		if err != nil {
+			panic(err)
+		}
+		if err := metrics.RegisterDBStats(sqlDB, "mysql"); err != nil {
+			panic(err)
+		}
+	}
 
 	// language
-	lang := beego.AppConfig.DefaultString("lang", "en|id")
-	languages := strings.Split(lang, "|")
-	for _, value := range languages {
+	for _, value := range cfg.App.Lang {
 		if err := i18n.SetMessage(value, "./conf/"+value+".ini"); err != nil {
 			panic("Failed to set message file for l10n")
 		}
@@ -92,40 +197,40 @@ func main() {
 	// beego config
 	beego.BConfig.Log.AccessLogs = false
 	beego.BConfig.Log.EnableStaticLogs = false
-	beego.BConfig.Listen.ServerTimeOut = serverTimeout
+	beego.BConfig.Listen.ServerTimeOut = cfg.App.ServerTimeout
 
 	// zap logger
-	zapLog := zaplogger.NewZapLogger(logPath, slackWebHookUrl)
+	zapLog := zaplogger.NewZapLoggerWithEncoding(cfg.Log.Path, cfg.Log.SlackWebhookUrl, cfg.Log.Encoding)
 
 	if beego.BConfig.RunMode == "dev" {
-		// db auto migrate dev environment
-		if err := db.AutoMigrate(
-			&domain.User{},
-			&domain.Profile{},
-			&domain.Swipe{},
-		); err != nil {
-			panic(err)
-		}
-
 		// static files swagger
 		beego.BConfig.WebConfig.DirectoryIndex = true
 		beego.BConfig.WebConfig.StaticDir["/swagger"] = "swagger"
 	}
 
 	// init redis
-	redisCache, err := cache.NewCache("redis", redisConnectionConfig)
+	redisCache, err := cache.NewCache("redis", cfg.Redis.Connection)
+
+	if err != nil {
+		panic(err)
+	}
 
+	// atomic counters shared between the replicas, ex: the rate limit and the two factor attempts
+	atomicCache, err := atomiccache.NewRedisCache(cfg.Redis.Connection)
 	if err != nil {
 		panic(err)
 	}
 
+	// totals of the paginated listings
+	paginator.UseCountCache(redisCache, cfg.Database.CountCacheTTL)
+
 	// config validator
 	validator.Validate.SetDatabaseConnection(db)
 
 	// jwt middleware
 	auth, err := jwt.NewJwt(&jwt.Options{
 		SignMethod:  jwt.HS256,
-		SecretKey:   jwtSecretKey,
+		SecretKey:   cfg.Jwt.SecretKey,
 		Locations:   "header:Authorization",
 		IdentityKey: "uid",
 	})
@@ -135,7 +240,52 @@ func main() {
 
 	// set adapter redis for jwt middleware
 	auth.SetAdapter(redisCache)
-	if initDataDummyProfileSeeder == "true" {
+
+	// rate limit middleware
+	rateLimitDefault, err := middlewares.ParseRateLimit(cfg.RateLimit.Default)
+	if err != nil {
+		panic(fmt.Errorf("ratelimit::default %w", err))
+	}
+	rateLimitRoutes, err := middlewares.ParseRateLimitRoutes(cfg.RateLimit.Routes)
+	if err != nil {
+		panic(fmt.Errorf("ratelimit::routes %w", err))
+	}
+	rateLimitConfig := middlewares.RateLimitConfig{
+		Store:   middlewares.NewMemoryRateLimitStore(),
+		KeyBy:   cfg.RateLimit.KeyBy,
+		Rules:   rateLimitRoutes,
+		Default: rateLimitDefault,
+	}
+	if cfg.RateLimit.Store == config.RateLimitStoreRedis {
+		rateLimitConfig.Store = middlewares.NewCacheRateLimitStore(atomicCache)
+	}
+
+	// oidc providers, a provider without client id is not enabled
+	oidcProviders := oidc.Registry{}
+	for name, providerConfig := range cfg.OAuth.Provider {
+		if providerConfig.ClientID == "" {
+			continue
+		}
+		provider, err := oidc.NewProvider(oidc.Config{
+			Name:         name,
+			Issuer:       providerConfig.Issuer,
+			ClientID:     providerConfig.ClientID,
+			ClientSecret: providerConfig.ClientSecret,
+			RedirectURL:  providerConfig.RedirectURL,
+			Scopes:       providerConfig.Scopes,
+		})
+		if err != nil {
+			panic(err)
+		}
+		oidcProviders[name] = provider
+	}
+
+	// users granted the admin role at startup
+	if err := domain.SeederRole(db, domain.RoleAdmin, cfg.Admin.BootstrapEmails); err != nil {
+		panic(err)
+	}
+
+	if cfg.App.InitDataDummyProfileSeeder {
 		domain.SeederDataUserProfile(db)
 	}
 	if beego.BConfig.RunMode != "prod" {
@@ -184,17 +334,67 @@ func main() {
 
 	// middleware init
 	beego.InsertFilter("*", beego.BeforeRouter, cors.Allow(&cors.Options{
-		AllowMethods:    []string{http.MethodGet, http.MethodPost},
+		AllowMethods:    []string{http.MethodGet, http.MethodPost, http.MethodPut, http.MethodDelete},
 		AllowAllOrigins: true,
 	}))
 
 	beego.InsertFilterChain("*", middlewares.RequestID())
-	beego.InsertFilterChain("/api/*", middlewares.BodyDumpWithConfig(middlewares.NewAccessLogMiddleware(zapLog, appVersion).Logger()))
+	beego.InsertFilterChain("/api/*", middlewares.TracingWithConfig(middlewares.TracingConfig{
+		ServerName: cfg.Tracing.ServiceName,
+	}))
+	if cfg.Metrics.Enabled {
+		beego.InsertFilterChain("/api/*", middlewares.Metrics())
+	}
+	accessLogConfig := middlewares.NewAccessLogMiddleware(zapLog, cfg.App.Version).Logger()
+	accessLogConfig.RedactRequestFields = cfg.AccessLog.RedactRequestFields
+	accessLogConfig.RedactResponseFields = cfg.AccessLog.RedactResponseFields
+	accessLogConfig.SkipFormFields = cfg.AccessLog.SkipFormFields
+	accessLogConfig.MaxBodySize = cfg.AccessLog.MaxBodySize
+	beego.InsertFilterChain("/api/*", middlewares.BodyDumpWithConfig(accessLogConfig))
 	beego.InsertFilterChain("/api/v1/*", middlewares.NewJwtMiddleware().JwtMiddleware(auth))
-	// health check
-	beego.Get("/health", func(ctx *beegoContext.Context) {
+	beego.InsertFilterChain("/api/admin/*", middlewares.NewJwtMiddleware().JwtMiddleware(auth))
+	beego.InsertFilterChain("/api/admin/*", middlewares.PermissionWithConfig(middlewares.PermissionConfig{
+		Rules: []middlewares.PermissionRule{
+			{Method: http.MethodGet, Path: "/api/admin/v1/users", Permission: domain.PermissionUsersRead},
+			{Method: http.MethodGet, Path: "/api/admin/v1/users/:id", Permission: domain.PermissionUsersRead},
+			{Method: http.MethodGet, Path: "/api/admin/v1/users/:id/actions", Permission: domain.PermissionUsersRead},
+			{Method: http.MethodPost, Path: "/api/admin/v1/users/:id/actions", Permission: domain.PermissionUsersModerate},
+			{Method: http.MethodPost, Path: "/api/admin/v1/users/:id/premium", Permission: domain.PermissionUsersPremium},
+			{Method: http.MethodPut, Path: "/api/admin/v1/users/:id/role", Permission: domain.PermissionUsersRole},
+			{Method: http.MethodDelete, Path: "/api/admin/v1/users/:id/photo", Permission: domain.PermissionPhotosModerate},
+			{Method: http.MethodGet, Path: "/api/admin/v1/audit-logs", Permission: domain.PermissionAuditRead},
+			{Method: "*", Path: "/api/admin/v1/reports", Permission: domain.PermissionReportsManage},
+			{Method: "*", Path: "/api/admin/v1/reports/*", Permission: domain.PermissionReportsManage},
+		},
+	}))
+	if cfg.RateLimit.Enabled {
+		beego.InsertFilterChain("/api/*", middlewares.RateLimitWithConfig(rateLimitConfig))
+	}
+	// prometheus metrics, served on their own port so they're not public with the api
+	metricsMux := http.NewServeMux()
+	metricsMux.Handle(cfg.Metrics.Path, promhttp.Handler())
+	metricsServer := &http.Server{
+		Addr:    fmt.Sprintf(":%d", cfg.Metrics.Port),
+		Handler: metricsMux,
+	}
+	// health check, readiness probes the dependencies registered in the registry
+	healthRegistry := health.NewRegistry(cfg.Health.Timeout)
+	healthRegistry.Register("mysql", health.DB(db))
+	healthRegistry.Register("redis", health.Cache(redisCache))
+	liveness := func(ctx *beegoContext.Context) {
 		ctx.Output.SetStatus(http.StatusOK)
 		ctx.Output.JSON(beego.M{"status": "alive"}, beego.BConfig.RunMode != "prod", false)
+	}
+	beego.Get("/health", liveness)
+	beego.Get("/health/live", liveness)
+	beego.Get("/health/ready", func(ctx *beegoContext.Context) {
+		report := healthRegistry.Check(ctx.Request.Context())
+		if report.Status == health.StatusUp {
+			ctx.Output.SetStatus(http.StatusOK)
+		} else {
+			ctx.Output.SetStatus(http.StatusServiceUnavailable)
+		}
+		ctx.Output.JSON(report, beego.BConfig.RunMode != "prod", false)
 	})
 
 	// default error handler
@@ -204,18 +404,131 @@ func main() {
 	userMysqlRepo := userRepository.NewMysqlRepository(db,zapLog)
 	profileMysqlRepo := profileRepository.NewMysqlRepository(db,zapLog)
 	swipeMysqlRepo := swipeRepository.NewMysqlRepository(db,zapLog)
+	oauthMysqlRepo := oauthRepository.NewMysqlRepository(db,zapLog)
+	dataExportMysqlRepo := dataExportRepository.NewMysqlRepository(db,zapLog)
+	blockMysqlRepo := blockRepository.NewMysqlRepository(db,zapLog)
+	reportMysqlRepo := reportRepository.NewMysqlRepository(db,zapLog)
+	moderationMysqlRepo := moderationRepository.NewMysqlRepository(db,zapLog)
+	auditMysqlRepo := auditRepository.NewMysqlRepository(db,zapLog)
+	outboxMysqlRepo := outboxRepository.NewMysqlRepository(db,zapLog)
+
+	// the repositories join the transaction carried by the context
+	txManager := database.NewTxManager(db)
+
+	// domain events, the in process subscribers of the bus and the broker the outbox is relayed to
+	eventBus := event.NewBus()
+	eventBus.Subscribe(event.All, func(ctx context.Context, e event.Event) error {
+		metrics.DomainEventsTotal.WithLabelValues(e.Name).Inc()
+		return nil
+	})
+	var eventBroker event.Broker = eventBus
+	var eventConsumer *kafka.Consumer
+	if cfg.Event.Broker == config.EventBrokerKafka {
+		kafkaConfig := kafka.Config{
+			Brokers:         cfg.Event.KafkaBrokers,
+			Topic:           cfg.Event.KafkaTopic,
+			GroupID:         cfg.Event.KafkaGroupID,
+			MaxAttempts:     cfg.Event.MaxAttempts,
+			DeadLetterTopic: cfg.Event.KafkaDeadLetterTopic,
+		}
+		eventBroker = kafka.NewBroker(kafkaConfig)
+		eventConsumer = kafka.NewConsumer(kafkaConfig, eventBus, zapLog)
+		eventConsumer.Start()
+	}
 
 	// init usecase
-	userUseCase := userUsecase.NewUserUseCase(timeoutContext,userMysqlRepo,profileMysqlRepo,auth,int(tokenExpired),zapLog)
-	profileUseCase := profileUsecase.NewProfileUseCase(timeoutContext,profileMysqlRepo,swipeMysqlRepo,zapLog)
-	swipeUseCase := swipeUsecase.NewSwipeUseCase(timeoutContext,swipeMysqlRepo,userMysqlRepo,zapLog)
+	outboxUseCase := outboxUsecase.NewOutboxUseCase(outboxMysqlRepo,eventBroker,cfg.Event.RelayBatchSize,cfg.Event.MaxAttempts,zapLog)
+	auditUseCase := auditUsecase.NewAuditUseCase(timeoutContext,auditMysqlRepo,zapLog)
+	userUseCase := userUsecase.NewUserUseCase(timeoutContext,txManager,outboxUseCase,userMysqlRepo,profileMysqlRepo,auth,int(cfg.Jwt.TokenExpired),redisCache,atomicCache,auditUseCase,zapLog)
+	profileUseCase := profileUsecase.NewProfileUseCase(timeoutContext,profileMysqlRepo,swipeMysqlRepo,blockMysqlRepo,zapLog)
+	swipeUseCase := swipeUsecase.NewSwipeUseCase(timeoutContext,txManager,outboxUseCase,swipeMysqlRepo,userMysqlRepo,profileMysqlRepo,blockMysqlRepo,zapLog)
+	oauthUseCase := oauthUsecase.NewOAuthUseCase(timeoutContext,txManager,outboxUseCase,oauthMysqlRepo,userMysqlRepo,profileMysqlRepo,userUseCase,oidcProviders,redisCache,zapLog)
+	dataExportUseCase := dataExportUsecase.NewDataExportUseCase(timeoutContext,dataExportMysqlRepo,userMysqlRepo,profileMysqlRepo,swipeMysqlRepo,oauthMysqlRepo,cfg.Account.ExportPath,cfg.Account.ExportExpire,zapLog)
+	blockUseCase := blockUsecase.NewBlockUseCase(timeoutContext,blockMysqlRepo,profileMysqlRepo,zapLog)
+	reportUseCase := reportUsecase.NewReportUseCase(timeoutContext,txManager,reportMysqlRepo,profileMysqlRepo,userMysqlRepo,moderationMysqlRepo,cfg.Moderation.AutoHideThreshold,auditUseCase,zapLog)
+	moderationUseCase := moderationUsecase.NewModerationUseCase(timeoutContext,txManager,moderationMysqlRepo,userMysqlRepo,reportMysqlRepo,auth,auditUseCase,zapLog)
+	adminUseCase := adminUsecase.NewAdminUseCase(timeoutContext,txManager,userMysqlRepo,profileMysqlRepo,moderationMysqlRepo,auth,auditUseCase,zapLog)
 
 	// init handler
 	userHandler.NewUserHandler(userUseCase,zapLog)
 	profileHandler.NewProfileHandler(profileUseCase,zapLog)
 	swipeHandler.NewSwipeHandler(swipeUseCase,zapLog)
+	oauthHandler.NewOAuthHandler(oauthUseCase,zapLog)
+	dataExportHandler.NewDataExportHandler(dataExportUseCase,zapLog)
+	blockHandler.NewBlockHandler(blockUseCase,zapLog)
+	reportHandler.NewReportHandler(reportUseCase,zapLog)
+	moderationHandler.NewModerationHandler(moderationUseCase,zapLog)
+	adminHandler.NewAdminHandler(adminUseCase,zapLog)
+	auditHandler.NewAuditHandler(auditUseCase,zapLog)
+
+	// background jobs, a run is locked in redis so only one replica or worker runs it
+	var jobLocker scheduler.Locker = scheduler.NewMemoryLocker()
+	var redisLocker *scheduler.RedisLocker
+	if cfg.Worker.LockStore == config.WorkerLockStoreRedis {
+		redisLocker, err = scheduler.NewRedisLocker(cfg.Redis.Connection)
+		if err != nil {
+			panic(err)
+		}
+		jobLocker = redisLocker
+	}
+	jobScheduler, err := newScheduler(cfg, timeoutContext, jobLocker, workerUseCases{
+		user:       userUseCase,
+		profile:    profileUseCase,
+		dataExport: dataExportUseCase,
+		audit:      auditUseCase,
+		outbox:     outboxUseCase,
+	}, zapLog)
+	if err != nil {
+		panic(err)
+	}
 
-	beego.BeeApp.Server.RegisterOnShutdown(func() {
+	// the grpc login shares the rate limit of the rest login
+	var grpcRateLimit *middlewares.RateLimitConfig
+	if cfg.RateLimit.Enabled {
+		grpcRateLimit = &rateLimitConfig
+	}
+	grpcServer := newGrpcServer(auth, grpcRateLimit, grpcUseCases{
+		user:    userUseCase,
+		profile: profileUseCase,
+		swipe:   swipeUseCase,
+	}, zapLog)
+
+	shutdown := func() {
+		jobScheduler.Stop()
+		grpcServer.GracefulStop()
+		if cfg.Metrics.Enabled {
+			ctx, cancel := context.WithTimeout(context.Background(), timeoutContext)
+			if err := metricsServer.Shutdown(ctx); err != nil {
+				log.Println("failed close metrics server")
+			}
+			cancel()
+		}
+		if redisLocker != nil {
+			if err := redisLocker.Close(); err != nil {
+				log.Println("failed close job locker")
+			}
+		}
+		if err := atomicCache.Close(); err != nil {
+			log.Println("failed close atomic cache")
+		}
+		if eventConsumer != nil {
+			if err := eventConsumer.Close(); err != nil {
+				log.Println("failed close event consumer")
+			}
+		}
+		if err := eventBroker.Close(); err != nil {
+			log.Println("failed close event broker")
+		}
+		ctx, cancel := context.WithTimeout(context.Background(), timeoutContext)
+		if err := tracerProvider.Shutdown(ctx); err != nil {
+			log.Println("failed flush traces")
+		}
+		cancel()
+		if resolver := database.ReplicaResolver(db); resolver != nil {
+			if err := resolver.Close(); err != nil {
+				log.Println("failed close database replicas")
+			}
+		}
 		if sqlDb, err := db.DB(); err != nil {
 			log.Println("error database connection ...")
 		} else {
@@ -225,7 +538,40 @@ func main() {
 				log.Println("close database connection ...")
 			}
 		}
-	})
+	}
+	if len(os.Args) > 1 && os.Args[1] == "worker" {
+		ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+		code := runWorker(ctx, jobScheduler, os.Args[2:], os.Stdout)
+		stop()
+		shutdown()
+		os.Exit(code)
+	}
+	if cfg.Worker.Enabled {
+		jobScheduler.Start()
+	}
+	if cfg.Grpc.Enabled {
+		listener, err := net.Listen("tcp", fmt.Sprintf(":%d", cfg.Grpc.Port))
+		if err != nil {
+			panic(err)
+		}
+		go func() {
+			if err := grpcServer.Serve(listener); err != nil {
+				log.Println("grpc server stopped:", err)
+			}
+		}()
+	}
+	if cfg.Metrics.Enabled {
+		listener, err := net.Listen("tcp", metricsServer.Addr)
+		if err != nil {
+			panic(err)
+		}
+		go func() {
+			if err := metricsServer.Serve(listener); err != nil && err != http.ErrServerClosed {
+				log.Println("metrics server stopped:", err)
+			}
+		}()
+	}
+	beego.BeeApp.Server.RegisterOnShutdown(shutdown)
 
 	// default error handler
 	beego.ErrorController(&internal.BaseController{})
@@ -0,0 +1,78 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"strconv"
+
+	"github.com/radyatamaa/dating-apps-api/pkg/migration"
+)
+
+const migrateUsage = "usage: dating-apps-api migrate up [n] | down [n] | status"
+
+// runMigrate runs the migrate command, ex: migrate up, migrate down 2, migrate status,
+// and returns the exit code.
+func runMigrate(ctx context.Context, migrator *migration.Migrator, args []string, out io.Writer) int {
+	if len(args) == 0 || len(args) > 2 {
+		fmt.Fprintln(out, migrateUsage)
+		return 2
+	}
+	steps := 0
+	if len(args) == 2 {
+		n, err := strconv.Atoi(args[1])
+		if err != nil || n <= 0 {
+			fmt.Fprintln(out, migrateUsage)
+			return 2
+		}
+		steps = n
+	}
+
+	switch args[0] {
+	case "up":
+		done, err := migrator.Up(ctx, steps)
+		for _, m := range done {
+			fmt.Fprintf(out, "applied %d_%s\n", m.Version, m.Name)
+		}
+		if err != nil {
+			fmt.Fprintln(out, err)
+			return 1
+		}
+		if len(done) == 0 {
+			fmt.Fprintln(out, "no pending migrations")
+		}
+	case "down":
+		done, err := migrator.Down(ctx, steps)
+		for _, m := range done {
+			fmt.Fprintf(out, "reverted %d_%s\n", m.Version, m.Name)
+		}
+		if err != nil {
+			fmt.Fprintln(out, err)
+			return 1
+		}
+		if len(done) == 0 {
+			fmt.Fprintln(out, "no applied migrations")
+		}
+	case "status":
+		if len(args) > 1 {
+			fmt.Fprintln(out, migrateUsage)
+			return 2
+		}
+		statuses, err := migrator.Status(ctx)
+		if err != nil {
+			fmt.Fprintln(out, err)
+			return 1
+		}
+		for _, s := range statuses {
+			appliedAt := "pending"
+			if s.Applied {
+				appliedAt = s.AppliedAt.Format("2006-01-02 15:04:05")
+			}
+			fmt.Fprintf(out, "%d_%s\t%s\n", s.Version, s.Name, appliedAt)
+		}
+	default:
+		fmt.Fprintln(out, migrateUsage)
+		return 2
+	}
+	return 0
+}
@@ -0,0 +1,11 @@
+// Package migrations embeds the versioned SQL migrations of each dialect.
+//
+// A migration is a pair of files <version>_<name>.up.sql and <version>_<name>.down.sql
+// in the directory of the dialect, ex: mysql/000002_add_profile_gender.up.sql, the
+// statements of a file are separated by a semicolon at the end of a line.
+package migrations
+
+import "embed"
+
+//go:embed mysql/*.sql postgres/*.sql
+var FS embed.FS
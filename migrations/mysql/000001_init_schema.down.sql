@@ -0,0 +1,3 @@
+DROP TABLE IF EXISTS `swipes`;
+DROP TABLE IF EXISTS `profile`;
+DROP TABLE IF EXISTS `users`;
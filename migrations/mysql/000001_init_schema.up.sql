@@ -0,0 +1,41 @@
+-- initial schema of the entities, the tables are created only when missing so the
+-- databases created by the former dev auto migration are adopted as they are, the
+-- columns and tables added since then are left to the next migrations.
+
+CREATE TABLE IF NOT EXISTS `users` (
+    `id` bigint AUTO_INCREMENT,
+    `password_hash` varchar(255),
+    `email` varchar(255),
+    `premium_expires_at` datetime(3) NULL,
+    `created_at` datetime(3) NULL,
+    `updated_at` datetime(3) NULL,
+    PRIMARY KEY (`id`)
+);
+
+CREATE TABLE IF NOT EXISTS `profile` (
+    `id` bigint AUTO_INCREMENT,
+    `user_id` bigint,
+    `name` varchar(255),
+    `photo` text,
+    `age` bigint,
+    `bio` text,
+    `longitude` double,
+    `latitude` double,
+    `created_at` datetime(3) NULL,
+    `updated_at` datetime(3) NULL,
+    PRIMARY KEY (`id`),
+    CONSTRAINT `fk_profile_user` FOREIGN KEY (`user_id`) REFERENCES `users`(`id`) ON DELETE CASCADE ON UPDATE CASCADE
+);
+
+CREATE TABLE IF NOT EXISTS `swipes` (
+    `id` bigint AUTO_INCREMENT,
+    `user_id` bigint,
+    `profile_id` bigint,
+    `swipe_type` varchar(255),
+    `created_at` datetime(3) NULL,
+    `updated_at` datetime(3) NULL,
+    PRIMARY KEY (`id`),
+    UNIQUE INDEX `idx_user_profile` (`user_id`,`profile_id`),
+    CONSTRAINT `fk_swipes_profile` FOREIGN KEY (`profile_id`) REFERENCES `profile`(`id`) ON DELETE CASCADE ON UPDATE CASCADE,
+    CONSTRAINT `fk_swipes_user` FOREIGN KEY (`user_id`) REFERENCES `users`(`id`) ON DELETE CASCADE ON UPDATE CASCADE
+);
@@ -0,0 +1,14 @@
+CREATE TABLE IF NOT EXISTS `outbox_events` (
+    `id` bigint AUTO_INCREMENT,
+    `event_id` varchar(36) NOT NULL,
+    `name` varchar(100),
+    `event_key` varchar(100),
+    `payload` text,
+    `occurred_at` datetime(3) NULL,
+    `published_at` datetime(3) NULL,
+    `attempts` bigint DEFAULT 0,
+    `last_error` text,
+    PRIMARY KEY (`id`),
+    UNIQUE INDEX `idx_outbox_events_event_id` (`event_id`),
+    INDEX `idx_outbox_events_published_at` (`published_at`)
+);
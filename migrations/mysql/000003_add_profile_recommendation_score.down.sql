@@ -0,0 +1 @@
+ALTER TABLE `profile` DROP INDEX `idx_profile_recommendation_score`, DROP COLUMN `recommendation_score`;
@@ -0,0 +1 @@
+ALTER TABLE `profile` ADD COLUMN `recommendation_score` double DEFAULT 0, ADD INDEX `idx_profile_recommendation_score` (`recommendation_score`);
@@ -0,0 +1 @@
+ALTER TABLE `users` DROP COLUMN `two_factor_enabled`, DROP COLUMN `two_factor_secret`, DROP COLUMN `two_factor_backup_codes`;
@@ -0,0 +1,13 @@
+CREATE TABLE IF NOT EXISTS `user_identities` (
+    `id` bigint AUTO_INCREMENT,
+    `user_id` bigint,
+    `provider` varchar(50),
+    `subject` varchar(255),
+    `email` varchar(255),
+    `created_at` datetime(3) NULL,
+    `updated_at` datetime(3) NULL,
+    PRIMARY KEY (`id`),
+    INDEX `idx_user_identities_user_id` (`user_id`),
+    UNIQUE INDEX `idx_provider_subject` (`provider`,`subject`),
+    CONSTRAINT `fk_user_identities_user` FOREIGN KEY (`user_id`) REFERENCES `users`(`id`) ON DELETE CASCADE ON UPDATE CASCADE
+);
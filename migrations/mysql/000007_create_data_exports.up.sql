@@ -0,0 +1,13 @@
+CREATE TABLE IF NOT EXISTS `data_exports` (
+    `id` bigint AUTO_INCREMENT,
+    `user_id` bigint,
+    `status` varchar(20),
+    `file_path` varchar(255),
+    `error` text,
+    `expires_at` datetime(3) NULL,
+    `created_at` datetime(3) NULL,
+    `updated_at` datetime(3) NULL,
+    PRIMARY KEY (`id`),
+    INDEX `idx_data_exports_user_id` (`user_id`),
+    CONSTRAINT `fk_data_exports_user` FOREIGN KEY (`user_id`) REFERENCES `users`(`id`) ON DELETE CASCADE ON UPDATE CASCADE
+);
@@ -0,0 +1,12 @@
+CREATE TABLE IF NOT EXISTS `blocks` (
+    `id` bigint AUTO_INCREMENT,
+    `user_id` bigint,
+    `blocked_user_id` bigint,
+    `created_at` datetime(3) NULL,
+    `updated_at` datetime(3) NULL,
+    PRIMARY KEY (`id`),
+    UNIQUE INDEX `idx_user_blocked_user` (`user_id`,`blocked_user_id`),
+    INDEX `idx_blocks_blocked_user_id` (`blocked_user_id`),
+    CONSTRAINT `fk_blocks_blocked_user` FOREIGN KEY (`blocked_user_id`) REFERENCES `users`(`id`) ON DELETE CASCADE ON UPDATE CASCADE,
+    CONSTRAINT `fk_blocks_user` FOREIGN KEY (`user_id`) REFERENCES `users`(`id`) ON DELETE CASCADE ON UPDATE CASCADE
+);
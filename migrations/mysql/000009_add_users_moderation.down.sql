@@ -0,0 +1 @@
+ALTER TABLE `users` DROP COLUMN `hidden_at`, DROP COLUMN `suspended_until`, DROP COLUMN `banned_at`;
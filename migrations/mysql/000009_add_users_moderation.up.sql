@@ -0,0 +1 @@
+ALTER TABLE `users` ADD COLUMN `hidden_at` datetime(3) NULL, ADD COLUMN `suspended_until` datetime(3) NULL, ADD COLUMN `banned_at` datetime(3) NULL;
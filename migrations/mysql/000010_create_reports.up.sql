@@ -0,0 +1,20 @@
+CREATE TABLE IF NOT EXISTS `reports` (
+    `id` bigint AUTO_INCREMENT,
+    `reporter_id` bigint,
+    `reported_user_id` bigint,
+    `profile_id` bigint,
+    `reason` varchar(50),
+    `description` text,
+    `status` varchar(20),
+    `reviewed_by` bigint,
+    `reviewed_at` datetime(3) NULL,
+    `created_at` datetime(3) NULL,
+    `updated_at` datetime(3) NULL,
+    PRIMARY KEY (`id`),
+    INDEX `idx_reports_reporter_id` (`reporter_id`),
+    INDEX `idx_reports_reported_user_id` (`reported_user_id`),
+    INDEX `idx_reports_status` (`status`),
+    CONSTRAINT `fk_reports_reported_user` FOREIGN KEY (`reported_user_id`) REFERENCES `users`(`id`) ON DELETE CASCADE ON UPDATE CASCADE,
+    CONSTRAINT `fk_reports_profile` FOREIGN KEY (`profile_id`) REFERENCES `profile`(`id`) ON DELETE CASCADE ON UPDATE CASCADE,
+    CONSTRAINT `fk_reports_reporter` FOREIGN KEY (`reporter_id`) REFERENCES `users`(`id`) ON DELETE CASCADE ON UPDATE CASCADE
+);
@@ -0,0 +1,13 @@
+CREATE TABLE IF NOT EXISTS `moderation_actions` (
+    `id` bigint AUTO_INCREMENT,
+    `user_id` bigint,
+    `actor_id` bigint,
+    `report_id` bigint,
+    `action` varchar(20),
+    `reason` text,
+    `expires_at` datetime(3) NULL,
+    `created_at` datetime(3) NULL,
+    PRIMARY KEY (`id`),
+    INDEX `idx_moderation_actions_user_id` (`user_id`),
+    CONSTRAINT `fk_moderation_actions_user` FOREIGN KEY (`user_id`) REFERENCES `users`(`id`) ON DELETE CASCADE ON UPDATE CASCADE
+);
@@ -0,0 +1,16 @@
+CREATE TABLE IF NOT EXISTS `audit_logs` (
+    `id` bigint AUTO_INCREMENT,
+    `actor_id` bigint,
+    `action` varchar(50),
+    `target_type` varchar(50),
+    `target_id` bigint,
+    `changes` text,
+    `ip` varchar(45),
+    `request_id` varchar(64),
+    `created_at` datetime(3) NULL,
+    PRIMARY KEY (`id`),
+    INDEX `idx_audit_logs_actor_id` (`actor_id`),
+    INDEX `idx_audit_logs_action` (`action`),
+    INDEX `idx_audit_logs_target_id` (`target_id`),
+    INDEX `idx_audit_logs_created_at` (`created_at`)
+);
@@ -0,0 +1,16 @@
+CREATE TABLE IF NOT EXISTS "outbox_events" (
+    "id" bigserial,
+    "event_id" varchar(36) NOT NULL,
+    "name" varchar(100),
+    "event_key" varchar(100),
+    "payload" text,
+    "occurred_at" timestamptz,
+    "published_at" timestamptz,
+    "attempts" bigint DEFAULT 0,
+    "last_error" text,
+    PRIMARY KEY ("id")
+);
+
+CREATE UNIQUE INDEX IF NOT EXISTS "idx_outbox_events_event_id" ON "outbox_events" ("event_id");
+
+CREATE INDEX IF NOT EXISTS "idx_outbox_events_published_at" ON "outbox_events" ("published_at");
@@ -0,0 +1,2 @@
+DROP INDEX IF EXISTS "idx_profile_recommendation_score";
+ALTER TABLE "profile" DROP COLUMN IF EXISTS "recommendation_score";
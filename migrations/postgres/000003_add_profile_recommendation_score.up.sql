@@ -0,0 +1,3 @@
+ALTER TABLE "profile" ADD COLUMN IF NOT EXISTS "recommendation_score" double precision DEFAULT 0;
+
+CREATE INDEX IF NOT EXISTS "idx_profile_recommendation_score" ON "profile" ("recommendation_score");
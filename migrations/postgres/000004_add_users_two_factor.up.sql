@@ -0,0 +1 @@
+ALTER TABLE "users" ADD COLUMN IF NOT EXISTS "two_factor_enabled" boolean DEFAULT false, ADD COLUMN IF NOT EXISTS "two_factor_secret" varchar(255), ADD COLUMN IF NOT EXISTS "two_factor_backup_codes" text;
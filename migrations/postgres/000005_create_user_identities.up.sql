@@ -0,0 +1,15 @@
+CREATE TABLE IF NOT EXISTS "user_identities" (
+    "id" bigserial,
+    "user_id" bigint,
+    "provider" varchar(50),
+    "subject" varchar(255),
+    "email" varchar(255),
+    "created_at" timestamptz,
+    "updated_at" timestamptz,
+    PRIMARY KEY ("id"),
+    CONSTRAINT "fk_user_identities_user" FOREIGN KEY ("user_id") REFERENCES "users"("id") ON DELETE CASCADE ON UPDATE CASCADE
+);
+
+CREATE UNIQUE INDEX IF NOT EXISTS "idx_provider_subject" ON "user_identities" ("provider","subject");
+
+CREATE INDEX IF NOT EXISTS "idx_user_identities_user_id" ON "user_identities" ("user_id");
@@ -0,0 +1,14 @@
+CREATE TABLE IF NOT EXISTS "data_exports" (
+    "id" bigserial,
+    "user_id" bigint,
+    "status" varchar(20),
+    "file_path" varchar(255),
+    "error" text,
+    "expires_at" timestamptz,
+    "created_at" timestamptz,
+    "updated_at" timestamptz,
+    PRIMARY KEY ("id"),
+    CONSTRAINT "fk_data_exports_user" FOREIGN KEY ("user_id") REFERENCES "users"("id") ON DELETE CASCADE ON UPDATE CASCADE
+);
+
+CREATE INDEX IF NOT EXISTS "idx_data_exports_user_id" ON "data_exports" ("user_id");
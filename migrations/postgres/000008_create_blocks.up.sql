@@ -0,0 +1,14 @@
+CREATE TABLE IF NOT EXISTS "blocks" (
+    "id" bigserial,
+    "user_id" bigint,
+    "blocked_user_id" bigint,
+    "created_at" timestamptz,
+    "updated_at" timestamptz,
+    PRIMARY KEY ("id"),
+    CONSTRAINT "fk_blocks_user" FOREIGN KEY ("user_id") REFERENCES "users"("id") ON DELETE CASCADE ON UPDATE CASCADE,
+    CONSTRAINT "fk_blocks_blocked_user" FOREIGN KEY ("blocked_user_id") REFERENCES "users"("id") ON DELETE CASCADE ON UPDATE CASCADE
+);
+
+CREATE INDEX IF NOT EXISTS "idx_blocks_blocked_user_id" ON "blocks" ("blocked_user_id");
+
+CREATE UNIQUE INDEX IF NOT EXISTS "idx_user_blocked_user" ON "blocks" ("user_id","blocked_user_id");
@@ -0,0 +1 @@
+ALTER TABLE "users" DROP COLUMN IF EXISTS "hidden_at", DROP COLUMN IF EXISTS "suspended_until", DROP COLUMN IF EXISTS "banned_at";
@@ -0,0 +1 @@
+ALTER TABLE "users" ADD COLUMN IF NOT EXISTS "hidden_at" timestamptz, ADD COLUMN IF NOT EXISTS "suspended_until" timestamptz, ADD COLUMN IF NOT EXISTS "banned_at" timestamptz;
@@ -0,0 +1,23 @@
+CREATE TABLE IF NOT EXISTS "reports" (
+    "id" bigserial,
+    "reporter_id" bigint,
+    "reported_user_id" bigint,
+    "profile_id" bigint,
+    "reason" varchar(50),
+    "description" text,
+    "status" varchar(20),
+    "reviewed_by" bigint,
+    "reviewed_at" timestamptz,
+    "created_at" timestamptz,
+    "updated_at" timestamptz,
+    PRIMARY KEY ("id"),
+    CONSTRAINT "fk_reports_reporter" FOREIGN KEY ("reporter_id") REFERENCES "users"("id") ON DELETE CASCADE ON UPDATE CASCADE,
+    CONSTRAINT "fk_reports_reported_user" FOREIGN KEY ("reported_user_id") REFERENCES "users"("id") ON DELETE CASCADE ON UPDATE CASCADE,
+    CONSTRAINT "fk_reports_profile" FOREIGN KEY ("profile_id") REFERENCES "profile"("id") ON DELETE CASCADE ON UPDATE CASCADE
+);
+
+CREATE INDEX IF NOT EXISTS "idx_reports_reported_user_id" ON "reports" ("reported_user_id");
+
+CREATE INDEX IF NOT EXISTS "idx_reports_reporter_id" ON "reports" ("reporter_id");
+
+CREATE INDEX IF NOT EXISTS "idx_reports_status" ON "reports" ("status");
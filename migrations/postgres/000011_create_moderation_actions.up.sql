@@ -0,0 +1,14 @@
+CREATE TABLE IF NOT EXISTS "moderation_actions" (
+    "id" bigserial,
+    "user_id" bigint,
+    "actor_id" bigint,
+    "report_id" bigint,
+    "action" varchar(20),
+    "reason" text,
+    "expires_at" timestamptz,
+    "created_at" timestamptz,
+    PRIMARY KEY ("id"),
+    CONSTRAINT "fk_moderation_actions_user" FOREIGN KEY ("user_id") REFERENCES "users"("id") ON DELETE CASCADE ON UPDATE CASCADE
+);
+
+CREATE INDEX IF NOT EXISTS "idx_moderation_actions_user_id" ON "moderation_actions" ("user_id");
@@ -0,0 +1,20 @@
+CREATE TABLE IF NOT EXISTS "audit_logs" (
+    "id" bigserial,
+    "actor_id" bigint,
+    "action" varchar(50),
+    "target_type" varchar(50),
+    "target_id" bigint,
+    "changes" text,
+    "ip" varchar(45),
+    "request_id" varchar(64),
+    "created_at" timestamptz,
+    PRIMARY KEY ("id")
+);
+
+CREATE INDEX IF NOT EXISTS "idx_audit_logs_created_at" ON "audit_logs" ("created_at");
+
+CREATE INDEX IF NOT EXISTS "idx_audit_logs_target_id" ON "audit_logs" ("target_id");
+
+CREATE INDEX IF NOT EXISTS "idx_audit_logs_action" ON "audit_logs" ("action");
+
+CREATE INDEX IF NOT EXISTS "idx_audit_logs_actor_id" ON "audit_logs" ("actor_id");
@@ -0,0 +1,197 @@
+// Package atomiccache provides the atomic commands missing in the beego cache.Cache,
+// ex: a counter incremented by the replicas sharing the redis.
+package atomiccache
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/gomodule/redigo/redis"
+)
+
+type (
+	// Cache is a cache with atomic commands.
+	Cache interface {
+		// SetNX sets key for ttl when it doesn't exist, it reports whether key was set.
+		SetNX(ctx context.Context, key string, ttl time.Duration) (bool, error)
+		// Incr increments the counter of key and returns its value,
+		// the counter created by the increment expires after ttl.
+		Incr(ctx context.Context, key string, ttl time.Duration) (int, error)
+		// Delete deletes key, a missing key isn't an error.
+		Delete(ctx context.Context, key string) error
+	}
+
+	// MemoryCache keeps the keys in the memory of the process, ex: a single replica or the tests.
+	MemoryCache struct {
+		mu   sync.Mutex
+		keys map[string]*memoryValue
+	}
+
+	memoryValue struct {
+		value    int
+		expireAt time.Time
+	}
+
+	// RedisCache shares the keys between the replicas sharing the redis.
+	RedisCache struct {
+		pool *redis.Pool
+	}
+
+	// redisConfig is the connection config of the beego redis cache,
+	// ex: {"conn":"127.0.0.1:6379","dbNum":"0","password":"secret"}
+	redisConfig struct {
+		Conn     string `json:"conn"`
+		DbNum    string `json:"dbNum"`
+		Password string `json:"password"`
+	}
+)
+
+// incrScript increments the counter and sets its expire when it's created by the increment,
+// both in one atomic command so the counter can't be left without expire.
+var incrScript = redis.NewScript(1, `
+local count = redis.call("INCR", KEYS[1])
+if count == 1 then
+	redis.call("PEXPIRE", KEYS[1], ARGV[1])
+end
+return count
+`)
+
+// NewMemoryCache returns a MemoryCache without keys.
+func NewMemoryCache() *MemoryCache {
+	return &MemoryCache{
+		keys: map[string]*memoryValue{},
+	}
+}
+
+// SetNX implements Cache.
+func (c *MemoryCache) SetNX(ctx context.Context, key string, ttl time.Duration) (bool, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if c.get(key, time.Now()) != nil {
+		return false, nil
+	}
+	c.keys[key] = &memoryValue{value: 1, expireAt: time.Now().Add(ttl)}
+	return true, nil
+}
+
+// Incr implements Cache.
+func (c *MemoryCache) Incr(ctx context.Context, key string, ttl time.Duration) (int, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	value := c.get(key, time.Now())
+	if value == nil {
+		value = &memoryValue{expireAt: time.Now().Add(ttl)}
+		c.keys[key] = value
+	}
+	value.value++
+	return value.value, nil
+}
+
+// Delete implements Cache.
+func (c *MemoryCache) Delete(ctx context.Context, key string) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	delete(c.keys, key)
+	return nil
+}
+
+// get returns the value of key, nil when it doesn't exist or expired.
+func (c *MemoryCache) get(key string, now time.Time) *memoryValue {
+	value, ok := c.keys[key]
+	if !ok {
+		return nil
+	}
+	if !value.expireAt.After(now) {
+		delete(c.keys, key)
+		return nil
+	}
+	return value
+}
+
+// NewRedisPool returns the redis pool of the connection config of the beego redis cache.
+func NewRedisPool(connConfig string) (*redis.Pool, error) {
+	var config redisConfig
+	if err := json.Unmarshal([]byte(connConfig), &config); err != nil {
+		return nil, fmt.Errorf("atomiccache: invalid redis config: %w", err)
+	}
+	dbNum := 0
+	if config.DbNum != "" {
+		n, err := strconv.Atoi(config.DbNum)
+		if err != nil {
+			return nil, fmt.Errorf("atomiccache: invalid redis dbNum %q", config.DbNum)
+		}
+		dbNum = n
+	}
+
+	return &redis.Pool{
+		MaxIdle:     2,
+		IdleTimeout: 3 * time.Minute,
+		Dial: func() (redis.Conn, error) {
+			return redis.Dial("tcp", config.Conn,
+				redis.DialDatabase(dbNum),
+				redis.DialPassword(config.Password))
+		},
+	}, nil
+}
+
+// NewRedisCache returns the RedisCache of the connection config of the beego redis cache.
+func NewRedisCache(connConfig string) (*RedisCache, error) {
+	pool, err := NewRedisPool(connConfig)
+	if err != nil {
+		return nil, err
+	}
+	return &RedisCache{pool: pool}, nil
+}
+
+// SetNX implements Cache with SET NX, the key expires after ttl.
+func (c *RedisCache) SetNX(ctx context.Context, key string, ttl time.Duration) (bool, error) {
+	conn, err := c.pool.GetContext(ctx)
+	if err != nil {
+		return false, err
+	}
+	defer conn.Close()
+
+	_, err = redis.String(conn.Do("SET", key, "1", "NX", "PX", ttl.Milliseconds()))
+	if err == redis.ErrNil {
+		return false, nil
+	}
+	if err != nil {
+		return false, err
+	}
+	return true, nil
+}
+
+// Incr implements Cache with INCR and PEXPIRE in a lua script.
+func (c *RedisCache) Incr(ctx context.Context, key string, ttl time.Duration) (int, error) {
+	conn, err := c.pool.GetContext(ctx)
+	if err != nil {
+		return 0, err
+	}
+	defer conn.Close()
+
+	return redis.Int(incrScript.Do(conn, key, ttl.Milliseconds()))
+}
+
+// Delete implements Cache with DEL.
+func (c *RedisCache) Delete(ctx context.Context, key string) error {
+	conn, err := c.pool.GetContext(ctx)
+	if err != nil {
+		return err
+	}
+	defer conn.Close()
+
+	_, err = conn.Do("DEL", key)
+	return err
+}
+
+// Close closes the connections of the cache.
+func (c *RedisCache) Close() error {
+	return c.pool.Close()
+}
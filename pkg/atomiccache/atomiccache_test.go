@@ -0,0 +1,79 @@
+package atomiccache
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/alicebob/miniredis/v2"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func testCache(t *testing.T, c Cache) {
+	ctx := context.Background()
+
+	ok, err := c.SetNX(ctx, "used", time.Minute)
+	require.NoError(t, err)
+	assert.True(t, ok)
+	ok, err = c.SetNX(ctx, "used", time.Minute)
+	require.NoError(t, err)
+	assert.False(t, ok)
+
+	// a deleted key is set again
+	require.NoError(t, c.Delete(ctx, "deleted"))
+	ok, err = c.SetNX(ctx, "deleted", time.Minute)
+	require.NoError(t, err)
+	assert.True(t, ok)
+	require.NoError(t, c.Delete(ctx, "deleted"))
+	ok, err = c.SetNX(ctx, "deleted", time.Minute)
+	require.NoError(t, err)
+	assert.True(t, ok)
+
+	// the concurrent increments are all counted
+	var wg sync.WaitGroup
+	for i := 0; i < 20; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			_, err := c.Incr(ctx, "counter", time.Minute)
+			assert.NoError(t, err)
+		}()
+	}
+	wg.Wait()
+	count, err := c.Incr(ctx, "counter", time.Minute)
+	require.NoError(t, err)
+	assert.Equal(t, 21, count)
+}
+
+func TestMemoryCache(t *testing.T) {
+	c := NewMemoryCache()
+	testCache(t, c)
+
+	count, err := c.Incr(context.Background(), "expired", time.Millisecond)
+	require.NoError(t, err)
+	assert.Equal(t, 1, count)
+	time.Sleep(5 * time.Millisecond)
+	count, err = c.Incr(context.Background(), "expired", time.Minute)
+	require.NoError(t, err)
+	assert.Equal(t, 1, count)
+}
+
+func TestRedisCache(t *testing.T) {
+	redisServer, err := miniredis.Run()
+	require.NoError(t, err)
+	defer redisServer.Close()
+
+	c, err := NewRedisCache(`{"conn":"` + redisServer.Addr() + `"}`)
+	require.NoError(t, err)
+	defer c.Close()
+	testCache(t, c)
+
+	// the counter expires after the ttl of its first increment
+	assert.Equal(t, time.Minute, redisServer.TTL("counter"))
+	assert.Equal(t, time.Minute, redisServer.TTL("used"))
+
+	_, err = NewRedisCache(`{"conn":"localhost:6379","dbNum":"a"}`)
+	assert.Error(t, err)
+}
@@ -0,0 +1,119 @@
+// Package authuser carries the authenticated user of a request in its context whatever its transport,
+// it's set from the JWT payload by the jwt middleware and the grpc auth interceptor.
+package authuser
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/radyatamaa/dating-apps-api/pkg/jwt"
+)
+
+// ErrUnauthenticated is returned when the context has no authenticated user.
+var ErrUnauthenticated = errors.New("request is not authenticated")
+
+type contextKey struct{}
+
+// AuthUser is the principal of an authenticated request.
+type AuthUser struct {
+	ID        int
+	ProfileID int
+	Email     string
+	Roles     []string
+	// Permissions granted to the roles at login
+	Permissions []string
+}
+
+// HasPermission reports whether the user has the permission.
+func (u AuthUser) HasPermission(permission string) bool {
+	for _, value := range u.Permissions {
+		if value == permission {
+			return true
+		}
+	}
+	return false
+}
+
+// FromPayload returns the AuthUser of the JWT payload generated at login,
+// an error when uid is missing or a claim has an unexpected type.
+func FromPayload(payload jwt.Payload) (AuthUser, error) {
+	var user AuthUser
+	var err error
+	if user.ID, err = intClaim(payload, "uid", true); err != nil {
+		return AuthUser{}, err
+	}
+	if user.ProfileID, err = intClaim(payload, "profile_id", false); err != nil {
+		return AuthUser{}, err
+	}
+	if user.Email, err = stringClaim(payload, "email"); err != nil {
+		return AuthUser{}, err
+	}
+	role, err := stringClaim(payload, "role")
+	if err != nil {
+		return AuthUser{}, err
+	}
+	if role != "" {
+		user.Roles = []string{role}
+	}
+
+	// the payload is decoded from json, permissions is a []interface{}
+	switch permissions := payload["permissions"].(type) {
+	case nil:
+	case []string:
+		user.Permissions = permissions
+	case []interface{}:
+		for _, value := range permissions {
+			permission, ok := value.(string)
+			if !ok {
+				return AuthUser{}, fmt.Errorf("authuser: invalid permission %v", value)
+			}
+			user.Permissions = append(user.Permissions, permission)
+		}
+	default:
+		return AuthUser{}, fmt.Errorf("authuser: invalid permissions %v", permissions)
+	}
+	return user, nil
+}
+
+// NewContext returns a copy of ctx carrying user.
+func NewContext(ctx context.Context, user AuthUser) context.Context {
+	return context.WithValue(ctx, contextKey{}, user)
+}
+
+// FromContext returns the user carried by ctx, ErrUnauthenticated without user.
+func FromContext(ctx context.Context) (AuthUser, error) {
+	user, ok := ctx.Value(contextKey{}).(AuthUser)
+	if !ok {
+		return AuthUser{}, ErrUnauthenticated
+	}
+	return user, nil
+}
+
+// intClaim returns the number claim of the payload, json numbers are decoded as float64.
+func intClaim(payload jwt.Payload, key string, required bool) (int, error) {
+	switch value := payload[key].(type) {
+	case float64:
+		return int(value), nil
+	case int:
+		return value, nil
+	case nil:
+		if required {
+			return 0, fmt.Errorf("authuser: missing %s", key)
+		}
+		return 0, nil
+	default:
+		return 0, fmt.Errorf("authuser: invalid %s %v", key, value)
+	}
+}
+
+func stringClaim(payload jwt.Payload, key string) (string, error) {
+	switch value := payload[key].(type) {
+	case string:
+		return value, nil
+	case nil:
+		return "", nil
+	default:
+		return "", fmt.Errorf("authuser: invalid %s %v", key, value)
+	}
+}
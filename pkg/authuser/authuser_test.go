@@ -0,0 +1,73 @@
+package authuser
+
+import (
+	"context"
+	"testing"
+
+	"github.com/radyatamaa/dating-apps-api/pkg/jwt"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFromPayload(t *testing.T) {
+	tests := []struct {
+		name    string
+		payload jwt.Payload
+		want    AuthUser
+		wantErr bool
+	}{
+		{
+			name: "decoded from json",
+			payload: jwt.Payload{
+				"uid":         float64(1),
+				"profile_id":  float64(2),
+				"email":       "admin@mail.com",
+				"role":        "admin",
+				"permissions": []interface{}{"users:read", "audit:read"},
+			},
+			want: AuthUser{ID: 1, ProfileID: 2, Email: "admin@mail.com", Roles: []string{"admin"}, Permissions: []string{"users:read", "audit:read"}},
+		},
+		{
+			name:    "without optional claims",
+			payload: jwt.Payload{"uid": float64(1)},
+			want:    AuthUser{ID: 1},
+		},
+		{
+			name:    "missing uid",
+			payload: jwt.Payload{"email": "user@mail.com"},
+			wantErr: true,
+		},
+		{
+			name:    "invalid uid",
+			payload: jwt.Payload{"uid": "1"},
+			wantErr: true,
+		},
+		{
+			name:    "invalid permissions",
+			payload: jwt.Payload{"uid": float64(1), "permissions": "users:read"},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := FromPayload(tt.payload)
+			if tt.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestFromContext(t *testing.T) {
+	_, err := FromContext(context.TODO())
+	assert.Equal(t, ErrUnauthenticated, err)
+
+	user, err := FromContext(NewContext(context.TODO(), AuthUser{ID: 1, Permissions: []string{"users:read"}}))
+	require.NoError(t, err)
+	assert.Equal(t, 1, user.ID)
+	assert.True(t, user.HasPermission("users:read"))
+	assert.False(t, user.HasPermission("users:role"))
+}
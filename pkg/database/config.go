@@ -43,6 +43,12 @@ type Config struct {
 	MaxIdleConnection     int
 	MaxLifeTimeConnection int
 	MaxIdleTimeConnection int
+	// Replicas the reads are sent to, as host:port with the credentials of the primary.
+	Replicas []string
+	// ReplicaMaxLag of a replica to serve the reads.
+	ReplicaMaxLag time.Duration
+	// ReplicaCheckInterval between the lag checks of the replicas.
+	ReplicaCheckInterval time.Duration
 }
 
 func defaultDatabaseConfig() Config {
@@ -2,14 +2,14 @@ package database
 
 import (
 	"database/sql"
-	beego "github.com/beego/beego/v2/server/web"
+	"fmt"
 	_ "github.com/newrelic/go-agent/v3/integrations/nrpgx"
 	"gorm.io/driver/mysql"
 	"gorm.io/driver/postgres"
 	"gorm.io/driver/sqlserver"
 	"gorm.io/gorm"
 	"gorm.io/gorm/logger"
-	"strconv"
+	"net"
 	"strings"
 	"sync"
 	"time"
@@ -42,72 +42,131 @@ func Conn() *gorm.DB {
 	return DB()
 }
 
-// DB creates a new instance of gorm.DB if a connection is not established.
-// return singleton instance.
+// DB returns the singleton instance of database connection opened by Open.
 func DB() *gorm.DB {
-	if dbInstance == nil {
-		dbOnce.Do(func() {
-			openDB()
-		})
-	}
 	return dbInstance
 }
 
-// openDB initialize gorm DB.
-func openDB() {
-	dbConfig, err := beego.AppConfig.GetSection("database")
+// Open creates a new instance of gorm.DB with the config if a connection is not established.
+// return singleton instance.
+func Open(config Config) (*gorm.DB, error) {
+	var err error
+	dbOnce.Do(func() {
+		dbInstance, err = openDB(config)
+	})
 	if err != nil {
-		panic(err)
+		return nil, err
 	}
+	return dbInstance, nil
+}
 
-	var dbDebug = true
-	var logLevel = logger.Info
-
-	if debug, err := beego.AppConfig.Bool("database::debug"); err == nil {
-		dbDebug = debug
+// openDB initialize gorm DB.
+func openDB(config Config) (*gorm.DB, error) {
+	dbConfig := map[string]string{
+		"driver":   config.Driver,
+		"host":     config.Host,
+		"port":     config.Port,
+		"name":     config.Name,
+		"username": config.Username,
+		"password": config.Password,
+		"options":  config.Options,
 	}
 
-	if !dbDebug {
+	var logLevel = logger.Info
+	if !config.Debug {
 		logLevel = logger.Silent
 	}
 
 	if _, err := sql.Open("nrpgx", buildDsn(templatePostgres, dbConfig)); err != nil {
-		panic(err)
-	} else {
-		gormDB, err := gorm.Open(
-			getDialect(dbConfig),
-			&gorm.Config{
-				SkipDefaultTransaction: true,
-				PrepareStmt:            true,
-				Logger:                 logger.Default.LogMode(logLevel),
-			},
-		)
+		return nil, err
+	}
+	gormDB, err := gorm.Open(
+		getDialect(dbConfig),
+		&gorm.Config{
+			SkipDefaultTransaction: true,
+			Logger:                 logger.Default.LogMode(logLevel),
+		},
+	)
+	if err != nil {
+		return nil, fmt.Errorf("cannot open database: %w", err)
+	}
+	sqlDb, err := gormDB.DB()
+	if err != nil {
+		return nil, err
+	}
+
+	if config.MaxOpenConnection > 0 {
+		maxOpenConn = config.MaxOpenConnection
+	}
+	if config.MaxIdleConnection > 0 {
+		maxIdleConn = config.MaxIdleConnection
+	}
+	if config.MaxLifeTimeConnection > 0 {
+		maxLifeTimeConn = config.MaxLifeTimeConnection
+	}
+	if config.MaxIdleTimeConnection > 0 {
+		maxIdleTimeConn = config.MaxIdleTimeConnection
+	}
+	sqlDb.SetMaxOpenConns(maxOpenConn)
+	sqlDb.SetMaxIdleConns(maxIdleConn)
+	sqlDb.SetConnMaxLifetime(time.Duration(maxLifeTimeConn) * time.Second)
+	sqlDb.SetConnMaxIdleTime(time.Duration(maxIdleTimeConn) * time.Second)
+
+	if len(config.Replicas) > 0 {
+		replicas, err := openReplicas(config, dbConfig)
 		if err != nil {
-			panic("cannot open database.")
+			return nil, err
 		}
-		dbInstance = gormDB
-		sqlDb, err := dbInstance.DB()
-		if err != nil {
-			panic(err)
+		resolver := NewResolver(replicas...)
+		resolver.MaxLag = config.ReplicaMaxLag
+		resolver.CheckInterval = config.ReplicaCheckInterval
+		if err := gormDB.Use(resolver); err != nil {
+			return nil, err
 		}
+	}
+	return gormDB, nil
+}
 
-		if parse, err := strconv.Atoi(dbConfig["maxopenconn"]); err == nil {
-			maxOpenConn = parse
+// openReplicas opens the replicas with the driver, the credentials and the pool settings of the primary.
+func openReplicas(config Config, dbConfig map[string]string) ([]*Replica, error) {
+	replicas := make([]*Replica, 0, len(config.Replicas))
+	for _, address := range config.Replicas {
+		host, port, err := net.SplitHostPort(strings.TrimSpace(address))
+		if err != nil {
+			return nil, fmt.Errorf("invalid replica %q, expected host:port: %w", address, err)
 		}
-		if parse, err := strconv.Atoi(dbConfig["maxidleconn"]); err == nil {
-			maxIdleConn = parse
+		replicaConfig := make(map[string]string, len(dbConfig))
+		for k, v := range dbConfig {
+			replicaConfig[k] = v
 		}
-		if parse, err := strconv.Atoi(dbConfig["maxlifetimeconn"]); err == nil {
-			maxLifeTimeConn = parse
+		replicaConfig["host"] = host
+		replicaConfig["port"] = port
+
+		gormDB, err := gorm.Open(getDialect(replicaConfig), &gorm.Config{Logger: logger.Default.LogMode(logger.Silent)})
+		if err != nil {
+			return nil, fmt.Errorf("cannot open replica %s: %w", address, err)
 		}
-		if parse, err := strconv.Atoi(dbConfig["maxidletimeconn"]); err == nil {
-			maxIdleTimeConn = parse
+		sqlDb, err := gormDB.DB()
+		if err != nil {
+			return nil, err
 		}
 		sqlDb.SetMaxOpenConns(maxOpenConn)
 		sqlDb.SetMaxIdleConns(maxIdleConn)
 		sqlDb.SetConnMaxLifetime(time.Duration(maxLifeTimeConn) * time.Second)
 		sqlDb.SetConnMaxIdleTime(time.Duration(maxIdleTimeConn) * time.Second)
+		replicas = append(replicas, &Replica{Name: address, DB: sqlDb})
+	}
+	return replicas, nil
+}
+
+// ReplicaResolver returns the Resolver registered on the db, nil without replicas.
+func ReplicaResolver(db *gorm.DB) *Resolver {
+	if plugin, ok := db.Config.Plugins[resolverName]; ok {
+		if resolver, ok := plugin.(*Resolver); ok {
+			return resolver
+		}
 	}
+	return nil
 }
 
 func getDialect(dbConfig map[string]string) gorm.Dialector {
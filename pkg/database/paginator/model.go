@@ -17,6 +17,8 @@ var (
 	DEFAULT_PAGE     = 0
 )
 
+// MetaPaginatorResponse of a page, total_exact is false when total_records is an estimate
+// or the records seen so far, ex: on the large listings.
 type MetaPaginatorResponse struct {
 	CurrentPage     int    `json:"current_page"`
 	PerPage         int    `json:"limit_per_page"`
@@ -24,6 +26,8 @@ type MetaPaginatorResponse struct {
 	NextPage        int    `json:"next_page"`
 	TotalRecords    int    `json:"total_records"`
 	TotalPages      int    `json:"total_pages"`
+	TotalExact      bool   `json:"total_exact"`
+	HasNext         bool   `json:"has_next"`
 	LabelPages      string `json:"label_pages"`
 	PageSizes       []int  `json:"page_sizes"`
 	DefaultPageSize int    `json:"default_page_size"`
@@ -46,7 +50,8 @@ func Pagination(pageRequest, pageSizeRequest int) (limit, page, offset int) {
 	return
 }
 
-func (p MetaPaginatorResponse) MappingPaginator(page, limit, offset, totalAllRecords, countData int) MetaPaginatorResponse {
+func (p MetaPaginatorResponse) MappingPaginator(page, limit, offset int, paging *Paginator, countData int) MetaPaginatorResponse {
+	totalAllRecords := int(paging.Total)
 	totalPage := int(math.Ceil(float64(totalAllRecords) / float64(limit)))
 	prev := page
 	next := page
@@ -55,7 +60,8 @@ func (p MetaPaginatorResponse) MappingPaginator(page, limit, offset, totalAllRec
 		prev = page - 1
 	}
 
-	if page != totalPage {
+	// the record fetched after the page tells the next page even when the total is not exact
+	if paging.HasNext {
 		next = page + 1
 	}
 
@@ -66,6 +72,8 @@ func (p MetaPaginatorResponse) MappingPaginator(page, limit, offset, totalAllRec
 		NextPage:     next,
 		TotalRecords: totalAllRecords,
 		TotalPages:   totalPage,
+		TotalExact:   paging.Exact,
+		HasNext:      paging.HasNext,
 	}
 
 	if totalPage == 0 {
@@ -2,12 +2,32 @@ package paginator
 
 import (
 	"context"
+	"crypto/sha256"
+	"database/sql"
+	"encoding/hex"
+	"fmt"
 	"math"
+	"reflect"
+	"strconv"
 	"strings"
+	"time"
 
+	"github.com/beego/beego/v2/client/cache"
+	"github.com/radyatamaa/dating-apps-api/pkg/database"
 	"gorm.io/gorm"
 )
 
+const countCacheKeyPrefix = "paginator:count:"
+
+var (
+	// ExactCountThreshold is the estimate under which a TotalEstimate query is counted exactly,
+	// the count of a small result being cheap.
+	ExactCountThreshold int64 = 1000
+
+	countCache    cache.Cache
+	countCacheTTL time.Duration
+)
+
 // Paginator structure containing pagination information and result records.
 // Can be sent to the client directly.
 type Paginator struct {
@@ -18,6 +38,17 @@ type Paginator struct {
 	PageSize    int
 	CurrentPage int
 	Records     interface{}
+	// HasNext reports whether a record exists after the page.
+	HasNext bool
+	// Exact is false when Total is an estimate or, when the count is skipped, the records seen so far.
+	Exact bool
+}
+
+// UseCountCache caches the exact totals of the queries with Query.CacheTotal in c for ttl, ex: a short
+// ttl in redis for the listings paged through repeatedly. A zero ttl disables the cache.
+func UseCountCache(c cache.Cache, ttl time.Duration) {
+	countCache = c
+	countCacheTTL = ttl
 }
 
 func paginateScope(ctx context.Context, page, pageSize int) func(db *gorm.DB) *gorm.DB {
@@ -49,10 +80,10 @@ func NewPaginator(db *gorm.DB, page, pageSize int, dest interface{}) *Paginator
 	}
 }
 
-func (p *Paginator) updatePageInfo() error {
+func (p *Paginator) updatePageInfo(ctx context.Context) error {
 	count := int64(0)
 
-	if err := p.db.Model(p.Records).Count(&count).Error; err != nil {
+	if err := database.FromContext(ctx, p.db).Model(p.Records).Count(&count).Error; err != nil {
 		return err
 	}
 
@@ -64,67 +95,168 @@ func (p *Paginator) updatePageInfo() error {
 	return nil
 }
 
-func (p *Paginator) updatePageInfoWithFilter(db *gorm.DB, associate []string) error {
-	//db := p.db.Model(p.Records)
-	count := int64(0)
+// Find requests page information (total records and max page) and
+// executes the transaction. Paginate struct is updated automatically, as
+// well as the destination slice given in NewPaginate().
+func (p *Paginator) Find(ctx context.Context) *gorm.DB {
+	p.updatePageInfo(ctx)
+	return p.db.Scopes(paginateScope(ctx, p.CurrentPage, p.PageSize)).Find(p.Records)
+}
 
-	//if len(associate) > 0 {
-	//	for _, v := range associate {
-	//		db.Joins(v)
-	//	}
-	//}
-	if err := db.Count(&count).Error; err != nil {
+// FindWithQuery executes the query on the page, CurrentPage being the offset of the records,
+// then sets the total as the TotalMode of the query. One more record than the page size is fetched
+// to know whether a next page exists, a last page tells the total without counting.
+// A malformed query returns its error instead of the unfiltered records.
+func (p *Paginator) FindWithQuery(ctx context.Context, query *database.Query, columns database.Columns) error {
+	db, err := query.Apply(database.FromContext(ctx, p.db), columns)
+	if err != nil {
 		return err
 	}
+	if p.PageSize != 0 {
+		db = db.Limit(p.PageSize + 1).Offset(p.CurrentPage)
+	}
 
-	p.Total = count
-	p.MaxPage = int64(math.Ceil(float64(count) / float64(p.PageSize)))
-	if p.MaxPage == 0 {
-		p.MaxPage = 1
+	if err := db.Find(p.Records).Error; err != nil {
+		return err
 	}
-	return nil
+	return p.updatePageInfoWithQuery(ctx, query, columns, p.trimRecords())
 }
 
-// Find requests page information (total records and max page) and
-// executes the transaction. Paginate struct is updated automatically, as
-// well as the destination slice given in NewPaginate().
-func (p *Paginator) Find(ctx context.Context) *gorm.DB {
-	p.updatePageInfo()
-	return p.db.Scopes(paginateScope(ctx, p.CurrentPage, p.PageSize)).Find(p.Records)
+// trimRecords drops the record fetched after the page and returns the number of records of the page,
+// -1 when the records are not a slice.
+func (p *Paginator) trimRecords() int {
+	records := reflect.Indirect(reflect.ValueOf(p.Records))
+	if records.Kind() != reflect.Slice {
+		return -1
+	}
+	if p.PageSize != 0 && records.Len() > p.PageSize {
+		p.HasNext = true
+		records.Set(records.Slice(0, p.PageSize))
+	}
+	return records.Len()
 }
 
-func (p *Paginator) FindWithFilter(ctx context.Context, order string, fields, associate, criteria []string, args ...interface{}) *gorm.DB {
+func (p *Paginator) updatePageInfoWithQuery(ctx context.Context, query *database.Query, columns database.Columns, fetched int) error {
+	// the records up to the end of the page are a lower bound of the total
+	seen := int64(p.CurrentPage)
+	if fetched > 0 {
+		seen += int64(fetched)
+	}
+	if p.HasNext {
+		seen++
+	}
+	if !p.HasNext && (fetched > 0 || (fetched == 0 && p.CurrentPage == 0)) {
+		p.setTotal(seen, true)
+		return nil
+	}
+	if query.TotalMode() == database.TotalNone {
+		p.setTotal(seen, false)
+		return nil
+	}
 
-	db := p.db.WithContext(ctx)
+	// the count only needs the joins and the criteria of the query, not its select and order
+	db, err := query.Filter(database.FromContext(ctx, p.db).Model(p.Records), columns)
+	if err != nil {
+		return err
+	}
 
-	if p.PageSize != 0 {
-		db = db.Limit(p.PageSize).Offset(p.CurrentPage)
+	if query.TotalMode() == database.TotalEstimate {
+		if estimate, ok := p.estimate(ctx, db); ok && estimate >= ExactCountThreshold {
+			if estimate < seen {
+				estimate = seen
+			}
+			p.setTotal(estimate, false)
+			return nil
+		}
 	}
-	if len(fields) > 0 {
-		db = db.Select(strings.Join(fields, ","))
+
+	count, err := p.count(ctx, db, query.CachesTotal())
+	if err != nil {
+		return err
 	}
-	if len(associate) > 0 {
-		for _, v := range associate {
-			db.Joins(v)
-		}
+	p.setTotal(count, true)
+	return nil
+}
+
+func (p *Paginator) setTotal(total int64, exact bool) {
+	p.Total = total
+	p.Exact = exact
+	p.MaxPage = 1
+	if p.PageSize != 0 && total > 0 {
+		p.MaxPage = int64(math.Ceil(float64(total) / float64(p.PageSize)))
 	}
-	if len(criteria) > 0 && len(args) == len(criteria) {
-		for i := range criteria {
-			db = db.Where(criteria[i], args[i])
-		}
+}
+
+// count runs the count of db, cached for the TTL of UseCountCache when cached. The reads on the primary
+// and in a transaction are never cached, they're the reads which must see the last writes.
+func (p *Paginator) count(ctx context.Context, db *gorm.DB, cached bool) (int64, error) {
+	count := int64(0)
+	if !cached || countCache == nil || countCacheTTL <= 0 || database.IsPrimary(ctx) || database.TxFromContext(ctx) != nil {
+		err := db.Count(&count).Error
+		return count, err
 	}
-	if len(criteria) > 0 {
-		for i := range criteria {
-			if strings.Contains(criteria[i],"OR"){
-				db = db.Where(criteria[i])
-			}
 
-		}
+	stmt := db.Session(&gorm.Session{DryRun: true}).Count(&count).Statement
+	hash := sha256.Sum256([]byte(stmt.SQL.String() + fmt.Sprint(stmt.Vars...)))
+	key := countCacheKeyPrefix + hex.EncodeToString(hash[:])
+	if cached, err := countCache.Get(ctx, key); err == nil && cached != nil {
+		return cache.GetInt64(cached), nil
+	}
+
+	if err := db.Count(&count).Error; err != nil {
+		return 0, err
+	}
+	// a cache failure only costs the next count
+	_ = countCache.Put(ctx, key, count, countCacheTTL)
+	return count, nil
+}
+
+// estimate returns the number of rows the mysql optimizer expects the query of db to read,
+// false when the dialect has no estimate or the plan fails.
+func (p *Paginator) estimate(ctx context.Context, db *gorm.DB) (int64, bool) {
+	if db.Dialector.Name() != "mysql" {
+		return 0, false
+	}
+	stmt := db.Session(&gorm.Session{DryRun: true}).Find(p.Records).Statement
 
+	rows, err := database.FromContext(ctx, p.db).Raw("EXPLAIN "+stmt.SQL.String(), stmt.Vars...).Rows()
+	if err != nil {
+		return 0, false
 	}
+	defer rows.Close()
 
-	result := db.Order(order).Find(p.Records)
-	p.updatePageInfoWithFilter(result,associate)
+	names, err := rows.Columns()
+	if err != nil {
+		return 0, false
+	}
+	// the rows read from each table of a join are multiplied by the rows of the previous tables
+	estimate, planned := 1.0, false
+	for rows.Next() {
+		values := make([]sql.NullString, len(names))
+		dest := make([]interface{}, len(names))
+		for i := range values {
+			dest[i] = &values[i]
+		}
+		if err := rows.Scan(dest...); err != nil {
+			return 0, false
+		}
 
-	return result
-}
\ No newline at end of file
+		read, filtered := 0.0, 100.0
+		for i, name := range names {
+			switch strings.ToLower(name) {
+			case "rows":
+				read, _ = strconv.ParseFloat(values[i].String, 64)
+			case "filtered":
+				if values[i].Valid {
+					filtered, _ = strconv.ParseFloat(values[i].String, 64)
+				}
+			}
+		}
+		estimate *= read * filtered / 100
+		planned = true
+	}
+	if rows.Err() != nil || !planned {
+		return 0, false
+	}
+	return int64(math.Round(estimate)), true
+}
@@ -0,0 +1,210 @@
+package paginator
+
+import (
+	"context"
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/beego/beego/v2/client/cache"
+	"github.com/radyatamaa/dating-apps-api/pkg/database"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gorm.io/driver/mysql"
+	"gorm.io/gorm"
+	"gorm.io/gorm/logger"
+)
+
+type pageUser struct {
+	ID    int
+	Email string
+}
+
+func newPageDB(t *testing.T) (*gorm.DB, sqlmock.Sqlmock) {
+	sqlDB, mock, err := sqlmock.New()
+	require.NoError(t, err)
+	t.Cleanup(func() { sqlDB.Close() })
+
+	db, err := gorm.Open(mysql.New(mysql.Config{Conn: sqlDB, SkipInitializeWithVersion: true}), &gorm.Config{
+		Logger: logger.Default.LogMode(logger.Silent),
+	})
+	require.NoError(t, err)
+	return db, mock
+}
+
+func userRows(ids ...int) *sqlmock.Rows {
+	rows := sqlmock.NewRows([]string{"id", "email"})
+	for _, id := range ids {
+		rows.AddRow(id, "user@mail.com")
+	}
+	return rows
+}
+
+func TestPaginator_FindWithQuery(t *testing.T) {
+	columns := database.MustColumnsOf(pageUser{})
+	selectPage := regexp.QuoteMeta("SELECT * FROM `page_users` WHERE `page_users`.`id` > ? LIMIT 3 OFFSET 2")
+	count := regexp.QuoteMeta("SELECT count(*) FROM `page_users` WHERE `page_users`.`id` > ?")
+	explain := regexp.QuoteMeta("EXPLAIN SELECT * FROM `page_users` WHERE `page_users`.`id` > ?")
+
+	tests := []struct {
+		name        string
+		total       database.TotalMode
+		mock        func(mock sqlmock.Sqlmock)
+		wantTotal   int64
+		wantExact   bool
+		wantHasNext bool
+		wantRecords int
+	}{
+		{
+			name:  "last page without count",
+			total: database.TotalExact,
+			mock: func(mock sqlmock.Sqlmock) {
+				mock.ExpectQuery(selectPage).WithArgs(0).WillReturnRows(userRows(3))
+			},
+			wantTotal:   3,
+			wantExact:   true,
+			wantRecords: 1,
+		},
+		{
+			name:  "exact",
+			total: database.TotalExact,
+			mock: func(mock sqlmock.Sqlmock) {
+				mock.ExpectQuery(selectPage).WithArgs(0).WillReturnRows(userRows(3, 4, 5))
+				mock.ExpectQuery(count).WithArgs(0).WillReturnRows(sqlmock.NewRows([]string{"count(*)"}).AddRow(7))
+			},
+			wantTotal:   7,
+			wantExact:   true,
+			wantHasNext: true,
+			wantRecords: 2,
+		},
+		{
+			name:  "none",
+			total: database.TotalNone,
+			mock: func(mock sqlmock.Sqlmock) {
+				mock.ExpectQuery(selectPage).WithArgs(0).WillReturnRows(userRows(3, 4, 5))
+			},
+			wantTotal:   5,
+			wantHasNext: true,
+			wantRecords: 2,
+		},
+		{
+			name:  "estimate",
+			total: database.TotalEstimate,
+			mock: func(mock sqlmock.Sqlmock) {
+				mock.ExpectQuery(selectPage).WithArgs(0).WillReturnRows(userRows(3, 4, 5))
+				mock.ExpectQuery(explain).WithArgs(0).WillReturnRows(
+					sqlmock.NewRows([]string{"id", "table", "rows", "filtered"}).AddRow(1, "page_users", 40000, 50))
+			},
+			wantTotal:   20000,
+			wantHasNext: true,
+			wantRecords: 2,
+		},
+		{
+			name:  "estimate under the threshold counted",
+			total: database.TotalEstimate,
+			mock: func(mock sqlmock.Sqlmock) {
+				mock.ExpectQuery(selectPage).WithArgs(0).WillReturnRows(userRows(3, 4, 5))
+				mock.ExpectQuery(explain).WithArgs(0).WillReturnRows(
+					sqlmock.NewRows([]string{"id", "table", "rows", "filtered"}).AddRow(1, "page_users", 10, 100))
+				mock.ExpectQuery(count).WithArgs(0).WillReturnRows(sqlmock.NewRows([]string{"count(*)"}).AddRow(7))
+			},
+			wantTotal:   7,
+			wantExact:   true,
+			wantHasNext: true,
+			wantRecords: 2,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			db, mock := newPageDB(t)
+			tt.mock(mock)
+
+			var records []pageUser
+			p := NewPaginator(db, 2, 2, &records)
+			err := p.FindWithQuery(context.TODO(), database.NewQuery().Where(database.Gt("id", 0)).Total(tt.total), columns)
+			require.NoError(t, err)
+
+			assert.Equal(t, tt.wantTotal, p.Total)
+			assert.Equal(t, tt.wantExact, p.Exact)
+			assert.Equal(t, tt.wantHasNext, p.HasNext)
+			assert.Len(t, records, tt.wantRecords)
+			assert.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
+
+func TestPaginator_CountCache(t *testing.T) {
+	UseCountCache(cache.NewMemoryCache(), time.Minute)
+	t.Cleanup(func() { UseCountCache(nil, 0) })
+
+	columns := database.MustColumnsOf(pageUser{})
+	selectPage := "SELECT \\* FROM `page_users`"
+	count := "SELECT count\\(\\*\\) FROM `page_users`"
+
+	tests := []struct {
+		name      string
+		ctx       context.Context
+		query     func() *database.Query
+		wantCount int
+	}{
+		{
+			name:      "cached total",
+			ctx:       context.TODO(),
+			query:     func() *database.Query { return database.NewQuery().Where(database.Gt("id", 0)).CacheTotal() },
+			wantCount: 1,
+		},
+		{
+			name:      "total not cached by default",
+			ctx:       context.TODO(),
+			query:     func() *database.Query { return database.NewQuery().Where(database.Gt("id", 1)) },
+			wantCount: 2,
+		},
+		{
+			name:      "primary read not cached",
+			ctx:       database.WithPrimary(context.TODO()),
+			query:     func() *database.Query { return database.NewQuery().Where(database.Gt("id", 2)).CacheTotal() },
+			wantCount: 2,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			db, mock := newPageDB(t)
+			mock.ExpectQuery(selectPage).WillReturnRows(userRows(1, 2))
+			mock.ExpectQuery(count).WillReturnRows(sqlmock.NewRows([]string{"count(*)"}).AddRow(9))
+			mock.ExpectQuery(selectPage).WillReturnRows(userRows(1, 2))
+			if tt.wantCount == 2 {
+				mock.ExpectQuery(count).WillReturnRows(sqlmock.NewRows([]string{"count(*)"}).AddRow(10))
+			}
+
+			totals := make([]int64, 0, 2)
+			for i := 0; i < 2; i++ {
+				var records []pageUser
+				p := NewPaginator(db, 0, 1, &records)
+				require.NoError(t, p.FindWithQuery(tt.ctx, tt.query(), columns))
+				assert.True(t, p.Exact)
+				totals = append(totals, p.Total)
+			}
+			if tt.wantCount == 1 {
+				assert.Equal(t, []int64{9, 9}, totals)
+			} else {
+				assert.Equal(t, []int64{9, 10}, totals)
+			}
+			assert.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
+
+func TestMetaPaginatorResponse_MappingPaginator(t *testing.T) {
+	got := MetaPaginatorResponse{}.MappingPaginator(2, 10, 10, &Paginator{Total: 21, HasNext: true}, 10)
+	assert.Equal(t, 3, got.NextPage)
+	assert.Equal(t, 1, got.PreviousPage)
+	assert.Equal(t, 3, got.TotalPages)
+	assert.False(t, got.TotalExact)
+	assert.True(t, got.HasNext)
+
+	got = MetaPaginatorResponse{}.MappingPaginator(3, 10, 20, &Paginator{Total: 21, Exact: true}, 1)
+	assert.Equal(t, 3, got.NextPage)
+	assert.True(t, got.TotalExact)
+	assert.False(t, got.HasNext)
+}
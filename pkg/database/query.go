@@ -0,0 +1,622 @@
+package database
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"regexp"
+	"sort"
+	"strings"
+	"sync"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+	"gorm.io/gorm/schema"
+)
+
+var (
+	ErrInvalidQuery    = errors.New("invalid query")
+	ErrUnknownColumn   = errors.New("unknown column")
+	ErrAmbiguousColumn = errors.New("ambiguous column")
+
+	identifierPattern = regexp.MustCompile(`^[A-Za-z_][A-Za-z0-9_]*$`)
+	aliasSeparator    = regexp.MustCompile(`(?i)\s+as\s+`)
+)
+
+type (
+	// Columns is the allow-list of the tables and the columns a Query can reference,
+	// the first table is the table of the repository.
+	Columns struct {
+		table  string
+		tables map[string]map[string]struct{}
+	}
+
+	// Criterion is a typed condition of a Query, ex: Eq("id", 1), Or(IsNull("banned_at"), Lt("banned_at", now)).
+	Criterion interface {
+		expression(columns Columns, aliases map[string]struct{}) (clause.Expression, error)
+	}
+
+	// comparison of a column to a value, the criteria are plain values so two queries built
+	// alike are equal, ex: to match the query of a mocked repository.
+	comparison struct {
+		column   string
+		operator string
+		value    interface{}
+	}
+
+	between struct {
+		column   string
+		from, to interface{}
+	}
+
+	in struct {
+		column string
+		values interface{}
+		not    bool
+	}
+
+	columnEq struct {
+		left, right string
+	}
+
+	group struct {
+		or       bool
+		criteria []Criterion
+	}
+
+	// Join of a table allowed by the Columns, ex: InnerJoin("profile", EqColumn("profile.user_id", "users.id")).
+	Join struct {
+		kind  string
+		table string
+		on    []Criterion
+	}
+
+	// Order of a column or a select alias, ex: Desc("id"), Asc("distance"), Random().
+	Order struct {
+		column string
+		desc   bool
+		random bool
+	}
+
+	selectField struct {
+		column string
+		alias  string
+		sql    string
+		args   []interface{}
+	}
+
+	// Query is a typed select, every column is checked against the Columns of the repository
+	// and a malformed query returns an error instead of running without its criteria.
+	//
+	//  query := database.NewQuery().
+	//      Select("users.*", "profile.id AS profile_id").
+	//      Join(database.LeftJoin("profile", database.EqColumn("profile.user_id", "users.id"))).
+	//      Where(database.Like("users.email", "%"+email+"%")).
+	//      OrderBy(database.Asc("users.id"))
+	Query struct {
+		fields   []selectField
+		joins    []Join
+		criteria []Criterion
+		orders   []Order
+		limit    int
+		offset   int
+		total    TotalMode
+		// cacheTotal caches the exact total in the count cache of the paginator
+		cacheTotal bool
+	}
+
+	// TotalMode is how a paginated Query counts the total of its records.
+	TotalMode int
+)
+
+const (
+	// TotalExact counts the records matching the query, the default.
+	TotalExact TotalMode = iota
+	// TotalEstimate reads the estimate of the query plan instead of counting, ex: on the large tables.
+	TotalEstimate
+	// TotalNone skips the count, the page only knows whether a next page exists.
+	TotalNone
+)
+
+// ColumnsOf returns the allow-list of the columns of the models, the first model is the table of the repository.
+func ColumnsOf(models ...interface{}) (Columns, error) {
+	columns := Columns{tables: map[string]map[string]struct{}{}}
+	cache := &sync.Map{}
+	for i, model := range models {
+		s, err := schema.Parse(model, cache, schema.NamingStrategy{})
+		if err != nil {
+			return Columns{}, err
+		}
+		names := make(map[string]struct{}, len(s.DBNames))
+		for _, name := range s.DBNames {
+			names[name] = struct{}{}
+		}
+		columns.tables[s.Table] = names
+		if i == 0 {
+			columns.table = s.Table
+		}
+	}
+	return columns, nil
+}
+
+// MustColumnsOf is ColumnsOf panicking on an error, for the constructors of the repositories.
+func MustColumnsOf(models ...interface{}) Columns {
+	columns, err := ColumnsOf(models...)
+	if err != nil {
+		panic(err)
+	}
+	return columns
+}
+
+// Table returns the table of the repository.
+func (c Columns) Table() string {
+	return c.table
+}
+
+// column resolves "column" or "table.column", an unqualified column is qualified with
+// the table of the repository first then with the only joinable table having it.
+func (c Columns) column(name string) (clause.Column, error) {
+	if table, column, ok := splitColumn(name); ok {
+		if columns, found := c.tables[table]; found {
+			if _, found := columns[column]; found {
+				return clause.Column{Table: table, Name: column}, nil
+			}
+		}
+		return clause.Column{}, fmt.Errorf("%w: %q", ErrUnknownColumn, name)
+	}
+	if !identifierPattern.MatchString(name) {
+		return clause.Column{}, fmt.Errorf("%w: %q", ErrUnknownColumn, name)
+	}
+	if _, found := c.tables[c.table][name]; found {
+		return clause.Column{Table: c.table, Name: name}, nil
+	}
+	var tables []string
+	for table, columns := range c.tables {
+		if _, found := columns[name]; found {
+			tables = append(tables, table)
+		}
+	}
+	switch len(tables) {
+	case 0:
+		return clause.Column{}, fmt.Errorf("%w: %q", ErrUnknownColumn, name)
+	case 1:
+		return clause.Column{Table: tables[0], Name: name}, nil
+	}
+	sort.Strings(tables)
+	return clause.Column{}, fmt.Errorf("%w: %q is a column of %s, use table.column",
+		ErrAmbiguousColumn, name, strings.Join(tables, ", "))
+}
+
+func splitColumn(name string) (string, string, bool) {
+	parts := strings.Split(name, ".")
+	if len(parts) != 2 || !identifierPattern.MatchString(parts[0]) || !identifierPattern.MatchString(parts[1]) {
+		return "", "", false
+	}
+	return parts[0], parts[1], true
+}
+
+func (c comparison) expression(columns Columns, _ map[string]struct{}) (clause.Expression, error) {
+	col, err := columns.column(c.column)
+	if err != nil {
+		return nil, err
+	}
+	// a list would not be expanded, In and NotIn compare to a list
+	if isList(c.value) {
+		return nil, fmt.Errorf("%w: %s compared to a list, use In or NotIn", ErrInvalidQuery, c.column)
+	}
+	switch c.operator {
+	case "=":
+		return clause.Eq{Column: col, Value: c.value}, nil
+	case "<>":
+		return clause.Neq{Column: col, Value: c.value}, nil
+	case ">":
+		return clause.Gt{Column: col, Value: c.value}, nil
+	case ">=":
+		return clause.Gte{Column: col, Value: c.value}, nil
+	case "<":
+		return clause.Lt{Column: col, Value: c.value}, nil
+	case "<=":
+		return clause.Lte{Column: col, Value: c.value}, nil
+	default:
+		return clause.Like{Column: col, Value: c.value}, nil
+	}
+}
+
+func (b between) expression(columns Columns, _ map[string]struct{}) (clause.Expression, error) {
+	col, err := columns.column(b.column)
+	if err != nil {
+		return nil, err
+	}
+	if isList(b.from) || isList(b.to) {
+		return nil, fmt.Errorf("%w: %s between a list", ErrInvalidQuery, b.column)
+	}
+	return clause.Expr{SQL: "? BETWEEN ? AND ?", Vars: []interface{}{col, b.from, b.to}}, nil
+}
+
+func (i in) expression(columns Columns, _ map[string]struct{}) (clause.Expression, error) {
+	col, err := columns.column(i.column)
+	if err != nil {
+		return nil, err
+	}
+	if !isList(i.values) {
+		return nil, fmt.Errorf("%w: %s IN expects a list, got %T", ErrInvalidQuery, i.column, i.values)
+	}
+	value := reflect.ValueOf(i.values)
+	list := make([]interface{}, 0, value.Len())
+	for n := 0; n < value.Len(); n++ {
+		list = append(list, value.Index(n).Interface())
+	}
+	if i.not {
+		return clause.Not(clause.IN{Column: col, Values: list}), nil
+	}
+	return clause.IN{Column: col, Values: list}, nil
+}
+
+func (c columnEq) expression(columns Columns, _ map[string]struct{}) (clause.Expression, error) {
+	left, err := columns.column(c.left)
+	if err != nil {
+		return nil, err
+	}
+	right, err := columns.column(c.right)
+	if err != nil {
+		return nil, err
+	}
+	return clause.Expr{SQL: "? = ?", Vars: []interface{}{left, right}}, nil
+}
+
+func (g group) expression(columns Columns, aliases map[string]struct{}) (clause.Expression, error) {
+	exprs, err := expressions(columns, aliases, g.criteria)
+	if err != nil {
+		return nil, err
+	}
+	if g.or {
+		return clause.Or(exprs...), nil
+	}
+	return clause.And(exprs...), nil
+}
+
+func isList(value interface{}) bool {
+	if value == nil {
+		return false
+	}
+	if _, bytes := value.([]byte); bytes {
+		return false
+	}
+	kind := reflect.TypeOf(value).Kind()
+	return kind == reflect.Slice || kind == reflect.Array
+}
+
+// Eq column = value, IS NULL when value is nil.
+func Eq(column string, value interface{}) Criterion {
+	return comparison{column: column, operator: "=", value: value}
+}
+
+// Ne column <> value, IS NOT NULL when value is nil.
+func Ne(column string, value interface{}) Criterion {
+	return comparison{column: column, operator: "<>", value: value}
+}
+
+// Gt column > value.
+func Gt(column string, value interface{}) Criterion {
+	return comparison{column: column, operator: ">", value: value}
+}
+
+// Gte column >= value.
+func Gte(column string, value interface{}) Criterion {
+	return comparison{column: column, operator: ">=", value: value}
+}
+
+// Lt column < value.
+func Lt(column string, value interface{}) Criterion {
+	return comparison{column: column, operator: "<", value: value}
+}
+
+// Lte column <= value.
+func Lte(column string, value interface{}) Criterion {
+	return comparison{column: column, operator: "<=", value: value}
+}
+
+// Like column LIKE pattern, the pattern is not escaped.
+func Like(column string, pattern string) Criterion {
+	return comparison{column: column, operator: "LIKE", value: pattern}
+}
+
+// IsNull column IS NULL.
+func IsNull(column string) Criterion {
+	return Eq(column, nil)
+}
+
+// IsNotNull column IS NOT NULL.
+func IsNotNull(column string) Criterion {
+	return Ne(column, nil)
+}
+
+// Between column BETWEEN from AND to.
+func Between(column string, from, to interface{}) Criterion {
+	return between{column: column, from: from, to: to}
+}
+
+// In column IN (values), values is a slice, an empty slice matches no record.
+func In(column string, values interface{}) Criterion {
+	return in{column: column, values: values}
+}
+
+// NotIn column NOT IN (values), values is a slice, an empty slice only excludes NULL.
+func NotIn(column string, values interface{}) Criterion {
+	return in{column: column, values: values, not: true}
+}
+
+// EqColumn left = right, ex: the condition of a join.
+func EqColumn(left, right string) Criterion {
+	return columnEq{left: left, right: right}
+}
+
+// And groups the criteria with AND.
+func And(criteria ...Criterion) Criterion {
+	return group{criteria: criteria}
+}
+
+// Or groups the criteria with OR, ex: Or(IsNull("suspended_until"), Lt("suspended_until", now)).
+func Or(criteria ...Criterion) Criterion {
+	return group{or: true, criteria: criteria}
+}
+
+func expressions(columns Columns, aliases map[string]struct{}, criteria []Criterion) ([]clause.Expression, error) {
+	if len(criteria) == 0 {
+		return nil, fmt.Errorf("%w: empty group of criteria", ErrInvalidQuery)
+	}
+	exprs := make([]clause.Expression, 0, len(criteria))
+	for _, criterion := range criteria {
+		if criterion == nil {
+			return nil, fmt.Errorf("%w: nil criterion", ErrInvalidQuery)
+		}
+		expr, err := criterion.expression(columns, aliases)
+		if err != nil {
+			return nil, err
+		}
+		exprs = append(exprs, expr)
+	}
+	return exprs, nil
+}
+
+// InnerJoin joins the table on the criteria.
+func InnerJoin(table string, on ...Criterion) Join {
+	return Join{kind: "INNER JOIN", table: table, on: on}
+}
+
+// LeftJoin left joins the table on the criteria.
+func LeftJoin(table string, on ...Criterion) Join {
+	return Join{kind: "LEFT JOIN", table: table, on: on}
+}
+
+// Asc orders by the column or the select alias ascending.
+func Asc(column string) Order {
+	return Order{column: column}
+}
+
+// Desc orders by the column or the select alias descending.
+func Desc(column string) Order {
+	return Order{column: column, desc: true}
+}
+
+// Random orders randomly with the function of the dialect.
+func Random() Order {
+	return Order{random: true}
+}
+
+// NewQuery returns a Query selecting every column of the table of the repository.
+func NewQuery() *Query {
+	return &Query{}
+}
+
+// Select the fields, ex: "*", "profile.*", "id", "profile.id AS profile_id".
+func (q *Query) Select(fields ...string) *Query {
+	for _, field := range fields {
+		parts := aliasSeparator.Split(strings.TrimSpace(field), -1)
+		f := selectField{column: parts[0]}
+		if len(parts) == 2 {
+			f.alias = parts[1]
+		} else if len(parts) > 2 {
+			f.alias = strings.Join(parts[1:], " as ")
+		}
+		q.fields = append(q.fields, f)
+	}
+	return q
+}
+
+// SelectExpr selects a computed field with its bound args, ex: a distance ordered by with Asc(alias).
+func (q *Query) SelectExpr(alias string, sql string, args ...interface{}) *Query {
+	q.fields = append(q.fields, selectField{alias: alias, sql: sql, args: args})
+	return q
+}
+
+// Join the tables.
+func (q *Query) Join(joins ...Join) *Query {
+	q.joins = append(q.joins, joins...)
+	return q
+}
+
+// Where adds the criteria, combined with AND.
+func (q *Query) Where(criteria ...Criterion) *Query {
+	q.criteria = append(q.criteria, criteria...)
+	return q
+}
+
+// OrderBy adds the orders.
+func (q *Query) OrderBy(orders ...Order) *Query {
+	q.orders = append(q.orders, orders...)
+	return q
+}
+
+// Limit the number of records, 0 is no limit.
+func (q *Query) Limit(limit int) *Query {
+	q.limit = limit
+	return q
+}
+
+// Offset skips the records, 0 is no offset.
+func (q *Query) Offset(offset int) *Query {
+	q.offset = offset
+	return q
+}
+
+// Total sets how the paginator counts the total of the query.
+func (q *Query) Total(mode TotalMode) *Query {
+	q.total = mode
+	return q
+}
+
+// CacheTotal caches the exact total of the paginated query for the TTL of the count cache,
+// ex: a listing paged through repeatedly. The total may then be stale for the TTL, a query
+// deciding on its total (a quota) must not cache it.
+func (q *Query) CacheTotal() *Query {
+	q.cacheTotal = true
+	return q
+}
+
+// CachesTotal reports whether the exact total of the query is cached, false for a nil query.
+func (q *Query) CachesTotal() bool {
+	return q != nil && q.cacheTotal
+}
+
+// TotalMode returns how the paginator counts the total of the query, TotalExact for a nil query.
+func (q *Query) TotalMode() TotalMode {
+	if q == nil {
+		return TotalExact
+	}
+	return q.total
+}
+
+// Filter applies the joins and the criteria only, ex: to count the records of the query.
+func (q *Query) Filter(db *gorm.DB, columns Columns) (*gorm.DB, error) {
+	if q == nil {
+		return db, nil
+	}
+	aliases, err := q.aliases()
+	if err != nil {
+		return nil, err
+	}
+
+	for _, join := range q.joins {
+		if _, found := columns.tables[join.table]; !found || join.table == columns.table {
+			return nil, fmt.Errorf("%w: join of %q", ErrUnknownColumn, join.table)
+		}
+		on, err := expressions(columns, aliases, join.on)
+		if err != nil {
+			return nil, err
+		}
+		db = db.Joins(join.kind+" ? ON ?", clause.Table{Name: join.table}, clause.And(on...))
+	}
+
+	if len(q.criteria) > 0 {
+		exprs, err := expressions(columns, aliases, q.criteria)
+		if err != nil {
+			return nil, err
+		}
+		db = db.Clauses(clause.Where{Exprs: exprs})
+	}
+	return db, nil
+}
+
+// Apply applies the whole query, the fields, the joins, the criteria, the orders, the limit and the offset.
+func (q *Query) Apply(db *gorm.DB, columns Columns) (*gorm.DB, error) {
+	if q == nil {
+		return db, nil
+	}
+	db, err := q.Filter(db, columns)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(q.fields) > 0 {
+		selects := make([]string, 0, len(q.fields))
+		args := make([]interface{}, 0)
+		for _, field := range q.fields {
+			sql, err := q.selectSQL(db, columns, field)
+			if err != nil {
+				return nil, err
+			}
+			selects = append(selects, sql)
+			args = append(args, field.args...)
+		}
+		db = db.Select(strings.Join(selects, ","), args...)
+	}
+
+	aliases, _ := q.aliases()
+	for _, order := range q.orders {
+		if order.random {
+			db = db.Order(randomFunction(db))
+			continue
+		}
+		if _, alias := aliases[order.column]; alias {
+			db = db.Order(clause.OrderByColumn{Column: clause.Column{Name: order.column}, Desc: order.desc})
+			continue
+		}
+		col, err := columns.column(order.column)
+		if err != nil {
+			return nil, err
+		}
+		db = db.Order(clause.OrderByColumn{Column: col, Desc: order.desc})
+	}
+
+	if q.limit > 0 {
+		db = db.Limit(q.limit)
+	}
+	if q.offset > 0 {
+		db = db.Offset(q.offset)
+	}
+	return db, nil
+}
+
+// aliases returns the aliases of the select, they can be ordered by.
+func (q *Query) aliases() (map[string]struct{}, error) {
+	aliases := map[string]struct{}{}
+	for _, field := range q.fields {
+		if field.alias == "" {
+			continue
+		}
+		if !identifierPattern.MatchString(field.alias) {
+			return nil, fmt.Errorf("%w: invalid alias %q", ErrInvalidQuery, field.alias)
+		}
+		aliases[field.alias] = struct{}{}
+	}
+	return aliases, nil
+}
+
+func (q *Query) selectSQL(db *gorm.DB, columns Columns, field selectField) (string, error) {
+	if field.sql != "" {
+		if strings.Count(field.sql, "?") != len(field.args) {
+			return "", fmt.Errorf("%w: select %s has %d placeholders for %d args",
+				ErrInvalidQuery, field.alias, strings.Count(field.sql, "?"), len(field.args))
+		}
+		return "(" + field.sql + ") AS " + db.Statement.Quote(field.alias), nil
+	}
+
+	if field.column == "*" {
+		return "*", nil
+	}
+	var sql string
+	if table := strings.TrimSuffix(field.column, ".*"); table != field.column {
+		if _, found := columns.tables[table]; !found {
+			return "", fmt.Errorf("%w: %q", ErrUnknownColumn, field.column)
+		}
+		sql = db.Statement.Quote(table) + ".*"
+	} else {
+		col, err := columns.column(field.column)
+		if err != nil {
+			return "", err
+		}
+		sql = db.Statement.Quote(col)
+	}
+	if field.alias != "" {
+		sql += " AS " + db.Statement.Quote(field.alias)
+	}
+	return sql, nil
+}
+
+func randomFunction(db *gorm.DB) string {
+	if db.Dialector.Name() == "mysql" {
+		return "RAND()"
+	}
+	return "RANDOM()"
+}
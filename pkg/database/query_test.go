@@ -0,0 +1,175 @@
+package database
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gorm.io/driver/mysql"
+	"gorm.io/gorm"
+	"gorm.io/gorm/logger"
+)
+
+type queryUser struct {
+	ID       int
+	Email    string
+	BannedAt *int
+}
+
+type queryProfile struct {
+	ID     int
+	UserID int
+	Name   string
+}
+
+func (queryProfile) TableName() string {
+	return "profile"
+}
+
+type queryBlock struct {
+	ID            int
+	UserID        int
+	BlockedUserID int
+}
+
+func newQueryDB(t *testing.T) *gorm.DB {
+	sqlDB, _, err := sqlmock.New()
+	require.NoError(t, err)
+	t.Cleanup(func() { sqlDB.Close() })
+
+	db, err := gorm.Open(mysql.New(mysql.Config{Conn: sqlDB, SkipInitializeWithVersion: true}), &gorm.Config{
+		DryRun: true,
+		Logger: logger.Default.LogMode(logger.Silent),
+	})
+	require.NoError(t, err)
+	return db
+}
+
+func TestQuery(t *testing.T) {
+	columns := MustColumnsOf(queryUser{}, queryProfile{}, queryBlock{})
+
+	tests := []struct {
+		name     string
+		query    *Query
+		wantSQL  string
+		wantVars []interface{}
+		wantErr  error
+	}{
+		{
+			name:     "nil query",
+			query:    nil,
+			wantSQL:  "SELECT * FROM `query_users`",
+			wantVars: []interface{}{},
+		},
+		{
+			name: "criteria",
+			query: NewQuery().Where(
+				Eq("id", 1),
+				In("profile.id", []int{2, 3}),
+				NotIn("email", []string{}),
+				Between("id", 1, 10),
+				Or(IsNull("banned_at"), Lt("banned_at", 5)),
+			),
+			wantSQL: "SELECT * FROM `query_users` WHERE `query_users`.`id` = ? AND `profile`.`id` IN (?,?) AND " +
+				"`query_users`.`email` IS NOT NULL AND (`query_users`.`id` BETWEEN ? AND ?) AND " +
+				"(`query_users`.`banned_at` IS NULL OR `query_users`.`banned_at` < ?)",
+			wantVars: []interface{}{1, 2, 3, 1, 10, 5},
+		},
+		{
+			name: "select, join, order and page",
+			query: NewQuery().
+				Select("query_users.*", "profile.name AS name").
+				SelectExpr("score", "id * ?", 2).
+				Join(LeftJoin("profile", EqColumn("profile.user_id", "query_users.id"))).
+				Where(Like("email", "%@mail.com")).
+				OrderBy(Desc("score"), Asc("id")).
+				Limit(10).
+				Offset(20),
+			wantSQL: "SELECT `query_users`.*,`profile`.`name` AS `name`,(id * ?) AS `score` FROM `query_users` " +
+				"LEFT JOIN `profile` ON `profile`.`user_id` = `query_users`.`id` WHERE `query_users`.`email` LIKE ? " +
+				"ORDER BY `score` DESC,`query_users`.`id` LIMIT 10 OFFSET 20",
+			wantVars: []interface{}{2, "%@mail.com"},
+		},
+		{
+			name:     "random order",
+			query:    NewQuery().OrderBy(Random()),
+			wantSQL:  "SELECT * FROM `query_users` ORDER BY RAND()",
+			wantVars: []interface{}{},
+		},
+		{
+			name:     "column of a single joinable table",
+			query:    NewQuery().Where(Eq("name", "a"), Eq("blocked_user_id", 2), Eq("profile.user_id", 3)),
+			wantSQL:  "SELECT * FROM `query_users` WHERE `profile`.`name` = ? AND `query_blocks`.`blocked_user_id` = ? AND `profile`.`user_id` = ?",
+			wantVars: []interface{}{"a", 2, 3},
+		},
+		{
+			name:    "ambiguous column",
+			query:   NewQuery().Where(Eq("user_id", 1)),
+			wantErr: ErrAmbiguousColumn,
+		},
+		{
+			name:    "unknown column",
+			query:   NewQuery().Where(Eq("password", "secret")),
+			wantErr: ErrUnknownColumn,
+		},
+		{
+			name:    "injected column",
+			query:   NewQuery().Where(Eq("id = 1 OR 1", 1)),
+			wantErr: ErrUnknownColumn,
+		},
+		{
+			name:    "unknown join",
+			query:   NewQuery().Join(InnerJoin("swipes", EqColumn("swipes.user_id", "id"))),
+			wantErr: ErrUnknownColumn,
+		},
+		{
+			name:    "unknown order",
+			query:   NewQuery().OrderBy(Asc("id; DROP TABLE query_users")),
+			wantErr: ErrUnknownColumn,
+		},
+		{
+			name:    "list compared with eq",
+			query:   NewQuery().Where(Eq("id", []int{1, 2})),
+			wantErr: ErrInvalidQuery,
+		},
+		{
+			name:    "in without a list",
+			query:   NewQuery().Where(In("id", 1)),
+			wantErr: ErrInvalidQuery,
+		},
+		{
+			name:    "empty or",
+			query:   NewQuery().Where(Or()),
+			wantErr: ErrInvalidQuery,
+		},
+		{
+			name:    "select expr args mismatch",
+			query:   NewQuery().SelectExpr("score", "id * ?"),
+			wantErr: ErrInvalidQuery,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			db, err := tt.query.Apply(newQueryDB(t), columns)
+			if tt.wantErr != nil {
+				assert.True(t, errors.Is(err, tt.wantErr), "got %v", err)
+				return
+			}
+			require.NoError(t, err)
+
+			stmt := db.Find(&[]queryUser{}).Statement
+			assert.Equal(t, tt.wantSQL, stmt.SQL.String())
+			assert.Equal(t, tt.wantVars, stmt.Vars)
+		})
+	}
+}
+
+func TestQueryEqual(t *testing.T) {
+	build := func(id int) *Query {
+		return NewQuery().Select("id").Where(Eq("id", id), Or(IsNull("banned_at"), In("id", []int{id})))
+	}
+	assert.Equal(t, build(1), build(1))
+	assert.NotEqual(t, build(1), build(2))
+}
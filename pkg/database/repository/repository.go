@@ -0,0 +1,149 @@
+// Package repository provides the base repository of a table, embedded by the repositories of the modules.
+package repository
+
+import (
+	"context"
+	"reflect"
+
+	"github.com/radyatamaa/dating-apps-api/pkg/database"
+	"github.com/radyatamaa/dating-apps-api/pkg/database/paginator"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// Repository implements the queries shared by the tables, the repository of a module embeds it
+// and only adds its domain queries and the typed wrappers of its entity.
+//
+//	type mysqlRepository struct {
+//	    baseRepository.Repository
+//	    zapLogger zaplogger.Logger
+//	}
+//
+//	repo := &mysqlRepository{Repository: baseRepository.New(db, domain.Profile{}, domain.User{})}
+type Repository struct {
+	db      *gorm.DB
+	model   reflect.Type
+	columns database.Columns
+}
+
+// New returns the Repository of the table of the model, the joinable models are the tables
+// the queries of the repository can join.
+func New(db *gorm.DB, model interface{}, joinable ...interface{}) Repository {
+	modelType := reflect.TypeOf(model)
+	for modelType.Kind() == reflect.Ptr {
+		modelType = modelType.Elem()
+	}
+	return Repository{
+		db:      db,
+		model:   modelType,
+		columns: database.MustColumnsOf(append([]interface{}{model}, joinable...)...),
+	}
+}
+
+func (r Repository) DB() *gorm.DB {
+	return r.db
+}
+
+// Table returns the name of the table of the repository.
+func (r Repository) Table() string {
+	return r.columns.Table()
+}
+
+// Columns returns the allow-list of the queries of the repository.
+func (r Repository) Columns() database.Columns {
+	return r.columns
+}
+
+func (r Repository) FetchWithFilterAndPagination(ctx context.Context, limit int, offset int, query *database.Query, model interface{}) (*paginator.Paginator, error) {
+	p := paginator.NewPaginator(r.db, offset, limit, model)
+	if err := p.FindWithQuery(ctx, query, r.columns); err != nil {
+		return p, err
+	}
+	return p, nil
+}
+
+func (r Repository) FetchWithFilter(ctx context.Context, query *database.Query, model interface{}) (interface{}, error) {
+	db, err := query.Apply(database.FromContext(ctx, r.db), r.columns)
+	if err != nil {
+		return nil, err
+	}
+	if err := db.Find(model).Error; err != nil {
+		return nil, err
+	}
+	return model, nil
+}
+
+// Count counts the rows matching the joins and the criteria of the query, never cached,
+// ex: a quota which must see the last writes.
+func (r Repository) Count(ctx context.Context, query *database.Query) (int64, error) {
+	db, err := query.Filter(database.FromContext(ctx, r.db).Model(reflect.New(r.model).Interface()), r.columns)
+	if err != nil {
+		return 0, err
+	}
+	count := int64(0)
+	if err := db.Count(&count).Error; err != nil {
+		return 0, err
+	}
+	return count, nil
+}
+
+func (r Repository) SingleWithFilter(ctx context.Context, query *database.Query, model interface{}) error {
+	db, err := query.Apply(database.FromContext(ctx, r.db), r.columns)
+	if err != nil {
+		return err
+	}
+	if err := db.First(model).Error; err != nil {
+		return err
+	}
+	return nil
+}
+
+// Create inserts the entity, value is a pointer to the entity or to a slice of entities.
+func (r Repository) Create(ctx context.Context, value interface{}) error {
+	return database.FromContext(ctx, r.db).Create(value).Error
+}
+
+// Updates updates the non zero fields of the entity by its primary key.
+func (r Repository) Updates(ctx context.Context, value interface{}) error {
+	return database.FromContext(ctx, r.db).Updates(value).Error
+}
+
+// Upsert inserts the entities, the updateFields are updated when the onConflictFields already exist.
+func (r Repository) Upsert(ctx context.Context, onConflictFields, updateFields []string, value interface{}) error {
+	columns := make([]clause.Column, 0, len(onConflictFields))
+	for i := range onConflictFields {
+		columns = append(columns, clause.Column{
+			Name: onConflictFields[i],
+		})
+	}
+
+	return database.FromContext(ctx, r.db).Clauses(clause.OnConflict{
+		Columns:   columns,
+		DoUpdates: clause.AssignmentColumns(updateFields),
+	}).Create(value).Error
+}
+
+func (r Repository) UpdateSelectedField(ctx context.Context, field []string, values map[string]interface{}, id int) error {
+
+	return database.FromContext(ctx, r.db).Table(r.Table()).Select(field).Where("id =?", id).Updates(values).Error
+}
+
+// Delete removes the row, even when the entity is soft deleted.
+func (r Repository) Delete(ctx context.Context, id int) (int, error) {
+
+	err := database.FromContext(ctx, r.db).Exec("delete from "+r.Table()+" where id =?", id).Error
+	if err != nil {
+		return id, err
+	}
+	return id, nil
+}
+
+// SoftDelete sets deleted_at of an entity with a gorm.DeletedAt field, else removes the row.
+func (r Repository) SoftDelete(ctx context.Context, id int) (int, error) {
+
+	err := database.FromContext(ctx, r.db).Where("id = ?", id).Delete(reflect.New(r.model).Interface()).Error
+	if err != nil {
+		return id, err
+	}
+	return id, nil
+}
@@ -0,0 +1,199 @@
+// Package repositorytest provides the contract test suite of the repositories built on the base repository.
+package repositorytest
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"regexp"
+	"sync"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/radyatamaa/dating-apps-api/pkg/database"
+	"github.com/radyatamaa/dating-apps-api/pkg/database/paginator"
+	"github.com/stretchr/testify/suite"
+	"gorm.io/driver/mysql"
+	"gorm.io/gorm"
+	"gorm.io/gorm/logger"
+	"gorm.io/gorm/schema"
+)
+
+// Repository is the contract shared by the repositories embedding the base repository.
+type Repository interface {
+	FetchWithFilterAndPagination(ctx context.Context, limit int, offset int, query *database.Query, model interface{}) (*paginator.Paginator, error)
+	FetchWithFilter(ctx context.Context, query *database.Query, model interface{}) (interface{}, error)
+	SingleWithFilter(ctx context.Context, query *database.Query, model interface{}) error
+	UpdateSelectedField(ctx context.Context, field []string, values map[string]interface{}, id int) error
+	Delete(ctx context.Context, id int) (int, error)
+	SoftDelete(ctx context.Context, id int) (int, error)
+}
+
+// Suite runs the contract of Repository against the repository returned by New, the table and
+// whether the rows are soft deleted are derived from Model.
+//
+//	suite.Run(t, &repositorytest.Suite{
+//	    New:   func(db *gorm.DB) repositorytest.Repository { return NewMysqlRepository(db, nil) },
+//	    Model: domain.Profile{},
+//	})
+type Suite struct {
+	suite.Suite
+	New   func(db *gorm.DB) Repository
+	Model interface{}
+
+	repository  Repository
+	mock        sqlmock.Sqlmock
+	table       string
+	softDeleted bool
+}
+
+func (s *Suite) SetupTest() {
+	sqlDB, mock, err := sqlmock.New()
+	s.Require().NoError(err)
+	s.T().Cleanup(func() { sqlDB.Close() })
+
+	db, err := gorm.Open(mysql.New(mysql.Config{Conn: sqlDB, SkipInitializeWithVersion: true}), &gorm.Config{
+		SkipDefaultTransaction: true,
+		Logger:                 logger.Default.LogMode(logger.Silent),
+	})
+	s.Require().NoError(err)
+
+	modelSchema, err := schema.Parse(s.Model, &sync.Map{}, db.NamingStrategy)
+	s.Require().NoError(err)
+
+	s.repository = s.New(db)
+	s.mock = mock
+	s.table = modelSchema.Table
+	s.softDeleted = modelSchema.LookUpField("deleted_at") != nil
+}
+
+func (s *Suite) TearDownTest() {
+	s.NoError(s.mock.ExpectationsWereMet())
+}
+
+// newRecords returns a pointer to a slice of Model.
+func (s *Suite) newRecords() interface{} {
+	return reflect.New(reflect.SliceOf(reflect.TypeOf(s.Model))).Interface()
+}
+
+// where returns the where clause of a query on the id, scoped to the rows not soft deleted.
+func (s *Suite) where() string {
+	where := " WHERE `" + s.table + "`.`id` = ?"
+	if s.softDeleted {
+		where += " AND `" + s.table + "`.`deleted_at` IS NULL"
+	}
+	return where
+}
+
+func (s *Suite) TestSingleWithFilter() {
+	s.mock.ExpectQuery(regexp.QuoteMeta("SELECT * FROM `" + s.table + "`" + s.where() + " ORDER BY `" + s.table + "`.`id` LIMIT 1")).
+		WithArgs(1).
+		WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(1))
+
+	model := reflect.New(reflect.TypeOf(s.Model)).Interface()
+	s.NoError(s.repository.SingleWithFilter(context.TODO(), database.NewQuery().Where(database.Eq("id", 1)), model))
+	s.Equal(1, reflect.ValueOf(model).Elem().FieldByName("ID").Interface())
+}
+
+func (s *Suite) TestSingleWithFilterNotFound() {
+	s.mock.ExpectQuery(regexp.QuoteMeta("SELECT * FROM `" + s.table + "`")).
+		WillReturnRows(sqlmock.NewRows([]string{"id"}))
+
+	model := reflect.New(reflect.TypeOf(s.Model)).Interface()
+	err := s.repository.SingleWithFilter(context.TODO(), database.NewQuery().Where(database.Eq("id", 1)), model)
+	s.True(errors.Is(err, gorm.ErrRecordNotFound), "got %v", err)
+}
+
+func (s *Suite) TestFetchWithFilter() {
+	s.mock.ExpectQuery(regexp.QuoteMeta("SELECT * FROM `" + s.table + "`" + s.where())).
+		WithArgs(1).
+		WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(1))
+
+	records := s.newRecords()
+	result, err := s.repository.FetchWithFilter(context.TODO(), database.NewQuery().Where(database.Eq("id", 1)), records)
+	s.NoError(err)
+	s.Equal(records, result)
+	s.Equal(1, reflect.ValueOf(records).Elem().Len())
+}
+
+func (s *Suite) TestFetchWithFilterMalformed() {
+	_, err := s.repository.FetchWithFilter(context.TODO(), database.NewQuery().Where(database.Eq("id = 1 OR 1", 1)), s.newRecords())
+	s.True(errors.Is(err, database.ErrUnknownColumn), "got %v", err)
+}
+
+func (s *Suite) TestFetchWithFilterAndPagination() {
+	// the last page tells the total without counting
+	s.mock.ExpectQuery(regexp.QuoteMeta("SELECT * FROM `" + s.table + "`" + s.where() + " LIMIT 11")).
+		WithArgs(1).
+		WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(1))
+
+	records := s.newRecords()
+	p, err := s.repository.FetchWithFilterAndPagination(context.TODO(), 10, 0, database.NewQuery().Where(database.Eq("id", 1)), records)
+	s.NoError(err)
+	s.Equal(int64(1), p.Total)
+	s.True(p.Exact)
+	s.False(p.HasNext)
+	s.Equal(1, reflect.ValueOf(records).Elem().Len())
+}
+
+func (s *Suite) TestFetchWithFilterAndPaginationHasNext() {
+	s.mock.ExpectQuery(regexp.QuoteMeta("SELECT * FROM `" + s.table + "`" + s.where() + " LIMIT 2 OFFSET 1")).
+		WithArgs(1).
+		WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(2).AddRow(3))
+	s.mock.ExpectQuery(regexp.QuoteMeta("SELECT count(*) FROM `" + s.table + "`" + s.where())).
+		WithArgs(1).
+		WillReturnRows(sqlmock.NewRows([]string{"count(*)"}).AddRow(5))
+
+	records := s.newRecords()
+	p, err := s.repository.FetchWithFilterAndPagination(context.TODO(), 1, 1, database.NewQuery().Where(database.Eq("id", 1)), records)
+	s.NoError(err)
+	s.Equal(int64(5), p.Total)
+	s.True(p.Exact)
+	s.True(p.HasNext)
+	s.Equal(1, reflect.ValueOf(records).Elem().Len())
+}
+
+func (s *Suite) TestFetchWithFilterAndPaginationCountError() {
+	s.mock.ExpectQuery(regexp.QuoteMeta("SELECT * FROM `" + s.table + "`")).
+		WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(1).AddRow(2))
+	s.mock.ExpectQuery(regexp.QuoteMeta("SELECT count(*) FROM `" + s.table + "`")).
+		WillReturnError(errors.New("count failed"))
+
+	_, err := s.repository.FetchWithFilterAndPagination(context.TODO(), 1, 0, database.NewQuery().Where(database.Eq("id", 1)), s.newRecords())
+	s.EqualError(err, "count failed")
+}
+
+func (s *Suite) TestUpdateSelectedField() {
+	s.mock.ExpectExec(regexp.QuoteMeta("UPDATE `"+s.table+"` SET `updated_at`=? WHERE id =?")).
+		WithArgs(sqlmock.AnyArg(), 1).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+
+	s.NoError(s.repository.UpdateSelectedField(context.TODO(), []string{"updated_at"}, map[string]interface{}{
+		"updated_at": "2024-01-01 00:00:00",
+	}, 1))
+}
+
+func (s *Suite) TestDelete() {
+	s.mock.ExpectExec(regexp.QuoteMeta("delete from " + s.table + " where id =?")).
+		WithArgs(1).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+
+	id, err := s.repository.Delete(context.TODO(), 1)
+	s.NoError(err)
+	s.Equal(1, id)
+}
+
+func (s *Suite) TestSoftDelete() {
+	if s.softDeleted {
+		s.mock.ExpectExec(regexp.QuoteMeta("UPDATE `"+s.table+"` SET `deleted_at`=? WHERE id = ? AND `"+s.table+"`.`deleted_at` IS NULL")).
+			WithArgs(sqlmock.AnyArg(), 1).
+			WillReturnResult(sqlmock.NewResult(0, 1))
+	} else {
+		s.mock.ExpectExec(regexp.QuoteMeta("DELETE FROM `" + s.table + "` WHERE id = ?")).
+			WithArgs(1).
+			WillReturnResult(sqlmock.NewResult(0, 1))
+	}
+
+	id, err := s.repository.SoftDelete(context.TODO(), 1)
+	s.NoError(err)
+	s.Equal(1, id)
+}
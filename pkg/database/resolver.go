@@ -0,0 +1,307 @@
+package database
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"math/rand"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+const (
+	DefaultReplicaMaxLag        = 5 * time.Second
+	DefaultReplicaCheckInterval = 5 * time.Second
+
+	resolverName = "database:resolver"
+)
+
+var (
+	ErrReplicationStopped = errors.New("replication is not running")
+
+	// mysql 8.0.22 renamed the columns of SHOW SLAVE STATUS, both are supported.
+	mysqlLagColumns = []string{"Seconds_Behind_Source", "Seconds_Behind_Master"}
+)
+
+type (
+	// LagFunc returns the replication lag of the replica.
+	LagFunc func(ctx context.Context, db *sql.DB) (time.Duration, error)
+
+	// Replica is a read only connection of the Resolver.
+	Replica struct {
+		Name string
+		DB   *sql.DB
+
+		mu      sync.RWMutex
+		healthy bool
+		lag     time.Duration
+		err     error
+	}
+
+	// ReplicaStatus is the last checked state of a replica.
+	ReplicaStatus struct {
+		Name    string
+		Healthy bool
+		Lag     time.Duration
+		Error   error
+	}
+
+	// Resolver is a gorm plugin sending the reads to the replicas whose lag is under MaxLag,
+	// the writes, the transactions, the locking reads and the reads of a context marked with
+	// WithPrimary stay on the primary, the reads fall back to the primary when no replica is healthy.
+	Resolver struct {
+		// MaxLag of a replica to serve the reads.
+		// Optional. Default value DefaultReplicaMaxLag.
+		MaxLag time.Duration
+
+		// CheckInterval between the lag checks of the replicas.
+		// Optional. Default value DefaultReplicaCheckInterval.
+		CheckInterval time.Duration
+
+		// Lag defines a function to measure the lag of a replica.
+		// Optional. Default value from the driver, MysqlLag or PostgresLag.
+		Lag LagFunc
+
+		replicas []*Replica
+		stop     chan struct{}
+		stopOnce sync.Once
+	}
+
+	primaryKey struct{}
+)
+
+// NewResolver returns a Resolver of the replicas.
+func NewResolver(replicas ...*Replica) *Resolver {
+	return &Resolver{
+		replicas: replicas,
+		stop:     make(chan struct{}),
+	}
+}
+
+// WithPrimary marks the context to read from the primary, ex: a read right after a write
+// which could be missing on a lagging replica.
+func WithPrimary(ctx context.Context) context.Context {
+	return context.WithValue(ctx, primaryKey{}, true)
+}
+
+// IsPrimary reports whether the context is marked to read from the primary.
+func IsPrimary(ctx context.Context) bool {
+	if ctx == nil {
+		return false
+	}
+	primary, _ := ctx.Value(primaryKey{}).(bool)
+	return primary
+}
+
+// Name gorm plugin name
+func (r *Resolver) Name() string {
+	return resolverName
+}
+
+// Initialize checks the replicas once then in background, and registers the callbacks switching the reads.
+func (r *Resolver) Initialize(db *gorm.DB) error {
+	if r.MaxLag <= 0 {
+		r.MaxLag = DefaultReplicaMaxLag
+	}
+	if r.CheckInterval <= 0 {
+		r.CheckInterval = DefaultReplicaCheckInterval
+	}
+	if r.Lag == nil {
+		switch db.Dialector.Name() {
+		case "postgres":
+			r.Lag = PostgresLag
+		case "mysql":
+			r.Lag = MysqlLag
+		default:
+			r.Lag = pingLag
+		}
+	}
+
+	if err := db.Callback().Query().Before("*").Register(resolverName, r.switchReplica); err != nil {
+		return err
+	}
+	if err := db.Callback().Row().Before("*").Register(resolverName, r.switchReplica); err != nil {
+		return err
+	}
+
+	r.Check(context.Background())
+	if len(r.replicas) > 0 {
+		go r.monitor()
+	}
+	return nil
+}
+
+// Check measures the lag of every replica.
+func (r *Resolver) Check(ctx context.Context) {
+	for _, replica := range r.replicas {
+		checkCtx, cancel := context.WithTimeout(ctx, r.CheckInterval)
+		lag, err := r.Lag(checkCtx, replica.DB)
+		cancel()
+
+		replica.mu.Lock()
+		replica.lag, replica.err = lag, err
+		replica.healthy = err == nil && lag <= r.MaxLag
+		replica.mu.Unlock()
+	}
+}
+
+// Statuses returns the last checked state of the replicas.
+func (r *Resolver) Statuses() []ReplicaStatus {
+	statuses := make([]ReplicaStatus, 0, len(r.replicas))
+	for _, replica := range r.replicas {
+		replica.mu.RLock()
+		statuses = append(statuses, ReplicaStatus{
+			Name:    replica.Name,
+			Healthy: replica.healthy,
+			Lag:     replica.lag,
+			Error:   replica.err,
+		})
+		replica.mu.RUnlock()
+	}
+	return statuses
+}
+
+// Close stops the lag checks and closes the connections of the replicas.
+func (r *Resolver) Close() error {
+	var err error
+	r.stopOnce.Do(func() {
+		close(r.stop)
+		for _, replica := range r.replicas {
+			if closeErr := replica.DB.Close(); closeErr != nil && err == nil {
+				err = closeErr
+			}
+		}
+	})
+	return err
+}
+
+func (r *Resolver) monitor() {
+	ticker := time.NewTicker(r.CheckInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-r.stop:
+			return
+		case <-ticker.C:
+			r.Check(context.Background())
+		}
+	}
+}
+
+// replica returns a random healthy replica, nil when none is healthy.
+func (r *Resolver) replica() *Replica {
+	healthy := make([]*Replica, 0, len(r.replicas))
+	for _, replica := range r.replicas {
+		replica.mu.RLock()
+		if replica.healthy {
+			healthy = append(healthy, replica)
+		}
+		replica.mu.RUnlock()
+	}
+	if len(healthy) == 0 {
+		return nil
+	}
+	return healthy[rand.Intn(len(healthy))]
+}
+
+func (r *Resolver) switchReplica(db *gorm.DB) {
+	if !r.readable(db.Statement) {
+		return
+	}
+	if replica := r.replica(); replica != nil {
+		db.Statement.ConnPool = replica.DB
+	}
+}
+
+// readable reports whether the statement can be served by a replica.
+func (r *Resolver) readable(stmt *gorm.Statement) bool {
+	if _, ok := stmt.ConnPool.(gorm.TxCommitter); ok {
+		return false
+	}
+	if IsPrimary(stmt.Context) {
+		return false
+	}
+	if _, locking := stmt.Clauses["FOR"]; locking {
+		return false
+	}
+	// a raw statement is only read from a replica when it is a plain select
+	if rawSQL := strings.TrimSpace(stmt.SQL.String()); rawSQL != "" {
+		lower := strings.ToLower(rawSQL)
+		return strings.HasPrefix(lower, "select") && !strings.HasSuffix(lower, "for update") && !strings.HasSuffix(lower, "for share")
+	}
+	return true
+}
+
+// MysqlLag returns Seconds_Behind_Source of SHOW SLAVE STATUS, the user needs the REPLICATION CLIENT privilege.
+func MysqlLag(ctx context.Context, db *sql.DB) (time.Duration, error) {
+	rows, err := db.QueryContext(ctx, "SHOW SLAVE STATUS")
+	if err != nil {
+		return 0, err
+	}
+	defer rows.Close()
+
+	columns, err := rows.Columns()
+	if err != nil {
+		return 0, err
+	}
+	if !rows.Next() {
+		if err := rows.Err(); err != nil {
+			return 0, err
+		}
+		return 0, ErrReplicationStopped
+	}
+	values := make([]sql.NullString, len(columns))
+	dest := make([]interface{}, len(columns))
+	for i := range values {
+		dest[i] = &values[i]
+	}
+	if err := rows.Scan(dest...); err != nil {
+		return 0, err
+	}
+
+	for _, lagColumn := range mysqlLagColumns {
+		for i, column := range columns {
+			if column != lagColumn {
+				continue
+			}
+			// null when the replication threads are stopped
+			if !values[i].Valid {
+				return 0, ErrReplicationStopped
+			}
+			seconds, err := strconv.ParseInt(values[i].String, 10, 64)
+			if err != nil {
+				return 0, err
+			}
+			return time.Duration(seconds) * time.Second, nil
+		}
+	}
+	return 0, ErrReplicationStopped
+}
+
+// PostgresLag returns the time since the last replayed transaction of a standby, postgres 10 or later.
+func PostgresLag(ctx context.Context, db *sql.DB) (time.Duration, error) {
+	var seconds sql.NullFloat64
+	// a caught up standby has no lag even when the primary has no recent transaction
+	if err := db.QueryRowContext(ctx, "SELECT CASE WHEN pg_last_wal_receive_lsn() = pg_last_wal_replay_lsn() THEN 0 "+
+		"ELSE EXTRACT(EPOCH FROM now() - pg_last_xact_replay_timestamp()) END WHERE pg_is_in_recovery()").
+		Scan(&seconds); err != nil {
+		if err == sql.ErrNoRows {
+			return 0, ErrReplicationStopped
+		}
+		return 0, err
+	}
+	// null until the first transaction is replayed
+	if !seconds.Valid {
+		return 0, nil
+	}
+	return time.Duration(seconds.Float64 * float64(time.Second)), nil
+}
+
+// pingLag only checks the replica is reachable for the drivers without a lag query.
+func pingLag(ctx context.Context, db *sql.DB) (time.Duration, error) {
+	return 0, db.PingContext(ctx)
+}
@@ -0,0 +1,169 @@
+package database
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gorm.io/driver/mysql"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+	"gorm.io/gorm/logger"
+)
+
+type resolverUser struct {
+	ID    int
+	Email string
+}
+
+func newResolverDB(t *testing.T, lag LagFunc) (*gorm.DB, sqlmock.Sqlmock, sqlmock.Sqlmock, *Resolver) {
+	primaryDB, primary, err := sqlmock.New()
+	require.NoError(t, err)
+	t.Cleanup(func() { primaryDB.Close() })
+	replicaDB, replica, err := sqlmock.New()
+	require.NoError(t, err)
+
+	db, err := gorm.Open(mysql.New(mysql.Config{Conn: primaryDB, SkipInitializeWithVersion: true}), &gorm.Config{
+		SkipDefaultTransaction: true,
+		Logger:                 logger.Default.LogMode(logger.Silent),
+	})
+	require.NoError(t, err)
+
+	resolver := NewResolver(&Replica{Name: "replica1:3306", DB: replicaDB})
+	resolver.CheckInterval = time.Hour
+	resolver.Lag = lag
+	require.NoError(t, db.Use(resolver))
+	t.Cleanup(func() { resolver.Close() })
+	return db, primary, replica, resolver
+}
+
+func lagOf(lag time.Duration, err error) LagFunc {
+	return func(ctx context.Context, db *sql.DB) (time.Duration, error) {
+		return lag, err
+	}
+}
+
+func TestResolver(t *testing.T) {
+	rows := func() *sqlmock.Rows {
+		return sqlmock.NewRows([]string{"id", "email"}).AddRow(1, "user@mail.com")
+	}
+
+	t.Run("read from replica", func(t *testing.T) {
+		db, primary, replica, _ := newResolverDB(t, lagOf(time.Second, nil))
+		replica.ExpectQuery("SELECT \\* FROM `resolver_users`").WillReturnRows(rows())
+		replica.ExpectQuery("SELECT count\\(\\*\\) FROM `resolver_users`").WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(1))
+		replica.ExpectQuery("SELECT id FROM resolver_users").WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(1))
+
+		var users []resolverUser
+		assert.NoError(t, db.Find(&users).Error)
+		var count int64
+		assert.NoError(t, db.Model(&resolverUser{}).Count(&count).Error)
+		var ids []int
+		assert.NoError(t, db.Raw("SELECT id FROM resolver_users").Scan(&ids).Error)
+		assert.NoError(t, primary.ExpectationsWereMet())
+		assert.NoError(t, replica.ExpectationsWereMet())
+	})
+
+	t.Run("write, transaction, locking read and primary context on primary", func(t *testing.T) {
+		db, primary, replica, _ := newResolverDB(t, lagOf(time.Second, nil))
+		primary.ExpectExec("INSERT INTO `resolver_users`").WillReturnResult(sqlmock.NewResult(1, 1))
+		primary.ExpectBegin()
+		primary.ExpectQuery("SELECT \\* FROM `resolver_users`").WillReturnRows(rows())
+		primary.ExpectCommit()
+		primary.ExpectQuery("SELECT \\* FROM `resolver_users` FOR UPDATE").WillReturnRows(rows())
+		primary.ExpectQuery("SELECT \\* FROM `resolver_users`").WillReturnRows(rows())
+		primary.ExpectExec("UPDATE resolver_users").WillReturnResult(sqlmock.NewResult(0, 1))
+
+		assert.NoError(t, db.Create(&resolverUser{Email: "user@mail.com"}).Error)
+		assert.NoError(t, db.Transaction(func(tx *gorm.DB) error {
+			var users []resolverUser
+			return tx.Find(&users).Error
+		}))
+		var locked []resolverUser
+		assert.NoError(t, db.Clauses(clause.Locking{Strength: "UPDATE"}).Find(&locked).Error)
+		var users []resolverUser
+		assert.NoError(t, db.WithContext(WithPrimary(context.TODO())).Find(&users).Error)
+		assert.NoError(t, db.Exec("UPDATE resolver_users SET email = ?", "new@mail.com").Error)
+		assert.NoError(t, primary.ExpectationsWereMet())
+		assert.NoError(t, replica.ExpectationsWereMet())
+	})
+
+	t.Run("fallback to primary when the replica lags", func(t *testing.T) {
+		db, primary, replica, resolver := newResolverDB(t, lagOf(time.Minute, nil))
+		primary.ExpectQuery("SELECT \\* FROM `resolver_users`").WillReturnRows(rows())
+
+		var users []resolverUser
+		assert.NoError(t, db.Find(&users).Error)
+		assert.Equal(t, []ReplicaStatus{{Name: "replica1:3306", Healthy: false, Lag: time.Minute}}, resolver.Statuses())
+		assert.NoError(t, primary.ExpectationsWereMet())
+		assert.NoError(t, replica.ExpectationsWereMet())
+	})
+
+	t.Run("fallback to primary when the replica is down then back to the replica", func(t *testing.T) {
+		lagErr := errors.New("connection refused")
+		lag := func(ctx context.Context, db *sql.DB) (time.Duration, error) {
+			return 0, lagErr
+		}
+		db, primary, replica, resolver := newResolverDB(t, lag)
+		primary.ExpectQuery("SELECT \\* FROM `resolver_users`").WillReturnRows(rows())
+		replica.ExpectQuery("SELECT \\* FROM `resolver_users`").WillReturnRows(rows())
+
+		var users []resolverUser
+		assert.NoError(t, db.Find(&users).Error)
+		assert.Equal(t, lagErr, resolver.Statuses()[0].Error)
+
+		lagErr = nil
+		resolver.Check(context.TODO())
+		assert.NoError(t, db.Find(&users).Error)
+		assert.NoError(t, primary.ExpectationsWereMet())
+		assert.NoError(t, replica.ExpectationsWereMet())
+	})
+}
+
+func TestMysqlLag(t *testing.T) {
+	tests := []struct {
+		name    string
+		rows    *sqlmock.Rows
+		wantLag time.Duration
+		wantErr error
+	}{
+		{
+			name:    "seconds behind source",
+			rows:    sqlmock.NewRows([]string{"Replica_IO_State", "Seconds_Behind_Source"}).AddRow("Waiting for source", "3"),
+			wantLag: 3 * time.Second,
+		},
+		{
+			name:    "seconds behind master",
+			rows:    sqlmock.NewRows([]string{"Slave_IO_State", "Seconds_Behind_Master"}).AddRow("Waiting for master", "0"),
+			wantLag: 0,
+		},
+		{
+			name:    "replication stopped",
+			rows:    sqlmock.NewRows([]string{"Slave_IO_State", "Seconds_Behind_Master"}).AddRow("", nil),
+			wantErr: ErrReplicationStopped,
+		},
+		{
+			name:    "not a replica",
+			rows:    sqlmock.NewRows([]string{"Slave_IO_State", "Seconds_Behind_Master"}),
+			wantErr: ErrReplicationStopped,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			db, mock, err := sqlmock.New()
+			require.NoError(t, err)
+			defer db.Close()
+			mock.ExpectQuery("SHOW SLAVE STATUS").WillReturnRows(tt.rows)
+
+			lag, err := MysqlLag(context.TODO(), db)
+			assert.Equal(t, tt.wantErr, err)
+			assert.Equal(t, tt.wantLag, lag)
+			assert.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
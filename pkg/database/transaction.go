@@ -0,0 +1,67 @@
+package database
+
+import (
+	"context"
+
+	"gorm.io/gorm"
+)
+
+type (
+	// TxManager runs a unit of work in a transaction carried by its context, the repositories
+	// resolving their connection with FromContext join it without being passed the transaction.
+	//
+	//	err := txManager.WithinTransaction(ctx, func(ctx context.Context) error {
+	//	    user, err := userRepository.Store(ctx, user)
+	//	    if err != nil {
+	//	        return err
+	//	    }
+	//	    _, err = profileRepository.Store(ctx, request.ToProfile(user.ID))
+	//	    return err
+	//	})
+	TxManager interface {
+		// WithinTransaction commits the transaction when fn returns nil and rolls it back otherwise,
+		// a nested call runs in a savepoint of the transaction of ctx.
+		WithinTransaction(ctx context.Context, fn func(ctx context.Context) error) error
+	}
+
+	txManager struct {
+		db *gorm.DB
+	}
+
+	txKey struct{}
+)
+
+// NewTxManager returns the TxManager of the transactions of db.
+func NewTxManager(db *gorm.DB) TxManager {
+	return &txManager{
+		db: db,
+	}
+}
+
+func (m *txManager) WithinTransaction(ctx context.Context, fn func(ctx context.Context) error) error {
+	return FromContext(ctx, m.db).Transaction(func(tx *gorm.DB) error {
+		return fn(WithTx(ctx, tx))
+	})
+}
+
+// WithTx returns a copy of ctx carrying the transaction tx.
+func WithTx(ctx context.Context, tx *gorm.DB) context.Context {
+	return context.WithValue(ctx, txKey{}, tx)
+}
+
+// TxFromContext returns the transaction carried by ctx, nil when there is none.
+func TxFromContext(ctx context.Context) *gorm.DB {
+	if ctx == nil {
+		return nil
+	}
+	tx, _ := ctx.Value(txKey{}).(*gorm.DB)
+	return tx
+}
+
+// FromContext returns the transaction carried by ctx, else db, bound to ctx.
+func FromContext(ctx context.Context, db *gorm.DB) *gorm.DB {
+	if tx := TxFromContext(ctx); tx != nil {
+		return tx.WithContext(ctx)
+	}
+	return db.WithContext(ctx)
+}
@@ -0,0 +1,105 @@
+package database
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gorm.io/driver/mysql"
+	"gorm.io/gorm"
+	"gorm.io/gorm/logger"
+)
+
+type txUser struct {
+	ID    int
+	Email string
+}
+
+func newTxDB(t *testing.T) (*gorm.DB, sqlmock.Sqlmock) {
+	sqlDB, mock, err := sqlmock.New()
+	require.NoError(t, err)
+	t.Cleanup(func() { sqlDB.Close() })
+
+	db, err := gorm.Open(mysql.New(mysql.Config{Conn: sqlDB, SkipInitializeWithVersion: true}), &gorm.Config{
+		SkipDefaultTransaction: true,
+		Logger:                 logger.Default.LogMode(logger.Silent),
+	})
+	require.NoError(t, err)
+	return db, mock
+}
+
+func TestTxManager(t *testing.T) {
+	create := func(ctx context.Context, db *gorm.DB, email string) error {
+		return FromContext(ctx, db).Create(&txUser{Email: email}).Error
+	}
+
+	t.Run("commit", func(t *testing.T) {
+		db, mock := newTxDB(t)
+		mock.ExpectBegin()
+		mock.ExpectExec("INSERT INTO `tx_users`").WillReturnResult(sqlmock.NewResult(1, 1))
+		mock.ExpectExec("INSERT INTO `tx_users`").WillReturnResult(sqlmock.NewResult(2, 1))
+		mock.ExpectCommit()
+
+		err := NewTxManager(db).WithinTransaction(context.TODO(), func(ctx context.Context) error {
+			assert.NotNil(t, TxFromContext(ctx))
+			if err := create(ctx, db, "john@mail.com"); err != nil {
+				return err
+			}
+			return create(ctx, db, "jane@mail.com")
+		})
+		assert.NoError(t, err)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("rollback on error", func(t *testing.T) {
+		db, mock := newTxDB(t)
+		mock.ExpectBegin()
+		mock.ExpectExec("INSERT INTO `tx_users`").WillReturnResult(sqlmock.NewResult(1, 1))
+		mock.ExpectRollback()
+
+		failed := errors.New("failed")
+		err := NewTxManager(db).WithinTransaction(context.TODO(), func(ctx context.Context) error {
+			if err := create(ctx, db, "john@mail.com"); err != nil {
+				return err
+			}
+			return failed
+		})
+		assert.Equal(t, failed, err)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("nested in a savepoint", func(t *testing.T) {
+		db, mock := newTxDB(t)
+		mock.ExpectBegin()
+		mock.ExpectExec("SAVEPOINT").WillReturnResult(sqlmock.NewResult(0, 0))
+		mock.ExpectExec("INSERT INTO `tx_users`").WillReturnResult(sqlmock.NewResult(1, 1))
+		mock.ExpectExec("ROLLBACK TO SAVEPOINT").WillReturnResult(sqlmock.NewResult(0, 0))
+		mock.ExpectCommit()
+
+		txManager := NewTxManager(db)
+		err := txManager.WithinTransaction(context.TODO(), func(ctx context.Context) error {
+			nestedErr := txManager.WithinTransaction(ctx, func(ctx context.Context) error {
+				if err := create(ctx, db, "john@mail.com"); err != nil {
+					return err
+				}
+				return errors.New("failed")
+			})
+			assert.Error(t, nestedErr)
+			return nil
+		})
+		assert.NoError(t, err)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("outside a transaction", func(t *testing.T) {
+		db, mock := newTxDB(t)
+		mock.ExpectExec("INSERT INTO `tx_users`").WillReturnResult(sqlmock.NewResult(1, 1))
+
+		assert.Nil(t, TxFromContext(context.TODO()))
+		assert.NoError(t, create(context.TODO(), db, "john@mail.com"))
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+}
@@ -0,0 +1,117 @@
+// Package event provides the domain events, the in process Bus of their subscribers and
+// the Broker interface of the message brokers they are relayed to.
+package event
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// All subscribes a Handler to every event.
+const All = "*"
+
+type (
+	// Event is a fact of the domain, ex: a user registered, published by a usecase in the
+	// transaction of its change and delivered at least once to the subscribers.
+	Event struct {
+		ID   string `json:"id"`
+		Name string `json:"name"`
+		// Key orders the events of a same aggregate, ex: the id of the user, the partition key of kafka.
+		Key        string          `json:"key"`
+		Payload    json.RawMessage `json:"payload"`
+		OccurredAt time.Time       `json:"occurred_at"`
+	}
+
+	// Handler of the events a subscriber is subscribed to, an event can be delivered more than
+	// once so a Handler must be idempotent.
+	Handler func(ctx context.Context, event Event) error
+
+	// Publisher records the events of a usecase, in the transaction of the context when there is one.
+	Publisher interface {
+		Publish(ctx context.Context, events ...Event) error
+	}
+
+	// Broker delivers the events to their subscribers, ex: the in process Bus or kafka.
+	Broker interface {
+		Publish(ctx context.Context, events ...Event) error
+		Close() error
+	}
+
+	// Bus is the in process Broker calling the handlers subscribed to the events.
+	Bus struct {
+		mu       sync.RWMutex
+		handlers map[string][]Handler
+	}
+)
+
+// New returns the Event of the payload marshaled to json.
+func New(name, key string, payload interface{}) (Event, error) {
+	data, err := json.Marshal(payload)
+	if err != nil {
+		return Event{}, fmt.Errorf("marshal event %s: %w", name, err)
+	}
+	return Event{
+		ID:         uuid.NewString(),
+		Name:       name,
+		Key:        key,
+		Payload:    data,
+		OccurredAt: time.Now(),
+	}, nil
+}
+
+// PublishNew publishes the Event of the payload to the publisher.
+func PublishNew(ctx context.Context, publisher Publisher, name, key string, payload interface{}) error {
+	e, err := New(name, key, payload)
+	if err != nil {
+		return err
+	}
+	return publisher.Publish(ctx, e)
+}
+
+// Decode unmarshals the payload of the event into v.
+func (e Event) Decode(v interface{}) error {
+	return json.Unmarshal(e.Payload, v)
+}
+
+// NewBus returns a Bus without subscribers.
+func NewBus() *Bus {
+	return &Bus{
+		handlers: map[string][]Handler{},
+	}
+}
+
+// Subscribe the handler to the events of the name, or to every event with All.
+func (b *Bus) Subscribe(name string, handler Handler) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.handlers[name] = append(b.handlers[name], handler)
+}
+
+// Publish calls the handlers of each event in the order of their subscription, every handler
+// is called and the first error is returned so the event is delivered again.
+func (b *Bus) Publish(ctx context.Context, events ...Event) error {
+	b.mu.RLock()
+	defer b.mu.RUnlock()
+
+	var firstErr error
+	for _, e := range events {
+		for _, handlers := range [][]Handler{b.handlers[e.Name], b.handlers[All]} {
+			for _, handler := range handlers {
+				if err := handler(ctx, e); err != nil && firstErr == nil {
+					firstErr = fmt.Errorf("handle event %s %s: %w", e.Name, e.ID, err)
+				}
+			}
+		}
+	}
+	return firstErr
+}
+
+// Close the Bus, the handlers have nothing to release.
+func (b *Bus) Close() error {
+	return nil
+}
@@ -0,0 +1,83 @@
+package event
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type userPayload struct {
+	UserID int `json:"user_id"`
+}
+
+func TestNew(t *testing.T) {
+	e, err := New("user.registered", "1", userPayload{UserID: 1})
+	require.NoError(t, err)
+
+	assert.NotEmpty(t, e.ID)
+	assert.Equal(t, "user.registered", e.Name)
+	assert.Equal(t, "1", e.Key)
+	assert.JSONEq(t, `{"user_id":1}`, string(e.Payload))
+	assert.False(t, e.OccurredAt.IsZero())
+
+	var payload userPayload
+	require.NoError(t, e.Decode(&payload))
+	assert.Equal(t, userPayload{UserID: 1}, payload)
+}
+
+func TestBus_Publish(t *testing.T) {
+	var calls []string
+	handler := func(name string, err error) Handler {
+		return func(ctx context.Context, e Event) error {
+			calls = append(calls, name+":"+e.Name)
+			return err
+		}
+	}
+
+	t.Run("handlers of the name and all", func(t *testing.T) {
+		calls = nil
+		bus := NewBus()
+		bus.Subscribe("user.registered", handler("welcome", nil))
+		bus.Subscribe(All, handler("analytics", nil))
+		bus.Subscribe("match.created", handler("notification", nil))
+
+		err := bus.Publish(context.TODO(), Event{Name: "user.registered"}, Event{Name: "match.created"})
+		assert.NoError(t, err)
+		assert.Equal(t, []string{
+			"welcome:user.registered",
+			"analytics:user.registered",
+			"notification:match.created",
+			"analytics:match.created",
+		}, calls)
+	})
+
+	t.Run("failed handler", func(t *testing.T) {
+		calls = nil
+		failed := errors.New("failed")
+		bus := NewBus()
+		bus.Subscribe("user.registered", handler("welcome", failed))
+		bus.Subscribe(All, handler("analytics", nil))
+
+		err := bus.Publish(context.TODO(), Event{ID: "1", Name: "user.registered"})
+		assert.ErrorIs(t, err, failed)
+		assert.Equal(t, []string{"welcome:user.registered", "analytics:user.registered"}, calls)
+	})
+
+	t.Run("without subscribers", func(t *testing.T) {
+		assert.NoError(t, NewBus().Publish(context.TODO(), Event{Name: "premium.activated"}))
+	})
+}
+
+func TestPublishNew(t *testing.T) {
+	bus := NewBus()
+	var got userPayload
+	bus.Subscribe("user.registered", func(ctx context.Context, e Event) error {
+		return e.Decode(&got)
+	})
+
+	require.NoError(t, PublishNew(context.TODO(), bus, "user.registered", "2", userPayload{UserID: 2}))
+	assert.Equal(t, userPayload{UserID: 2}, got)
+}
@@ -0,0 +1,220 @@
+// Package kafka provides the kafka Broker of the domain events and the Consumer delivering them
+// to the subscribers of the Bus.
+package kafka
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/radyatamaa/dating-apps-api/pkg/event"
+	"github.com/radyatamaa/dating-apps-api/pkg/zaplogger"
+	kafkaGo "github.com/segmentio/kafka-go"
+)
+
+const (
+	headerEventName = "event-name"
+	headerError     = "error"
+	retryBackoff    = time.Second
+)
+
+type (
+	// Config of the connection to the kafka cluster.
+	Config struct {
+		Brokers []string
+		Topic   string
+		// GroupID of the consumers, each event is delivered to one consumer of the group
+		GroupID string
+		// MaxAttempts of the handlers of a message, the message is then dead lettered and committed
+		MaxAttempts int
+		// DeadLetterTopic receives the messages failing MaxAttempts times, they're logged and skipped when empty
+		DeadLetterTopic string
+	}
+
+	// Broker produces the events to the topic, the key of the event is the key of the message so
+	// the events of a same key are in the same partition and consumed in order.
+	Broker struct {
+		writer *kafkaGo.Writer
+	}
+
+	// Consumer reads the events of the topic and publishes them to the Bus, the offset of a message
+	// is committed once its handlers succeeded so an event is consumed again after a restart.
+	// A message failing maxAttempts times is written to the dead letter topic and committed
+	// so it doesn't hold the next messages of its partition.
+	Consumer struct {
+		reader      *kafkaGo.Reader
+		deadLetter  *kafkaGo.Writer
+		bus         event.Broker
+		maxAttempts int
+		zapLogger   zaplogger.Logger
+		wg          sync.WaitGroup
+		cancel      context.CancelFunc
+	}
+)
+
+// NewBroker returns the Broker of the topic.
+func NewBroker(config Config) *Broker {
+	return &Broker{
+		writer: &kafkaGo.Writer{
+			Addr:         kafkaGo.TCP(config.Brokers...),
+			Topic:        config.Topic,
+			Balancer:     &kafkaGo.Hash{},
+			RequiredAcks: kafkaGo.RequireAll,
+		},
+	}
+}
+
+// Publish writes the events to the topic and returns when they're acknowledged by the brokers.
+func (b *Broker) Publish(ctx context.Context, events ...event.Event) error {
+	messages := make([]kafkaGo.Message, 0, len(events))
+	for _, e := range events {
+		value, err := json.Marshal(e)
+		if err != nil {
+			return fmt.Errorf("marshal event %s: %w", e.ID, err)
+		}
+		messages = append(messages, kafkaGo.Message{
+			Key:     []byte(e.Key),
+			Value:   value,
+			Headers: []kafkaGo.Header{{Key: headerEventName, Value: []byte(e.Name)}},
+			Time:    e.OccurredAt,
+		})
+	}
+	return b.writer.WriteMessages(ctx, messages...)
+}
+
+// Close flushes the pending messages and closes the connections.
+func (b *Broker) Close() error {
+	return b.writer.Close()
+}
+
+// NewConsumer returns the Consumer of the group delivering the events to the bus.
+func NewConsumer(config Config, bus event.Broker, zapLogger zaplogger.Logger) *Consumer {
+	c := &Consumer{
+		reader: kafkaGo.NewReader(kafkaGo.ReaderConfig{
+			Brokers: config.Brokers,
+			Topic:   config.Topic,
+			GroupID: config.GroupID,
+		}),
+		bus:         bus,
+		maxAttempts: config.MaxAttempts,
+		zapLogger:   zapLogger,
+	}
+	if config.DeadLetterTopic != "" {
+		c.deadLetter = &kafkaGo.Writer{
+			Addr:         kafkaGo.TCP(config.Brokers...),
+			Topic:        config.DeadLetterTopic,
+			Balancer:     &kafkaGo.Hash{},
+			RequiredAcks: kafkaGo.RequireAll,
+		}
+	}
+	return c
+}
+
+// Start consumes the topic in the background until Close.
+func (c *Consumer) Start() {
+	ctx, cancel := context.WithCancel(context.Background())
+	c.cancel = cancel
+
+	c.wg.Add(1)
+	go func() {
+		defer c.wg.Done()
+		c.consume(ctx)
+	}()
+}
+
+func (c *Consumer) consume(ctx context.Context) {
+	for {
+		message, err := c.reader.FetchMessage(ctx)
+		if err != nil {
+			if errors.Is(err, context.Canceled) {
+				return
+			}
+			c.zapLogger.Errorw("failed fetch kafka message", "topic", c.reader.Config().Topic, zaplogger.FieldError, err)
+			time.Sleep(retryBackoff)
+			continue
+		}
+		c.zapLogger.KafkaProcessMessage(message.Topic, message.Partition, string(message.Value), 0, message.Offset, message.Time)
+
+		// a failed message is retried before the next one, committing a later offset would skip it
+		err = c.handle(ctx, message)
+		for attempt := 1; err != nil && attempt < c.maxAttempts; attempt++ {
+			select {
+			case <-ctx.Done():
+				return
+			case <-time.After(retryBackoff):
+			}
+			err = c.handle(ctx, message)
+		}
+		if err != nil && !c.deadLetterMessage(ctx, message, err) {
+			return
+		}
+		if err := c.reader.CommitMessages(ctx, message); err != nil {
+			c.zapLogger.Errorw("failed commit kafka message", "topic", message.Topic, "offset", message.Offset, zaplogger.FieldError, err)
+			continue
+		}
+		c.zapLogger.KafkaLogCommittedMessage(message.Topic, message.Partition, message.Offset)
+	}
+}
+
+// handle publishes the event of the message to the bus, a malformed message is skipped.
+func (c *Consumer) handle(ctx context.Context, message kafkaGo.Message) error {
+	var e event.Event
+	if err := json.Unmarshal(message.Value, &e); err != nil {
+		c.zapLogger.Errorw("failed decode kafka message", "topic", message.Topic, "offset", message.Offset, zaplogger.FieldError, err)
+		return nil
+	}
+	if err := c.bus.Publish(ctx, e); err != nil {
+		c.zapLogger.Errorw("failed handle kafka message", "topic", message.Topic, "offset", message.Offset, zaplogger.FieldError, err)
+		return err
+	}
+	return nil
+}
+
+// deadLetterMessage writes the message failing maxAttempts times to the dead letter topic with the
+// error of its last attempt, the message is logged and skipped without dead letter topic.
+// It returns false when the consumer is closed before the message is written.
+func (c *Consumer) deadLetterMessage(ctx context.Context, message kafkaGo.Message, cause error) bool {
+	if c.deadLetter == nil {
+		c.zapLogger.Errorw("skipped kafka message after max attempts", "topic", message.Topic, "offset", message.Offset, "value", string(message.Value), zaplogger.FieldError, cause)
+		return true
+	}
+
+	deadLetter := kafkaGo.Message{
+		Key:     message.Key,
+		Value:   message.Value,
+		Headers: append(append([]kafkaGo.Header(nil), message.Headers...), kafkaGo.Header{Key: headerError, Value: []byte(cause.Error())}),
+		Time:    message.Time,
+	}
+	for {
+		err := c.deadLetter.WriteMessages(ctx, deadLetter)
+		if err == nil {
+			c.zapLogger.Errorw("dead lettered kafka message after max attempts", "topic", message.Topic, "offset", message.Offset, "dead_letter_topic", c.deadLetter.Topic, zaplogger.FieldError, cause)
+			return true
+		}
+		c.zapLogger.Errorw("failed write kafka dead letter message", "topic", c.deadLetter.Topic, "offset", message.Offset, zaplogger.FieldError, err)
+		// the message isn't committed until it's dead lettered, it would be lost
+		select {
+		case <-ctx.Done():
+			return false
+		case <-time.After(retryBackoff):
+		}
+	}
+}
+
+// Close stops the consumption and leaves the group.
+func (c *Consumer) Close() error {
+	if c.cancel != nil {
+		c.cancel()
+	}
+	c.wg.Wait()
+	if c.deadLetter != nil {
+		if err := c.deadLetter.Close(); err != nil {
+			c.reader.Close()
+			return err
+		}
+	}
+	return c.reader.Close()
+}
@@ -0,0 +1,161 @@
+package health
+
+import (
+	"context"
+	"errors"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/beego/beego/v2/client/cache"
+	"gorm.io/gorm"
+)
+
+const (
+	StatusUp   = "up"
+	StatusDown = "down"
+
+	DefaultTimeout = 2 * time.Second
+
+	cacheProbeKey = "health:probe"
+)
+
+var ErrTimeout = errors.New("health check timed out")
+
+type (
+	// CheckFunc probes a dependency, a nil error means the dependency is up.
+	CheckFunc func(ctx context.Context) error
+
+	// Registry of the readiness checkers by name, subsystems register their own probes.
+	Registry struct {
+		timeout time.Duration
+
+		mu     sync.RWMutex
+		checks map[string]CheckFunc
+	}
+
+	CheckResult struct {
+		Status    string `json:"status"`
+		LatencyMs int64  `json:"latencyMs"`
+		Error     string `json:"error,omitempty"`
+	}
+
+	Report struct {
+		Status string                 `json:"status"`
+		Checks map[string]CheckResult `json:"checks"`
+	}
+)
+
+// NewRegistry returns a Registry running every check with the timeout.
+func NewRegistry(timeout time.Duration) *Registry {
+	if timeout <= 0 {
+		timeout = DefaultTimeout
+	}
+	return &Registry{
+		timeout: timeout,
+		checks:  map[string]CheckFunc{},
+	}
+}
+
+// Register registers the check by name, a check with the same name is replaced.
+func (r *Registry) Register(name string, check CheckFunc) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.checks[name] = check
+}
+
+// Names returns the names of the registered checks.
+func (r *Registry) Names() []string {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	names := make([]string, 0, len(r.checks))
+	for name := range r.checks {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// Check runs the registered checks concurrently, the report is down when any check is down.
+func (r *Registry) Check(ctx context.Context) Report {
+	r.mu.RLock()
+	checks := make(map[string]CheckFunc, len(r.checks))
+	for name, check := range r.checks {
+		checks[name] = check
+	}
+	r.mu.RUnlock()
+
+	report := Report{
+		Status: StatusUp,
+		Checks: make(map[string]CheckResult, len(checks)),
+	}
+	var (
+		mu sync.Mutex
+		wg sync.WaitGroup
+	)
+	for name, check := range checks {
+		wg.Add(1)
+		go func(name string, check CheckFunc) {
+			defer wg.Done()
+			result := r.run(ctx, check)
+
+			mu.Lock()
+			defer mu.Unlock()
+			report.Checks[name] = result
+			if result.Status == StatusDown {
+				report.Status = StatusDown
+			}
+		}(name, check)
+	}
+	wg.Wait()
+	return report
+}
+
+// run runs the check with the timeout, the timeout is enforced even when the
+// check ignores the context, ex: the beego redis cache.
+func (r *Registry) run(ctx context.Context, check CheckFunc) CheckResult {
+	ctx, cancel := context.WithTimeout(ctx, r.timeout)
+	defer cancel()
+
+	start := time.Now()
+	done := make(chan error, 1)
+	go func() {
+		done <- check(ctx)
+	}()
+
+	var err error
+	select {
+	case err = <-done:
+	case <-ctx.Done():
+		err = ErrTimeout
+	}
+
+	result := CheckResult{
+		Status:    StatusUp,
+		LatencyMs: time.Since(start).Milliseconds(),
+	}
+	if err != nil {
+		result.Status = StatusDown
+		result.Error = err.Error()
+	}
+	return result
+}
+
+// DB returns a check pinging the database connection.
+func DB(db *gorm.DB) CheckFunc {
+	return func(ctx context.Context) error {
+		sqlDB, err := db.DB()
+		if err != nil {
+			return err
+		}
+		return sqlDB.PingContext(ctx)
+	}
+}
+
+// Cache returns a check sending a command to the cache, a missing key is still up.
+func Cache(c cache.Cache) CheckFunc {
+	return func(ctx context.Context) error {
+		_, err := c.IsExist(ctx, cacheProbeKey)
+		return err
+	}
+}
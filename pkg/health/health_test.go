@@ -0,0 +1,91 @@
+package health
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/assert"
+	"gorm.io/driver/mysql"
+	"gorm.io/gorm"
+)
+
+func TestRegistry_Check(t *testing.T) {
+	up := func(ctx context.Context) error { return nil }
+	down := func(ctx context.Context) error { return errors.New("connection refused") }
+	slow := func(ctx context.Context) error {
+		time.Sleep(time.Second)
+		return nil
+	}
+
+	tests := []struct {
+		name       string
+		checks     map[string]CheckFunc
+		wantStatus string
+		wantChecks map[string]string
+		wantError  map[string]string
+	}{
+		{
+			name:       "no checks",
+			checks:     map[string]CheckFunc{},
+			wantStatus: StatusUp,
+			wantChecks: map[string]string{},
+		},
+		{
+			name:       "all up",
+			checks:     map[string]CheckFunc{"mysql": up, "redis": up},
+			wantStatus: StatusUp,
+			wantChecks: map[string]string{"mysql": StatusUp, "redis": StatusUp},
+		},
+		{
+			name:       "one down",
+			checks:     map[string]CheckFunc{"mysql": up, "redis": down},
+			wantStatus: StatusDown,
+			wantChecks: map[string]string{"mysql": StatusUp, "redis": StatusDown},
+			wantError:  map[string]string{"redis": "connection refused"},
+		},
+		{
+			name:       "timeout",
+			checks:     map[string]CheckFunc{"mysql": slow},
+			wantStatus: StatusDown,
+			wantChecks: map[string]string{"mysql": StatusDown},
+			wantError:  map[string]string{"mysql": ErrTimeout.Error()},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			registry := NewRegistry(50 * time.Millisecond)
+			for name, check := range tt.checks {
+				registry.Register(name, check)
+			}
+
+			report := registry.Check(context.TODO())
+			assert.Equal(t, tt.wantStatus, report.Status)
+			assert.Len(t, report.Checks, len(tt.wantChecks))
+			for name, status := range tt.wantChecks {
+				assert.Equal(t, status, report.Checks[name].Status, name)
+				assert.Equal(t, tt.wantError[name], report.Checks[name].Error, name)
+			}
+		})
+	}
+}
+
+func TestDB(t *testing.T) {
+	sqlDB, mock, err := sqlmock.New(sqlmock.MonitorPingsOption(true))
+	assert.NoError(t, err)
+	defer sqlDB.Close()
+
+	// gorm pings on open
+	mock.ExpectPing()
+	db, err := gorm.Open(mysql.New(mysql.Config{Conn: sqlDB, SkipInitializeWithVersion: true}), &gorm.Config{})
+	assert.NoError(t, err)
+
+	mock.ExpectPing()
+	assert.NoError(t, DB(db)(context.TODO()))
+
+	mock.ExpectPing().WillReturnError(errors.New("connection refused"))
+	assert.Error(t, DB(db)(context.TODO()))
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
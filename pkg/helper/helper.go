@@ -6,14 +6,19 @@ import (
 	"io"
 	"mime/multipart"
 	"net/http"
+	"net/url"
 	"os"
 	"path/filepath"
 	"reflect"
+	"strings"
 
 	beegoContext "github.com/beego/beego/v2/server/web/context"
 	"github.com/beego/i18n"
 )
 
+// StoragePath is the directory of the uploaded files, served under /external/storage.
+const StoragePath = "external/storage"
+
 var ErrInvalidFormatJpeg = errors.New("format must be JPEG image")
 
 func ItemExists(arrayType interface{}, item interface{}) bool {
@@ -76,7 +81,7 @@ func ValidateFile(fileHeader *multipart.FileHeader) error {
 
 func UploadFileJpeg(beegoCtx *beegoContext.Context,file multipart.File) (string,error)  {
 	nameOfFile := fmt.Sprintf("%s.jpeg",GenerateRandomString(10))
-	outputPath := fmt.Sprintf("external/storage")
+	outputPath := StoragePath
 	// Create the uploads folder if it doesn't exist
 	err := os.MkdirAll(outputPath, os.ModePerm)
 	if err != nil {
@@ -101,6 +106,24 @@ func UploadFileJpeg(beegoCtx *beegoContext.Context,file multipart.File) (string,
 	return outputPath,nil
 }
 
+// DeleteFileJpeg removes a file uploaded by UploadFileJpeg, urls of other hosts are ignored.
+func DeleteFileJpeg(fileUrl string) error {
+	parse, err := url.Parse(fileUrl)
+	if err != nil {
+		return err
+	}
+	outputPath := StoragePath + "/"
+	index := strings.Index(parse.Path, "/"+outputPath)
+	if index < 0 {
+		return nil
+	}
+	nameOfFile := filepath.Base(parse.Path[index:])
+	if err := os.Remove(filepath.Join(outputPath, nameOfFile)); err != nil && !os.IsNotExist(err) {
+		return err
+	}
+	return nil
+}
+
 func GetHttpOrHttps(beegoCtx *beegoContext.Context) string {
 	// Get the original scheme from X-Forwarded-Proto header if available
 	https := beegoCtx.Input.Header("X-Forwarded-Proto")
@@ -45,6 +45,9 @@ type (
 		// DestroyToken Destroy the cache of a token.
 		DestroyToken(r *http.Request) error
 
+		// DestroyContextToken Destroy the cache of the token authenticated by the middlewares in the context.
+		DestroyContextToken(ctx context.Context) error
+
 		// DestroyIdentity Destroy the identification mark.
 		DestroyIdentity(issuer, identity interface{}) error
 
@@ -202,6 +205,12 @@ func (j *jwt) SetAdapter(adapter Adapter) JWT {
 	return j
 }
 
+// PayloadFromContext Retrieve the payload authenticated by the middlewares from the context.
+func PayloadFromContext(ctx context.Context) (Payload, bool) {
+	payload, ok := ctx.Value(defaultPayloadCtxKey).(Payload)
+	return payload, ok
+}
+
 // MiddlewareRPCAuth Implemented basic JWT permission authentication.
 func (j *jwt) MiddlewareRPCAuth(ctx context.Context, token string) (context.Context, error) {
 	payload, err := j.parseTokenRPC(token)
@@ -338,11 +347,15 @@ func (j *jwt) RetreadToken(token string, expiredTime int, ignoreExpired ...bool)
 
 // DestroyToken Destroy the cache of a token.
 func (j *jwt) DestroyToken(r *http.Request) error {
+	return j.destroyToken(j.seekToken(r))
+}
+
+func (j *jwt) destroyToken(token string) error {
 	if j.identityKey == "" {
 		return nil
 	}
 
-	claims, err := j.parseToken(j.seekToken(r), true)
+	claims, err := j.parseToken(token, true)
 	if err != nil {
 		return err
 	}
@@ -358,6 +371,12 @@ func (j *jwt) DestroyToken(r *http.Request) error {
 	return j.removeIdentity(claims[jwtIssuer].(string), claims[j.identityKey])
 }
 
+// DestroyContextToken Destroy the cache of the token authenticated by the middlewares in the context.
+func (j *jwt) DestroyContextToken(ctx context.Context) error {
+	token, _ := ctx.Value(defaultTokenCtxKey).(string)
+	return j.destroyToken(token)
+}
+
 // DestroyIdentity Destroy the identification mark.
 func (j *jwt) DestroyIdentity(issuer, identity interface{}) error {
 	return j.removeIdentity(issuer, identity)
@@ -50,6 +50,20 @@ func (mr *MockJWTMockRecorder) Ctx(ctx interface{}) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Ctx", reflect.TypeOf((*MockJWT)(nil).Ctx), ctx)
 }
 
+// DestroyContextToken mocks base method.
+func (m *MockJWT) DestroyContextToken(ctx context.Context) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DestroyContextToken", ctx)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DestroyContextToken indicates an expected call of DestroyContextToken.
+func (mr *MockJWTMockRecorder) DestroyContextToken(ctx interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DestroyContextToken", reflect.TypeOf((*MockJWT)(nil).DestroyContextToken), ctx)
+}
+
 // DestroyIdentity mocks base method.
 func (m *MockJWT) DestroyIdentity(issuer, identity interface{}) error {
 	m.ctrl.T.Helper()
@@ -231,4 +245,3 @@ func (mr *MockJWTMockRecorder) SetAdapter(adapter interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetAdapter", reflect.TypeOf((*MockJWT)(nil).SetAdapter), adapter)
 }
-
@@ -0,0 +1,112 @@
+package metrics
+
+import (
+	"database/sql"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/collectors"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+)
+
+const namespace = "dating_apps"
+
+// http
+var (
+	HTTPRequestsTotal = promauto.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "http_requests_total",
+		Help:      "Number of HTTP requests by method, route template and status.",
+	}, []string{"method", "route", "status"})
+
+	HTTPRequestDuration = promauto.NewHistogramVec(prometheus.HistogramOpts{
+		Namespace: namespace,
+		Name:      "http_request_duration_seconds",
+		Help:      "Latency of the HTTP requests by method, route template and status.",
+		Buckets:   prometheus.DefBuckets,
+	}, []string{"method", "route", "status"})
+
+	HTTPRequestsInFlight = promauto.NewGaugeVec(prometheus.GaugeOpts{
+		Namespace: namespace,
+		Name:      "http_requests_in_flight",
+		Help:      "Number of HTTP requests being served by method and route template.",
+	}, []string{"method", "route"})
+)
+
+// database
+var (
+	DBQueryDuration = promauto.NewHistogramVec(prometheus.HistogramOpts{
+		Namespace: namespace,
+		Name:      "db_query_duration_seconds",
+		Help:      "Duration of the GORM queries by table and operation.",
+		Buckets:   []float64{.001, .0025, .005, .01, .025, .05, .1, .25, .5, 1, 2.5, 5},
+	}, []string{"table", "operation"})
+
+	DBQueryErrorsTotal = promauto.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "db_query_errors_total",
+		Help:      "Number of the failed GORM queries by table and operation, record not found excluded.",
+	}, []string{"table", "operation"})
+)
+
+// business
+var (
+	SwipesTotal = promauto.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "swipes_total",
+		Help:      "Number of swipes by type.",
+	}, []string{"type"})
+
+	SwipeQuotaRejectionsTotal = promauto.NewCounter(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "swipe_quota_rejections_total",
+		Help:      "Number of swipes rejected by the daily quota.",
+	})
+
+	RegistrationsTotal = promauto.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "registrations_total",
+		Help:      "Number of registered users by method, password or the oauth provider.",
+	}, []string{"method"})
+
+	PremiumPurchasesTotal = promauto.NewCounter(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "premium_purchases_total",
+		Help:      "Number of premium purchases.",
+	})
+)
+
+// events
+var (
+	DomainEventsTotal = promauto.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "domain_events_total",
+		Help:      "Number of domain events delivered to the subscribers by name.",
+	}, []string{"name"})
+
+	OutboxRelayedTotal = promauto.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "outbox_relayed_total",
+		Help:      "Number of outbox events relayed to the broker by status, published, failed or dead when the event reached the max attempts and blocks its key.",
+	}, []string{"status"})
+)
+
+// jobs
+var (
+	JobRunsTotal = promauto.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "job_runs_total",
+		Help:      "Number of scheduled job runs by job and status, success, failed, skipped by the lock of another replica or error.",
+	}, []string{"job", "status"})
+
+	JobDuration = promauto.NewHistogramVec(prometheus.HistogramOpts{
+		Namespace: namespace,
+		Name:      "job_duration_seconds",
+		Help:      "Duration of the scheduled job runs with their retries by job.",
+		Buckets:   []float64{.01, .05, .1, .5, 1, 5, 10, 30, 60, 300},
+	}, []string{"job"})
+)
+
+// RegisterDBStats registers the connection pool stats of the database.
+func RegisterDBStats(db *sql.DB, name string) error {
+	return prometheus.Register(collectors.NewDBStatsCollector(db, name))
+}
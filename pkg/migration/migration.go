@@ -0,0 +1,299 @@
+package migration
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io/fs"
+	"path"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// TableName of the applied migrations.
+const TableName = "schema_migrations"
+
+var (
+	ErrUnsupportedDialect = errors.New("migrations are not supported for the dialect")
+	ErrInvalidFileName    = errors.New("invalid migration file name, expected <version>_<name>.up.sql or <version>_<name>.down.sql")
+	ErrDuplicateVersion   = errors.New("duplicate migration version")
+	ErrMissingFile        = errors.New("migration is missing its up or down file")
+	ErrUnknownVersion     = errors.New("applied migration version has no migration file")
+
+	fileNamePattern = regexp.MustCompile(`^(\d+)_(\w+)\.(up|down)\.sql$`)
+	// a statement ends with a semicolon at the end of a line
+	statementSeparator = regexp.MustCompile(`;\s*\n`)
+
+	createTableSQL = "CREATE TABLE IF NOT EXISTS " + TableName + " (version BIGINT NOT NULL PRIMARY KEY, name VARCHAR(255) NOT NULL, applied_at TIMESTAMP NOT NULL)"
+)
+
+type (
+	Migration struct {
+		Version int64
+		Name    string
+		Up      string
+		Down    string
+	}
+
+	// Record is the applied migration stored in the schema_migrations table.
+	Record struct {
+		Version   int64     `gorm:"column:version;primarykey;autoIncrement:false"`
+		Name      string    `gorm:"column:name"`
+		AppliedAt time.Time `gorm:"column:applied_at"`
+	}
+
+	Status struct {
+		Version   int64
+		Name      string
+		Applied   bool
+		AppliedAt time.Time
+	}
+
+	// Migrator applies the migrations of the dialect of the database, each migration
+	// and its record run in a transaction. On postgres a failed migration is rolled back
+	// and not recorded, on mysql a DDL statement commits implicitly so the statements
+	// before the failed one stay applied: a mysql migration must hold a single DDL
+	// statement or be idempotent to be applied again.
+	Migrator struct {
+		db         *gorm.DB
+		migrations []Migration
+	}
+)
+
+// TableName name of table
+func (r Record) TableName() string {
+	return TableName
+}
+
+// Dialect returns the directory of the migrations of the gorm dialect.
+func Dialect(db *gorm.DB) (string, error) {
+	switch name := db.Dialector.Name(); name {
+	case "mysql", "postgres":
+		return name, nil
+	default:
+		return "", fmt.Errorf("%w: %s", ErrUnsupportedDialect, name)
+	}
+}
+
+// NewMigrator loads the migrations of the dialect directory of the file system.
+func NewMigrator(db *gorm.DB, fsys fs.FS, dialect string) (*Migrator, error) {
+	migrations, err := Load(fsys, dialect)
+	if err != nil {
+		return nil, err
+	}
+	return &Migrator{db: db, migrations: migrations}, nil
+}
+
+// Load returns the migrations of the directory sorted by version.
+func Load(fsys fs.FS, dir string) ([]Migration, error) {
+	entries, err := fs.ReadDir(fsys, dir)
+	if err != nil {
+		return nil, err
+	}
+
+	byVersion := map[int64]*Migration{}
+	for _, entry := range entries {
+		if entry.IsDir() || !strings.HasSuffix(entry.Name(), ".sql") {
+			continue
+		}
+		match := fileNamePattern.FindStringSubmatch(entry.Name())
+		if match == nil {
+			return nil, fmt.Errorf("%w: %s", ErrInvalidFileName, entry.Name())
+		}
+		version, err := strconv.ParseInt(match[1], 10, 64)
+		if err != nil {
+			return nil, fmt.Errorf("%w: %s", ErrInvalidFileName, entry.Name())
+		}
+		content, err := fs.ReadFile(fsys, path.Join(dir, entry.Name()))
+		if err != nil {
+			return nil, err
+		}
+
+		migration, ok := byVersion[version]
+		if !ok {
+			migration = &Migration{Version: version, Name: match[2]}
+			byVersion[version] = migration
+		} else if migration.Name != match[2] {
+			return nil, fmt.Errorf("%w: %d", ErrDuplicateVersion, version)
+		}
+		if match[3] == "up" {
+			migration.Up = string(content)
+		} else {
+			migration.Down = string(content)
+		}
+	}
+
+	migrations := make([]Migration, 0, len(byVersion))
+	for _, migration := range byVersion {
+		if migration.Up == "" || migration.Down == "" {
+			return nil, fmt.Errorf("%w: %d_%s", ErrMissingFile, migration.Version, migration.Name)
+		}
+		migrations = append(migrations, *migration)
+	}
+	sort.Slice(migrations, func(i, j int) bool {
+		return migrations[i].Version < migrations[j].Version
+	})
+	return migrations, nil
+}
+
+// Status returns every migration with its applied state.
+func (m *Migrator) Status(ctx context.Context) ([]Status, error) {
+	applied, err := m.applied(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	result := make([]Status, 0, len(m.migrations))
+	for _, migration := range m.migrations {
+		status := Status{Version: migration.Version, Name: migration.Name}
+		if record, ok := applied[migration.Version]; ok {
+			status.Applied = true
+			status.AppliedAt = record.AppliedAt
+		}
+		result = append(result, status)
+	}
+	return result, nil
+}
+
+// Pending returns the migrations not applied yet.
+func (m *Migrator) Pending(ctx context.Context) ([]Migration, error) {
+	applied, err := m.applied(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	pending := make([]Migration, 0)
+	for _, migration := range m.migrations {
+		if _, ok := applied[migration.Version]; !ok {
+			pending = append(pending, migration)
+		}
+	}
+	return pending, nil
+}
+
+// Up applies the pending migrations in order, all of them when steps is 0.
+// A migration is recorded only when all its statements succeed, see Migrator for
+// the DDL applied by a failed mysql migration.
+func (m *Migrator) Up(ctx context.Context, steps int) ([]Migration, error) {
+	pending, err := m.Pending(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if steps > 0 && steps < len(pending) {
+		pending = pending[:steps]
+	}
+
+	done := make([]Migration, 0, len(pending))
+	for _, migration := range pending {
+		if err := m.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+			if err := execStatements(tx, migration.Up); err != nil {
+				return err
+			}
+			return tx.Create(&Record{
+				Version:   migration.Version,
+				Name:      migration.Name,
+				AppliedAt: time.Now().UTC(),
+			}).Error
+		}); err != nil {
+			return done, fmt.Errorf("migrate up %d_%s: %w", migration.Version, migration.Name, err)
+		}
+		done = append(done, migration)
+	}
+	return done, nil
+}
+
+// Down reverts the applied migrations from the latest, one when steps is 0.
+func (m *Migrator) Down(ctx context.Context, steps int) ([]Migration, error) {
+	applied, err := m.applied(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if steps <= 0 {
+		steps = 1
+	}
+
+	byVersion := make(map[int64]Migration, len(m.migrations))
+	for _, migration := range m.migrations {
+		byVersion[migration.Version] = migration
+	}
+	versions := make([]int64, 0, len(applied))
+	for version := range applied {
+		versions = append(versions, version)
+	}
+	sort.Slice(versions, func(i, j int) bool {
+		return versions[i] > versions[j]
+	})
+	if steps < len(versions) {
+		versions = versions[:steps]
+	}
+
+	done := make([]Migration, 0, len(versions))
+	for _, version := range versions {
+		migration, ok := byVersion[version]
+		if !ok {
+			return done, fmt.Errorf("%w: %d", ErrUnknownVersion, version)
+		}
+		if err := m.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+			if err := execStatements(tx, migration.Down); err != nil {
+				return err
+			}
+			return tx.Where("version = ?", migration.Version).Delete(&Record{}).Error
+		}); err != nil {
+			return done, fmt.Errorf("migrate down %d_%s: %w", migration.Version, migration.Name, err)
+		}
+		done = append(done, migration)
+	}
+	return done, nil
+}
+
+// applied returns the applied migrations by version, the table is created when missing.
+func (m *Migrator) applied(ctx context.Context) (map[int64]Record, error) {
+	db := m.db.WithContext(ctx)
+	if err := db.Exec(createTableSQL).Error; err != nil {
+		return nil, err
+	}
+
+	var records []Record
+	if err := db.Order("version ASC").Find(&records).Error; err != nil {
+		return nil, err
+	}
+	applied := make(map[int64]Record, len(records))
+	for _, record := range records {
+		applied[record.Version] = record
+	}
+	return applied, nil
+}
+
+func execStatements(tx *gorm.DB, content string) error {
+	for _, statement := range Statements(content) {
+		if err := tx.Exec(statement).Error; err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Statements splits the content of a migration file into statements, the comment lines are removed.
+func Statements(content string) []string {
+	lines := make([]string, 0)
+	for _, line := range strings.Split(content, "\n") {
+		if strings.HasPrefix(strings.TrimSpace(line), "--") {
+			continue
+		}
+		lines = append(lines, line)
+	}
+
+	statements := make([]string, 0)
+	for _, statement := range statementSeparator.Split(strings.Join(lines, "\n")+"\n", -1) {
+		statement = strings.TrimSuffix(strings.TrimSpace(statement), ";")
+		if statement != "" {
+			statements = append(statements, statement)
+		}
+	}
+	return statements
+}
@@ -0,0 +1,207 @@
+package migration
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"testing/fstest"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gorm.io/driver/mysql"
+	"gorm.io/gorm"
+	"gorm.io/gorm/logger"
+
+	"github.com/radyatamaa/dating-apps-api/migrations"
+)
+
+func testFS() fstest.MapFS {
+	return fstest.MapFS{
+		"mysql/000001_init.up.sql":          {Data: []byte("-- users\nCREATE TABLE users (id bigint);\nCREATE TABLE profiles (id bigint);\n")},
+		"mysql/000001_init.down.sql":        {Data: []byte("DROP TABLE profiles;\nDROP TABLE users;\n")},
+		"mysql/000002_add_gender.up.sql":    {Data: []byte("ALTER TABLE profiles ADD gender varchar(10);\n")},
+		"mysql/000002_add_gender.down.sql":  {Data: []byte("ALTER TABLE profiles DROP gender;\n")},
+		"mysql/README.md":                   {Data: []byte("not a migration")},
+		"postgres/000001_init.up.sql":       {Data: []byte("CREATE TABLE users (id bigserial);\n")},
+		"postgres/000001_init.down.sql":     {Data: []byte("DROP TABLE users;\n")},
+		"invalid/000001_init.sql":           {Data: []byte("CREATE TABLE users (id bigint);\n")},
+		"missing/000001_init.up.sql":        {Data: []byte("CREATE TABLE users (id bigint);\n")},
+		"duplicate/000001_init.up.sql":      {Data: []byte("CREATE TABLE users (id bigint);\n")},
+		"duplicate/000001_init.down.sql":    {Data: []byte("DROP TABLE users;\n")},
+		"duplicate/000001_users.up.sql":     {Data: []byte("CREATE TABLE users (id bigint);\n")},
+		"duplicate/000001_users.down.sql":   {Data: []byte("DROP TABLE users;\n")},
+		"unordered/000010_later.up.sql":     {Data: []byte("SELECT 10;\n")},
+		"unordered/000010_later.down.sql":   {Data: []byte("SELECT 10;\n")},
+		"unordered/000002_earlier.up.sql":   {Data: []byte("SELECT 2;\n")},
+		"unordered/000002_earlier.down.sql": {Data: []byte("SELECT 2;\n")},
+	}
+}
+
+func newMigrator(t *testing.T) (*Migrator, sqlmock.Sqlmock) {
+	sqlDB, mock, err := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual))
+	require.NoError(t, err)
+	t.Cleanup(func() { sqlDB.Close() })
+
+	db, err := gorm.Open(mysql.New(mysql.Config{Conn: sqlDB, SkipInitializeWithVersion: true}), &gorm.Config{
+		Logger: logger.Default.LogMode(logger.Silent),
+	})
+	require.NoError(t, err)
+
+	migrator, err := NewMigrator(db, testFS(), "mysql")
+	require.NoError(t, err)
+	return migrator, mock
+}
+
+func expectApplied(mock sqlmock.Sqlmock, versions ...int64) {
+	mock.ExpectExec(createTableSQL).WillReturnResult(sqlmock.NewResult(0, 0))
+	rows := sqlmock.NewRows([]string{"version", "name", "applied_at"})
+	for _, version := range versions {
+		rows.AddRow(version, "init", time.Date(2022, 6, 1, 0, 0, 0, 0, time.UTC))
+	}
+	mock.ExpectQuery("SELECT * FROM `schema_migrations` ORDER BY version ASC").WillReturnRows(rows)
+}
+
+func TestLoad(t *testing.T) {
+	tests := []struct {
+		name         string
+		dir          string
+		wantVersions []int64
+		wantErr      error
+	}{
+		{name: "success", dir: "mysql", wantVersions: []int64{1, 2}},
+		{name: "sorted by version", dir: "unordered", wantVersions: []int64{2, 10}},
+		{name: "invalid file name", dir: "invalid", wantErr: ErrInvalidFileName},
+		{name: "missing down file", dir: "missing", wantErr: ErrMissingFile},
+		{name: "duplicate version", dir: "duplicate", wantErr: ErrDuplicateVersion},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			migrations, err := Load(testFS(), tt.dir)
+			if tt.wantErr != nil {
+				assert.True(t, errors.Is(err, tt.wantErr), err)
+				return
+			}
+			require.NoError(t, err)
+			versions := make([]int64, 0)
+			for _, migration := range migrations {
+				versions = append(versions, migration.Version)
+			}
+			assert.Equal(t, tt.wantVersions, versions)
+		})
+	}
+}
+
+func TestLoad_Embedded(t *testing.T) {
+	for _, dialect := range []string{"mysql", "postgres"} {
+		t.Run(dialect, func(t *testing.T) {
+			loaded, err := Load(migrations.FS, dialect)
+			require.NoError(t, err)
+			assert.NotEmpty(t, loaded)
+			for _, migration := range loaded {
+				assert.NotEmpty(t, Statements(migration.Up))
+				assert.NotEmpty(t, Statements(migration.Down))
+			}
+		})
+	}
+}
+
+func TestStatements(t *testing.T) {
+	content := "-- comment; ignored\nCREATE TABLE users (\n    id bigint\n);\n\nINSERT INTO users VALUES ('a;b');\nDROP TABLE tmp"
+	assert.Equal(t, []string{
+		"CREATE TABLE users (\n    id bigint\n)",
+		"INSERT INTO users VALUES ('a;b')",
+		"DROP TABLE tmp",
+	}, Statements(content))
+}
+
+func TestMigrator_Status(t *testing.T) {
+	migrator, mock := newMigrator(t)
+	expectApplied(mock, 1)
+
+	statuses, err := migrator.Status(context.TODO())
+	require.NoError(t, err)
+	assert.Equal(t, []Status{
+		{Version: 1, Name: "init", Applied: true, AppliedAt: time.Date(2022, 6, 1, 0, 0, 0, 0, time.UTC)},
+		{Version: 2, Name: "add_gender"},
+	}, statuses)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestMigrator_Up(t *testing.T) {
+	t.Run("success", func(t *testing.T) {
+		migrator, mock := newMigrator(t)
+		expectApplied(mock, 1)
+		mock.ExpectBegin()
+		mock.ExpectExec("ALTER TABLE profiles ADD gender varchar(10)").WillReturnResult(sqlmock.NewResult(0, 0))
+		mock.ExpectExec("INSERT INTO `schema_migrations` (`version`,`name`,`applied_at`) VALUES (?,?,?)").
+			WithArgs(int64(2), "add_gender", sqlmock.AnyArg()).
+			WillReturnResult(sqlmock.NewResult(2, 1))
+		mock.ExpectCommit()
+
+		done, err := migrator.Up(context.TODO(), 0)
+		require.NoError(t, err)
+		require.Len(t, done, 1)
+		assert.Equal(t, int64(2), done[0].Version)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("steps", func(t *testing.T) {
+		migrator, mock := newMigrator(t)
+		expectApplied(mock)
+		mock.ExpectBegin()
+		mock.ExpectExec("CREATE TABLE users (id bigint)").WillReturnResult(sqlmock.NewResult(0, 0))
+		mock.ExpectExec("CREATE TABLE profiles (id bigint)").WillReturnResult(sqlmock.NewResult(0, 0))
+		mock.ExpectExec("INSERT INTO `schema_migrations` (`version`,`name`,`applied_at`) VALUES (?,?,?)").
+			WithArgs(int64(1), "init", sqlmock.AnyArg()).
+			WillReturnResult(sqlmock.NewResult(1, 1))
+		mock.ExpectCommit()
+
+		done, err := migrator.Up(context.TODO(), 1)
+		require.NoError(t, err)
+		assert.Len(t, done, 1)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("failed migration is rolled back", func(t *testing.T) {
+		migrator, mock := newMigrator(t)
+		expectApplied(mock, 1)
+		mock.ExpectBegin()
+		mock.ExpectExec("ALTER TABLE profiles ADD gender varchar(10)").WillReturnError(errors.New("duplicate column"))
+		mock.ExpectRollback()
+
+		done, err := migrator.Up(context.TODO(), 0)
+		assert.EqualError(t, err, "migrate up 2_add_gender: duplicate column")
+		assert.Empty(t, done)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+}
+
+func TestMigrator_Down(t *testing.T) {
+	t.Run("success", func(t *testing.T) {
+		migrator, mock := newMigrator(t)
+		expectApplied(mock, 1, 2)
+		mock.ExpectBegin()
+		mock.ExpectExec("ALTER TABLE profiles DROP gender").WillReturnResult(sqlmock.NewResult(0, 0))
+		mock.ExpectExec("DELETE FROM `schema_migrations` WHERE version = ?").
+			WithArgs(int64(2)).
+			WillReturnResult(sqlmock.NewResult(0, 1))
+		mock.ExpectCommit()
+
+		done, err := migrator.Down(context.TODO(), 0)
+		require.NoError(t, err)
+		require.Len(t, done, 1)
+		assert.Equal(t, int64(2), done[0].Version)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("unknown version", func(t *testing.T) {
+		migrator, mock := newMigrator(t)
+		expectApplied(mock, 1, 2, 3)
+
+		_, err := migrator.Down(context.TODO(), 1)
+		assert.True(t, errors.Is(err, ErrUnknownVersion))
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+}
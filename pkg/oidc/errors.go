@@ -0,0 +1,26 @@
+package oidc
+
+import "errors"
+
+var (
+	// indicates the provider config is missing the name, issuer or client id
+	ErrInvalidConfig = errors.New("oidc provider config is invalid")
+
+	// indicates the provider is not configured
+	ErrProviderNotFound = errors.New("oidc provider not found")
+
+	// indicates the discovery document of the issuer couldn't be fetched
+	ErrDiscoveryFailed = errors.New("oidc discovery failed")
+
+	// indicates the authorization code couldn't be exchanged
+	ErrExchangeFailed = errors.New("oidc code exchange failed")
+
+	// indicates the token response doesn't contain an id token
+	ErrMissingIDToken = errors.New("oidc token response missing id_token")
+
+	// indicates the id token signature or claims are invalid
+	ErrInvalidIDToken = errors.New("oidc id token is invalid")
+
+	// indicates the id token is signed by a key missing from the JWKS
+	ErrUnknownKey = errors.New("oidc signing key not found")
+)
@@ -0,0 +1,353 @@
+package oidc
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+
+	jwts "github.com/golang-jwt/jwt/v4"
+)
+
+type (
+	Provider interface {
+		// Name Returns the name of the provider, ex: google.
+		Name() string
+
+		// AuthCodeURL Returns the authorization url the user agent is redirected to,
+		// the code challenge is the S256 challenge of the PKCE code verifier.
+		AuthCodeURL(ctx context.Context, state, nonce, codeChallenge string) (string, error)
+
+		// Exchange Exchanges the authorization code and the PKCE code verifier for tokens.
+		Exchange(ctx context.Context, code, codeVerifier string) (*Token, error)
+
+		// VerifyIDToken Verifies the signature of the id token against the provider JWKS,
+		// the issuer, the audience, the expiration and the nonce and returns its claims.
+		VerifyIDToken(ctx context.Context, rawIDToken, nonce string) (*Claims, error)
+	}
+
+	// Registry of the configured providers by name.
+	Registry map[string]Provider
+)
+
+type Config struct {
+	// Name of the provider used in the routes, ex: google.
+	Name string
+
+	// Issuer of the provider, the discovery document is served from
+	// ${Issuer}/.well-known/openid-configuration.
+	Issuer string
+
+	ClientID     string
+	ClientSecret string
+	RedirectURL  string
+
+	// Scopes requested, openid is always requested.
+	Scopes []string
+
+	// HTTPClient used to call the provider.
+	// Optional. Default value http.Client with 10 seconds timeout.
+	HTTPClient *http.Client
+}
+
+type Token struct {
+	AccessToken string `json:"access_token"`
+	TokenType   string `json:"token_type"`
+	IDToken     string `json:"id_token"`
+	ExpiresIn   int    `json:"expires_in"`
+}
+
+type Claims struct {
+	Issuer        string
+	Subject       string
+	Email         string
+	EmailVerified bool
+	Name          string
+	Picture       string
+}
+
+type discovery struct {
+	Issuer                string `json:"issuer"`
+	AuthorizationEndpoint string `json:"authorization_endpoint"`
+	TokenEndpoint         string `json:"token_endpoint"`
+	JwksURI               string `json:"jwks_uri"`
+}
+
+type provider struct {
+	config Config
+	client *http.Client
+
+	mu        sync.RWMutex
+	discovery *discovery
+	keys      map[string]interface{}
+	keysAt    time.Time
+}
+
+const (
+	discoveryPath      = "/.well-known/openid-configuration"
+	jwksRefreshBackoff = time.Minute
+	clockSkew          = time.Minute
+)
+
+// NewProvider returns a Provider for the config, the discovery document is fetched lazily.
+func NewProvider(config Config) (Provider, error) {
+	if config.Name == "" || config.Issuer == "" || config.ClientID == "" {
+		return nil, ErrInvalidConfig
+	}
+	client := config.HTTPClient
+	if client == nil {
+		client = &http.Client{Timeout: 10 * time.Second}
+	}
+	return &provider{
+		config: config,
+		client: client,
+		keys:   map[string]interface{}{},
+	}, nil
+}
+
+// Get returns the provider by name.
+func (r Registry) Get(name string) (Provider, error) {
+	if p, ok := r[strings.ToLower(name)]; ok {
+		return p, nil
+	}
+	return nil, ErrProviderNotFound
+}
+
+// GenerateRandom returns a random url safe string, used for state, nonce and code verifier.
+func GenerateRandom() (string, error) {
+	random := make([]byte, 32)
+	if _, err := rand.Read(random); err != nil {
+		return "", err
+	}
+	return base64.RawURLEncoding.EncodeToString(random), nil
+}
+
+// CodeChallengeS256 returns the PKCE S256 code challenge of the code verifier.
+func CodeChallengeS256(codeVerifier string) string {
+	sum := sha256.Sum256([]byte(codeVerifier))
+	return base64.RawURLEncoding.EncodeToString(sum[:])
+}
+
+func (p *provider) Name() string {
+	return p.config.Name
+}
+
+func (p *provider) AuthCodeURL(ctx context.Context, state, nonce, codeChallenge string) (string, error) {
+	d, err := p.getDiscovery(ctx)
+	if err != nil {
+		return "", err
+	}
+
+	scopes := []string{"openid"}
+	for _, scope := range p.config.Scopes {
+		if scope != "openid" && scope != "" {
+			scopes = append(scopes, scope)
+		}
+	}
+
+	query := url.Values{}
+	query.Set("response_type", "code")
+	query.Set("client_id", p.config.ClientID)
+	query.Set("redirect_uri", p.config.RedirectURL)
+	query.Set("scope", strings.Join(scopes, " "))
+	query.Set("state", state)
+	query.Set("nonce", nonce)
+	query.Set("code_challenge", codeChallenge)
+	query.Set("code_challenge_method", "S256")
+
+	separator := "?"
+	if strings.Contains(d.AuthorizationEndpoint, "?") {
+		separator = "&"
+	}
+	return d.AuthorizationEndpoint + separator + query.Encode(), nil
+}
+
+func (p *provider) Exchange(ctx context.Context, code, codeVerifier string) (*Token, error) {
+	d, err := p.getDiscovery(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	form := url.Values{}
+	form.Set("grant_type", "authorization_code")
+	form.Set("code", code)
+	form.Set("redirect_uri", p.config.RedirectURL)
+	form.Set("client_id", p.config.ClientID)
+	form.Set("code_verifier", codeVerifier)
+	if p.config.ClientSecret != "" {
+		form.Set("client_secret", p.config.ClientSecret)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, d.TokenEndpoint, strings.NewReader(form.Encode()))
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	req.Header.Set("Accept", "application/json")
+
+	res, err := p.client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer res.Body.Close()
+
+	body, err := ioutil.ReadAll(res.Body)
+	if err != nil {
+		return nil, err
+	}
+	if res.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("%w: %s %s", ErrExchangeFailed, res.Status, string(body))
+	}
+
+	token := new(Token)
+	if err := json.Unmarshal(body, token); err != nil {
+		return nil, err
+	}
+	if token.IDToken == "" {
+		return nil, ErrMissingIDToken
+	}
+	return token, nil
+}
+
+func (p *provider) VerifyIDToken(ctx context.Context, rawIDToken, nonce string) (*Claims, error) {
+	d, err := p.getDiscovery(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	parser := jwts.Parser{
+		ValidMethods:         []string{"RS256", "RS384", "RS512", "ES256", "ES384", "ES512"},
+		SkipClaimsValidation: true,
+	}
+	claims := jwts.MapClaims{}
+	if _, err := parser.ParseWithClaims(rawIDToken, claims, func(t *jwts.Token) (interface{}, error) {
+		kid, _ := t.Header["kid"].(string)
+		return p.getKey(ctx, d, kid)
+	}); err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidIDToken, err)
+	}
+
+	now := time.Now()
+	if !claims.VerifyIssuer(d.Issuer, true) {
+		return nil, fmt.Errorf("%w: unexpected issuer", ErrInvalidIDToken)
+	}
+	if !claims.VerifyAudience(p.config.ClientID, true) {
+		return nil, fmt.Errorf("%w: unexpected audience", ErrInvalidIDToken)
+	}
+	if !claims.VerifyExpiresAt(now.Add(-clockSkew).Unix(), true) {
+		return nil, fmt.Errorf("%w: token is expired", ErrInvalidIDToken)
+	}
+	if !claims.VerifyIssuedAt(now.Add(clockSkew).Unix(), false) {
+		return nil, fmt.Errorf("%w: token used before issued", ErrInvalidIDToken)
+	}
+	if tokenNonce, _ := claims["nonce"].(string); nonce != "" && tokenNonce != nonce {
+		return nil, fmt.Errorf("%w: unexpected nonce", ErrInvalidIDToken)
+	}
+
+	result := &Claims{Issuer: d.Issuer}
+	result.Subject, _ = claims["sub"].(string)
+	result.Email, _ = claims["email"].(string)
+	result.Name, _ = claims["name"].(string)
+	result.Picture, _ = claims["picture"].(string)
+	// apple sends email_verified as a string
+	switch v := claims["email_verified"].(type) {
+	case bool:
+		result.EmailVerified = v
+	case string:
+		result.EmailVerified = v == "true"
+	}
+	if result.Subject == "" {
+		return nil, fmt.Errorf("%w: missing subject", ErrInvalidIDToken)
+	}
+	return result, nil
+}
+
+// getDiscovery fetches and caches the discovery document of the issuer.
+func (p *provider) getDiscovery(ctx context.Context) (*discovery, error) {
+	p.mu.RLock()
+	d := p.discovery
+	p.mu.RUnlock()
+	if d != nil {
+		return d, nil
+	}
+
+	d = new(discovery)
+	if err := p.getJSON(ctx, strings.TrimSuffix(p.config.Issuer, "/")+discoveryPath, d); err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrDiscoveryFailed, err)
+	}
+	if strings.TrimSuffix(d.Issuer, "/") != strings.TrimSuffix(p.config.Issuer, "/") {
+		return nil, fmt.Errorf("%w: issuer %s doesn't match %s", ErrDiscoveryFailed, d.Issuer, p.config.Issuer)
+	}
+
+	p.mu.Lock()
+	p.discovery = d
+	p.mu.Unlock()
+	return d, nil
+}
+
+// getKey returns the verification key by kid, the JWKS is refetched when the kid is unknown
+// to follow the key rotation of the provider.
+func (p *provider) getKey(ctx context.Context, d *discovery, kid string) (interface{}, error) {
+	p.mu.RLock()
+	key, ok := p.keys[kid]
+	fetchedAt, fetchedKeys := p.keysAt, len(p.keys)
+	p.mu.RUnlock()
+	if ok {
+		return key, nil
+	}
+	if time.Since(fetchedAt) < jwksRefreshBackoff && fetchedKeys > 0 {
+		return nil, ErrUnknownKey
+	}
+
+	var set jwks
+	if err := p.getJSON(ctx, d.JwksURI, &set); err != nil {
+		return nil, err
+	}
+	keys, err := set.keys()
+	if err != nil {
+		return nil, err
+	}
+
+	p.mu.Lock()
+	p.keys = keys
+	p.keysAt = time.Now()
+	p.mu.Unlock()
+
+	if key, ok := keys[kid]; ok {
+		return key, nil
+	}
+	// a single key set may omit the kid
+	if kid == "" && len(keys) == 1 {
+		for _, key := range keys {
+			return key, nil
+		}
+	}
+	return nil, ErrUnknownKey
+}
+
+func (p *provider) getJSON(ctx context.Context, uri string, dest interface{}) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, uri, nil)
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Accept", "application/json")
+
+	res, err := p.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer res.Body.Close()
+
+	if res.StatusCode != http.StatusOK {
+		return fmt.Errorf("unexpected status %s from %s", res.Status, uri)
+	}
+	return json.NewDecoder(res.Body).Decode(dest)
+}
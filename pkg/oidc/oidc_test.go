@@ -0,0 +1,82 @@
+package oidc_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/radyatamaa/dating-apps-api/pkg/oidc"
+	"github.com/radyatamaa/dating-apps-api/pkg/oidc/oidctest"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestProvider_AuthorizationCodeFlow(t *testing.T) {
+	issuer := oidctest.NewIssuer()
+	defer issuer.Close()
+
+	provider, err := oidc.NewProvider(oidc.Config{
+		Name:        "fake",
+		Issuer:      issuer.URL,
+		ClientID:    "client-id",
+		RedirectURL: "http://localhost/api/v1/oauth/fake/callback",
+		Scopes:      []string{"email", "profile"},
+	})
+	assert.NoError(t, err)
+
+	state, _ := oidc.GenerateRandom()
+	nonce, _ := oidc.GenerateRandom()
+	verifier, _ := oidc.GenerateRandom()
+
+	authURL, err := provider.AuthCodeURL(context.TODO(), state, nonce, oidc.CodeChallengeS256(verifier))
+	assert.NoError(t, err)
+	assert.Contains(t, authURL, "code_challenge_method=S256")
+
+	code, gotState, err := issuer.Authorize(authURL)
+	assert.NoError(t, err)
+	assert.Equal(t, state, gotState)
+
+	t.Run("invalid code verifier", func(t *testing.T) {
+		_, err := provider.Exchange(context.TODO(), code, "wrong")
+		assert.True(t, errors.Is(err, oidc.ErrExchangeFailed))
+	})
+
+	code, _, err = issuer.Authorize(authURL)
+	assert.NoError(t, err)
+	token, err := provider.Exchange(context.TODO(), code, verifier)
+	assert.NoError(t, err)
+
+	t.Run("invalid nonce", func(t *testing.T) {
+		_, err := provider.VerifyIDToken(context.TODO(), token.IDToken, "other")
+		assert.True(t, errors.Is(err, oidc.ErrInvalidIDToken))
+	})
+
+	t.Run("invalid audience", func(t *testing.T) {
+		other, _ := oidc.NewProvider(oidc.Config{Name: "other", Issuer: issuer.URL, ClientID: "other"})
+		_, err := other.VerifyIDToken(context.TODO(), token.IDToken, nonce)
+		assert.True(t, errors.Is(err, oidc.ErrInvalidIDToken))
+	})
+
+	t.Run("success", func(t *testing.T) {
+		claims, err := provider.VerifyIDToken(context.TODO(), token.IDToken, nonce)
+		assert.NoError(t, err)
+		assert.Equal(t, "1234567890", claims.Subject)
+		assert.Equal(t, "john@mail.com", claims.Email)
+		assert.True(t, claims.EmailVerified)
+	})
+}
+
+func TestRegistry_Get(t *testing.T) {
+	provider, err := oidc.NewProvider(oidc.Config{Name: "google", Issuer: "https://accounts.google.com", ClientID: "id"})
+	assert.NoError(t, err)
+	registry := oidc.Registry{"google": provider}
+
+	got, err := registry.Get("Google")
+	assert.NoError(t, err)
+	assert.Equal(t, "google", got.Name())
+
+	_, err = registry.Get("apple")
+	assert.Equal(t, oidc.ErrProviderNotFound, err)
+
+	_, err = oidc.NewProvider(oidc.Config{Name: "google"})
+	assert.Equal(t, oidc.ErrInvalidConfig, err)
+}
@@ -0,0 +1,30 @@
+// Package requestinfo carries the info of a request in its context whatever its transport,
+// it's set by the http middleware and the grpc interceptor of the request id.
+package requestinfo
+
+import "context"
+
+type contextKey struct{}
+
+// Info of a request.
+type Info struct {
+	// ID of the request, ex: the X-Request-ID header
+	ID string
+	// IP of the client
+	IP string
+	// Host the request was sent to, ex: the host header or the grpc authority
+	Host string
+	// Scheme of the request, ex: https behind a proxy setting X-Forwarded-Proto
+	Scheme string
+}
+
+// NewContext returns a copy of ctx carrying info.
+func NewContext(ctx context.Context, info Info) context.Context {
+	return context.WithValue(ctx, contextKey{}, info)
+}
+
+// FromContext returns the info carried by ctx, the zero Info without info.
+func FromContext(ctx context.Context) Info {
+	info, _ := ctx.Value(contextKey{}).(Info)
+	return info
+}
@@ -0,0 +1,138 @@
+package response
+
+import (
+	"context"
+	"errors"
+	"sync"
+
+	"github.com/beego/i18n"
+	"github.com/radyatamaa/dating-apps-api/pkg/authuser"
+	"github.com/radyatamaa/dating-apps-api/pkg/helper"
+	"gorm.io/gorm"
+)
+
+// Error is a domain error returned by the usecases, it carries the api response of the error:
+// the error code, the http status, the i18n key of the message and the errors of the fields.
+type Error struct {
+	// Code of the response, ex: ERROR-API-029
+	Code string
+	// HTTPStatus of the response
+	HTTPStatus int
+	// Key is the i18n key of the message, ex: message.errorLimitSwipeOrLike
+	Key string
+	// Fields are the errors of the fields of the response
+	Fields []Errors
+
+	text string
+	err  error
+}
+
+// registeredError maps the errors matching target to a domain error.
+type registeredError struct {
+	target    error
+	domainErr *Error
+}
+
+var (
+	registryMu sync.RWMutex
+	// errorsByCode are the domain errors by code, the first error of a code is kept.
+	errorsByCode = map[string]*Error{}
+	// registry are the domain errors of the errors of the other packages, the first matching error is used.
+	registry = []registeredError{
+		{context.DeadlineExceeded, ErrRequestTimeout},
+		{gorm.ErrRecordNotFound, ErrDataNotFound},
+		{authuser.ErrUnauthenticated, ErrUnauthorized},
+		{helper.ErrInvalidFormatJpeg, ErrInvalidFormatJpeg},
+	}
+)
+
+// NewError returns a domain error, text is the message of Error().
+// The error is the domain error of code for ErrorCodeText unless code already has one.
+func NewError(code string, httpStatus int, key, text string) *Error {
+	domainErr := &Error{Code: code, HTTPStatus: httpStatus, Key: key, text: text}
+
+	registryMu.Lock()
+	defer registryMu.Unlock()
+	if _, ok := errorsByCode[code]; !ok {
+		errorsByCode[code] = domainErr
+	}
+	return domainErr
+}
+
+func (e *Error) Error() string {
+	if e.err != nil {
+		return e.text + ": " + e.err.Error()
+	}
+	return e.text
+}
+
+func (e *Error) Unwrap() error {
+	return e.err
+}
+
+// Is reports whether target is a domain error with the same code,
+// the copies of Wrap and WithFields match their domain error.
+func (e *Error) Is(target error) bool {
+	t, ok := target.(*Error)
+	return ok && t.Code == e.Code
+}
+
+// Wrap returns a copy of the domain error caused by err.
+func (e *Error) Wrap(err error) *Error {
+	wrapped := *e
+	wrapped.err = err
+	return &wrapped
+}
+
+// WithFields returns a copy of the domain error with the errors of fields.
+func (e *Error) WithFields(fields ...Errors) *Error {
+	withFields := *e
+	withFields.Fields = append(append([]Errors(nil), e.Fields...), fields...)
+	return &withFields
+}
+
+// Message returns the translated message of the domain error.
+func (e *Error) Message(locale string) string {
+	return i18n.Tr(locale, e.Key)
+}
+
+// RegisterError maps the errors matching target with errors.Is to domainErr,
+// ex: the sentinel errors of the other packages.
+func RegisterError(target error, domainErr *Error) {
+	registryMu.Lock()
+	defer registryMu.Unlock()
+	registry = append(registry, registeredError{target, domainErr})
+}
+
+// errorByCode returns the domain error of code.
+func errorByCode(code string) (*Error, bool) {
+	registryMu.RLock()
+	defer registryMu.RUnlock()
+	domainErr, ok := errorsByCode[code]
+	return domainErr, ok
+}
+
+// LookupError returns the domain error of err: err itself when it wraps a domain error,
+// ErrValidation for the validation errors and the registered domain error otherwise.
+// It returns false when err isn't mapped.
+func LookupError(err error) (*Error, bool) {
+	if err == nil {
+		return nil, false
+	}
+	var domainErr *Error
+	if errors.As(err, &domainErr) {
+		return domainErr, true
+	}
+	if isValidationError(err) {
+		return ErrValidation, true
+	}
+
+	registryMu.RLock()
+	defer registryMu.RUnlock()
+	for _, registered := range registry {
+		if errors.Is(err, registered.target) {
+			return registered.domainErr, true
+		}
+	}
+	return nil, false
+}
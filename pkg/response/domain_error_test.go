@@ -0,0 +1,69 @@
+package response
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"testing"
+
+	validatorGo "github.com/go-playground/validator/v10"
+	"github.com/radyatamaa/dating-apps-api/pkg/authuser"
+	"github.com/stretchr/testify/assert"
+	"gorm.io/gorm"
+)
+
+func TestLookupError(t *testing.T) {
+	errCustom := errors.New("custom")
+	errDomain := NewError("ERROR-TEST-001", http.StatusConflict, "message.errorTest", "test")
+	RegisterError(errCustom, errDomain)
+
+	tests := []struct {
+		name   string
+		err    error
+		want   *Error
+		wantOk bool
+	}{
+		{name: "domain error", err: ErrMatchNotFound, want: ErrMatchNotFound, wantOk: true},
+		{name: "wrapped domain error", err: fmt.Errorf("swipe: %w", ErrLimitSwipeOrLike), want: ErrLimitSwipeOrLike, wantOk: true},
+		{name: "deadline exceeded", err: fmt.Errorf("query: %w", context.DeadlineExceeded), want: ErrRequestTimeout, wantOk: true},
+		{name: "record not found", err: gorm.ErrRecordNotFound, want: ErrDataNotFound, wantOk: true},
+		{name: "unauthenticated", err: authuser.ErrUnauthenticated, want: ErrUnauthorized, wantOk: true},
+		{name: "validation", err: validatorGo.ValidationErrors{}, want: ErrValidation, wantOk: true},
+		{name: "registered", err: errCustom, want: errDomain, wantOk: true},
+		{name: "unmapped", err: errors.New("connection refused")},
+		{name: "nil"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, ok := LookupError(tt.err)
+			assert.Equal(t, tt.wantOk, ok)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestError(t *testing.T) {
+	cause := errors.New("connection refused")
+	wrapped := ErrDataExportNotReady.Wrap(cause)
+	assert.ErrorIs(t, wrapped, ErrDataExportNotReady)
+	assert.ErrorIs(t, wrapped, cause)
+	assert.Equal(t, "data export is not ready or expired: connection refused", wrapped.Error())
+	assert.Equal(t, "data export is not ready or expired", ErrDataExportNotReady.Error())
+
+	withFields := ErrValidation.WithFields(Errors{Field: "age", Description: "age is invalid"})
+	assert.ErrorIs(t, withFields, ErrValidation)
+	assert.Equal(t, []Errors{{Field: "age", Description: "age is invalid"}}, withFields.Fields)
+	assert.Empty(t, ErrValidation.Fields)
+	assert.NotErrorIs(t, withFields, ErrMatchNotFound)
+}
+
+func TestErrorCodeText(t *testing.T) {
+	assert.Equal(t, ErrTooManyRequests.Message("en"), ErrorCodeText(TooManyRequestsCodeError, "en"))
+	assert.Equal(t, ErrMatchNotFound.Message("en"), ErrorCodeText(MatchNotFoundErrorCode, "en"))
+	assert.Empty(t, ErrorCodeText("ERROR-TEST-404", "en"))
+
+	// a code keeps its first domain error
+	NewError(MatchNotFoundErrorCode, http.StatusNotFound, "message.errorOther", "other")
+	assert.Equal(t, ErrMatchNotFound.Message("en"), ErrorCodeText(MatchNotFoundErrorCode, "en"))
+}
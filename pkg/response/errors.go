@@ -1,7 +1,7 @@
 package response
 
 import (
-	"errors"
+	"net/http"
 
 	"github.com/beego/i18n"
 )
@@ -20,122 +20,98 @@ Format : Format XXXX-XXX-XXX
 */
 
 const (
-	ApiKeyNotRegisteredCodeError    = "ERROR-AUTH-001"
-	MissingApiKeyCodeError          = "ERROR-AUTH-002"
-	InvalidApiKeyCodeError          = "ERROR-AUTH-003"
-	UnauthorizedCodeError           = "ERROR-AUTH-004"
-	RequestForbiddenCodeError       = "ERROR-API-001"
-	ResourceNotFoundCodeError       = "ERROR-API-002"
-	RequestTimeoutCodeError         = "ERROR-API-003"
-	ApiValidationCodeError          = "ERROR-API-004"
-	DataNotFoundCodeError           = "ERROR-API-005"
-	InvalidCredentialCodeError      = "ERROR-API-007"
-	InvalidTokenCodeError           = "ERROR-API-008"
-	ExpiredTokenCodeError           = "ERROR-API-009"
-	MissingTokenCodeError           = "ERROR-API-010"
-	AuthElseWhereCodeError          = "ERROR-API-011"
-	NotAllowedTransaction           = "ERROR-API-012"
-	TransactionAlreadyExist         = "ERROR-API-013"
-	TransactionRejected             = "ERROR-API-014"
-	TransactionNotFound             = "ERROR-API-015"
-	InsufficientLimit               = "ERROR-API-016"
-	InvalidReturnAmount             = "ERROR-API-017"
-	DataAlreadyExistCodeError       = "ERROR-API-018"
-	InvalidMinMax                   = "ERROR-API-019"
-	InvalidActiveDate               = "ERROR-API-020"
-	CustomerStatusNotFoundErrorCode = "ERROR-API-021"
-	LimitStatusNotFoundErrorCode    = "ERROR-API-022"
-	CustomerIDNotFoundErrorCode     = "ERROR-API-023"
-	TenorIDNotFoundErrorCode        = "ERROR-API-024"
-	InvalidActiveEndDate            = "ERROR-API-025"
-	QueryParamInvalidCode           = "ERROR-API-026"
-	PathParamInvalidCode            = "ERROR-API-027"
-	ServerErrorCode                 = "ERROR-API-999"
-	InvalidEmailPasswordErrorCode   = "ERROR-API-028"
-	LimitSwipeOrLikeErrorCode       = "ERROR-API-029"
-	InvalidFormatJpegErrorCode 		= "ERROR-API-030"
+	ApiKeyNotRegisteredCodeError     = "ERROR-AUTH-001"
+	MissingApiKeyCodeError           = "ERROR-AUTH-002"
+	InvalidApiKeyCodeError           = "ERROR-AUTH-003"
+	UnauthorizedCodeError            = "ERROR-AUTH-004"
+	RequestForbiddenCodeError        = "ERROR-API-001"
+	ResourceNotFoundCodeError        = "ERROR-API-002"
+	RequestTimeoutCodeError          = "ERROR-API-003"
+	ApiValidationCodeError           = "ERROR-API-004"
+	DataNotFoundCodeError            = "ERROR-API-005"
+	InvalidCredentialCodeError       = "ERROR-API-007"
+	InvalidTokenCodeError            = "ERROR-API-008"
+	ExpiredTokenCodeError            = "ERROR-API-009"
+	MissingTokenCodeError            = "ERROR-API-010"
+	AuthElseWhereCodeError           = "ERROR-API-011"
+	DataAlreadyExistCodeError        = "ERROR-API-018"
+	QueryParamInvalidCode            = "ERROR-API-026"
+	PathParamInvalidCode             = "ERROR-API-027"
+	ServerErrorCode                  = "ERROR-API-999"
+	InvalidEmailPasswordErrorCode    = "ERROR-API-028"
+	LimitSwipeOrLikeErrorCode        = "ERROR-API-029"
+	InvalidFormatJpegErrorCode       = "ERROR-API-030"
+	TooManyRequestsCodeError         = "ERROR-API-031"
+	InvalidTwoFactorCodeErrorCode    = "ERROR-API-032"
+	TwoFactorNotEnrolledErrorCode    = "ERROR-API-033"
+	TwoFactorAlreadyEnabledErrorCode = "ERROR-API-034"
+	InvalidChallengeTokenErrorCode   = "ERROR-API-035"
+	OAuthProviderNotFoundErrorCode   = "ERROR-API-036"
+	InvalidOAuthStateErrorCode       = "ERROR-API-037"
+	OAuthLoginFailedErrorCode        = "ERROR-API-038"
+	OAuthEmailNotVerifiedErrorCode   = "ERROR-API-039"
+	DataExportNotReadyErrorCode      = "ERROR-API-040"
+	CannotBlockSelfErrorCode         = "ERROR-API-041"
+	MatchNotFoundErrorCode           = "ERROR-API-042"
+	AccountSuspendedErrorCode        = "ERROR-API-043"
+	AccountBannedErrorCode           = "ERROR-API-044"
+	CannotReportSelfErrorCode        = "ERROR-API-045"
 )
 
 var (
 	//query param invalid
-	ErrQueryParamInvalid = errors.New("query param is invalid")
+	ErrQueryParamInvalid = NewError(QueryParamInvalidCode, http.StatusBadRequest, "message.errorQueryParamInvalid", "query param is invalid")
 
+	ErrRequestTimeout    = NewError(RequestTimeoutCodeError, http.StatusRequestTimeout, "message.errorRequestTimeout", "request timeout")
+	ErrDataNotFound      = NewError(DataNotFoundCodeError, http.StatusBadRequest, "message.errorDataNotFound", "data not found")
+	ErrUnauthorized      = NewError(UnauthorizedCodeError, http.StatusUnauthorized, "message.errorUnauthorized", "unauthorized")
+	ErrValidation        = NewError(ApiValidationCodeError, http.StatusBadRequest, "message.errorValidation", "request is invalid")
+	ErrInvalidFormatJpeg = NewError(InvalidFormatJpegErrorCode, http.StatusBadRequest, "message.errorInvalidFormatJpeg", "format must be JPEG image")
+	ErrServerError       = NewError(ServerErrorCode, http.StatusInternalServerError, "message.errorServerError", "internal server error")
 
-	ErrInvalidEmailPassword     = errors.New("invalid Email and Password")
-	ErrLimitSwipeOrLike = errors.New("max swipe or like is 10 you couldn't continue , please purchase premium for unlimited swip and like")
+	ErrInvalidEmailPassword = NewError(InvalidEmailPasswordErrorCode, http.StatusBadRequest, "message.errorInvalidEmailPassword", "invalid Email and Password")
+	ErrLimitSwipeOrLike     = NewError(LimitSwipeOrLikeErrorCode, http.StatusBadRequest, "message.errorLimitSwipeOrLike", "max swipe or like is 10 you couldn't continue , please purchase premium for unlimited swip and like")
+
+	ErrInvalidTwoFactorCode    = NewError(InvalidTwoFactorCodeErrorCode, http.StatusBadRequest, "message.errorInvalidTwoFactorCode", "invalid two factor authentication code")
+	ErrTwoFactorNotEnrolled    = NewError(TwoFactorNotEnrolledErrorCode, http.StatusBadRequest, "message.errorTwoFactorNotEnrolled", "two factor authentication is not enrolled")
+	ErrTwoFactorAlreadyEnabled = NewError(TwoFactorAlreadyEnabledErrorCode, http.StatusBadRequest, "message.errorTwoFactorAlreadyEnabled", "two factor authentication is already enabled")
+	ErrInvalidChallengeToken   = NewError(InvalidChallengeTokenErrorCode, http.StatusUnauthorized, "message.errorInvalidChallengeToken", "challenge token is invalid or expired")
+
+	ErrOAuthProviderNotFound = NewError(OAuthProviderNotFoundErrorCode, http.StatusNotFound, "message.errorOAuthProviderNotFound", "oauth provider not found")
+	ErrInvalidOAuthState     = NewError(InvalidOAuthStateErrorCode, http.StatusBadRequest, "message.errorInvalidOAuthState", "oauth state is invalid or expired")
+	ErrOAuthLoginFailed      = NewError(OAuthLoginFailedErrorCode, http.StatusUnauthorized, "message.errorOAuthLoginFailed", "oauth login failed")
+	ErrOAuthEmailNotVerified = NewError(OAuthEmailNotVerifiedErrorCode, http.StatusBadRequest, "message.errorOAuthEmailNotVerified", "oauth email is not verified")
+
+	ErrDataExportNotReady = NewError(DataExportNotReadyErrorCode, http.StatusBadRequest, "message.errorDataExportNotReady", "data export is not ready or expired")
+
+	ErrCannotBlockSelf = NewError(CannotBlockSelfErrorCode, http.StatusBadRequest, "message.errorCannotBlockSelf", "can't block your own profile")
+	ErrMatchNotFound   = NewError(MatchNotFoundErrorCode, http.StatusBadRequest, "message.errorMatchNotFound", "match not found")
+
+	ErrAccountSuspended = NewError(AccountSuspendedErrorCode, http.StatusForbidden, "message.errorAccountSuspended", "account is suspended")
+	ErrAccountBanned    = NewError(AccountBannedErrorCode, http.StatusForbidden, "message.errorAccountBanned", "account is banned")
+	ErrCannotReportSelf = NewError(CannotReportSelfErrorCode, http.StatusBadRequest, "message.errorCannotReportSelf", "can't report your own profile")
+	ErrRequestForbidden = NewError(RequestForbiddenCodeError, http.StatusForbidden, "message.errorRequestForbidden", "request forbidden")
+
+	ErrApiKeyNotRegistered = NewError(ApiKeyNotRegisteredCodeError, http.StatusUnauthorized, "message.errorApiKeyNotRegistered", "api key is not registered")
+	ErrMissingApiKey       = NewError(MissingApiKeyCodeError, http.StatusUnauthorized, "message.errorMissingApiKey", "api key is missing")
+	ErrInvalidApiKey       = NewError(InvalidApiKeyCodeError, http.StatusUnauthorized, "message.errorInvalidApiKey", "api key is invalid")
+	ErrInvalidCredential   = NewError(InvalidCredentialCodeError, http.StatusUnauthorized, "message.errorInvalidCredential", "invalid credential")
+	ErrInvalidToken        = NewError(InvalidTokenCodeError, http.StatusUnauthorized, "message.errorInvalidToken", "token is invalid")
+	ErrExpiredToken        = NewError(ExpiredTokenCodeError, http.StatusUnauthorized, "message.errorExpiredToken", "token is expired")
+	ErrMissingToken        = NewError(MissingTokenCodeError, http.StatusUnauthorized, "message.errorMissingToken", "token is missing")
+	ErrAuthElseWhere       = NewError(AuthElseWhereCodeError, http.StatusUnauthorized, "message.errorAuthElseWhere", "logged in elsewhere")
+
+	ErrResourceNotFound = NewError(ResourceNotFoundCodeError, http.StatusNotFound, "message.errorResourceNotFound", "resource not found")
+	ErrDataAlreadyExist = NewError(DataAlreadyExistCodeError, http.StatusConflict, "message.errorDataAlreadyExist", "data already exist")
+	ErrPathParamInvalid = NewError(PathParamInvalidCode, http.StatusBadRequest, "message.errorPathParamInvalid", "path param is invalid")
+	ErrTooManyRequests  = NewError(TooManyRequestsCodeError, http.StatusTooManyRequests, "message.errorTooManyRequests", "too many requests")
 )
 
+// ErrorCodeText returns the translated message of the domain error of code, empty when code isn't a domain error.
 func ErrorCodeText(code, locale string, args ...interface{}) string {
-	switch code {
-	case ApiKeyNotRegisteredCodeError:
-		return i18n.Tr(locale, "message.errorApiKeyNotRegistered", args)
-	case MissingApiKeyCodeError:
-		return i18n.Tr(locale, "message.errorMissingApiKey", args)
-	case ApiValidationCodeError:
-		return i18n.Tr(locale, "message.errorValidation", args)
-	case InvalidApiKeyCodeError:
-		return i18n.Tr(locale, "message.errorInvalidApiKey", args)
-	case UnauthorizedCodeError:
-		return i18n.Tr(locale, "message.errorUnauthorized", args)
-	case RequestForbiddenCodeError:
-		return i18n.Tr(locale, "message.errorRequestForbidden", args)
-	case ResourceNotFoundCodeError:
-		return i18n.Tr(locale, "message.errorResourceNotFound", args)
-	case ServerErrorCode:
-		return i18n.Tr(locale, "message.errorServerError", args)
-	case RequestTimeoutCodeError:
-		return i18n.Tr(locale, "message.errorRequestTimeout", args)
-	case InvalidCredentialCodeError:
-		return i18n.Tr(locale, "message.errorInvalidCredential", args)
-	case DataNotFoundCodeError:
-		return i18n.Tr(locale, "message.errorDataNotFound", args)
-	case InvalidTokenCodeError:
-		return i18n.Tr(locale, "message.errorInvalidToken", args)
-	case ExpiredTokenCodeError:
-		return i18n.Tr(locale, "message.errorExpiredToken", args)
-	case MissingTokenCodeError:
-		return i18n.Tr(locale, "message.errorMissingToken", args)
-	case AuthElseWhereCodeError:
-		return i18n.Tr(locale, "message.errorAuthElseWhere", args)
-	case NotAllowedTransaction:
-		return i18n.Tr(locale, "message.errorNotAllowedTransaction", args)
-	case TransactionAlreadyExist:
-		return i18n.Tr(locale, "message.errorTransactionAlreadyExist", args)
-	case TransactionRejected:
-		return i18n.Tr(locale, "message.errorTransactionRejected", args)
-	case TransactionNotFound:
-		return i18n.Tr(locale, "message.errorTransactionNotFound", args)
-	case InsufficientLimit:
-		return i18n.Tr(locale, "message.errorInsufficientLimit", args)
-	case InvalidReturnAmount:
-		return i18n.Tr(locale, "message.errorInvalidReturnAmount", args)
-	case DataAlreadyExistCodeError:
-		return i18n.Tr(locale, "message.errorDataAlreadyExist", args)
-	case InvalidMinMax:
-		return i18n.Tr(locale, "message.errorInvalidMinMax", args)
-	case InvalidActiveDate:
-		return i18n.Tr(locale, "message.errorActiveMoreThanExpired", args)
-	case InvalidActiveEndDate:
-		return i18n.Tr(locale, "message.errorActiveMoreThanEnd", args)
-	case CustomerStatusNotFoundErrorCode:
-		return i18n.Tr(locale, "message.errorCustomerStatusNotFound", args)
-	case LimitStatusNotFoundErrorCode:
-		return i18n.Tr(locale, "message.errorLimitStatusNotFound", args)
-	case CustomerIDNotFoundErrorCode:
-		return i18n.Tr(locale, "message.errorCustomerIDNotFound", args)
-	case TenorIDNotFoundErrorCode:
-		return i18n.Tr(locale, "message.errorTenorIDNotFound", args)
-	case QueryParamInvalidCode:
-		return i18n.Tr(locale, "message.errorQueryParamInvalid", args)
-	case PathParamInvalidCode:
-		return i18n.Tr(locale, "message.errorPathParamInvalid", args)
-	case InvalidEmailPasswordErrorCode:
-		return i18n.Tr(locale, "message.errorInvalidEmailPassword", args)
-	case LimitSwipeOrLikeErrorCode:
-		return i18n.Tr(locale, "message.errorLimitSwipeOrLike", args)
-	case InvalidFormatJpegErrorCode:
-		return i18n.Tr(locale, "message.errorInvalidFormatJpeg", args)
-	default:
+	domainErr, ok := errorByCode(code)
+	if !ok {
 		return ""
 	}
+	return i18n.Tr(locale, domainErr.Key, args)
 }
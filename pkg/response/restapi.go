@@ -6,6 +6,8 @@ import (
 	"fmt"
 	"io"
 	"net/http"
+	"runtime"
+	"runtime/debug"
 	"time"
 
 	"github.com/beego/i18n"
@@ -14,6 +16,7 @@ import (
 	"github.com/beego/beego/v2/server/web/context"
 	validatorGo "github.com/go-playground/validator/v10"
 	"github.com/radyatamaa/dating-apps-api/pkg/validator"
+	"github.com/radyatamaa/dating-apps-api/pkg/zaplogger"
 )
 
 type (
@@ -56,6 +59,7 @@ func (r ApiResponse) ResponseError(ctx *context.Context, httpStatus int, errorCo
 	var errorValidations []Errors = nil
 
 	ctx.Output.SetStatus(httpStatus)
+	ctx.Input.SetData(zaplogger.ErrorCodeKey, errorCode)
 
 	if err != nil {
 		if ctx.Input.RequestBody != nil {
@@ -94,6 +98,11 @@ func (r ApiResponse) ResponseError(ctx *context.Context, httpStatus int, errorCo
 		}
 	}
 
+	// the fields of a domain error, ex: the errors of the fields returned by a usecase
+	if domainErr, ok := LookupError(err); ok && len(domainErr.Fields) > 0 {
+		errorValidations = append(errorValidations, domainErr.Fields...)
+	}
+
 	apiResponse.RequestId = ctx.ResponseWriter.ResponseWriter.Header().Get("X-REQUEST-ID")
 	apiResponse.Code = errorCode
 	apiResponse.Message = message
@@ -103,6 +112,46 @@ func (r ApiResponse) ResponseError(ctx *context.Context, httpStatus int, errorCo
 	return ctx.Output.JSON(apiResponse, beego.BConfig.RunMode != "prod", false)
 }
 
+// Fail responds the domain error of err with its message in lang, see LookupError.
+// The unmapped errors are responded as a server error and logged with their stack by the access log.
+func Fail(ctx *context.Context, lang string, err error) error {
+	domainErr, ok := LookupError(err)
+	if !ok {
+		domainErr = ErrServerError
+		setUnmappedStackTrace(ctx, err)
+	}
+	return ApiResponse{}.ResponseError(ctx, domainErr.HTTPStatus, domainErr.Code, domainErr.Message(lang), err)
+}
+
+// setUnmappedStackTrace sets the stack of the unmapped error in the stack trace of the request,
+// the stack trace set by the usecase is kept with the location of the error.
+func setUnmappedStackTrace(ctx *context.Context, err error) {
+	stackTrace, _ := ctx.Input.GetData("stackTrace").(*zaplogger.ListErrors)
+	if stackTrace == nil {
+		stackTrace = zaplogger.StackTraceFromContext(ctx.Request.Context())
+	}
+	if stackTrace == nil {
+		stackTrace = &zaplogger.ListErrors{Error: err.Error()}
+		// the caller of Fail, ex: BaseController.Fail
+		if function, file, line, ok := runtime.Caller(2); ok {
+			stackTrace.File = file
+			stackTrace.Function = runtime.FuncForPC(function).Name()
+			stackTrace.Line = line
+		}
+		ctx.Input.SetData("stackTrace", stackTrace)
+	}
+	if stackTrace.Extra == nil {
+		stackTrace.Extra = string(debug.Stack())
+	}
+}
+
+// isValidationError reports whether err is an error of the request validation.
+func isValidationError(err error) bool {
+	var validationErrors validatorGo.ValidationErrors
+	var dynamicStructError *validator.ValidateDynamicStructError
+	return errors.As(err, &validationErrors) || errors.As(err, &dynamicStructError) || len(checkJsonRequest(err)) > 0
+}
+
 // checkJsonRequest Response API
 func checkJsonRequest(err error) (response []Errors) {
 	var syntaxError *json.SyntaxError
@@ -0,0 +1,83 @@
+package scheduler
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/gomodule/redigo/redis"
+	"github.com/radyatamaa/dating-apps-api/pkg/atomiccache"
+)
+
+type (
+	// Locker acquires a key for ttl, a key is acquired once until it expires.
+	Locker interface {
+		Acquire(ctx context.Context, key string, ttl time.Duration) (bool, error)
+	}
+
+	// MemoryLocker locks the jobs of the process only, ex: a single replica or the tests.
+	MemoryLocker struct {
+		mu   sync.Mutex
+		keys map[string]time.Time
+	}
+
+	// RedisLocker locks the jobs between the replicas sharing the redis.
+	RedisLocker struct {
+		pool *redis.Pool
+	}
+)
+
+// NewMemoryLocker returns a MemoryLocker without keys.
+func NewMemoryLocker() *MemoryLocker {
+	return &MemoryLocker{
+		keys: map[string]time.Time{},
+	}
+}
+
+// Acquire implements Locker.
+func (l *MemoryLocker) Acquire(ctx context.Context, key string, ttl time.Duration) (bool, error) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	now := time.Now()
+	for k, expireAt := range l.keys {
+		if !expireAt.After(now) {
+			delete(l.keys, k)
+		}
+	}
+	if _, ok := l.keys[key]; ok {
+		return false, nil
+	}
+	l.keys[key] = now.Add(ttl)
+	return true, nil
+}
+
+// NewRedisLocker returns the RedisLocker of the connection config of the beego redis cache.
+func NewRedisLocker(connConfig string) (*RedisLocker, error) {
+	pool, err := atomiccache.NewRedisPool(connConfig)
+	if err != nil {
+		return nil, fmt.Errorf("scheduler: %w", err)
+	}
+	return &RedisLocker{pool: pool}, nil
+}
+
+// Acquire implements Locker with SET NX, the key expires after ttl.
+func (l *RedisLocker) Acquire(ctx context.Context, key string, ttl time.Duration) (bool, error) {
+	conn := l.pool.Get()
+	defer conn.Close()
+
+	_, err := redis.String(conn.Do("SET", key, "1", "NX", "PX", ttl.Milliseconds()))
+	if err == redis.ErrNil {
+		return false, nil
+	}
+	if err != nil {
+		return false, err
+	}
+	return true, nil
+}
+
+// Close closes the connections of the locker.
+func (l *RedisLocker) Close() error {
+	return l.pool.Close()
+}
@@ -0,0 +1,220 @@
+// Package scheduler runs the background jobs on cron schedules, a run of a job is locked
+// through the Locker so only one replica runs it, and a failed run is retried with backoff.
+package scheduler
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/radyatamaa/dating-apps-api/pkg/metrics"
+	"github.com/radyatamaa/dating-apps-api/pkg/tracing"
+	"github.com/radyatamaa/dating-apps-api/pkg/zaplogger"
+	"github.com/robfig/cron/v3"
+)
+
+const (
+	DefaultTimeout = 5 * time.Minute
+	DefaultBackoff = time.Second
+	maxBackoff     = time.Minute
+	lockPrefix     = "scheduler:"
+)
+
+// ErrJobNotFound is returned by RunNow for a job which isn't registered.
+var ErrJobNotFound = errors.New("scheduler: job not found")
+
+var parser = cron.NewParser(cron.Minute | cron.Hour | cron.Dom | cron.Month | cron.Dow | cron.Descriptor)
+
+type (
+	// Job is a periodic work, Run must be idempotent as a run can be retried.
+	Job struct {
+		Name string
+		// Schedule is a cron expression with 5 fields, ex: */5 * * * *, or a descriptor, ex: @hourly, @every 10s
+		Schedule string
+		// Timeout of a run, the run of a schedule is locked for Timeout so it should be shorter than the schedule interval
+		Timeout time.Duration
+		// Retries of a failed run, the first retry waits Backoff and the next ones double it
+		Retries int
+		Backoff time.Duration
+		Run     func(ctx context.Context) error
+	}
+
+	// Scheduler runs the registered jobs until Stop.
+	Scheduler struct {
+		locker    Locker
+		zapLogger zaplogger.Logger
+		jobs      []scheduledJob
+		cancel    context.CancelFunc
+		wg        sync.WaitGroup
+	}
+
+	scheduledJob struct {
+		Job
+		schedule cron.Schedule
+	}
+)
+
+// ParseSchedule validates the schedule of a Job.
+func ParseSchedule(spec string) error {
+	_, err := parseSchedule(spec)
+	return err
+}
+
+func parseSchedule(spec string) (cron.Schedule, error) {
+	schedule, err := parser.Parse(spec)
+	if err != nil {
+		return nil, fmt.Errorf("scheduler: invalid schedule %q: %w", spec, err)
+	}
+	return schedule, nil
+}
+
+// New returns a Scheduler without jobs.
+func New(locker Locker, zapLogger zaplogger.Logger) *Scheduler {
+	return &Scheduler{
+		locker:    locker,
+		zapLogger: zapLogger,
+	}
+}
+
+// Register adds the job, the jobs are registered before Start.
+func (s *Scheduler) Register(job Job) error {
+	if job.Name == "" || job.Run == nil {
+		return errors.New("scheduler: job without name or run")
+	}
+	for i := range s.jobs {
+		if s.jobs[i].Name == job.Name {
+			return fmt.Errorf("scheduler: job %s already registered", job.Name)
+		}
+	}
+	schedule, err := parseSchedule(job.Schedule)
+	if err != nil {
+		return err
+	}
+	if job.Timeout <= 0 {
+		job.Timeout = DefaultTimeout
+	}
+	if job.Backoff <= 0 {
+		job.Backoff = DefaultBackoff
+	}
+	s.jobs = append(s.jobs, scheduledJob{Job: job, schedule: schedule})
+	return nil
+}
+
+// Jobs returns the names of the registered jobs.
+func (s *Scheduler) Jobs() []string {
+	names := make([]string, 0, len(s.jobs))
+	for i := range s.jobs {
+		names = append(names, s.jobs[i].Name)
+	}
+	return names
+}
+
+// Start runs each job on its schedule in the background, the runs of a job never overlap in the process.
+func (s *Scheduler) Start() {
+	ctx, cancel := context.WithCancel(context.Background())
+	s.cancel = cancel
+
+	for i := range s.jobs {
+		job := s.jobs[i]
+		s.wg.Add(1)
+		go func() {
+			defer s.wg.Done()
+			s.loop(ctx, job)
+		}()
+	}
+}
+
+// Stop stops the schedules and waits for the running jobs, their context is canceled.
+func (s *Scheduler) Stop() {
+	if s.cancel != nil {
+		s.cancel()
+	}
+	s.wg.Wait()
+}
+
+// RunNow runs the job once without its lock, ex: from the worker command.
+func (s *Scheduler) RunNow(ctx context.Context, name string) error {
+	for i := range s.jobs {
+		if s.jobs[i].Name == name {
+			return s.run(ctx, s.jobs[i])
+		}
+	}
+	return ErrJobNotFound
+}
+
+func (s *Scheduler) loop(ctx context.Context, job scheduledJob) {
+	for {
+		next := nextRun(job.schedule, time.Now())
+		timer := time.NewTimer(time.Until(next))
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return
+		case <-timer.C:
+		}
+
+		// the lock of the scheduled time is taken by the first replica and expires after the timeout
+		acquired, err := s.locker.Acquire(ctx, lockPrefix+job.Name+":"+strconv.FormatInt(next.Unix(), 10), job.Timeout)
+		if err != nil {
+			metrics.JobRunsTotal.WithLabelValues(job.Name, "error").Inc()
+			s.zapLogger.Errorw("failed lock job", "job", job.Name, zaplogger.FieldError, err)
+			continue
+		}
+		if !acquired {
+			metrics.JobRunsTotal.WithLabelValues(job.Name, "skipped").Inc()
+			continue
+		}
+		if err := s.run(ctx, job); err != nil {
+			s.zapLogger.Errorw("failed run job", "job", job.Name, zaplogger.FieldError, err)
+		}
+	}
+}
+
+// nextRun returns the next time of the schedule, an @every schedule is aligned on the multiples
+// of its delay so the replicas agree on the times whenever they started.
+func nextRun(schedule cron.Schedule, now time.Time) time.Time {
+	if every, ok := schedule.(cron.ConstantDelaySchedule); ok {
+		return now.Truncate(every.Delay).Add(every.Delay)
+	}
+	return schedule.Next(now)
+}
+
+// run runs the job and retries it with an exponential backoff until it succeeds or its retries are exhausted.
+func (s *Scheduler) run(ctx context.Context, job scheduledJob) error {
+	ctx, cancel := context.WithTimeout(ctx, job.Timeout)
+	defer cancel()
+	ctx, span := tracing.Start(ctx, "scheduler."+job.Name)
+	defer span.End()
+
+	start := time.Now()
+	defer func() {
+		metrics.JobDuration.WithLabelValues(job.Name).Observe(time.Since(start).Seconds())
+	}()
+
+	backoff := job.Backoff
+	for attempt := 0; ; attempt++ {
+		err := job.Run(ctx)
+		if err == nil {
+			metrics.JobRunsTotal.WithLabelValues(job.Name, "success").Inc()
+			return nil
+		}
+		if attempt >= job.Retries {
+			metrics.JobRunsTotal.WithLabelValues(job.Name, "failed").Inc()
+			return err
+		}
+		s.zapLogger.Warnw("retry job", "job", job.Name, "attempt", attempt+1, zaplogger.FieldError, err)
+
+		select {
+		case <-ctx.Done():
+			metrics.JobRunsTotal.WithLabelValues(job.Name, "failed").Inc()
+			return err
+		case <-time.After(backoff):
+		}
+		if backoff *= 2; backoff > maxBackoff {
+			backoff = maxBackoff
+		}
+	}
+}
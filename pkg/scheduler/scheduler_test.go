@@ -0,0 +1,151 @@
+package scheduler
+
+import (
+	"context"
+	"errors"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/alicebob/miniredis/v2"
+	"github.com/golang/mock/gomock"
+	mockZaplogger "github.com/radyatamaa/dating-apps-api/pkg/zaplogger/mocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newLogger(t *testing.T) *mockZaplogger.MockLogger {
+	ctrl := gomock.NewController(t)
+	t.Cleanup(ctrl.Finish)
+	zapLogger := mockZaplogger.NewMockLogger(ctrl)
+	zapLogger.EXPECT().Warnw(gomock.Any(), gomock.Any()).AnyTimes()
+	zapLogger.EXPECT().Errorw(gomock.Any(), gomock.Any()).AnyTimes()
+	return zapLogger
+}
+
+func TestScheduler_Register(t *testing.T) {
+	run := func(ctx context.Context) error { return nil }
+	s := New(NewMemoryLocker(), newLogger(t))
+
+	assert.NoError(t, s.Register(Job{Name: "purge", Schedule: "@every 1h", Run: run}))
+	assert.NoError(t, s.Register(Job{Name: "expire", Schedule: "*/5 * * * *", Run: run}))
+	assert.Error(t, s.Register(Job{Name: "purge", Schedule: "@hourly", Run: run}))
+	assert.Error(t, s.Register(Job{Name: "invalid", Schedule: "* * *", Run: run}))
+	assert.Error(t, s.Register(Job{Name: "without run", Schedule: "@hourly"}))
+	assert.Equal(t, []string{"purge", "expire"}, s.Jobs())
+}
+
+func TestScheduler_RunNow(t *testing.T) {
+	failed := errors.New("failed")
+
+	t.Run("retried until success", func(t *testing.T) {
+		var calls int32
+		s := New(NewMemoryLocker(), newLogger(t))
+		require.NoError(t, s.Register(Job{Name: "job", Schedule: "@hourly", Retries: 2, Backoff: time.Millisecond,
+			Run: func(ctx context.Context) error {
+				if atomic.AddInt32(&calls, 1) < 3 {
+					return failed
+				}
+				return nil
+			}}))
+
+		assert.NoError(t, s.RunNow(context.TODO(), "job"))
+		assert.Equal(t, int32(3), calls)
+	})
+
+	t.Run("retries exhausted", func(t *testing.T) {
+		var calls int32
+		s := New(NewMemoryLocker(), newLogger(t))
+		require.NoError(t, s.Register(Job{Name: "job", Schedule: "@hourly", Retries: 1, Backoff: time.Millisecond,
+			Run: func(ctx context.Context) error {
+				atomic.AddInt32(&calls, 1)
+				return failed
+			}}))
+
+		assert.Equal(t, failed, s.RunNow(context.TODO(), "job"))
+		assert.Equal(t, int32(2), calls)
+	})
+
+	t.Run("not found", func(t *testing.T) {
+		assert.Equal(t, ErrJobNotFound, New(NewMemoryLocker(), newLogger(t)).RunNow(context.TODO(), "job"))
+	})
+}
+
+func TestScheduler_Start(t *testing.T) {
+	// two replicas sharing the locker run each scheduled time once
+	var calls int32
+	locker := NewMemoryLocker()
+	replicas := []*Scheduler{New(locker, newLogger(t)), New(locker, newLogger(t))}
+	for _, s := range replicas {
+		require.NoError(t, s.Register(Job{Name: "job", Schedule: "@every 1s", Timeout: time.Second,
+			Run: func(ctx context.Context) error {
+				atomic.AddInt32(&calls, 1)
+				return nil
+			}}))
+		s.Start()
+	}
+
+	time.Sleep(time.Until(time.Now().Truncate(time.Second).Add(1500 * time.Millisecond)))
+	for _, s := range replicas {
+		s.Stop()
+	}
+	assert.Equal(t, int32(1), atomic.LoadInt32(&calls))
+}
+
+func TestNextRun(t *testing.T) {
+	now := time.Date(2022, 6, 1, 10, 20, 30, 0, time.UTC)
+
+	every, err := parseSchedule("@every 1h")
+	require.NoError(t, err)
+	assert.Equal(t, time.Date(2022, 6, 1, 11, 0, 0, 0, time.UTC), nextRun(every, now))
+
+	cron, err := parseSchedule("*/15 * * * *")
+	require.NoError(t, err)
+	assert.Equal(t, time.Date(2022, 6, 1, 10, 30, 0, 0, time.UTC), nextRun(cron, now))
+}
+
+func TestLocker_Acquire(t *testing.T) {
+	redisServer, err := miniredis.Run()
+	require.NoError(t, err)
+	t.Cleanup(redisServer.Close)
+
+	redisLocker, err := NewRedisLocker(`{"conn":"` + redisServer.Addr() + `"}`)
+	require.NoError(t, err)
+	t.Cleanup(func() { redisLocker.Close() })
+
+	lockers := map[string]Locker{
+		"memory": NewMemoryLocker(),
+		"redis":  redisLocker,
+	}
+	for name, locker := range lockers {
+		t.Run(name, func(t *testing.T) {
+			acquired, err := locker.Acquire(context.TODO(), "scheduler:job:1", time.Minute)
+			require.NoError(t, err)
+			assert.True(t, acquired)
+
+			acquired, err = locker.Acquire(context.TODO(), "scheduler:job:1", time.Minute)
+			require.NoError(t, err)
+			assert.False(t, acquired)
+
+			acquired, err = locker.Acquire(context.TODO(), "scheduler:job:2", time.Minute)
+			require.NoError(t, err)
+			assert.True(t, acquired)
+		})
+	}
+
+	t.Run("redis expired", func(t *testing.T) {
+		acquired, err := redisLocker.Acquire(context.TODO(), "scheduler:job:3", time.Second)
+		require.NoError(t, err)
+		assert.True(t, acquired)
+
+		redisServer.FastForward(2 * time.Second)
+		acquired, err = redisLocker.Acquire(context.TODO(), "scheduler:job:3", time.Second)
+		require.NoError(t, err)
+		assert.True(t, acquired)
+	})
+
+	t.Run("invalid config", func(t *testing.T) {
+		_, err := NewRedisLocker(`{"conn":"localhost:6379","dbNum":"a"}`)
+		assert.Error(t, err)
+	})
+}
@@ -0,0 +1,126 @@
+package totp
+
+import (
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha1"
+	"crypto/subtle"
+	"encoding/base32"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"net/url"
+	"strings"
+	"time"
+)
+
+const (
+	// DefaultPeriod time step of a code in seconds (RFC 6238).
+	DefaultPeriod = 30
+	// DefaultDigits length of a code.
+	DefaultDigits = 6
+	// DefaultSkew number of time steps accepted before and after the current one.
+	DefaultSkew = 1
+
+	secretSize = 20
+)
+
+var (
+	// indicates the secret is not a valid base32 string
+	ErrInvalidSecret = errors.New("totp secret is invalid")
+
+	encoding = base32.StdEncoding.WithPadding(base32.NoPadding)
+)
+
+// GenerateSecret generates a random base32 encoded secret.
+func GenerateSecret() (string, error) {
+	secret := make([]byte, secretSize)
+	if _, err := rand.Read(secret); err != nil {
+		return "", err
+	}
+	return encoding.EncodeToString(secret), nil
+}
+
+// KeyURI returns the otpauth uri of a secret, it can be rendered as a qr code
+// and scanned by authenticator apps.
+//
+//	otpauth://totp/DatingApps:john@mail.com?secret=XXX&issuer=DatingApps&algorithm=SHA1&digits=6&period=30
+func KeyURI(issuer, account, secret string) string {
+	query := url.Values{}
+	query.Set("secret", secret)
+	query.Set("issuer", issuer)
+	query.Set("algorithm", "SHA1")
+	query.Set("digits", fmt.Sprint(DefaultDigits))
+	query.Set("period", fmt.Sprint(DefaultPeriod))
+
+	return (&url.URL{
+		Scheme:   "otpauth",
+		Host:     "totp",
+		Path:     "/" + issuer + ":" + account,
+		RawQuery: query.Encode(),
+	}).String()
+}
+
+// GenerateCode generates the code of a secret at the given time.
+func GenerateCode(secret string, t time.Time) (string, error) {
+	key, err := decodeSecret(secret)
+	if err != nil {
+		return "", err
+	}
+	return generateCode(key, counter(t)), nil
+}
+
+// Validate reports whether code is valid for the secret at the given time, the
+// code of DefaultSkew time steps before and after is accepted to tolerate clock drift.
+// The matching time step is returned so the caller can reject replayed codes.
+func Validate(code, secret string, t time.Time) (int64, bool) {
+	code = strings.TrimSpace(code)
+	if len(code) != DefaultDigits {
+		return 0, false
+	}
+
+	key, err := decodeSecret(secret)
+	if err != nil {
+		return 0, false
+	}
+
+	current := counter(t)
+	for i := -DefaultSkew; i <= DefaultSkew; i++ {
+		step := current + int64(i)
+		if subtle.ConstantTimeCompare([]byte(generateCode(key, step)), []byte(code)) == 1 {
+			return step, true
+		}
+	}
+	return 0, false
+}
+
+func counter(t time.Time) int64 {
+	return t.Unix() / DefaultPeriod
+}
+
+func decodeSecret(secret string) ([]byte, error) {
+	key, err := encoding.DecodeString(strings.ToUpper(strings.TrimRight(secret, "=")))
+	if err != nil || len(key) == 0 {
+		return nil, ErrInvalidSecret
+	}
+	return key, nil
+}
+
+// generateCode implements HOTP (RFC 4226) for the given counter.
+func generateCode(key []byte, counter int64) string {
+	msg := make([]byte, 8)
+	binary.BigEndian.PutUint64(msg, uint64(counter))
+
+	mac := hmac.New(sha1.New, key)
+	mac.Write(msg)
+	sum := mac.Sum(nil)
+
+	offset := sum[len(sum)-1] & 0x0f
+	value := binary.BigEndian.Uint32(sum[offset:offset+4]) & 0x7fffffff
+
+	mod := uint32(1)
+	for i := 0; i < DefaultDigits; i++ {
+		mod *= 10
+	}
+	return fmt.Sprintf("%0*d", DefaultDigits, value%mod)
+}
@@ -0,0 +1,69 @@
+package totp
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// secret "12345678901234567890" of the RFC 6238 test vectors
+const rfcSecret = "GEZDGNBVGY3TQOJQGEZDGNBVGY3TQOJQ"
+
+func TestGenerateCode(t *testing.T) {
+	tests := []struct {
+		name string
+		time time.Time
+		want string
+	}{
+		{name: "59", time: time.Unix(59, 0), want: "287082"},
+		{name: "1111111109", time: time.Unix(1111111109, 0), want: "081804"},
+		{name: "1234567890", time: time.Unix(1234567890, 0), want: "005924"},
+		{name: "2000000000", time: time.Unix(2000000000, 0), want: "279037"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := GenerateCode(rfcSecret, tt.time)
+			assert.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestValidate(t *testing.T) {
+	now := time.Unix(1111111109, 0)
+
+	step, ok := Validate("081804", rfcSecret, now)
+	assert.True(t, ok)
+	assert.Equal(t, now.Unix()/DefaultPeriod, step)
+
+	// previous time step is still accepted
+	_, ok = Validate("081804", rfcSecret, now.Add(DefaultPeriod*time.Second))
+	assert.True(t, ok)
+
+	_, ok = Validate("081804", rfcSecret, now.Add(3*DefaultPeriod*time.Second))
+	assert.False(t, ok)
+
+	_, ok = Validate("000000", rfcSecret, now)
+	assert.False(t, ok)
+
+	_, ok = Validate("081804", "not base32 !", now)
+	assert.False(t, ok)
+}
+
+func TestGenerateSecretAndKeyURI(t *testing.T) {
+	secret, err := GenerateSecret()
+	assert.NoError(t, err)
+	assert.Len(t, secret, 32)
+
+	code, err := GenerateCode(secret, time.Now())
+	assert.NoError(t, err)
+	_, ok := Validate(code, secret, time.Now())
+	assert.True(t, ok)
+
+	uri := KeyURI("DatingApps", "john@mail.com", secret)
+	assert.True(t, strings.HasPrefix(uri, "otpauth://totp/DatingApps:john@mail.com?"))
+	assert.Contains(t, uri, "secret="+secret)
+	assert.Contains(t, uri, "issuer=DatingApps")
+}
@@ -8,6 +8,7 @@ import (
 	reflect "reflect"
 	time "time"
 
+	context "github.com/beego/beego/v2/server/web/context"
 	gomock "github.com/golang/mock/gomock"
 	zaplogger "github.com/radyatamaa/dating-apps-api/pkg/zaplogger"
 	zap "go.uber.org/zap"
@@ -100,6 +101,23 @@ func (mr *MockLoggerMockRecorder) Errorf(format interface{}, args ...interface{}
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Errorf", reflect.TypeOf((*MockLogger)(nil).Errorf), varargs...)
 }
 
+// Errorw mocks base method.
+func (m *MockLogger) Errorw(msg string, keysAndValues ...interface{}) {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{msg}
+	for _, a := range keysAndValues {
+		varargs = append(varargs, a)
+	}
+	m.ctrl.Call(m, "Errorw", varargs...)
+}
+
+// Errorw indicates an expected call of Errorw.
+func (mr *MockLoggerMockRecorder) Errorw(msg interface{}, keysAndValues ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]interface{}{msg}, keysAndValues...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Errorw", reflect.TypeOf((*MockLogger)(nil).Errorw), varargs...)
+}
+
 // Fatal mocks base method.
 func (m *MockLogger) Fatal(args ...interface{}) {
 	m.ctrl.T.Helper()
@@ -174,6 +192,23 @@ func (mr *MockLoggerMockRecorder) Infof(format interface{}, args ...interface{})
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Infof", reflect.TypeOf((*MockLogger)(nil).Infof), varargs...)
 }
 
+// Infow mocks base method.
+func (m *MockLogger) Infow(msg string, keysAndValues ...interface{}) {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{msg}
+	for _, a := range keysAndValues {
+		varargs = append(varargs, a)
+	}
+	m.ctrl.Call(m, "Infow", varargs...)
+}
+
+// Infow indicates an expected call of Infow.
+func (mr *MockLoggerMockRecorder) Infow(msg interface{}, keysAndValues ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]interface{}{msg}, keysAndValues...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Infow", reflect.TypeOf((*MockLogger)(nil).Infow), varargs...)
+}
+
 // KafkaLogCommittedMessage mocks base method.
 func (m *MockLogger) KafkaLogCommittedMessage(topic string, partition int, offset int64) {
 	m.ctrl.T.Helper()
@@ -277,6 +312,37 @@ func (mr *MockLoggerMockRecorder) Warnf(format interface{}, args ...interface{})
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Warnf", reflect.TypeOf((*MockLogger)(nil).Warnf), varargs...)
 }
 
+// Warnw mocks base method.
+func (m *MockLogger) Warnw(msg string, keysAndValues ...interface{}) {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{msg}
+	for _, a := range keysAndValues {
+		varargs = append(varargs, a)
+	}
+	m.ctrl.Call(m, "Warnw", varargs...)
+}
+
+// Warnw indicates an expected call of Warnw.
+func (mr *MockLoggerMockRecorder) Warnw(msg interface{}, keysAndValues ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]interface{}{msg}, keysAndValues...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Warnw", reflect.TypeOf((*MockLogger)(nil).Warnw), varargs...)
+}
+
+// WithContext mocks base method.
+func (m *MockLogger) WithContext(beegoCtx *context.Context) zaplogger.Logger {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "WithContext", beegoCtx)
+	ret0, _ := ret[0].(zaplogger.Logger)
+	return ret0
+}
+
+// WithContext indicates an expected call of WithContext.
+func (mr *MockLoggerMockRecorder) WithContext(beegoCtx interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "WithContext", reflect.TypeOf((*MockLogger)(nil).WithContext), beegoCtx)
+}
+
 // WithFields mocks base method.
 func (m *MockLogger) WithFields(keyValues zaplogger.Fields) zaplogger.Logger {
 	m.ctrl.T.Helper()
@@ -302,4 +368,3 @@ func (mr *MockLoggerMockRecorder) WithName(name interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "WithName", reflect.TypeOf((*MockLogger)(nil).WithName), name)
 }
-
@@ -0,0 +1,26 @@
+package zaplogger
+
+import "context"
+
+type stackTraceKey struct{}
+
+// NewStackTraceContext returns a copy of ctx collecting the stack trace of the failure of the request,
+// the usecases set it with SetStackTrace whatever the transport of the request.
+func NewStackTraceContext(ctx context.Context) context.Context {
+	return context.WithValue(ctx, stackTraceKey{}, new(*ListErrors))
+}
+
+// SetStackTrace sets the stack trace of the request of ctx, it's ignored when ctx doesn't collect it.
+func SetStackTrace(ctx context.Context, stackTrace *ListErrors) {
+	if holder, ok := ctx.Value(stackTraceKey{}).(**ListErrors); ok {
+		*holder = stackTrace
+	}
+}
+
+// StackTraceFromContext returns the stack trace set on ctx, nil without stack trace.
+func StackTraceFromContext(ctx context.Context) *ListErrors {
+	if holder, ok := ctx.Value(stackTraceKey{}).(**ListErrors); ok {
+		return *holder
+	}
+	return nil
+}
@@ -5,8 +5,11 @@ import (
 	"runtime"
 	"time"
 
+	beegoContext "github.com/beego/beego/v2/server/web/context"
 	"github.com/bluele/zapslack"
+	"github.com/radyatamaa/dating-apps-api/pkg/authuser"
 	"github.com/radyatamaa/dating-apps-api/pkg/helper"
+	"github.com/radyatamaa/dating-apps-api/pkg/tracing"
 	"go.uber.org/zap"
 	"go.uber.org/zap/zapcore"
 	"gopkg.in/natefinch/lumberjack.v2"
@@ -34,6 +37,28 @@ const (
 	REQUEST  = "REQUEST"
 	REPLY    = "REPLY"
 	TIME     = "TIME"
+
+	// EncodingJSON encodes the logs as JSON.
+	EncodingJSON = "json"
+	// EncodingConsole encodes the logs as human readable lines.
+	EncodingConsole = "console"
+
+	// request scoped fields
+	FieldRequestID  = "request_id"
+	FieldTraceID    = "trace_id"
+	FieldUserID     = "user_id"
+	FieldMethod     = "method"
+	FieldRoute      = "route"
+	FieldStatus     = "status"
+	FieldLatencyMs  = "latency_ms"
+	FieldErrorCode  = "error_code"
+	FieldError      = "error"
+	FieldAppVersion = "app_version"
+
+	// ErrorCodeKey is the input data key of the api error code of the response.
+	ErrorCodeKey = "errorCode"
+	// StartTimeKey is the input data key of the time the request started.
+	StartTimeKey = "startTime"
 )
 
 type (
@@ -61,6 +86,12 @@ type Logger interface {
 
 	Errorf(format string, args ...interface{})
 
+	Infow(msg string, keysAndValues ...interface{})
+
+	Warnw(msg string, keysAndValues ...interface{})
+
+	Errorw(msg string, keysAndValues ...interface{})
+
 	Error(args ...interface{})
 
 	Fatalf(format string, args ...interface{})
@@ -71,6 +102,9 @@ type Logger interface {
 
 	WithFields(keyValues Fields) Logger
 
+	// WithContext returns a logger carrying the request scoped fields of the beego context.
+	WithContext(beegoCtx *beegoContext.Context) Logger
+
 	WithName(name string)
 
 	Sync() error
@@ -90,7 +124,14 @@ type zapLogger struct {
 	sugaredLogger *zap.SugaredLogger
 }
 
+// NewZapLogger returns a logger writing human readable lines to the console and logPath.
 func NewZapLogger(logPath, slackWebHookUrl string) Logger {
+	return NewZapLoggerWithEncoding(logPath, slackWebHookUrl, EncodingConsole)
+}
+
+// NewZapLoggerWithEncoding returns a logger writing to the console and logPath,
+// encoding is EncodingJSON or EncodingConsole.
+func NewZapLoggerWithEncoding(logPath, slackWebHookUrl, encoding string) Logger {
 
 	// First, define our level-handling logic.
 	highPriority := zap.LevelEnablerFunc(func(lvl zapcore.Level) bool {
@@ -128,12 +169,20 @@ func NewZapLogger(logPath, slackWebHookUrl string) Logger {
 		EncodeCaller:   zapcore.ShortCallerEncoder,
 	}
 
-	consoleEncoder := zapcore.NewConsoleEncoder(encoderConfig)
+	var encoder zapcore.Encoder
+	if encoding == EncodingJSON {
+		encoderConfig.EncodeLevel = zapcore.LowercaseLevelEncoder
+		encoderConfig.EncodeTime = zapcore.ISO8601TimeEncoder
+		encoderConfig.EncodeDuration = zapcore.MillisDurationEncoder
+		encoder = zapcore.NewJSONEncoder(encoderConfig)
+	} else {
+		encoder = zapcore.NewConsoleEncoder(encoderConfig)
+	}
 
 	core := zapcore.NewTee(
-		zapcore.NewCore(consoleEncoder, consoleErrors, highPriority),
-		zapcore.NewCore(consoleEncoder, consoleDebugging, lowPriority),
-		zapcore.NewCore(consoleEncoder, fileSyncer, highPriority),
+		zapcore.NewCore(encoder, consoleErrors, highPriority),
+		zapcore.NewCore(encoder, consoleDebugging, lowPriority),
+		zapcore.NewCore(encoder, fileSyncer, highPriority),
 	)
 
 	logger := zap.New(
@@ -249,6 +298,21 @@ func (l *zapLogger) Errorf(format string, args ...interface{}) {
 	l.sugaredLogger.Errorf(format, args...)
 }
 
+// Infow logs a message with some additional context, the variadic key-value pairs are structured fields.
+func (l *zapLogger) Infow(msg string, keysAndValues ...interface{}) {
+	l.sugaredLogger.Infow(msg, keysAndValues...)
+}
+
+// Warnw logs a message with some additional context, the variadic key-value pairs are structured fields.
+func (l *zapLogger) Warnw(msg string, keysAndValues ...interface{}) {
+	l.sugaredLogger.Warnw(msg, keysAndValues...)
+}
+
+// Errorw logs a message with some additional context, the variadic key-value pairs are structured fields.
+func (l *zapLogger) Errorw(msg string, keysAndValues ...interface{}) {
+	l.sugaredLogger.Errorw(msg, keysAndValues...)
+}
+
 // Error not uses fmt.Sprintf to log a templated message.
 func (l *zapLogger) Error(args ...interface{}) {
 	l.sugaredLogger.Error(args...)
@@ -278,6 +342,51 @@ func (l *zapLogger) WithFields(fields Fields) Logger {
 	return &zapLogger{newLogger}
 }
 
+func (l *zapLogger) WithContext(beegoCtx *beegoContext.Context) Logger {
+	return l.WithFields(ContextFields(beegoCtx))
+}
+
+// ContextFields returns the request scoped fields of the beego context,
+// the fields unknown yet are omitted.
+func ContextFields(beegoCtx *beegoContext.Context) Fields {
+	fields := Fields{}
+	if beegoCtx == nil {
+		return fields
+	}
+	if beegoCtx.ResponseWriter != nil && beegoCtx.ResponseWriter.ResponseWriter != nil {
+		if requestId := beegoCtx.ResponseWriter.Header().Get("X-REQUEST-ID"); requestId != "" {
+			fields[FieldRequestID] = requestId
+		}
+		if beegoCtx.ResponseWriter.Status != 0 {
+			fields[FieldStatus] = beegoCtx.ResponseWriter.Status
+		}
+	}
+	// the status is set on the output before the response is written
+	if _, ok := fields[FieldStatus]; !ok && beegoCtx.Output != nil && beegoCtx.Output.Status != 0 {
+		fields[FieldStatus] = beegoCtx.Output.Status
+	}
+	if beegoCtx.Request != nil {
+		fields[FieldMethod] = beegoCtx.Request.Method
+		if traceId := tracing.TraceID(beegoCtx.Request.Context()); traceId != "" {
+			fields[FieldTraceID] = traceId
+		}
+		if user, err := authuser.FromContext(beegoCtx.Request.Context()); err == nil {
+			fields[FieldUserID] = user.ID
+		}
+	}
+	if beegoCtx.Input != nil {
+		if route, ok := beegoCtx.Input.GetData("RouterPattern").(string); ok && route != "" {
+			fields[FieldRoute] = route
+		} else if beegoCtx.Request != nil {
+			fields[FieldRoute] = beegoCtx.Request.URL.Path
+		}
+		if errorCode, ok := beegoCtx.Input.GetData(ErrorCodeKey).(string); ok {
+			fields[FieldErrorCode] = errorCode
+		}
+	}
+	return fields
+}
+
 func (s zapLogger) Desugar() *zap.Logger {
 	return s.sugaredLogger.Desugar()
 }
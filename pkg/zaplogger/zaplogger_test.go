@@ -0,0 +1,85 @@
+package zaplogger
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	beegoContext "github.com/beego/beego/v2/server/web/context"
+	"github.com/radyatamaa/dating-apps-api/pkg/authuser"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestContextFields(t *testing.T) {
+	newContext := func(user *authuser.AuthUser) *beegoContext.Context {
+		r := httptest.NewRequest(http.MethodGet, "/api/v1/profile/10", nil)
+		if user != nil {
+			r = r.WithContext(authuser.NewContext(r.Context(), *user))
+		}
+		ctx := beegoContext.NewContext()
+		ctx.Reset(httptest.NewRecorder(), r)
+		ctx.ResponseWriter.Header().Set("X-REQUEST-ID", "request-id")
+		return ctx
+	}
+
+	tests := []struct {
+		name string
+		ctx  func() *beegoContext.Context
+		want Fields
+	}{
+		{
+			name: "anonymous request",
+			ctx: func() *beegoContext.Context {
+				return newContext(nil)
+			},
+			want: Fields{FieldRequestID: "request-id", FieldMethod: http.MethodGet, FieldRoute: "/api/v1/profile/10"},
+		},
+		{
+			name: "routed request with error",
+			ctx: func() *beegoContext.Context {
+				ctx := newContext(&authuser.AuthUser{ID: 7})
+				ctx.Input.SetData("RouterPattern", "/api/v1/profile/:id")
+				ctx.Input.SetData(ErrorCodeKey, "ERROR-API-002")
+				ctx.Output.SetStatus(http.StatusBadRequest)
+				return ctx
+			},
+			want: Fields{
+				FieldRequestID: "request-id",
+				FieldMethod:    http.MethodGet,
+				FieldRoute:     "/api/v1/profile/:id",
+				FieldUserID:    7,
+				FieldStatus:    http.StatusBadRequest,
+				FieldErrorCode: "ERROR-API-002",
+			},
+		},
+		{
+			name: "nil context",
+			ctx: func() *beegoContext.Context {
+				return nil
+			},
+			want: Fields{},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, ContextFields(tt.ctx()))
+		})
+	}
+}
+
+func TestStackTrace(t *testing.T) {
+	stackTrace := &ListErrors{Error: "failed", Line: 10}
+
+	ctx := NewStackTraceContext(context.Background())
+	assert.Nil(t, StackTraceFromContext(ctx))
+
+	// the stack trace set by a derived context is seen by the request context
+	derived, cancel := context.WithCancel(ctx)
+	defer cancel()
+	SetStackTrace(derived, stackTrace)
+	assert.Equal(t, stackTrace, StackTraceFromContext(ctx))
+
+	SetStackTrace(context.Background(), stackTrace)
+	assert.Nil(t, StackTraceFromContext(context.Background()))
+}
@@ -0,0 +1,160 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"time"
+
+	"github.com/radyatamaa/dating-apps-api/internal/audit"
+	"github.com/radyatamaa/dating-apps-api/internal/config"
+	"github.com/radyatamaa/dating-apps-api/internal/dataexport"
+	"github.com/radyatamaa/dating-apps-api/internal/outbox"
+	"github.com/radyatamaa/dating-apps-api/internal/profile"
+	"github.com/radyatamaa/dating-apps-api/internal/user"
+	"github.com/radyatamaa/dating-apps-api/pkg/helper"
+	"github.com/radyatamaa/dating-apps-api/pkg/scheduler"
+	"github.com/radyatamaa/dating-apps-api/pkg/zaplogger"
+)
+
+const workerUsage = "usage: dating-apps-api worker | worker run <job> | worker list"
+
+// workerUseCases are the usecases run by the background jobs.
+type workerUseCases struct {
+	user       user.UseCase
+	profile    profile.UseCase
+	dataExport dataexport.UseCase
+	audit      audit.UseCase
+	outbox     outbox.UseCase
+}
+
+// newScheduler returns the scheduler with the background jobs registered.
+func newScheduler(cfg *config.Config, timeoutContext time.Duration, locker scheduler.Locker, u workerUseCases, zapLog zaplogger.Logger) (*scheduler.Scheduler, error) {
+	jobs := []scheduler.Job{
+		{
+			// relay the domain events of the outbox to the broker, a failed relay is retried by the next run
+			Name:     "relay-outbox",
+			Schedule: "@every " + cfg.Event.RelayInterval.String(),
+			Timeout:  timeoutContext,
+			Run: func(ctx context.Context) error {
+				_, err := u.outbox.Relay(ctx)
+				return err
+			},
+		},
+		{
+			// purge deleted accounts after the grace period with their data exports
+			Name:     "purge-deleted-accounts",
+			Schedule: "@every " + cfg.Account.PurgeInterval.String(),
+			Run: func(ctx context.Context) error {
+				purged, err := u.user.PurgeDeletedUsers(ctx, time.Now().Add(-cfg.Account.DeletionGracePeriod))
+				for _, userId := range purged {
+					if err := u.dataExport.DeleteUserExports(ctx, userId); err != nil {
+						zapLog.Errorw("failed delete data exports", zaplogger.FieldUserID, userId, zaplogger.FieldError, err)
+					}
+				}
+				return err
+			},
+		},
+		{
+			Name:     "purge-expired-exports",
+			Schedule: "@every " + cfg.Account.PurgeInterval.String(),
+			Run: func(ctx context.Context) error {
+				_, err := u.dataExport.PurgeExpiredExports(ctx, time.Now())
+				return err
+			},
+		},
+		{
+			// the exports interrupted by a restart are failed so the user can request a new one
+			Name:     "fail-stale-exports",
+			Schedule: "@every " + cfg.Account.PurgeInterval.String(),
+			Run: func(ctx context.Context) error {
+				_, err := u.dataExport.FailStaleExports(ctx, time.Now())
+				return err
+			},
+		},
+		{
+			Name:     "purge-audit-logs",
+			Schedule: "@every " + cfg.Account.PurgeInterval.String(),
+			Run: func(ctx context.Context) error {
+				_, err := u.audit.PurgeExpiredAuditLogs(ctx, time.Now().Add(-cfg.Audit.Retention))
+				return err
+			},
+		},
+		{
+			Name:     "purge-outbox-events",
+			Schedule: "@every " + cfg.Account.PurgeInterval.String(),
+			Run: func(ctx context.Context) error {
+				_, err := u.outbox.PurgePublishedEvents(ctx, time.Now().Add(-cfg.Event.Retention))
+				return err
+			},
+		},
+		{
+			Name:     "expire-premium-users",
+			Schedule: cfg.Worker.ExpirePremiumSchedule,
+			Run: func(ctx context.Context) error {
+				_, err := u.user.ExpirePremiumUsers(ctx, time.Now())
+				return err
+			},
+		},
+		{
+			// the photos uploaded within storageOrphanAge can still be saved in their profile
+			Name:     "clean-orphaned-photos",
+			Schedule: cfg.Worker.CleanStorageSchedule,
+			Run: func(ctx context.Context) error {
+				_, err := u.profile.CleanOrphanedPhotos(ctx, helper.StoragePath, time.Now().Add(-cfg.Worker.StorageOrphanAge))
+				return err
+			},
+		},
+		{
+			Name:     "recompute-recommendation-scores",
+			Schedule: cfg.Worker.RecommendationSchedule,
+			Run: func(ctx context.Context) error {
+				_, err := u.profile.RecomputeRecommendationScores(ctx, time.Now().Add(-cfg.Worker.RecommendationWindow))
+				return err
+			},
+		},
+	}
+
+	s := scheduler.New(locker, zapLog)
+	for _, job := range jobs {
+		// the jobs without their own timeout run with the timeout and the retries of the worker config
+		if job.Timeout == 0 {
+			job.Timeout = cfg.Worker.Timeout
+			job.Retries = cfg.Worker.Retries
+			job.Backoff = cfg.Worker.RetryBackoff
+		}
+		if err := s.Register(job); err != nil {
+			return nil, err
+		}
+	}
+	return s, nil
+}
+
+// runWorker runs the worker command, ex: worker, worker run expire-premium-users, worker list,
+// and returns the exit code. The worker runs the scheduled jobs until ctx is done.
+func runWorker(ctx context.Context, s *scheduler.Scheduler, args []string, out io.Writer) int {
+	if len(args) == 0 {
+		fmt.Fprintf(out, "running %d jobs\n", len(s.Jobs()))
+		s.Start()
+		<-ctx.Done()
+		s.Stop()
+		return 0
+	}
+
+	switch {
+	case args[0] == "list" && len(args) == 1:
+		for _, name := range s.Jobs() {
+			fmt.Fprintln(out, name)
+		}
+	case args[0] == "run" && len(args) == 2:
+		if err := s.RunNow(ctx, args[1]); err != nil {
+			fmt.Fprintln(out, err)
+			return 1
+		}
+		fmt.Fprintf(out, "ran %s\n", args[1])
+	default:
+		fmt.Fprintln(out, workerUsage)
+		return 2
+	}
+	return 0
+}